@@ -0,0 +1,82 @@
+package actions
+
+import (
+	"context"
+	"sort"
+
+	"code.cloudfoundry.org/korifi/api/actions/shared"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+)
+
+const (
+	BuildpackOrderSourceApp     = "app"
+	BuildpackOrderSourceCluster = "cluster"
+)
+
+type BuildpackOrderRecord struct {
+	AppGUID    string
+	Source     string
+	Buildpacks []string
+}
+
+type AppBuildpacks struct {
+	appRepo       shared.CFAppRepository
+	buildpackRepo shared.CFBuildpackRepository
+}
+
+func NewAppBuildpacks(appRepo shared.CFAppRepository, buildpackRepo shared.CFBuildpackRepository) *AppBuildpacks {
+	return &AppBuildpacks{
+		appRepo:       appRepo,
+		buildpackRepo: buildpackRepo,
+	}
+}
+
+// GetBuildpackOrder returns the buildpacks that will be used to detect and
+// build the app, in order. These are the buildpacks specified on the app or,
+// if it does not specify any, the buildpacks of the cluster builder. Spaces
+// have no default buildpacks, so there is no space level to fall back to.
+func (a *AppBuildpacks) GetBuildpackOrder(ctx context.Context, authInfo authorization.Info, appGUID string) (BuildpackOrderRecord, error) {
+	app, err := a.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		return BuildpackOrderRecord{}, err
+	}
+
+	if app.Lifecycle.Type == string(korifiv1alpha1.DockerPackage) {
+		return BuildpackOrderRecord{AppGUID: appGUID, Buildpacks: []string{}}, nil
+	}
+
+	if len(app.Lifecycle.Data.Buildpacks) > 0 {
+		return BuildpackOrderRecord{
+			AppGUID:    appGUID,
+			Source:     BuildpackOrderSourceApp,
+			Buildpacks: app.Lifecycle.Data.Buildpacks,
+		}, nil
+	}
+
+	clusterBuildpacks, err := a.buildpackRepo.ListBuildpacks(ctx, authInfo)
+	if err != nil {
+		return BuildpackOrderRecord{}, err
+	}
+
+	return BuildpackOrderRecord{
+		AppGUID:    appGUID,
+		Source:     BuildpackOrderSourceCluster,
+		Buildpacks: buildpackNamesByPosition(clusterBuildpacks),
+	}, nil
+}
+
+func buildpackNamesByPosition(buildpacks []repositories.BuildpackRecord) []string {
+	sorted := append([]repositories.BuildpackRecord{}, buildpacks...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Position < sorted[j].Position
+	})
+
+	names := make([]string, 0, len(sorted))
+	for _, buildpack := range sorted {
+		names = append(names, buildpack.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,125 @@
+package actions_test
+
+import (
+	"context"
+	"errors"
+
+	. "code.cloudfoundry.org/korifi/api/actions"
+	sfake "code.cloudfoundry.org/korifi/api/actions/shared/fake"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppBuildpacks", func() {
+	var (
+		appRepo       *sfake.CFAppRepository
+		buildpackRepo *sfake.CFBuildpackRepository
+		authInfo      authorization.Info
+
+		appBuildpacks *AppBuildpacks
+
+		orderRecord BuildpackOrderRecord
+		orderErr    error
+	)
+
+	BeforeEach(func() {
+		appRepo = new(sfake.CFAppRepository)
+		buildpackRepo = new(sfake.CFBuildpackRepository)
+		authInfo = authorization.Info{Token: "a-token"}
+
+		appRepo.GetAppReturns(repositories.AppRecord{
+			GUID: "the-app-guid",
+			Lifecycle: repositories.Lifecycle{
+				Type: "buildpack",
+				Data: repositories.LifecycleData{
+					Buildpacks: []string{"app-bp-1", "app-bp-2"},
+				},
+			},
+		}, nil)
+
+		buildpackRepo.ListBuildpacksReturns([]repositories.BuildpackRecord{
+			{Name: "cluster-bp-2", Position: 2},
+			{Name: "cluster-bp-1", Position: 1},
+			{Name: "cluster-bp-3", Position: 3},
+		}, nil)
+
+		appBuildpacks = NewAppBuildpacks(appRepo, buildpackRepo)
+	})
+
+	JustBeforeEach(func() {
+		orderRecord, orderErr = appBuildpacks.GetBuildpackOrder(context.Background(), authInfo, "the-app-guid")
+	})
+
+	It("gets the app", func() {
+		Expect(appRepo.GetAppCallCount()).To(Equal(1))
+		_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(actualAppGUID).To(Equal("the-app-guid"))
+	})
+
+	It("returns the buildpacks specified on the app", func() {
+		Expect(orderErr).NotTo(HaveOccurred())
+		Expect(orderRecord).To(Equal(BuildpackOrderRecord{
+			AppGUID:    "the-app-guid",
+			Source:     "app",
+			Buildpacks: []string{"app-bp-1", "app-bp-2"},
+		}))
+		Expect(buildpackRepo.ListBuildpacksCallCount()).To(BeZero())
+	})
+
+	When("the app does not specify buildpacks", func() {
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{
+				GUID:      "the-app-guid",
+				Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+			}, nil)
+		})
+
+		It("returns the cluster builder buildpacks in detection order", func() {
+			Expect(orderErr).NotTo(HaveOccurred())
+			Expect(orderRecord).To(Equal(BuildpackOrderRecord{
+				AppGUID:    "the-app-guid",
+				Source:     "cluster",
+				Buildpacks: []string{"cluster-bp-1", "cluster-bp-2", "cluster-bp-3"},
+			}))
+		})
+
+		When("listing the cluster buildpacks fails", func() {
+			BeforeEach(func() {
+				buildpackRepo.ListBuildpacksReturns(nil, errors.New("list-buildpacks-err"))
+			})
+
+			It("returns the error", func() {
+				Expect(orderErr).To(MatchError("list-buildpacks-err"))
+			})
+		})
+	})
+
+	When("the app is a docker app", func() {
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{
+				GUID:      "the-app-guid",
+				Lifecycle: repositories.Lifecycle{Type: "docker"},
+			}, nil)
+		})
+
+		It("returns no buildpacks", func() {
+			Expect(orderErr).NotTo(HaveOccurred())
+			Expect(orderRecord.Buildpacks).To(BeEmpty())
+			Expect(buildpackRepo.ListBuildpacksCallCount()).To(BeZero())
+		})
+	})
+
+	When("getting the app fails", func() {
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{}, errors.New("get-app-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(orderErr).To(MatchError("get-app-err"))
+		})
+	})
+})
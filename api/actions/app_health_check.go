@@ -0,0 +1,105 @@
+package actions
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/api/actions/shared"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+)
+
+type (
+	AppHealthCheckRecord struct {
+		AppGUID     string
+		ProcessGUID string
+		HealthCheck repositories.HealthCheck
+	}
+
+	// SetAppHealthCheckMessage holds the health check fields to update on
+	// the web process of an app. Unset fields are left unchanged.
+	SetAppHealthCheckMessage struct {
+		AppGUID                  string
+		Type                     *string
+		HTTPEndpoint             *string
+		InvocationTimeoutSeconds *int64
+		TimeoutSeconds           *int64
+	}
+
+	AppHealthCheck struct {
+		appRepo     shared.CFAppRepository
+		processRepo shared.CFProcessRepository
+	}
+)
+
+func NewAppHealthCheck(appRepo shared.CFAppRepository, processRepo shared.CFProcessRepository) *AppHealthCheck {
+	return &AppHealthCheck{
+		appRepo:     appRepo,
+		processRepo: processRepo,
+	}
+}
+
+// GetWebHealthCheck returns the health check of the web process of an app
+func (a *AppHealthCheck) GetWebHealthCheck(ctx context.Context, authInfo authorization.Info, appGUID string) (AppHealthCheckRecord, error) {
+	webProcess, err := a.getWebProcess(ctx, authInfo, appGUID)
+	if err != nil {
+		return AppHealthCheckRecord{}, err
+	}
+
+	return toAppHealthCheckRecord(webProcess), nil
+}
+
+// SetWebHealthCheck updates the health check of the web process of an app.
+// The process controller turns it into the probes of the app workload.
+func (a *AppHealthCheck) SetWebHealthCheck(ctx context.Context, authInfo authorization.Info, message SetAppHealthCheckMessage) (AppHealthCheckRecord, error) {
+	webProcess, err := a.getWebProcess(ctx, authInfo, message.AppGUID)
+	if err != nil {
+		return AppHealthCheckRecord{}, err
+	}
+
+	patchedProcess, err := a.processRepo.PatchProcess(ctx, authInfo, repositories.PatchProcessMessage{
+		SpaceGUID:                           webProcess.SpaceGUID,
+		ProcessGUID:                         webProcess.GUID,
+		HealthCheckType:                     message.Type,
+		HealthCheckHTTPEndpoint:             message.HTTPEndpoint,
+		HealthCheckInvocationTimeoutSeconds: message.InvocationTimeoutSeconds,
+		HealthCheckTimeoutSeconds:           message.TimeoutSeconds,
+	})
+	if err != nil {
+		return AppHealthCheckRecord{}, err
+	}
+
+	return toAppHealthCheckRecord(patchedProcess), nil
+}
+
+func (a *AppHealthCheck) getWebProcess(ctx context.Context, authInfo authorization.Info, appGUID string) (repositories.ProcessRecord, error) {
+	app, err := a.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		return repositories.ProcessRecord{}, err
+	}
+
+	processes, err := a.processRepo.ListProcesses(ctx, authInfo, repositories.ListProcessesMessage{
+		AppGUIDs:  []string{app.GUID},
+		SpaceGUID: app.SpaceGUID,
+	})
+	if err != nil {
+		return repositories.ProcessRecord{}, err
+	}
+
+	for _, process := range processes {
+		if process.Type == korifiv1alpha1.ProcessTypeWeb {
+			return process, nil
+		}
+	}
+
+	return repositories.ProcessRecord{}, apierrors.NewNotFoundError(nil, repositories.ProcessResourceType)
+}
+
+func toAppHealthCheckRecord(process repositories.ProcessRecord) AppHealthCheckRecord {
+	return AppHealthCheckRecord{
+		AppGUID:     process.AppGUID,
+		ProcessGUID: process.GUID,
+		HealthCheck: process.HealthCheck,
+	}
+}
@@ -0,0 +1,207 @@
+package actions_test
+
+import (
+	"context"
+	"errors"
+
+	. "code.cloudfoundry.org/korifi/api/actions"
+	sfake "code.cloudfoundry.org/korifi/api/actions/shared/fake"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppHealthCheck", func() {
+	var (
+		appRepo     *sfake.CFAppRepository
+		processRepo *sfake.CFProcessRepository
+		authInfo    authorization.Info
+
+		appHealthCheck *AppHealthCheck
+
+		healthCheckRecord AppHealthCheckRecord
+		healthCheckErr    error
+	)
+
+	BeforeEach(func() {
+		appRepo = new(sfake.CFAppRepository)
+		processRepo = new(sfake.CFProcessRepository)
+		authInfo = authorization.Info{Token: "a-token"}
+
+		appRepo.GetAppReturns(repositories.AppRecord{
+			GUID:      "the-app-guid",
+			SpaceGUID: "the-space-guid",
+		}, nil)
+
+		processRepo.ListProcessesReturns([]repositories.ProcessRecord{
+			{
+				GUID:      "worker-process-guid",
+				SpaceGUID: "the-space-guid",
+				AppGUID:   "the-app-guid",
+				Type:      "worker",
+				HealthCheck: repositories.HealthCheck{
+					Type: "process",
+				},
+			},
+			{
+				GUID:      "web-process-guid",
+				SpaceGUID: "the-space-guid",
+				AppGUID:   "the-app-guid",
+				Type:      "web",
+				HealthCheck: repositories.HealthCheck{
+					Type: "port",
+					Data: repositories.HealthCheckData{
+						TimeoutSeconds: 60,
+					},
+				},
+			},
+		}, nil)
+
+		appHealthCheck = NewAppHealthCheck(appRepo, processRepo)
+	})
+
+	Describe("GetWebHealthCheck", func() {
+		JustBeforeEach(func() {
+			healthCheckRecord, healthCheckErr = appHealthCheck.GetWebHealthCheck(context.Background(), authInfo, "the-app-guid")
+		})
+
+		It("returns the health check of the web process", func() {
+			Expect(healthCheckErr).NotTo(HaveOccurred())
+			Expect(healthCheckRecord).To(Equal(AppHealthCheckRecord{
+				AppGUID:     "the-app-guid",
+				ProcessGUID: "web-process-guid",
+				HealthCheck: repositories.HealthCheck{
+					Type: "port",
+					Data: repositories.HealthCheckData{
+						TimeoutSeconds: 60,
+					},
+				},
+			}))
+		})
+
+		It("lists the processes of the app in its space", func() {
+			Expect(processRepo.ListProcessesCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := processRepo.ListProcessesArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ListProcessesMessage{
+				AppGUIDs:  []string{"the-app-guid"},
+				SpaceGUID: "the-space-guid",
+			}))
+		})
+
+		When("the app has no web process", func() {
+			BeforeEach(func() {
+				processRepo.ListProcessesReturns([]repositories.ProcessRecord{{GUID: "worker-process-guid", Type: "worker"}}, nil)
+			})
+
+			It("returns a not found error", func() {
+				Expect(healthCheckErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+
+		When("getting the app fails", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, errors.New("get-app-err"))
+			})
+
+			It("returns the error", func() {
+				Expect(healthCheckErr).To(MatchError("get-app-err"))
+			})
+		})
+
+		When("listing the processes fails", func() {
+			BeforeEach(func() {
+				processRepo.ListProcessesReturns(nil, errors.New("list-processes-err"))
+			})
+
+			It("returns the error", func() {
+				Expect(healthCheckErr).To(MatchError("list-processes-err"))
+			})
+		})
+	})
+
+	Describe("SetWebHealthCheck", func() {
+		var message SetAppHealthCheckMessage
+
+		BeforeEach(func() {
+			message = SetAppHealthCheckMessage{
+				AppGUID:                  "the-app-guid",
+				Type:                     tools.PtrTo("http"),
+				HTTPEndpoint:             tools.PtrTo("/healthz"),
+				InvocationTimeoutSeconds: tools.PtrTo(int64(5)),
+			}
+
+			processRepo.PatchProcessReturns(repositories.ProcessRecord{
+				GUID:    "web-process-guid",
+				AppGUID: "the-app-guid",
+				Type:    "web",
+				HealthCheck: repositories.HealthCheck{
+					Type: "http",
+					Data: repositories.HealthCheckData{
+						HTTPEndpoint:             "/healthz",
+						InvocationTimeoutSeconds: 5,
+						TimeoutSeconds:           60,
+					},
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			healthCheckRecord, healthCheckErr = appHealthCheck.SetWebHealthCheck(context.Background(), authInfo, message)
+		})
+
+		It("patches the health check of the web process only", func() {
+			Expect(healthCheckErr).NotTo(HaveOccurred())
+			Expect(processRepo.PatchProcessCallCount()).To(Equal(1))
+			_, actualAuthInfo, patchMessage := processRepo.PatchProcessArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(patchMessage).To(Equal(repositories.PatchProcessMessage{
+				SpaceGUID:                           "the-space-guid",
+				ProcessGUID:                         "web-process-guid",
+				HealthCheckType:                     tools.PtrTo("http"),
+				HealthCheckHTTPEndpoint:             tools.PtrTo("/healthz"),
+				HealthCheckInvocationTimeoutSeconds: tools.PtrTo(int64(5)),
+			}))
+		})
+
+		It("returns the updated health check", func() {
+			Expect(healthCheckRecord).To(Equal(AppHealthCheckRecord{
+				AppGUID:     "the-app-guid",
+				ProcessGUID: "web-process-guid",
+				HealthCheck: repositories.HealthCheck{
+					Type: "http",
+					Data: repositories.HealthCheckData{
+						HTTPEndpoint:             "/healthz",
+						InvocationTimeoutSeconds: 5,
+						TimeoutSeconds:           60,
+					},
+				},
+			}))
+		})
+
+		When("the app has no web process", func() {
+			BeforeEach(func() {
+				processRepo.ListProcessesReturns([]repositories.ProcessRecord{}, nil)
+			})
+
+			It("returns a not found error and does not patch any process", func() {
+				Expect(healthCheckErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				Expect(processRepo.PatchProcessCallCount()).To(BeZero())
+			})
+		})
+
+		When("patching the process fails", func() {
+			BeforeEach(func() {
+				processRepo.PatchProcessReturns(repositories.ProcessRecord{}, errors.New("patch-process-err"))
+			})
+
+			It("returns the error", func() {
+				Expect(healthCheckErr).To(MatchError("patch-process-err"))
+			})
+		})
+	})
+})
@@ -46,7 +46,7 @@ func (a *AppLogs) Read(ctx context.Context, logger logr.Logger, authInfo authori
 		return []repositories.LogRecord{}, nil
 	}
 
-	buildLogs, err := a.buildRepo.GetBuildLogs(ctx, authInfo, app.SpaceGUID, build.GUID)
+	buildLogs, err := a.buildRepo.GetBuildLogs(ctx, authInfo, repositories.BuildLogsMessage{BuildGUID: build.GUID})
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch build logs", "AppGUID", appGUID, "BuildGUID", build.GUID)
 	}
@@ -99,6 +99,13 @@ var _ = Describe("ReadAppLogs", func() {
 		Expect(message.Limit).To(Equal(int64(100)))
 	})
 
+	It("reads the logs of the latest build", func() {
+		Expect(buildRepo.GetBuildLogsCallCount()).To(Equal(1))
+		_, actualAuthInfo, message := buildRepo.GetBuildLogsArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(message.BuildGUID).To(Equal(buildGUID))
+	})
+
 	It("returns the list of build and app records", func() {
 		Expect(returnedErr).NotTo(HaveOccurred())
 		Expect(returnedRecords).To(Equal(append(buildLogs, logs...)))
@@ -0,0 +1,93 @@
+package actions
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/api/actions/shared"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+//counterfeiter:generate -o fake -fake-name ImageSizeRepository . ImageSizeRepository
+
+type (
+	ImageSizeRepository interface {
+		GetImageSize(ctx context.Context, imageRef string) (int64, error)
+	}
+
+	AppStorageRecord struct {
+		AppGUID      string
+		PackageSizes map[string]int64
+		DropletSizes map[string]int64
+		TotalSize    int64
+	}
+
+	AppStorage struct {
+		packageRepo shared.CFPackageRepository
+		dropletRepo shared.CFDropletRepository
+		imageRepo   ImageSizeRepository
+	}
+)
+
+func NewAppStorage(packageRepo shared.CFPackageRepository, dropletRepo shared.CFDropletRepository, imageRepo ImageSizeRepository) *AppStorage {
+	return &AppStorage{
+		packageRepo: packageRepo,
+		dropletRepo: dropletRepo,
+		imageRepo:   imageRepo,
+	}
+}
+
+// GetAppStorage returns the registry sizes of the images of all packages and
+// droplets of an app, keyed by package and droplet guid
+func (a *AppStorage) GetAppStorage(ctx context.Context, authInfo authorization.Info, appGUID string) (AppStorageRecord, error) {
+	record := AppStorageRecord{
+		AppGUID:      appGUID,
+		PackageSizes: map[string]int64{},
+		DropletSizes: map[string]int64{},
+	}
+
+	packages, err := a.packageRepo.ListPackages(ctx, authInfo, repositories.ListPackagesMessage{AppGUIDs: []string{appGUID}})
+	if err != nil {
+		return AppStorageRecord{}, err
+	}
+
+	if len(packages) == 0 {
+		return record, nil
+	}
+
+	packageGUIDs := []string{}
+	for _, pkg := range packages {
+		packageGUIDs = append(packageGUIDs, pkg.GUID)
+
+		size, err := a.getImageSize(ctx, pkg.ImageRef)
+		if err != nil {
+			return AppStorageRecord{}, err
+		}
+		record.PackageSizes[pkg.GUID] = size
+		record.TotalSize += size
+	}
+
+	droplets, err := a.dropletRepo.ListDroplets(ctx, authInfo, repositories.ListDropletsMessage{PackageGUIDs: packageGUIDs})
+	if err != nil {
+		return AppStorageRecord{}, err
+	}
+
+	for _, droplet := range droplets {
+		size, err := a.getImageSize(ctx, droplet.Image)
+		if err != nil {
+			return AppStorageRecord{}, err
+		}
+		record.DropletSizes[droplet.GUID] = size
+		record.TotalSize += size
+	}
+
+	return record, nil
+}
+
+func (a *AppStorage) getImageSize(ctx context.Context, imageRef string) (int64, error) {
+	if imageRef == "" {
+		return 0, nil
+	}
+
+	return a.imageRepo.GetImageSize(ctx, imageRef)
+}
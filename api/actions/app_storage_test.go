@@ -0,0 +1,155 @@
+package actions_test
+
+import (
+	"context"
+	"errors"
+
+	. "code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/actions/fake"
+	sfake "code.cloudfoundry.org/korifi/api/actions/shared/fake"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppStorage", func() {
+	var (
+		packageRepo *sfake.CFPackageRepository
+		dropletRepo *sfake.CFDropletRepository
+		imageRepo   *fake.ImageSizeRepository
+		authInfo    authorization.Info
+
+		appStorage *AppStorage
+
+		storageRecord AppStorageRecord
+		storageErr    error
+	)
+
+	BeforeEach(func() {
+		packageRepo = new(sfake.CFPackageRepository)
+		dropletRepo = new(sfake.CFDropletRepository)
+		imageRepo = new(fake.ImageSizeRepository)
+		authInfo = authorization.Info{Token: "a-token"}
+
+		packageRepo.ListPackagesReturns([]repositories.PackageRecord{
+			{GUID: "package-1", ImageRef: "registry/package-1"},
+			{GUID: "package-2", ImageRef: "registry/package-2"},
+		}, nil)
+
+		dropletRepo.ListDropletsReturns([]repositories.DropletRecord{
+			{GUID: "droplet-1", Image: "registry/droplet-1"},
+			{GUID: "droplet-2", Image: "registry/droplet-2"},
+		}, nil)
+
+		imageSizes := map[string]int64{
+			"registry/package-1": 100,
+			"registry/package-2": 200,
+			"registry/droplet-1": 1000,
+			"registry/droplet-2": 3000,
+		}
+		imageRepo.GetImageSizeStub = func(_ context.Context, imageRef string) (int64, error) {
+			return imageSizes[imageRef], nil
+		}
+
+		appStorage = NewAppStorage(packageRepo, dropletRepo, imageRepo)
+	})
+
+	JustBeforeEach(func() {
+		storageRecord, storageErr = appStorage.GetAppStorage(context.Background(), authInfo, "the-app-guid")
+	})
+
+	It("returns the size of each package and droplet", func() {
+		Expect(storageErr).NotTo(HaveOccurred())
+		Expect(storageRecord.AppGUID).To(Equal("the-app-guid"))
+		Expect(storageRecord.PackageSizes).To(Equal(map[string]int64{
+			"package-1": 100,
+			"package-2": 200,
+		}))
+		Expect(storageRecord.DropletSizes).To(Equal(map[string]int64{
+			"droplet-1": 1000,
+			"droplet-2": 3000,
+		}))
+	})
+
+	It("aggregates the sizes per app", func() {
+		Expect(storageErr).NotTo(HaveOccurred())
+		Expect(storageRecord.TotalSize).To(BeEquivalentTo(4300))
+	})
+
+	It("lists the packages of the app", func() {
+		Expect(packageRepo.ListPackagesCallCount()).To(Equal(1))
+		_, actualAuthInfo, message := packageRepo.ListPackagesArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(message.AppGUIDs).To(ConsistOf("the-app-guid"))
+	})
+
+	It("lists the droplets of the app packages", func() {
+		Expect(dropletRepo.ListDropletsCallCount()).To(Equal(1))
+		_, actualAuthInfo, message := dropletRepo.ListDropletsArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(message.PackageGUIDs).To(ConsistOf("package-1", "package-2"))
+	})
+
+	When("a package has no image yet", func() {
+		BeforeEach(func() {
+			packageRepo.ListPackagesReturns([]repositories.PackageRecord{
+				{GUID: "package-1", ImageRef: "registry/package-1"},
+				{GUID: "package-2"},
+			}, nil)
+		})
+
+		It("counts it as empty", func() {
+			Expect(storageErr).NotTo(HaveOccurred())
+			Expect(storageRecord.PackageSizes).To(HaveKeyWithValue("package-2", BeEquivalentTo(0)))
+			Expect(storageRecord.TotalSize).To(BeEquivalentTo(4100))
+			Expect(imageRepo.GetImageSizeCallCount()).To(Equal(3))
+		})
+	})
+
+	When("the app has no packages", func() {
+		BeforeEach(func() {
+			packageRepo.ListPackagesReturns([]repositories.PackageRecord{}, nil)
+		})
+
+		It("returns an empty record", func() {
+			Expect(storageErr).NotTo(HaveOccurred())
+			Expect(storageRecord.PackageSizes).To(BeEmpty())
+			Expect(storageRecord.DropletSizes).To(BeEmpty())
+			Expect(storageRecord.TotalSize).To(BeZero())
+			Expect(dropletRepo.ListDropletsCallCount()).To(BeZero())
+		})
+	})
+
+	When("listing packages fails", func() {
+		BeforeEach(func() {
+			packageRepo.ListPackagesReturns(nil, errors.New("list-packages-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(storageErr).To(MatchError("list-packages-err"))
+		})
+	})
+
+	When("listing droplets fails", func() {
+		BeforeEach(func() {
+			dropletRepo.ListDropletsReturns(nil, errors.New("list-droplets-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(storageErr).To(MatchError("list-droplets-err"))
+		})
+	})
+
+	When("getting an image size fails", func() {
+		BeforeEach(func() {
+			imageRepo.GetImageSizeStub = nil
+			imageRepo.GetImageSizeReturns(0, errors.New("image-size-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(storageErr).To(MatchError("image-size-err"))
+		})
+	})
+})
@@ -10,11 +10,12 @@ import (
 )
 
 type Normalizer struct {
-	NormalizeStub        func(payloads.ManifestApplication, manifest.AppState) payloads.ManifestApplication
+	NormalizeStub        func(payloads.ManifestApplication, manifest.AppState, string) payloads.ManifestApplication
 	normalizeMutex       sync.RWMutex
 	normalizeArgsForCall []struct {
 		arg1 payloads.ManifestApplication
 		arg2 manifest.AppState
+		arg3 string
 	}
 	normalizeReturns struct {
 		result1 payloads.ManifestApplication
@@ -26,19 +27,20 @@ type Normalizer struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *Normalizer) Normalize(arg1 payloads.ManifestApplication, arg2 manifest.AppState) payloads.ManifestApplication {
+func (fake *Normalizer) Normalize(arg1 payloads.ManifestApplication, arg2 manifest.AppState, arg3 string) payloads.ManifestApplication {
 	fake.normalizeMutex.Lock()
 	ret, specificReturn := fake.normalizeReturnsOnCall[len(fake.normalizeArgsForCall)]
 	fake.normalizeArgsForCall = append(fake.normalizeArgsForCall, struct {
 		arg1 payloads.ManifestApplication
 		arg2 manifest.AppState
-	}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.NormalizeStub
 	fakeReturns := fake.normalizeReturns
-	fake.recordInvocation("Normalize", []interface{}{arg1, arg2})
+	fake.recordInvocation("Normalize", []interface{}{arg1, arg2, arg3})
 	fake.normalizeMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -52,17 +54,17 @@ func (fake *Normalizer) NormalizeCallCount() int {
 	return len(fake.normalizeArgsForCall)
 }
 
-func (fake *Normalizer) NormalizeCalls(stub func(payloads.ManifestApplication, manifest.AppState) payloads.ManifestApplication) {
+func (fake *Normalizer) NormalizeCalls(stub func(payloads.ManifestApplication, manifest.AppState, string) payloads.ManifestApplication) {
 	fake.normalizeMutex.Lock()
 	defer fake.normalizeMutex.Unlock()
 	fake.NormalizeStub = stub
 }
 
-func (fake *Normalizer) NormalizeArgsForCall(i int) (payloads.ManifestApplication, manifest.AppState) {
+func (fake *Normalizer) NormalizeArgsForCall(i int) (payloads.ManifestApplication, manifest.AppState, string) {
 	fake.normalizeMutex.RLock()
 	defer fake.normalizeMutex.RUnlock()
 	argsForCall := fake.normalizeArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *Normalizer) NormalizeReturns(result1 payloads.ManifestApplication) {
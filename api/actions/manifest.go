@@ -2,13 +2,13 @@ package actions
 
 import (
 	"context"
-	"fmt"
 
 	"code.cloudfoundry.org/korifi/api/actions/manifest"
 	"code.cloudfoundry.org/korifi/api/actions/shared"
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 )
 
 //counterfeiter:generate -o fake -fake-name StateCollector . StateCollector
@@ -18,7 +18,7 @@ type StateCollector interface {
 
 //counterfeiter:generate -o fake -fake-name Normalizer . Normalizer
 type Normalizer interface {
-	Normalize(appInfo payloads.ManifestApplication, appState manifest.AppState) payloads.ManifestApplication
+	Normalize(appInfo payloads.ManifestApplication, appState manifest.AppState, defaultDomainName string) payloads.ManifestApplication
 }
 
 //counterfeiter:generate -o fake -fake-name Applier . Applier
@@ -27,26 +27,26 @@ type Applier interface {
 }
 
 type Manifest struct {
-	domainRepo        shared.CFDomainRepository
-	defaultDomainName string
-	stateCollector    StateCollector
-	normalizer        Normalizer
-	applier           Applier
+	appRepo        shared.CFAppRepository
+	domainRepo     shared.CFDomainRepository
+	stateCollector StateCollector
+	normalizer     Normalizer
+	applier        Applier
 }
 
-func NewManifest(domainRepo shared.CFDomainRepository, defaultDomainName string, stateCollector StateCollector, normalizer Normalizer, applier Applier,
+func NewManifest(appRepo shared.CFAppRepository, domainRepo shared.CFDomainRepository, stateCollector StateCollector, normalizer Normalizer, applier Applier,
 ) *Manifest {
 	return &Manifest{
-		domainRepo:        domainRepo,
-		defaultDomainName: defaultDomainName,
-		stateCollector:    stateCollector,
-		normalizer:        normalizer,
-		applier:           applier,
+		appRepo:        appRepo,
+		domainRepo:     domainRepo,
+		stateCollector: stateCollector,
+		normalizer:     normalizer,
+		applier:        applier,
 	}
 }
 
 func (a *Manifest) Apply(ctx context.Context, authInfo authorization.Info, spaceGUID string, manifesto payloads.Manifest) error {
-	err := a.ensureDefaultDomainConfigured(ctx, authInfo)
+	defaultDomain, err := a.getDefaultDomain(ctx, authInfo)
 	if err != nil {
 		return err
 	}
@@ -56,7 +56,7 @@ func (a *Manifest) Apply(ctx context.Context, authInfo authorization.Info, space
 		if err != nil {
 			return err
 		}
-		appInfo = a.normalizer.Normalize(appInfo, appState)
+		appInfo = a.normalizer.Normalize(appInfo, appState, defaultDomain.Name)
 		err = a.applier.Apply(ctx, authInfo, spaceGUID, appInfo, appState)
 		if err != nil {
 			return err
@@ -66,15 +66,37 @@ func (a *Manifest) Apply(ctx context.Context, authInfo authorization.Info, space
 	return nil
 }
 
-func (a *Manifest) ensureDefaultDomainConfigured(ctx context.Context, authInfo authorization.Info) error {
-	_, err := a.domainRepo.GetDomainByName(ctx, authInfo, a.defaultDomainName)
+func (a *Manifest) GenerateManifest(ctx context.Context, authInfo authorization.Info, appGUID string) (payloads.Manifest, error) {
+	app, err := a.appRepo.GetApp(ctx, authInfo, appGUID)
 	if err != nil {
-		return apierrors.AsUnprocessableEntity(
+		return payloads.Manifest{}, err
+	}
+
+	appState, err := a.stateCollector.CollectState(ctx, authInfo, app.Name, app.SpaceGUID)
+	if err != nil {
+		return payloads.Manifest{}, err
+	}
+
+	appEnv, err := a.appRepo.GetAppEnv(ctx, authInfo, appGUID)
+	if err != nil {
+		return payloads.Manifest{}, err
+	}
+
+	return payloads.Manifest{
+		Version:      1,
+		Applications: []payloads.ManifestApplication{manifest.GenerateApplication(appState, appEnv.EnvironmentVariables)},
+	}, nil
+}
+
+func (a *Manifest) getDefaultDomain(ctx context.Context, authInfo authorization.Info) (repositories.DomainRecord, error) {
+	defaultDomain, err := a.domainRepo.GetDefaultDomain(ctx, authInfo)
+	if err != nil {
+		return repositories.DomainRecord{}, apierrors.AsUnprocessableEntity(
 			err,
-			fmt.Sprintf("The configured default domain %q was not found", a.defaultDomainName),
+			"The default domain was not found",
 			apierrors.NotFoundError{},
 		)
 	}
 
-	return nil
+	return defaultDomain, nil
 }
@@ -21,6 +21,7 @@ type Applier struct {
 	routeRepo           shared.CFRouteRepository
 	serviceInstanceRepo shared.CFServiceInstanceRepository
 	serviceBindingRepo  shared.CFServiceBindingRepository
+	rollbackCreatedApps bool
 }
 
 func NewApplier(
@@ -30,6 +31,7 @@ func NewApplier(
 	routeRepo shared.CFRouteRepository,
 	serviceInstanceRepo shared.CFServiceInstanceRepository,
 	serviceBindingRepo shared.CFServiceBindingRepository,
+	rollbackCreatedApps bool,
 ) *Applier {
 	return &Applier{
 		appRepo:             appRepo,
@@ -38,15 +40,27 @@ func NewApplier(
 		routeRepo:           routeRepo,
 		serviceInstanceRepo: serviceInstanceRepo,
 		serviceBindingRepo:  serviceBindingRepo,
+		rollbackCreatedApps: rollbackCreatedApps,
 	}
 }
 
 func (a *Applier) Apply(ctx context.Context, authInfo authorization.Info, spaceGUID string, appInfo payloads.ManifestApplication, appState AppState) error {
+	appCreated := appState.App.GUID == ""
+
 	appState, err := a.applyApp(ctx, authInfo, spaceGUID, appInfo, appState)
 	if err != nil {
 		return err
 	}
 
+	err = a.applyAppResources(ctx, authInfo, appInfo, appState)
+	if err != nil && appCreated {
+		return a.handleCreatedAppFailure(ctx, authInfo, appState.App, err)
+	}
+
+	return err
+}
+
+func (a *Applier) applyAppResources(ctx context.Context, authInfo authorization.Info, appInfo payloads.ManifestApplication, appState AppState) error {
 	if err := a.applyProcesses(ctx, authInfo, appInfo, appState); err != nil {
 		return err
 	}
@@ -58,6 +72,25 @@ func (a *Applier) Apply(ctx context.Context, authInfo authorization.Info, spaceG
 	return a.applyServices(ctx, authInfo, appInfo, appState)
 }
 
+// handleCreatedAppFailure reports that the app was created but the rest of
+// its manifest could not be applied. When rollback is enabled the app is
+// deleted again, so that retrying the push starts from a clean slate.
+func (a *Applier) handleCreatedAppFailure(ctx context.Context, authInfo authorization.Info, app repositories.AppRecord, applyErr error) error {
+	if !a.rollbackCreatedApps {
+		return fmt.Errorf("app %q was created but applying its manifest failed: %w", app.Name, applyErr)
+	}
+
+	err := a.appRepo.DeleteApp(ctx, authInfo, repositories.DeleteAppMessage{
+		AppGUID:   app.GUID,
+		SpaceGUID: app.SpaceGUID,
+	})
+	if err != nil {
+		return fmt.Errorf("app %q was created but applying its manifest failed: %w (rolling back the app failed: %v)", app.Name, applyErr, err)
+	}
+
+	return fmt.Errorf("app %q was rolled back as applying its manifest failed: %w", app.Name, applyErr)
+}
+
 func (a *Applier) applyApp(
 	ctx context.Context,
 	authInfo authorization.Info,
@@ -138,6 +171,7 @@ func (a *Applier) createOrUpdateRoute(ctx context.Context, authInfo authorizatio
 			DomainGUID:      domainRecord.GUID,
 			DomainNamespace: domainRecord.Namespace,
 			DomainName:      domainRecord.Name,
+			AppGUID:         appState.App.GUID,
 		})
 	if err != nil {
 		return fmt.Errorf("getOrCreateRoute: %w", err)
@@ -40,7 +40,7 @@ var _ = Describe("Applier", func() {
 		routeRepo = new(fake.CFRouteRepository)
 		serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
 		serviceBindingRepo = new(fake.CFServiceBindingRepository)
-		applier = manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo)
+		applier = manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo, false)
 		ctx = context.Background()
 		authInfo = authorization.Info{Token: "a-token"}
 		appInfo = payloads.ManifestApplication{
@@ -146,6 +146,101 @@ var _ = Describe("Applier", func() {
 		})
 	})
 
+	Describe("creating an app with a route", func() {
+		BeforeEach(func() {
+			appRepo.CreateAppReturns(repositories.AppRecord{
+				Name:      "my-app",
+				GUID:      "app-guid",
+				SpaceGUID: "space-guid",
+			}, nil)
+			appInfo.Routes = []payloads.ManifestRoute{
+				{Route: tools.PtrTo("r1.my.domain")},
+			}
+			domainRepo.GetDomainByNameReturns(repositories.DomainRecord{
+				Namespace: "domain-namespace",
+				Name:      "my.domain",
+				GUID:      "domain-guid",
+			}, nil)
+			routeRepo.GetOrCreateRouteReturns(repositories.RouteRecord{
+				GUID:      "route-guid",
+				SpaceGUID: "space-guid",
+			}, nil)
+		})
+
+		It("attaches the new app to the route as a web destination", func() {
+			Expect(applierErr).NotTo(HaveOccurred())
+
+			Expect(routeRepo.AddDestinationsToRouteCallCount()).To(Equal(1))
+			_, _, addDestinationMessage := routeRepo.AddDestinationsToRouteArgsForCall(0)
+			Expect(addDestinationMessage.RouteGUID).To(Equal("route-guid"))
+			Expect(addDestinationMessage.NewDestinations).To(ConsistOf(repositories.DestinationMessage{
+				AppGUID:     "app-guid",
+				ProcessType: "web",
+			}))
+		})
+
+		When("applying the route fails", func() {
+			BeforeEach(func() {
+				routeRepo.GetOrCreateRouteReturns(repositories.RouteRecord{}, errors.New("get-create-route-err"))
+			})
+
+			It("reports that the app was created regardless", func() {
+				Expect(applierErr).To(MatchError(ContainSubstring(`app "my-app" was created but applying its manifest failed`)))
+				Expect(applierErr).To(MatchError(ContainSubstring("get-create-route-err")))
+				Expect(appRepo.DeleteAppCallCount()).To(BeZero())
+			})
+
+			When("rolling back created apps is enabled", func() {
+				BeforeEach(func() {
+					applier = manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo, true)
+				})
+
+				It("deletes the app", func() {
+					Expect(appRepo.DeleteAppCallCount()).To(Equal(1))
+					_, actualAuthInfo, deleteAppMessage := appRepo.DeleteAppArgsForCall(0)
+					Expect(actualAuthInfo).To(Equal(authInfo))
+					Expect(deleteAppMessage).To(Equal(repositories.DeleteAppMessage{
+						AppGUID:   "app-guid",
+						SpaceGUID: "space-guid",
+					}))
+				})
+
+				It("reports the rollback", func() {
+					Expect(applierErr).To(MatchError(ContainSubstring(`app "my-app" was rolled back`)))
+					Expect(applierErr).To(MatchError(ContainSubstring("get-create-route-err")))
+				})
+
+				When("deleting the app fails", func() {
+					BeforeEach(func() {
+						appRepo.DeleteAppReturns(errors.New("delete-app-err"))
+					})
+
+					It("reports both errors", func() {
+						Expect(applierErr).To(MatchError(ContainSubstring("get-create-route-err")))
+						Expect(applierErr).To(MatchError(ContainSubstring("delete-app-err")))
+					})
+				})
+			})
+		})
+
+		When("the app already existed", func() {
+			BeforeEach(func() {
+				appState.App = repositories.AppRecord{
+					Name:      "my-app",
+					GUID:      "app-guid",
+					SpaceGUID: "space-guid",
+				}
+				applier = manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo, true)
+				routeRepo.GetOrCreateRouteReturns(repositories.RouteRecord{}, errors.New("get-create-route-err"))
+			})
+
+			It("does not delete it when applying the route fails", func() {
+				Expect(applierErr).To(MatchError("createOrUpdateRoutes: getOrCreateRoute: get-create-route-err"))
+				Expect(appRepo.DeleteAppCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("applying processes", func() {
 		BeforeEach(func() {
 			appState.App.GUID = "app-guid"
@@ -300,6 +395,7 @@ var _ = Describe("Applier", func() {
 				DomainNamespace: "domain-namespace",
 				DomainName:      "domain-name",
 				DomainGUID:      "domain-guid",
+				AppGUID:         "app-guid",
 			}))
 		})
 
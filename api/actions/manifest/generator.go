@@ -0,0 +1,108 @@
+package manifest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/tools"
+	"golang.org/x/exp/maps"
+)
+
+// GenerateApplication builds the manifest application describing the current
+// state of an app. Applying the result to the same app should be a no-op.
+func GenerateApplication(appState AppState, envVars map[string]string) payloads.ManifestApplication {
+	appInfo := payloads.ManifestApplication{
+		Name:       appState.App.Name,
+		Env:        envVars,
+		Buildpacks: appState.App.Lifecycle.Data.Buildpacks,
+		Metadata: payloads.MetadataPatch{
+			Labels:      userMetadata(appState.App.Labels),
+			Annotations: userMetadata(appState.App.Annotations),
+		},
+	}
+
+	processTypes := maps.Keys(appState.Processes)
+	sort.Strings(processTypes)
+	for _, processType := range processTypes {
+		appInfo.Processes = append(appInfo.Processes, generateProcess(appState, processType))
+	}
+
+	routes := maps.Keys(appState.Routes)
+	sort.Strings(routes)
+	for _, route := range routes {
+		appInfo.Routes = append(appInfo.Routes, payloads.ManifestRoute{Route: tools.PtrTo(route)})
+	}
+	appInfo.NoRoute = len(routes) == 0
+
+	serviceNames := maps.Keys(appState.ServiceBindings)
+	sort.Strings(serviceNames)
+	for _, serviceName := range serviceNames {
+		appInfo.Services = append(appInfo.Services, payloads.ManifestApplicationService{
+			Name:        serviceName,
+			BindingName: appState.ServiceBindings[serviceName].Name,
+		})
+	}
+
+	return appInfo
+}
+
+func generateProcess(appState AppState, processType string) payloads.ManifestApplicationProcess {
+	process := appState.Processes[processType]
+
+	processInfo := payloads.ManifestApplicationProcess{
+		Type:            process.Type,
+		Instances:       tools.PtrTo(process.DesiredInstances),
+		Memory:          tools.PtrTo(fmt.Sprintf("%dM", process.MemoryMB)),
+		DiskQuota:       tools.PtrTo(fmt.Sprintf("%dM", process.DiskQuotaMB)),
+		HealthCheckType: tools.PtrTo(process.HealthCheck.Type),
+	}
+
+	if process.Command != "" {
+		processInfo.Command = tools.PtrTo(process.Command)
+	}
+
+	if process.HealthCheck.Data.HTTPEndpoint != "" {
+		processInfo.HealthCheckHTTPEndpoint = tools.PtrTo(process.HealthCheck.Data.HTTPEndpoint)
+	}
+
+	if process.HealthCheck.Data.InvocationTimeoutSeconds != 0 {
+		processInfo.HealthCheckInvocationTimeout = tools.PtrTo(process.HealthCheck.Data.InvocationTimeoutSeconds)
+	}
+
+	if process.HealthCheck.Data.TimeoutSeconds != 0 {
+		processInfo.Timeout = tools.PtrTo(process.HealthCheck.Data.TimeoutSeconds)
+	}
+
+	return processInfo
+}
+
+// userMetadata drops the labels and annotations that korifi manages itself,
+// as they are not meant to be set via a manifest
+func userMetadata(metadata map[string]string) map[string]*string {
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	result := map[string]*string{}
+	for key, value := range metadata {
+		if isReservedMetadataKey(key) {
+			continue
+		}
+		result[key] = tools.PtrTo(value)
+	}
+
+	return result
+}
+
+// isReservedMetadataKey tells whether the key is prefixed with the
+// cloudfoundry.org domain or one of its subdomains, which users cannot set
+func isReservedMetadataKey(key string) bool {
+	prefix, _, found := strings.Cut(key, "/")
+	if !found {
+		return false
+	}
+
+	return prefix == "cloudfoundry.org" || strings.HasSuffix(prefix, ".cloudfoundry.org")
+}
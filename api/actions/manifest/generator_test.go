@@ -0,0 +1,208 @@
+package manifest_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
+	"code.cloudfoundry.org/korifi/api/actions/shared/fake"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("GenerateApplication", func() {
+	var (
+		appState manifest.AppState
+		envVars  map[string]string
+		appInfo  payloads.ManifestApplication
+	)
+
+	BeforeEach(func() {
+		appState = manifest.AppState{
+			App: repositories.AppRecord{
+				GUID:      "app-guid",
+				Name:      "my-app",
+				SpaceGUID: "space-guid",
+				Labels: map[string]string{
+					"foo":                              "FOO",
+					"korifi.cloudfoundry.org/app-guid": "app-guid",
+					"cloudfoundry.org/org-guid":        "org-guid",
+					"cloudfoundry.org.example.com/foo": "FOO",
+				},
+				Annotations: map[string]string{
+					"bar":                             "BAR",
+					"korifi.cloudfoundry.org/app-rev": "1",
+					"notcloudfoundry.org/bar":         "BAR",
+				},
+				Lifecycle: repositories.Lifecycle{
+					Type: "buildpack",
+					Data: repositories.LifecycleData{
+						Buildpacks: []string{"buildpack-a"},
+					},
+				},
+			},
+			Processes: map[string]repositories.ProcessRecord{
+				"web": {
+					GUID:             "web-guid",
+					Type:             "web",
+					Command:          "start-web",
+					DesiredInstances: 2,
+					MemoryMB:         512,
+					DiskQuotaMB:      1024,
+					HealthCheck: repositories.HealthCheck{
+						Type: "http",
+						Data: repositories.HealthCheckData{
+							HTTPEndpoint:             "/healthz",
+							InvocationTimeoutSeconds: 3,
+							TimeoutSeconds:           60,
+						},
+					},
+				},
+				"worker": {
+					GUID:             "worker-guid",
+					Type:             "worker",
+					DesiredInstances: 1,
+					MemoryMB:         256,
+					DiskQuotaMB:      512,
+					HealthCheck: repositories.HealthCheck{
+						Type: "process",
+					},
+				},
+			},
+			Routes: map[string]repositories.RouteRecord{
+				"my-app.my.domain/path": {GUID: "route-guid"},
+			},
+			ServiceBindings: map[string]repositories.ServiceBindingRecord{
+				"my-service": {GUID: "binding-guid", Name: tools.PtrTo("my-binding")},
+			},
+		}
+		envVars = map[string]string{"FOO": "bar"}
+	})
+
+	JustBeforeEach(func() {
+		appInfo = manifest.GenerateApplication(appState, envVars)
+	})
+
+	It("describes the app", func() {
+		Expect(appInfo.Name).To(Equal("my-app"))
+		Expect(appInfo.Env).To(Equal(map[string]string{"FOO": "bar"}))
+		Expect(appInfo.Buildpacks).To(ConsistOf("buildpack-a"))
+		Expect(appInfo.NoRoute).To(BeFalse())
+		Expect(appInfo.Routes).To(ConsistOf(payloads.ManifestRoute{Route: tools.PtrTo("my-app.my.domain/path")}))
+		Expect(appInfo.Services).To(ConsistOf(payloads.ManifestApplicationService{
+			Name:        "my-service",
+			BindingName: tools.PtrTo("my-binding"),
+		}))
+	})
+
+	It("excludes metadata managed by korifi", func() {
+		Expect(appInfo.Metadata.Labels).To(Equal(map[string]*string{
+			"foo":                              tools.PtrTo("FOO"),
+			"cloudfoundry.org.example.com/foo": tools.PtrTo("FOO"),
+		}))
+		Expect(appInfo.Metadata.Annotations).To(Equal(map[string]*string{
+			"bar":                     tools.PtrTo("BAR"),
+			"notcloudfoundry.org/bar": tools.PtrTo("BAR"),
+		}))
+	})
+
+	It("describes the processes", func() {
+		Expect(appInfo.Processes).To(Equal([]payloads.ManifestApplicationProcess{
+			{
+				Type:                         "web",
+				Command:                      tools.PtrTo("start-web"),
+				Instances:                    tools.PtrTo(2),
+				Memory:                       tools.PtrTo("512M"),
+				DiskQuota:                    tools.PtrTo("1024M"),
+				HealthCheckType:              tools.PtrTo("http"),
+				HealthCheckHTTPEndpoint:      tools.PtrTo("/healthz"),
+				HealthCheckInvocationTimeout: tools.PtrTo(int64(3)),
+				Timeout:                      tools.PtrTo(int64(60)),
+			},
+			{
+				Type:            "worker",
+				Instances:       tools.PtrTo(1),
+				Memory:          tools.PtrTo("256M"),
+				DiskQuota:       tools.PtrTo("512M"),
+				HealthCheckType: tools.PtrTo("process"),
+			},
+		}))
+	})
+
+	When("the app has no routes", func() {
+		BeforeEach(func() {
+			appState.Routes = map[string]repositories.RouteRecord{}
+		})
+
+		It("sets no-route", func() {
+			Expect(appInfo.NoRoute).To(BeTrue())
+			Expect(appInfo.Routes).To(BeEmpty())
+		})
+	})
+
+	Describe("applying the generated manifest", func() {
+		var (
+			appRepo             *fake.CFAppRepository
+			processRepo         *fake.CFProcessRepository
+			routeRepo           *fake.CFRouteRepository
+			serviceBindingRepo  *fake.CFServiceBindingRepository
+			serviceInstanceRepo *fake.CFServiceInstanceRepository
+			applyErr            error
+		)
+
+		JustBeforeEach(func() {
+			appRepo = new(fake.CFAppRepository)
+			processRepo = new(fake.CFProcessRepository)
+			routeRepo = new(fake.CFRouteRepository)
+			serviceBindingRepo = new(fake.CFServiceBindingRepository)
+			serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
+			applier := manifest.NewApplier(appRepo, new(fake.CFDomainRepository), processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo, false)
+
+			normalizedAppInfo := manifest.NewNormalizer().Normalize(appInfo, appState, "my.domain")
+			applyErr = applier.Apply(context.Background(), authorization.Info{}, "space-guid", normalizedAppInfo, appState)
+		})
+
+		It("reproduces the same state", func() {
+			Expect(applyErr).NotTo(HaveOccurred())
+
+			Expect(appRepo.CreateAppCallCount()).To(Equal(0))
+			Expect(appRepo.PatchAppCallCount()).To(Equal(1))
+			_, _, patchAppMessage := appRepo.PatchAppArgsForCall(0)
+			Expect(patchAppMessage.AppGUID).To(Equal("app-guid"))
+			Expect(patchAppMessage.EnvironmentVariables).To(Equal(envVars))
+			Expect(patchAppMessage.Lifecycle.Data.Buildpacks).To(PointTo(ConsistOf("buildpack-a")))
+
+			Expect(processRepo.CreateProcessCallCount()).To(Equal(0))
+			Expect(processRepo.PatchProcessCallCount()).To(Equal(2))
+			patchedProcesses := map[string]repositories.PatchProcessMessage{}
+			for i := 0; i < processRepo.PatchProcessCallCount(); i++ {
+				_, _, message := processRepo.PatchProcessArgsForCall(i)
+				patchedProcesses[message.ProcessGUID] = message
+			}
+			Expect(patchedProcesses["web-guid"].Command).To(PointTo(Equal("start-web")))
+			Expect(patchedProcesses["web-guid"].DesiredInstances).To(PointTo(Equal(2)))
+			Expect(patchedProcesses["web-guid"].MemoryMB).To(PointTo(BeEquivalentTo(512)))
+			Expect(patchedProcesses["web-guid"].DiskQuotaMB).To(PointTo(BeEquivalentTo(1024)))
+			Expect(patchedProcesses["web-guid"].HealthCheckType).To(PointTo(Equal("http")))
+			Expect(patchedProcesses["web-guid"].HealthCheckHTTPEndpoint).To(PointTo(Equal("/healthz")))
+			Expect(patchedProcesses["web-guid"].HealthCheckInvocationTimeoutSeconds).To(PointTo(BeEquivalentTo(3)))
+			Expect(patchedProcesses["web-guid"].HealthCheckTimeoutSeconds).To(PointTo(BeEquivalentTo(60)))
+			Expect(patchedProcesses["worker-guid"].DesiredInstances).To(PointTo(Equal(1)))
+			Expect(patchedProcesses["worker-guid"].MemoryMB).To(PointTo(BeEquivalentTo(256)))
+			Expect(patchedProcesses["worker-guid"].DiskQuotaMB).To(PointTo(BeEquivalentTo(512)))
+			Expect(patchedProcesses["worker-guid"].HealthCheckType).To(PointTo(Equal("process")))
+
+			Expect(routeRepo.GetOrCreateRouteCallCount()).To(Equal(0))
+			Expect(routeRepo.AddDestinationsToRouteCallCount()).To(Equal(0))
+			Expect(routeRepo.RemoveDestinationFromRouteCallCount()).To(Equal(0))
+
+			Expect(serviceInstanceRepo.ListServiceInstancesCallCount()).To(Equal(0))
+			Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(0))
+		})
+	})
+})
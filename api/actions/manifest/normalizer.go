@@ -8,20 +8,16 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 )
 
-type Normalizer struct {
-	defaultDomainName string
-}
+type Normalizer struct{}
 
-func NewNormalizer(defaultDomainName string) Normalizer {
-	return Normalizer{
-		defaultDomainName: defaultDomainName,
-	}
+func NewNormalizer() Normalizer {
+	return Normalizer{}
 }
 
-func (n Normalizer) Normalize(appInfo payloads.ManifestApplication, appState AppState) payloads.ManifestApplication {
+func (n Normalizer) Normalize(appInfo payloads.ManifestApplication, appState AppState, defaultDomainName string) payloads.ManifestApplication {
 	fixDeprecatedFields(&appInfo)
 	processes := n.normalizeProcesses(appInfo, appState)
-	routes := n.normalizeRoutes(appInfo, appState)
+	routes := n.normalizeRoutes(appInfo, appState, defaultDomainName)
 
 	return payloads.ManifestApplication{
 		Name:       appInfo.Name,
@@ -98,7 +94,7 @@ func (n Normalizer) normalizeProcesses(appInfo payloads.ManifestApplication, app
 	return processes
 }
 
-func (n Normalizer) normalizeRoutes(appInfo payloads.ManifestApplication, appState AppState) []payloads.ManifestRoute {
+func (n Normalizer) normalizeRoutes(appInfo payloads.ManifestApplication, appState AppState, defaultDomainName string) []payloads.ManifestRoute {
 	if appInfo.NoRoute {
 		return nil
 	}
@@ -108,27 +104,27 @@ func (n Normalizer) normalizeRoutes(appInfo payloads.ManifestApplication, appSta
 	needsAutomaticRoutes := len(routes) == 0 && len(appState.Routes) == 0
 	if needsAutomaticRoutes {
 		if appInfo.DefaultRoute {
-			routes = append(routes, n.configureDefaultRoute(appInfo.Name))
+			routes = append(routes, n.configureDefaultRoute(appInfo.Name, defaultDomainName))
 		}
 
 		if appInfo.RandomRoute {
-			routes = append(routes, n.configureRandomRoute(appInfo.Name))
+			routes = append(routes, n.configureRandomRoute(appInfo.Name, defaultDomainName))
 		}
 	}
 
 	return routes
 }
 
-func (n Normalizer) configureDefaultRoute(appName string) payloads.ManifestRoute {
-	defaultRouteString := appName + "." + n.defaultDomainName
+func (n Normalizer) configureDefaultRoute(appName, defaultDomainName string) payloads.ManifestRoute {
+	defaultRouteString := appName + "." + defaultDomainName
 	return payloads.ManifestRoute{
 		Route: &defaultRouteString,
 	}
 }
 
-func (n Normalizer) configureRandomRoute(appName string) payloads.ManifestRoute {
+func (n Normalizer) configureRandomRoute(appName, defaultDomainName string) payloads.ManifestRoute {
 	randomHostname := appName + "-" + generateRandomRoute()
-	routeString := randomHostname + "." + n.defaultDomainName
+	routeString := randomHostname + "." + defaultDomainName
 	return payloads.ManifestRoute{
 		Route: &routeString,
 	}
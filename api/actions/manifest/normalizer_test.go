@@ -56,11 +56,11 @@ var _ = Describe("Normalizer", func() {
 			Processes: nil,
 			Routes:    nil,
 		}
-		normalizer = manifest.NewNormalizer(defaultDomainName)
+		normalizer = manifest.NewNormalizer()
 	})
 
 	JustBeforeEach(func() {
-		normalizedAppInfo = normalizer.Normalize(appInfo, appState)
+		normalizedAppInfo = normalizer.Normalize(appInfo, appState, defaultDomainName)
 	})
 
 	Describe("app normalization", func() {
@@ -182,7 +182,7 @@ var _ = Describe("Normalizer", func() {
 					})
 				}
 
-				updatedAppInfo := normalizer.Normalize(appInfo, appState)
+				updatedAppInfo := normalizer.Normalize(appInfo, appState, defaultDomainName)
 				webProc := getWebProcess(updatedAppInfo)
 
 				Expect(webProc.Memory).To(Equal(effective.Memory))
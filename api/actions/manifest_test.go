@@ -21,6 +21,7 @@ var _ = Describe("ApplyManifest", func() {
 		manifestAction *actions.Manifest
 		applyErr       error
 
+		appRepository    *reposfake.CFAppRepository
 		domainRepository *reposfake.CFDomainRepository
 		stateCollector   *fake.StateCollector
 		normalizer       *fake.Normalizer
@@ -30,6 +31,7 @@ var _ = Describe("ApplyManifest", func() {
 	)
 
 	BeforeEach(func() {
+		appRepository = new(reposfake.CFAppRepository)
 		domainRepository = new(reposfake.CFDomainRepository)
 		stateCollector = new(fake.StateCollector)
 		normalizer = new(fake.Normalizer)
@@ -63,7 +65,9 @@ var _ = Describe("ApplyManifest", func() {
 			}},
 		}
 
-		manifestAction = actions.NewManifest(domainRepository, "my.domain", stateCollector, normalizer, applier)
+		domainRepository.GetDefaultDomainReturns(repositories.DomainRecord{Name: "my.domain"}, nil)
+
+		manifestAction = actions.NewManifest(appRepository, domainRepository, stateCollector, normalizer, applier)
 	})
 
 	JustBeforeEach(func() {
@@ -73,9 +77,7 @@ var _ = Describe("ApplyManifest", func() {
 	It("normalizes the manifest and then applies it", func() {
 		Expect(applyErr).NotTo(HaveOccurred())
 
-		Expect(domainRepository.GetDomainByNameCallCount()).To(Equal(1))
-		_, _, actualDomain := domainRepository.GetDomainByNameArgsForCall(0)
-		Expect(actualDomain).To(Equal("my.domain"))
+		Expect(domainRepository.GetDefaultDomainCallCount()).To(Equal(1))
 
 		Expect(stateCollector.CollectStateCallCount()).To(Equal(2))
 		_, _, actualAppName, actualSpaceGUID := stateCollector.CollectStateArgsForCall(0)
@@ -86,12 +88,14 @@ var _ = Describe("ApplyManifest", func() {
 		Expect(actualSpaceGUID).To(Equal("space-guid"))
 
 		Expect(normalizer.NormalizeCallCount()).To(Equal(2))
-		actualAppInManifest, actualState := normalizer.NormalizeArgsForCall(0)
+		actualAppInManifest, actualState, actualDefaultDomainName := normalizer.NormalizeArgsForCall(0)
 		Expect(actualAppInManifest.Name).To(Equal("app1"))
 		Expect(actualState.App.GUID).To(Equal("app1-guid"))
-		actualAppInManifest, actualState = normalizer.NormalizeArgsForCall(1)
+		Expect(actualDefaultDomainName).To(Equal("my.domain"))
+		actualAppInManifest, actualState, actualDefaultDomainName = normalizer.NormalizeArgsForCall(1)
 		Expect(actualAppInManifest.Name).To(Equal("app2"))
 		Expect(actualState.App.GUID).To(Equal("app2-guid"))
+		Expect(actualDefaultDomainName).To(Equal("my.domain"))
 
 		Expect(applier.ApplyCallCount()).To(Equal(2))
 		_, _, actualSpaceGUID, actualAppInManifest, actualState = applier.ApplyArgsForCall(0)
@@ -106,7 +110,7 @@ var _ = Describe("ApplyManifest", func() {
 
 	When("the default domain does not exist", func() {
 		BeforeEach(func() {
-			domainRepository.GetDomainByNameReturns(repositories.DomainRecord{}, apierrors.NewNotFoundError(nil, "domain"))
+			domainRepository.GetDefaultDomainReturns(repositories.DomainRecord{}, apierrors.NewNotFoundError(nil, "domain"))
 		})
 
 		It("returns an unprocessable entity error", func() {
@@ -116,7 +120,7 @@ var _ = Describe("ApplyManifest", func() {
 
 	When("getting the default domain fails", func() {
 		BeforeEach(func() {
-			domainRepository.GetDomainByNameReturns(repositories.DomainRecord{}, errors.New("get-domain-err"))
+			domainRepository.GetDefaultDomainReturns(repositories.DomainRecord{}, errors.New("get-domain-err"))
 		})
 
 		It("returns the error", func() {
@@ -144,3 +148,88 @@ var _ = Describe("ApplyManifest", func() {
 		})
 	})
 })
+
+var _ = Describe("GenerateManifest", func() {
+	var (
+		manifestAction *actions.Manifest
+		appRepository  *reposfake.CFAppRepository
+		stateCollector *fake.StateCollector
+
+		generatedManifest payloads.Manifest
+		generateErr       error
+	)
+
+	BeforeEach(func() {
+		appRepository = new(reposfake.CFAppRepository)
+		stateCollector = new(fake.StateCollector)
+
+		appRepository.GetAppReturns(repositories.AppRecord{
+			GUID:      "app-guid",
+			Name:      "my-app",
+			SpaceGUID: "space-guid",
+		}, nil)
+		appRepository.GetAppEnvReturns(repositories.AppEnvRecord{
+			EnvironmentVariables: map[string]string{"FOO": "bar"},
+		}, nil)
+		stateCollector.CollectStateReturns(manifest.AppState{
+			App: repositories.AppRecord{
+				GUID: "app-guid",
+				Name: "my-app",
+			},
+		}, nil)
+
+		manifestAction = actions.NewManifest(appRepository, new(reposfake.CFDomainRepository), stateCollector, new(fake.Normalizer), new(fake.Applier))
+	})
+
+	JustBeforeEach(func() {
+		generatedManifest, generateErr = manifestAction.GenerateManifest(context.Background(), authorization.Info{}, "app-guid")
+	})
+
+	It("generates a manifest from the app state", func() {
+		Expect(generateErr).NotTo(HaveOccurred())
+
+		Expect(appRepository.GetAppCallCount()).To(Equal(1))
+		_, _, actualAppGUID := appRepository.GetAppArgsForCall(0)
+		Expect(actualAppGUID).To(Equal("app-guid"))
+
+		Expect(stateCollector.CollectStateCallCount()).To(Equal(1))
+		_, _, actualAppName, actualSpaceGUID := stateCollector.CollectStateArgsForCall(0)
+		Expect(actualAppName).To(Equal("my-app"))
+		Expect(actualSpaceGUID).To(Equal("space-guid"))
+
+		Expect(generatedManifest.Version).To(Equal(1))
+		Expect(generatedManifest.Applications).To(HaveLen(1))
+		Expect(generatedManifest.Applications[0].Name).To(Equal("my-app"))
+		Expect(generatedManifest.Applications[0].Env).To(Equal(map[string]string{"FOO": "bar"}))
+	})
+
+	When("getting the app fails", func() {
+		BeforeEach(func() {
+			appRepository.GetAppReturns(repositories.AppRecord{}, errors.New("get-app-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(generateErr).To(MatchError("get-app-err"))
+		})
+	})
+
+	When("collecting the app state fails", func() {
+		BeforeEach(func() {
+			stateCollector.CollectStateReturns(manifest.AppState{}, errors.New("collect-state-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(generateErr).To(MatchError("collect-state-err"))
+		})
+	})
+
+	When("getting the app env fails", func() {
+		BeforeEach(func() {
+			appRepository.GetAppEnvReturns(repositories.AppEnvRecord{}, errors.New("get-env-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(generateErr).To(MatchError("get-env-err"))
+		})
+	})
+})
@@ -3,7 +3,6 @@ package actions
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/actions/shared"
@@ -18,14 +17,20 @@ import (
 )
 
 const (
-	ApplicationContainerName = "application"
-	EnvCFInstanceIndex       = "CF_INSTANCE_INDEX"
-	LabelGUID                = "korifi.cloudfoundry.org/guid"
-	LabelVersion             = "korifi.cloudfoundry.org/version"
-	stateStarting            = "STARTING"
-	stateRunning             = "RUNNING"
-	stateDown                = "DOWN"
-	stateCrashed             = "CRASHED"
+	LabelGUID     = "korifi.cloudfoundry.org/guid"
+	LabelVersion  = "korifi.cloudfoundry.org/version"
+	stateStarting = "STARTING"
+	stateRunning  = "RUNNING"
+	stateDown     = "DOWN"
+	stateCrashed  = "CRASHED"
+
+	// A process instance is crash-looping when its application container
+	// has been restarted at least crashLoopRestartThreshold times and the
+	// last crash happened within crashLoopWindow, or when kubernetes is
+	// backing off restarting it
+	crashLoopRestartThreshold = 3
+	crashLoopWindow           = 5 * time.Minute
+	crashLoopBackOffReason    = "CrashLoopBackOff"
 )
 
 //counterfeiter:generate -o fake -fake-name MetricsRepository . MetricsRepository
@@ -43,12 +48,21 @@ type (
 	}
 
 	PodStatsRecord struct {
-		Type      string
-		Index     int
-		State     string `default:"DOWN"`
-		Usage     Usage
-		MemQuota  *int64
-		DiskQuota *int64
+		Type            string
+		Index           int
+		State           string `default:"DOWN"`
+		Usage           Usage
+		MemQuota        *int64
+		DiskQuota       *int64
+		CrashLooping    bool
+		LastCrashReason *string
+	}
+
+	InstanceUsageRecord struct {
+		ProcessGUID string
+		ProcessType string
+		Index       int
+		Usage       Usage
 	}
 
 	ProcessStats struct {
@@ -107,7 +121,7 @@ func (a *ProcessStats) FetchStats(ctx context.Context, authInfo authorization.In
 	}
 
 	for _, m := range metrics {
-		index, err := extractIndex(m.Pod)
+		index, err := korifiv1alpha1.AppWorkloadInstanceIndex(m.Pod)
 		if err != nil {
 			return nil, err
 		}
@@ -122,32 +136,13 @@ func (a *ProcessStats) FetchStats(ctx context.Context, authInfo authorization.In
 		}
 
 		records[index].State = podState
+		records[index].CrashLooping, records[index].LastCrashReason = getCrashLoopStatus(m.Pod)
 
-		metricsMap := aggregateContainerMetrics(m.Metrics.Containers)
-		if len(metricsMap) == 0 {
+		usage, ok := usageFromMetrics(m.Metrics)
+		if !ok {
 			continue
 		}
-
-		if cpuQuantity, ok := metricsMap["cpu"]; ok {
-			value := float64(cpuQuantity.ScaledValue(resource.Nano))
-			// CF tracks CPU usage as a percentage of cores used.
-			// Convert the number of nanoCPU to CPU for greatest accuracy.
-			percentage := value / 1e9
-			records[index].Usage.CPU = &percentage
-		}
-
-		if memQuantity, ok := metricsMap["memory"]; ok {
-			value := memQuantity.Value()
-			records[index].Usage.Mem = &value
-		}
-
-		if storageQuantity, ok := metricsMap["storage"]; ok {
-			value := storageQuantity.Value()
-			records[index].Usage.Disk = &value
-		}
-
-		time := m.Metrics.Timestamp.UTC().Format(time.RFC3339)
-		records[index].Usage.Time = &time
+		records[index].Usage = usage
 
 		records[index].MemQuota = tools.PtrTo(megabytesToBytes(processRecord.MemoryMB))
 		records[index].DiskQuota = tools.PtrTo(megabytesToBytes(processRecord.DiskQuotaMB))
@@ -155,46 +150,112 @@ func (a *ProcessStats) FetchStats(ctx context.Context, authInfo authorization.In
 	return records, nil
 }
 
-func extractIndex(pod corev1.Pod) (int, error) {
-	container, err := extractProcessContainer(pod.Spec.Containers)
+// FetchInstanceUsage returns the resource usage of every instance of every
+// process of an app. Instances without metrics (e.g. because metrics-server
+// is not available) report zero usage and no usage time.
+func (a *ProcessStats) FetchInstanceUsage(ctx context.Context, authInfo authorization.Info, appGUID string) ([]InstanceUsageRecord, error) {
+	appRecord, err := a.appRepo.GetApp(ctx, authInfo, appGUID)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	indexString, err := extractEnvVarFromContainer(*container, EnvCFInstanceIndex)
+	processes, err := a.processRepo.ListProcesses(ctx, authInfo, repositories.ListProcessesMessage{
+		AppGUIDs:  []string{appGUID},
+		SpaceGUID: appRecord.SpaceGUID,
+	})
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	index, err := strconv.Atoi(indexString)
+	records := []InstanceUsageRecord{}
+	if appRecord.State == repositories.StoppedState {
+		return records, nil
+	}
+
+	metrics, err := a.metricsRepo.GetMetrics(ctx, authInfo, appRecord.SpaceGUID, client.MatchingLabels{
+		korifiv1alpha1.CFAppGUIDLabelKey: appRecord.GUID,
+		LabelVersion:                     appRecord.Revision,
+	})
 	if err != nil {
-		return 0, fmt.Errorf("%s is not a valid index: %w", EnvCFInstanceIndex, err)
+		return nil, err
 	}
 
-	if index < 0 {
-		return 0, fmt.Errorf("%s is not a valid index: instance indexes can't be negative", EnvCFInstanceIndex)
+	for _, process := range processes {
+		usages := make([]Usage, process.DesiredInstances)
+		for i := range usages {
+			usages[i] = zeroUsage()
+		}
+
+		for _, m := range metrics {
+			if m.Pod.Labels[LabelGUID] != process.GUID {
+				continue
+			}
+
+			index, err := korifiv1alpha1.AppWorkloadInstanceIndex(m.Pod)
+			if err != nil {
+				return nil, err
+			}
+
+			if index >= len(usages) {
+				continue
+			}
+
+			if usage, ok := usageFromMetrics(m.Metrics); ok {
+				usages[index] = usage
+			}
+		}
+
+		for i, usage := range usages {
+			records = append(records, InstanceUsageRecord{
+				ProcessGUID: process.GUID,
+				ProcessType: process.Type,
+				Index:       i,
+				Usage:       usage,
+			})
+		}
 	}
 
-	return index, nil
+	return records, nil
 }
 
-func extractProcessContainer(containers []corev1.Container) (*corev1.Container, error) {
-	for i, c := range containers {
-		if c.Name == ApplicationContainerName {
-			return &containers[i], nil
-		}
+func usageFromMetrics(podMetrics metricsv1beta1.PodMetrics) (Usage, bool) {
+	metricsMap := aggregateContainerMetrics(podMetrics.Containers)
+	if len(metricsMap) == 0 {
+		return Usage{}, false
+	}
+
+	usage := Usage{}
+
+	if cpuQuantity, ok := metricsMap["cpu"]; ok {
+		value := float64(cpuQuantity.ScaledValue(resource.Nano))
+		// CF tracks CPU usage as a percentage of cores used.
+		// Convert the number of nanoCPU to CPU for greatest accuracy.
+		percentage := value / 1e9
+		usage.CPU = &percentage
+	}
+
+	if memQuantity, ok := metricsMap["memory"]; ok {
+		value := memQuantity.Value()
+		usage.Mem = &value
 	}
-	return nil, fmt.Errorf("container %q not found", ApplicationContainerName)
+
+	if storageQuantity, ok := metricsMap["storage"]; ok {
+		value := storageQuantity.Value()
+		usage.Disk = &value
+	}
+
+	time := podMetrics.Timestamp.UTC().Format(time.RFC3339)
+	usage.Time = &time
+
+	return usage, true
 }
 
-func extractEnvVarFromContainer(container corev1.Container, envVar string) (string, error) {
-	envs := container.Env
-	for _, e := range envs {
-		if e.Name == envVar {
-			return e.Value, nil
-		}
+func zeroUsage() Usage {
+	return Usage{
+		CPU:  tools.PtrTo(0.0),
+		Mem:  tools.PtrTo(int64(0)),
+		Disk: tools.PtrTo(int64(0)),
 	}
-	return "", fmt.Errorf("%s not set", envVar)
 }
 
 // Logic from Kubernetes in Action 2nd Edition - Ch 6.
@@ -220,6 +281,42 @@ func getPodState(pod corev1.Pod) string {
 	return stateStarting
 }
 
+func getCrashLoopStatus(pod corev1.Pod) (bool, *string) {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != korifiv1alpha1.AppWorkloadContainerName {
+			continue
+		}
+
+		lastCrash := status.LastTerminationState.Terminated
+		if lastCrash == nil {
+			lastCrash = status.State.Terminated
+		}
+		if lastCrash == nil {
+			return false, nil
+		}
+
+		lastCrashReason := tools.PtrTo(crashReason(lastCrash))
+
+		if status.State.Waiting != nil && status.State.Waiting.Reason == crashLoopBackOffReason {
+			return true, lastCrashReason
+		}
+
+		crashedRecently := time.Since(lastCrash.FinishedAt.Time) <= crashLoopWindow
+		return status.RestartCount >= crashLoopRestartThreshold && crashedRecently, lastCrashReason
+	}
+
+	return false, nil
+}
+
+func crashReason(terminated *corev1.ContainerStateTerminated) string {
+	reason := terminated.Reason
+	if reason == "" {
+		reason = "Error"
+	}
+
+	return fmt.Sprintf("%s (exit code %d)", reason, terminated.ExitCode)
+}
+
 func podHasTerminatedContainer(pod corev1.Pod) bool {
 	for _, cond := range pod.Status.ContainerStatuses {
 		if cond.State.Terminated != nil {
@@ -17,6 +17,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -296,6 +297,264 @@ var _ = Describe("ProcessStats", func() {
 			})
 		})
 	})
+
+	Describe("crash-loop detection", func() {
+		var applicationStatus *corev1.ContainerStatus
+
+		BeforeEach(func() {
+			podMetrics[0].Pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+				Name:         "application",
+				RestartCount: 5,
+				State: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{StartedAt: metav1.Now()},
+				},
+				LastTerminationState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   137,
+						Reason:     "OOMKilled",
+						FinishedAt: metav1.NewTime(time.Now().Add(-time.Minute)),
+					},
+				},
+			}}
+			applicationStatus = &podMetrics[0].Pod.Status.ContainerStatuses[0]
+		})
+
+		It("reports instances that keep crashing as crash-looping", func() {
+			Expect(responseErr).NotTo(HaveOccurred())
+			Expect(responseRecords[0].CrashLooping).To(BeTrue())
+			Expect(responseRecords[0].LastCrashReason).To(PointTo(Equal("OOMKilled (exit code 137)")))
+		})
+
+		It("does not report healthy instances", func() {
+			Expect(responseRecords[1].CrashLooping).To(BeFalse())
+			Expect(responseRecords[1].LastCrashReason).To(BeNil())
+		})
+
+		When("the instance has not restarted often enough", func() {
+			BeforeEach(func() {
+				applicationStatus.RestartCount = 2
+			})
+
+			It("is not crash-looping but reports the last crash reason", func() {
+				Expect(responseRecords[0].CrashLooping).To(BeFalse())
+				Expect(responseRecords[0].LastCrashReason).To(PointTo(Equal("OOMKilled (exit code 137)")))
+			})
+		})
+
+		When("the last crash happened outside the crash-loop window", func() {
+			BeforeEach(func() {
+				applicationStatus.LastTerminationState.Terminated.FinishedAt = metav1.NewTime(time.Now().Add(-time.Hour))
+			})
+
+			It("is not crash-looping", func() {
+				Expect(responseRecords[0].CrashLooping).To(BeFalse())
+			})
+		})
+
+		When("kubernetes is backing off restarting the instance", func() {
+			BeforeEach(func() {
+				applicationStatus.RestartCount = 1
+				applicationStatus.State = corev1.ContainerState{
+					Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+				}
+				applicationStatus.LastTerminationState.Terminated = &corev1.ContainerStateTerminated{
+					ExitCode:   1,
+					FinishedAt: metav1.NewTime(time.Now().Add(-time.Hour)),
+				}
+			})
+
+			It("is crash-looping", func() {
+				Expect(responseRecords[0].CrashLooping).To(BeTrue())
+				Expect(responseRecords[0].LastCrashReason).To(PointTo(Equal("Error (exit code 1)")))
+			})
+		})
+
+		When("the instance has never crashed", func() {
+			BeforeEach(func() {
+				applicationStatus.RestartCount = 0
+				applicationStatus.LastTerminationState = corev1.ContainerState{}
+			})
+
+			It("is not crash-looping", func() {
+				Expect(responseRecords[0].CrashLooping).To(BeFalse())
+				Expect(responseRecords[0].LastCrashReason).To(BeNil())
+			})
+		})
+	})
+})
+
+var _ = Describe("ProcessStats FetchInstanceUsage", func() {
+	var (
+		processRepo *sfake.CFProcessRepository
+		metricsRepo *fake.MetricsRepository
+		appRepo     *sfake.CFAppRepository
+		authInfo    authorization.Info
+
+		processStats *ProcessStats
+
+		usageRecords []InstanceUsageRecord
+		usageErr     error
+	)
+
+	BeforeEach(func() {
+		processRepo = new(sfake.CFProcessRepository)
+		metricsRepo = new(fake.MetricsRepository)
+		appRepo = new(sfake.CFAppRepository)
+		authInfo = authorization.Info{Token: "a-token"}
+
+		appRepo.GetAppReturns(repositories.AppRecord{
+			GUID:      "the-app-guid",
+			SpaceGUID: "the-space-guid",
+			State:     "STARTED",
+			Revision:  "1",
+		}, nil)
+
+		processRepo.ListProcessesReturns([]repositories.ProcessRecord{
+			{GUID: "web-guid", AppGUID: "the-app-guid", Type: "web", DesiredInstances: 2},
+			{GUID: "worker-guid", AppGUID: "the-app-guid", Type: "worker", DesiredInstances: 1},
+		}, nil)
+
+		webPod0 := createPod("0", "1")
+		webPod0.Labels[cfProcessGuidKey] = "web-guid"
+		webPod1 := createPod("1", "1")
+		webPod1.Labels[cfProcessGuidKey] = "web-guid"
+		workerPod0 := createPod("0", "1")
+		workerPod0.Labels[cfProcessGuidKey] = "worker-guid"
+
+		metricsRepo.GetMetricsReturns([]repositories.PodMetrics{
+			{Pod: webPod0, Metrics: createPodMetrics("123m", "456", "890")},
+			{Pod: webPod1, Metrics: createPodMetrics("124m", "457", "891")},
+			{Pod: workerPod0, Metrics: createPodMetrics("500m", "1000", "2000")},
+		}, nil)
+
+		processStats = NewProcessStats(processRepo, appRepo, metricsRepo)
+	})
+
+	JustBeforeEach(func() {
+		usageRecords, usageErr = processStats.FetchInstanceUsage(context.Background(), authInfo, "the-app-guid")
+	})
+
+	It("lists the app processes", func() {
+		Expect(usageErr).NotTo(HaveOccurred())
+		Expect(processRepo.ListProcessesCallCount()).To(Equal(1))
+		_, actualAuthInfo, message := processRepo.ListProcessesArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(message).To(Equal(repositories.ListProcessesMessage{
+			AppGUIDs:  []string{"the-app-guid"},
+			SpaceGUID: "the-space-guid",
+		}))
+	})
+
+	It("fetches the metrics of the app pods", func() {
+		Expect(metricsRepo.GetMetricsCallCount()).To(Equal(1))
+		_, actualAuthInfo, spaceGUID, labelMatcher := metricsRepo.GetMetricsArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authInfo))
+		Expect(spaceGUID).To(Equal("the-space-guid"))
+		Expect(labelMatcher).To(Equal(client.MatchingLabels{
+			korifiv1alpha1.CFAppGUIDLabelKey: "the-app-guid",
+			LabelVersion:                     "1",
+		}))
+	})
+
+	It("returns the usage of each instance alongside its index", func() {
+		Expect(usageErr).NotTo(HaveOccurred())
+		Expect(usageRecords).To(ConsistOf(
+			MatchFields(IgnoreExtras, Fields{
+				"ProcessType": Equal("web"),
+				"Index":       Equal(0),
+				"Usage": MatchFields(IgnoreExtras, Fields{
+					"CPU":  Equal(tools.PtrTo(0.123)),
+					"Mem":  Equal(tools.PtrTo(int64(456))),
+					"Disk": Equal(tools.PtrTo(int64(890))),
+					"Time": Not(BeNil()),
+				}),
+			}),
+			MatchFields(IgnoreExtras, Fields{
+				"ProcessType": Equal("web"),
+				"Index":       Equal(1),
+				"Usage": MatchFields(IgnoreExtras, Fields{
+					"CPU": Equal(tools.PtrTo(0.124)),
+					"Mem": Equal(tools.PtrTo(int64(457))),
+				}),
+			}),
+			MatchFields(IgnoreExtras, Fields{
+				"ProcessType": Equal("worker"),
+				"Index":       Equal(0),
+				"Usage": MatchFields(IgnoreExtras, Fields{
+					"CPU": Equal(tools.PtrTo(0.5)),
+					"Mem": Equal(tools.PtrTo(int64(1000))),
+				}),
+			}),
+		))
+	})
+
+	When("metrics are unavailable", func() {
+		BeforeEach(func() {
+			pod := createPod("0", "1")
+			pod.Labels[cfProcessGuidKey] = "web-guid"
+			metricsRepo.GetMetricsReturns([]repositories.PodMetrics{
+				{Pod: pod, Metrics: metricsv1beta1.PodMetrics{}},
+			}, nil)
+		})
+
+		It("returns zero usage with unknown time for every instance", func() {
+			Expect(usageErr).NotTo(HaveOccurred())
+			Expect(usageRecords).To(HaveLen(3))
+			for _, record := range usageRecords {
+				Expect(record.Usage).To(Equal(Usage{
+					CPU:  tools.PtrTo(0.0),
+					Mem:  tools.PtrTo(int64(0)),
+					Disk: tools.PtrTo(int64(0)),
+				}))
+			}
+		})
+	})
+
+	When("the app is stopped", func() {
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{
+				GUID:      "the-app-guid",
+				SpaceGUID: "the-space-guid",
+				State:     "STOPPED",
+			}, nil)
+		})
+
+		It("returns no usage", func() {
+			Expect(usageErr).NotTo(HaveOccurred())
+			Expect(usageRecords).To(BeEmpty())
+			Expect(metricsRepo.GetMetricsCallCount()).To(BeZero())
+		})
+	})
+
+	When("getting the app fails", func() {
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{}, errors.New("get-app-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(usageErr).To(MatchError("get-app-err"))
+		})
+	})
+
+	When("listing the processes fails", func() {
+		BeforeEach(func() {
+			processRepo.ListProcessesReturns(nil, errors.New("list-processes-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(usageErr).To(MatchError("list-processes-err"))
+		})
+	})
+
+	When("getting the metrics fails", func() {
+		BeforeEach(func() {
+			metricsRepo.GetMetricsReturns(nil, errors.New("get-metrics-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(usageErr).To(MatchError("get-metrics-err"))
+		})
+	})
 })
 
 func createPod(index, version string) corev1.Pod {
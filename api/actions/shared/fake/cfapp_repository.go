@@ -41,6 +41,19 @@ type CFAppRepository struct {
 		result1 repositories.AppEnvVarsRecord
 		result2 error
 	}
+	DeleteAppStub        func(context.Context, authorization.Info, repositories.DeleteAppMessage) error
+	deleteAppMutex       sync.RWMutex
+	deleteAppArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeleteAppMessage
+	}
+	deleteAppReturns struct {
+		result1 error
+	}
+	deleteAppReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetAppStub        func(context.Context, authorization.Info, string) (repositories.AppRecord, error)
 	getAppMutex       sync.RWMutex
 	getAppArgsForCall []struct {
@@ -72,6 +85,21 @@ type CFAppRepository struct {
 		result1 repositories.AppRecord
 		result2 error
 	}
+	GetAppEnvStub        func(context.Context, authorization.Info, string) (repositories.AppEnvRecord, error)
+	getAppEnvMutex       sync.RWMutex
+	getAppEnvArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getAppEnvReturns struct {
+		result1 repositories.AppEnvRecord
+		result2 error
+	}
+	getAppEnvReturnsOnCall map[int]struct {
+		result1 repositories.AppEnvRecord
+		result2 error
+	}
 	PatchAppStub        func(context.Context, authorization.Info, repositories.PatchAppMessage) (repositories.AppRecord, error)
 	patchAppMutex       sync.RWMutex
 	patchAppArgsForCall []struct {
@@ -223,6 +251,69 @@ func (fake *CFAppRepository) CreateOrPatchAppEnvVarsReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *CFAppRepository) DeleteApp(arg1 context.Context, arg2 authorization.Info, arg3 repositories.DeleteAppMessage) error {
+	fake.deleteAppMutex.Lock()
+	ret, specificReturn := fake.deleteAppReturnsOnCall[len(fake.deleteAppArgsForCall)]
+	fake.deleteAppArgsForCall = append(fake.deleteAppArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeleteAppMessage
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteAppStub
+	fakeReturns := fake.deleteAppReturns
+	fake.recordInvocation("DeleteApp", []interface{}{arg1, arg2, arg3})
+	fake.deleteAppMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFAppRepository) DeleteAppCallCount() int {
+	fake.deleteAppMutex.RLock()
+	defer fake.deleteAppMutex.RUnlock()
+	return len(fake.deleteAppArgsForCall)
+}
+
+func (fake *CFAppRepository) DeleteAppCalls(stub func(context.Context, authorization.Info, repositories.DeleteAppMessage) error) {
+	fake.deleteAppMutex.Lock()
+	defer fake.deleteAppMutex.Unlock()
+	fake.DeleteAppStub = stub
+}
+
+func (fake *CFAppRepository) DeleteAppArgsForCall(i int) (context.Context, authorization.Info, repositories.DeleteAppMessage) {
+	fake.deleteAppMutex.RLock()
+	defer fake.deleteAppMutex.RUnlock()
+	argsForCall := fake.deleteAppArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFAppRepository) DeleteAppReturns(result1 error) {
+	fake.deleteAppMutex.Lock()
+	defer fake.deleteAppMutex.Unlock()
+	fake.DeleteAppStub = nil
+	fake.deleteAppReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFAppRepository) DeleteAppReturnsOnCall(i int, result1 error) {
+	fake.deleteAppMutex.Lock()
+	defer fake.deleteAppMutex.Unlock()
+	fake.DeleteAppStub = nil
+	if fake.deleteAppReturnsOnCall == nil {
+		fake.deleteAppReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteAppReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *CFAppRepository) GetApp(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.AppRecord, error) {
 	fake.getAppMutex.Lock()
 	ret, specificReturn := fake.getAppReturnsOnCall[len(fake.getAppArgsForCall)]
@@ -356,6 +447,72 @@ func (fake *CFAppRepository) GetAppByNameAndSpaceReturnsOnCall(i int, result1 re
 	}{result1, result2}
 }
 
+func (fake *CFAppRepository) GetAppEnv(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.AppEnvRecord, error) {
+	fake.getAppEnvMutex.Lock()
+	ret, specificReturn := fake.getAppEnvReturnsOnCall[len(fake.getAppEnvArgsForCall)]
+	fake.getAppEnvArgsForCall = append(fake.getAppEnvArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetAppEnvStub
+	fakeReturns := fake.getAppEnvReturns
+	fake.recordInvocation("GetAppEnv", []interface{}{arg1, arg2, arg3})
+	fake.getAppEnvMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFAppRepository) GetAppEnvCallCount() int {
+	fake.getAppEnvMutex.RLock()
+	defer fake.getAppEnvMutex.RUnlock()
+	return len(fake.getAppEnvArgsForCall)
+}
+
+func (fake *CFAppRepository) GetAppEnvCalls(stub func(context.Context, authorization.Info, string) (repositories.AppEnvRecord, error)) {
+	fake.getAppEnvMutex.Lock()
+	defer fake.getAppEnvMutex.Unlock()
+	fake.GetAppEnvStub = stub
+}
+
+func (fake *CFAppRepository) GetAppEnvArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getAppEnvMutex.RLock()
+	defer fake.getAppEnvMutex.RUnlock()
+	argsForCall := fake.getAppEnvArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFAppRepository) GetAppEnvReturns(result1 repositories.AppEnvRecord, result2 error) {
+	fake.getAppEnvMutex.Lock()
+	defer fake.getAppEnvMutex.Unlock()
+	fake.GetAppEnvStub = nil
+	fake.getAppEnvReturns = struct {
+		result1 repositories.AppEnvRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFAppRepository) GetAppEnvReturnsOnCall(i int, result1 repositories.AppEnvRecord, result2 error) {
+	fake.getAppEnvMutex.Lock()
+	defer fake.getAppEnvMutex.Unlock()
+	fake.GetAppEnvStub = nil
+	if fake.getAppEnvReturnsOnCall == nil {
+		fake.getAppEnvReturnsOnCall = make(map[int]struct {
+			result1 repositories.AppEnvRecord
+			result2 error
+		})
+	}
+	fake.getAppEnvReturnsOnCall[i] = struct {
+		result1 repositories.AppEnvRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFAppRepository) PatchApp(arg1 context.Context, arg2 authorization.Info, arg3 repositories.PatchAppMessage) (repositories.AppRecord, error) {
 	fake.patchAppMutex.Lock()
 	ret, specificReturn := fake.patchAppReturnsOnCall[len(fake.patchAppArgsForCall)]
@@ -429,10 +586,14 @@ func (fake *CFAppRepository) Invocations() map[string][][]interface{} {
 	defer fake.createAppMutex.RUnlock()
 	fake.createOrPatchAppEnvVarsMutex.RLock()
 	defer fake.createOrPatchAppEnvVarsMutex.RUnlock()
+	fake.deleteAppMutex.RLock()
+	defer fake.deleteAppMutex.RUnlock()
 	fake.getAppMutex.RLock()
 	defer fake.getAppMutex.RUnlock()
 	fake.getAppByNameAndSpaceMutex.RLock()
 	defer fake.getAppByNameAndSpaceMutex.RUnlock()
+	fake.getAppEnvMutex.RLock()
+	defer fake.getAppEnvMutex.RUnlock()
 	fake.patchAppMutex.RLock()
 	defer fake.patchAppMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
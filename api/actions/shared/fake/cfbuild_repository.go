@@ -11,13 +11,12 @@ import (
 )
 
 type CFBuildRepository struct {
-	GetBuildLogsStub        func(context.Context, authorization.Info, string, string) ([]repositories.LogRecord, error)
+	GetBuildLogsStub        func(context.Context, authorization.Info, repositories.BuildLogsMessage) ([]repositories.LogRecord, error)
 	getBuildLogsMutex       sync.RWMutex
 	getBuildLogsArgsForCall []struct {
 		arg1 context.Context
 		arg2 authorization.Info
-		arg3 string
-		arg4 string
+		arg3 repositories.BuildLogsMessage
 	}
 	getBuildLogsReturns struct {
 		result1 []repositories.LogRecord
@@ -47,21 +46,20 @@ type CFBuildRepository struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *CFBuildRepository) GetBuildLogs(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string) ([]repositories.LogRecord, error) {
+func (fake *CFBuildRepository) GetBuildLogs(arg1 context.Context, arg2 authorization.Info, arg3 repositories.BuildLogsMessage) ([]repositories.LogRecord, error) {
 	fake.getBuildLogsMutex.Lock()
 	ret, specificReturn := fake.getBuildLogsReturnsOnCall[len(fake.getBuildLogsArgsForCall)]
 	fake.getBuildLogsArgsForCall = append(fake.getBuildLogsArgsForCall, struct {
 		arg1 context.Context
 		arg2 authorization.Info
-		arg3 string
-		arg4 string
-	}{arg1, arg2, arg3, arg4})
+		arg3 repositories.BuildLogsMessage
+	}{arg1, arg2, arg3})
 	stub := fake.GetBuildLogsStub
 	fakeReturns := fake.getBuildLogsReturns
-	fake.recordInvocation("GetBuildLogs", []interface{}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("GetBuildLogs", []interface{}{arg1, arg2, arg3})
 	fake.getBuildLogsMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -75,17 +73,17 @@ func (fake *CFBuildRepository) GetBuildLogsCallCount() int {
 	return len(fake.getBuildLogsArgsForCall)
 }
 
-func (fake *CFBuildRepository) GetBuildLogsCalls(stub func(context.Context, authorization.Info, string, string) ([]repositories.LogRecord, error)) {
+func (fake *CFBuildRepository) GetBuildLogsCalls(stub func(context.Context, authorization.Info, repositories.BuildLogsMessage) ([]repositories.LogRecord, error)) {
 	fake.getBuildLogsMutex.Lock()
 	defer fake.getBuildLogsMutex.Unlock()
 	fake.GetBuildLogsStub = stub
 }
 
-func (fake *CFBuildRepository) GetBuildLogsArgsForCall(i int) (context.Context, authorization.Info, string, string) {
+func (fake *CFBuildRepository) GetBuildLogsArgsForCall(i int) (context.Context, authorization.Info, repositories.BuildLogsMessage) {
 	fake.getBuildLogsMutex.RLock()
 	defer fake.getBuildLogsMutex.RUnlock()
 	argsForCall := fake.getBuildLogsArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *CFBuildRepository) GetBuildLogsReturns(result1 []repositories.LogRecord, result2 error) {
@@ -23,16 +23,18 @@ type CFProcessRepository interface {
 type CFAppRepository interface {
 	GetApp(context.Context, authorization.Info, string) (repositories.AppRecord, error)
 	GetAppByNameAndSpace(context.Context, authorization.Info, string, string) (repositories.AppRecord, error)
+	GetAppEnv(context.Context, authorization.Info, string) (repositories.AppEnvRecord, error)
 	CreateOrPatchAppEnvVars(context.Context, authorization.Info, repositories.CreateOrPatchAppEnvVarsMessage) (repositories.AppEnvVarsRecord, error)
 	CreateApp(context.Context, authorization.Info, repositories.CreateAppMessage) (repositories.AppRecord, error)
 	PatchApp(context.Context, authorization.Info, repositories.PatchAppMessage) (repositories.AppRecord, error)
+	DeleteApp(context.Context, authorization.Info, repositories.DeleteAppMessage) error
 }
 
 //counterfeiter:generate -o fake -fake-name CFBuildRepository . CFBuildRepository
 
 type CFBuildRepository interface {
 	GetLatestBuildByAppGUID(context.Context, authorization.Info, string, string) (repositories.BuildRecord, error)
-	GetBuildLogs(context.Context, authorization.Info, string, string) ([]repositories.LogRecord, error)
+	GetBuildLogs(context.Context, authorization.Info, repositories.BuildLogsMessage) ([]repositories.LogRecord, error)
 }
 
 //counterfeiter:generate -o fake -fake-name PodRepository . PodRepository
@@ -45,6 +47,7 @@ type PodRepository interface {
 
 type CFDomainRepository interface {
 	GetDomainByName(context.Context, authorization.Info, string) (repositories.DomainRecord, error)
+	GetDefaultDomain(context.Context, authorization.Info) (repositories.DomainRecord, error)
 }
 
 //counterfeiter:generate -o fake -fake-name CFRouteRepository . CFRouteRepository
@@ -68,3 +71,18 @@ type CFServiceBindingRepository interface {
 type CFServiceInstanceRepository interface {
 	ListServiceInstances(context.Context, authorization.Info, repositories.ListServiceInstanceMessage) ([]repositories.ServiceInstanceRecord, error)
 }
+
+//counterfeiter:generate -o fake -fake-name CFPackageRepository . CFPackageRepository
+type CFPackageRepository interface {
+	ListPackages(context.Context, authorization.Info, repositories.ListPackagesMessage) ([]repositories.PackageRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name CFDropletRepository . CFDropletRepository
+type CFDropletRepository interface {
+	ListDroplets(context.Context, authorization.Info, repositories.ListDropletsMessage) ([]repositories.DropletRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name CFBuildpackRepository . CFBuildpackRepository
+type CFBuildpackRepository interface {
+	ListBuildpacks(context.Context, authorization.Info) ([]repositories.BuildpackRecord, error)
+}
@@ -42,6 +42,18 @@ var _ = Describe("CertInspector", func() {
 		Expect(id.Name).To(Equal("alice"))
 	})
 
+	When("the certificate carries groups", func() {
+		BeforeEach(func() {
+			certData, keyData := testhelpers.ObtainClientCert(testEnv, "alice", "platform-admins")
+			certPEM = append(certData, keyData...)
+		})
+
+		It("extracts the groups", func() {
+			Expect(inspectorErr).NotTo(HaveOccurred())
+			Expect(id.Groups).To(ContainElement("platform-admins"))
+		})
+	})
+
 	When("the certificate is not recognized by the cluster", func() {
 		BeforeEach(func() {
 			certPEM = generateUnsignedCert("alice")
@@ -43,6 +43,27 @@ func (o *NamespacePermissions) GetAuthorizedSpaceNamespaces(ctx context.Context,
 }
 
 func (o *NamespacePermissions) getAuthorizedNamespaces(ctx context.Context, info Info, orgSpaceLabel, resourceType string) (map[string]bool, error) {
+	cache, hasCache := namespacesCacheFromContext(ctx)
+	cacheKey := resourceType + "/" + info.Hash()
+	if hasCache {
+		if namespaces, ok := cache.get(cacheKey); ok {
+			return namespaces, nil
+		}
+	}
+
+	authorizedNamespaces, err := o.listAuthorizedNamespaces(ctx, info, orgSpaceLabel, resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasCache {
+		cache.set(cacheKey, authorizedNamespaces)
+	}
+
+	return authorizedNamespaces, nil
+}
+
+func (o *NamespacePermissions) listAuthorizedNamespaces(ctx context.Context, info Info, orgSpaceLabel, resourceType string) (map[string]bool, error) {
 	identity, err := o.identityProvider.GetIdentity(ctx, info)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get identity: %w", err)
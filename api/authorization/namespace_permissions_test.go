@@ -157,6 +157,31 @@ var _ = Describe("Namespace Permissions", func() {
 				Expect(namespaces).To(Equal(map[string]bool{org1NS: true}))
 			})
 
+			When("the context caches authorized namespaces", func() {
+				BeforeEach(func() {
+					ctx = authorization.NewNamespacesCacheContext(ctx)
+				})
+
+				It("reuses the result for the rest of the context", func() {
+					createRoleBindingForUser(userName, roleName1, org2NS)
+
+					cachedNamespaces, err := nsPerms.GetAuthorizedOrgNamespaces(ctx, authInfo)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(cachedNamespaces).To(Equal(map[string]bool{org1NS: true}))
+					Expect(identityProvider.GetIdentityCallCount()).To(Equal(1))
+
+					freshNamespaces, err := nsPerms.GetAuthorizedOrgNamespaces(authorization.NewNamespacesCacheContext(context.Background()), authInfo)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(freshNamespaces).To(Equal(map[string]bool{org1NS: true, org2NS: true}))
+				})
+
+				It("does not share the result with space lookups", func() {
+					spaceNamespaces, err := nsPerms.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(spaceNamespaces).To(BeEmpty())
+				})
+			})
+
 			When("the user does not have a rolebinding associated with it", func() {
 				BeforeEach(func() {
 					identityProvider.GetIdentityReturns(authorization.Identity{
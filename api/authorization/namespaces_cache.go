@@ -0,0 +1,46 @@
+package authorization
+
+import (
+	"context"
+	"maps"
+	"sync"
+)
+
+type namespacesCacheKey int
+
+var authorizedNamespacesCacheKey namespacesCacheKey
+
+type authorizedNamespacesCache struct {
+	mu         sync.Mutex
+	namespaces map[string]map[string]bool
+}
+
+// NewNamespacesCacheContext returns a context in which the namespaces an
+// identity is authorized in are only looked up once. The cache lives in the
+// context, so it goes away with the request it was created for and
+// permission changes are picked up by the next one.
+func NewNamespacesCacheContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, authorizedNamespacesCacheKey, &authorizedNamespacesCache{
+		namespaces: map[string]map[string]bool{},
+	})
+}
+
+func namespacesCacheFromContext(ctx context.Context) (*authorizedNamespacesCache, bool) {
+	cache, ok := ctx.Value(authorizedNamespacesCacheKey).(*authorizedNamespacesCache)
+	return cache, ok
+}
+
+func (c *authorizedNamespacesCache) get(key string) (map[string]bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	namespaces, ok := c.namespaces[key]
+	return maps.Clone(namespaces), ok
+}
+
+func (c *authorizedNamespacesCache) set(key string, namespaces map[string]bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.namespaces[key] = maps.Clone(namespaces)
+}
@@ -40,6 +40,16 @@ var _ = Describe("TokenReviewer", func() {
 		Expect(id.Name).To(Equal(oidcPrefix + "alice"))
 	})
 
+	When("the token carries groups", func() {
+		BeforeEach(func() {
+			token = authProvider.GenerateJWTToken("alice", "platform-admins")
+		})
+
+		It("extracts the groups", func() {
+			Expect(id.Groups).To(ContainElement("platform-admins"))
+		})
+	})
+
 	When("the token is issued for a serviceaccount", func() {
 		BeforeEach(func() {
 			restartEnvTest(authProvider.APIServerExtraArgs("system:serviceaccount:cf:"))
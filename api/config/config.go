@@ -39,6 +39,28 @@ type (
 		DefaultDomainName                        string                 `yaml:"defaultDomainName"`
 		UserCertificateExpirationWarningDuration string                 `yaml:"userCertificateExpirationWarningDuration"`
 		DefaultLifecycleConfig                   DefaultLifecycleConfig `yaml:"defaultLifecycleConfig"`
+		InheritedAppMetadataKeys                 []string               `yaml:"inheritedAppMetadataKeys"`
+		WatchResyncPeriod                        string                 `yaml:"watchResyncPeriod"`
+		ReconcileFailureThreshold                string                 `yaml:"reconcileFailureThreshold"`
+		MaxProcessInstances                      int                    `yaml:"maxProcessInstances"`
+		MaxProcessDiskQuotaMB                    int64                  `yaml:"maxProcessDiskQuotaMB"`
+		DefaultProcessDiskQuotaMB                int64                  `yaml:"defaultProcessDiskQuotaMB"`
+		MaxProcessMemoryMB                       int64                  `yaml:"maxProcessMemoryMB"`
+		HPAIntegration                           bool                   `yaml:"hpaIntegration"`
+		PropagateProcessTypeEnv                  bool                   `yaml:"propagateProcessTypeEnv"`
+		RollbackAppsOnFailedManifest             bool                   `yaml:"rollbackAppsOnFailedManifest"`
+		EmitRepositoryEvents                     bool                   `yaml:"emitRepositoryEvents"`
+		ValidateRouteHostnames                   bool                   `yaml:"validateRouteHostnames"`
+		MaxRoutesPerApp                          int                    `yaml:"maxRoutesPerApp"`
+		OrgCreationAllowedGroups                 []string               `yaml:"orgCreationAllowedGroups"`
+		TraceRepositoryOperations                bool                   `yaml:"traceRepositoryOperations"`
+		TraceExporterEndpoint                    string                 `yaml:"traceExporterEndpoint"`
+		TraceExporterInsecure                    bool                   `yaml:"traceExporterInsecure"`
+		MaxConcurrentSpaceCreationsPerOrg        int                    `yaml:"maxConcurrentSpaceCreationsPerOrg"`
+		FeatureFlags                             map[string]bool        `yaml:"featureFlags"`
+		ValidateSpaceOrg                         bool                   `yaml:"validateSpaceOrg"`
+		RejectTerminatingOrgNames                bool                   `yaml:"rejectTerminatingOrgNames"`
+		EnforceMetadataLimits                    bool                   `yaml:"enforceMetadataLimits"`
 
 		RoleMappings map[string]Role `yaml:"roleMappings"`
 
@@ -111,6 +133,34 @@ func (c *APIConfig) validate() error {
 		}
 	}
 
+	if c.WatchResyncPeriod != "" {
+		if _, err := time.ParseDuration(c.WatchResyncPeriod); err != nil {
+			return errors.New(`invalid duration format for watchResyncPeriod. Use a format like "30s"`)
+		}
+	}
+
+	if c.ReconcileFailureThreshold != "" {
+		if _, err := time.ParseDuration(c.ReconcileFailureThreshold); err != nil {
+			return errors.New(`invalid duration format for reconcileFailureThreshold. Use a format like "1m"`)
+		}
+	}
+
+	if c.MaxProcessInstances < 0 {
+		return errors.New("maxProcessInstances must not be negative")
+	}
+
+	if c.MaxProcessDiskQuotaMB < 0 {
+		return errors.New("maxProcessDiskQuotaMB must not be negative")
+	}
+
+	if c.MaxProcessMemoryMB < 0 {
+		return errors.New("maxProcessMemoryMB must not be negative")
+	}
+
+	if c.MaxConcurrentSpaceCreationsPerOrg < 0 {
+		return errors.New("maxConcurrentSpaceCreationsPerOrg must not be negative")
+	}
+
 	if c.BuilderName == "" {
 		return errors.New("BuilderName must have a value")
 	}
@@ -126,6 +176,21 @@ func (c *APIConfig) GetUserCertificateDuration() time.Duration {
 	return d
 }
 
+// GetWatchResyncPeriod returns how long to wait before first re-reading
+// objects being awaited on create. Zero (the default) disables resyncing.
+func (c *APIConfig) GetWatchResyncPeriod() time.Duration {
+	d, _ := time.ParseDuration(c.WatchResyncPeriod)
+	return d
+}
+
+// GetReconcileFailureThreshold returns how long a resource must have been
+// failing to reconcile before its record reports the failure. Zero (the
+// default) reports failures straight away.
+func (c *APIConfig) GetReconcileFailureThreshold() time.Duration {
+	d, _ := time.ParseDuration(c.ReconcileFailureThreshold)
+	return d
+}
+
 func (c *APIConfig) composeServerURL() (string, error) {
 	toReturn := defaultExternalProtocol + "://" + c.ExternalFQDN
 
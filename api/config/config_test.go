@@ -2,6 +2,7 @@ package config_test
 
 import (
 	"os"
+	"time"
 
 	"go.uber.org/zap/zapcore"
 
@@ -88,6 +89,36 @@ var _ = Describe("Config", func() {
 			StagingMemoryMB: 10,
 		}))
 		Expect(cfg.ContainerRegistryType).To(BeEmpty())
+		Expect(cfg.GetWatchResyncPeriod()).To(BeZero())
+		Expect(cfg.GetReconcileFailureThreshold()).To(BeZero())
+		Expect(cfg.MaxProcessInstances).To(BeZero())
+		Expect(cfg.MaxProcessDiskQuotaMB).To(BeZero())
+		Expect(cfg.DefaultProcessDiskQuotaMB).To(BeZero())
+		Expect(cfg.MaxProcessMemoryMB).To(BeZero())
+		Expect(cfg.HPAIntegration).To(BeFalse())
+		Expect(cfg.PropagateProcessTypeEnv).To(BeFalse())
+		Expect(cfg.ValidateRouteHostnames).To(BeFalse())
+		Expect(cfg.MaxRoutesPerApp).To(BeZero())
+		Expect(cfg.OrgCreationAllowedGroups).To(BeEmpty())
+		Expect(cfg.TraceRepositoryOperations).To(BeFalse())
+		Expect(cfg.TraceExporterEndpoint).To(BeEmpty())
+		Expect(cfg.TraceExporterInsecure).To(BeFalse())
+		Expect(cfg.MaxConcurrentSpaceCreationsPerOrg).To(BeZero())
+		Expect(cfg.FeatureFlags).To(BeEmpty())
+		Expect(cfg.ValidateSpaceOrg).To(BeFalse())
+		Expect(cfg.RejectTerminatingOrgNames).To(BeFalse())
+		Expect(cfg.EnforceMetadataLimits).To(BeFalse())
+	})
+
+	When("feature flags are set", func() {
+		BeforeEach(func() {
+			configMap["featureFlags"] = map[string]bool{"route_creation": false}
+		})
+
+		It("loads them", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.FeatureFlags).To(Equal(map[string]bool{"route_creation": false}))
+		})
 	})
 
 	When("the FQDN is not specified", func() {
@@ -175,6 +206,88 @@ var _ = Describe("Config", func() {
 		})
 	})
 
+	When("the WatchResyncPeriod is set", func() {
+		BeforeEach(func() {
+			configMap["watchResyncPeriod"] = "30s"
+		})
+
+		It("parses it", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.GetWatchResyncPeriod()).To(Equal(30 * time.Second))
+		})
+
+		When("it is invalid", func() {
+			BeforeEach(func() {
+				configMap["watchResyncPeriod"] = "invalid-duration"
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("invalid duration format for watchResyncPeriod")))
+			})
+		})
+	})
+
+	When("the MaxProcessInstances is negative", func() {
+		BeforeEach(func() {
+			configMap["maxProcessInstances"] = -1
+		})
+
+		It("returns an error", func() {
+			Expect(loadErr).To(MatchError(ContainSubstring("maxProcessInstances must not be negative")))
+		})
+	})
+
+	When("the MaxProcessDiskQuotaMB is negative", func() {
+		BeforeEach(func() {
+			configMap["maxProcessDiskQuotaMB"] = -1
+		})
+
+		It("returns an error", func() {
+			Expect(loadErr).To(MatchError(ContainSubstring("maxProcessDiskQuotaMB must not be negative")))
+		})
+	})
+
+	When("the MaxProcessMemoryMB is negative", func() {
+		BeforeEach(func() {
+			configMap["maxProcessMemoryMB"] = -1
+		})
+
+		It("returns an error", func() {
+			Expect(loadErr).To(MatchError(ContainSubstring("maxProcessMemoryMB must not be negative")))
+		})
+	})
+
+	When("the MaxConcurrentSpaceCreationsPerOrg is negative", func() {
+		BeforeEach(func() {
+			configMap["maxConcurrentSpaceCreationsPerOrg"] = -1
+		})
+
+		It("returns an error", func() {
+			Expect(loadErr).To(MatchError(ContainSubstring("maxConcurrentSpaceCreationsPerOrg must not be negative")))
+		})
+	})
+
+	When("the ReconcileFailureThreshold is set", func() {
+		BeforeEach(func() {
+			configMap["reconcileFailureThreshold"] = "1m"
+		})
+
+		It("parses it", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.GetReconcileFailureThreshold()).To(Equal(time.Minute))
+		})
+
+		When("it is invalid", func() {
+			BeforeEach(func() {
+				configMap["reconcileFailureThreshold"] = "invalid-duration"
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("invalid duration format for reconcileFailureThreshold")))
+			})
+		})
+	})
+
 	When("the builder is not specified", func() {
 		BeforeEach(func() {
 			delete(configMap, "builderName")
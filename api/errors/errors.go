@@ -371,6 +371,22 @@ func NewRollingDeployNotSupportedError(runnerName string) RollingDeployNotSuppor
 	}
 }
 
+type FeatureDisabledError struct {
+	apiError
+}
+
+func NewFeatureDisabledError(featureFlag string) FeatureDisabledError {
+	return FeatureDisabledError{
+		apiError: apiError{
+			cause:      fmt.Errorf("feature flag %q is disabled", featureFlag),
+			title:      "CF-FeatureDisabled",
+			detail:     "Feature Disabled: " + featureFlag,
+			code:       330002,
+			httpStatus: http.StatusForbidden,
+		},
+	}
+}
+
 func FromK8sError(err error, resourceType string) error {
 	if webhookValidationError, ok := webhooks.WebhookErrorToValidationError(err); ok {
 		return NewUnprocessableEntityError(err, webhookValidationError.GetMessage())
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -62,6 +63,19 @@ var _ = Describe("FromK8sError", func() {
 		})
 	})
 
+	When("webhook validation error", func() {
+		BeforeEach(func() {
+			err = webhooks.ValidationError{
+				Type:    webhooks.DuplicateNameErrorType,
+				Message: "Service binding already exists",
+			}.ExportJSONError()
+		})
+
+		It("translates it to unprocessable entity api error", func() {
+			Expect(actualErr).To(Equal(apierrors.NewUnprocessableEntityError(err, "Service binding already exists")))
+		})
+	})
+
 	When("unknown error", func() {
 		BeforeEach(func() {
 			err = errors.New("bar")
@@ -39,6 +39,20 @@ type CFDomainRepository struct {
 	deleteDomainReturnsOnCall map[int]struct {
 		result1 error
 	}
+	GetDefaultDomainStub        func(context.Context, authorization.Info) (repositories.DomainRecord, error)
+	getDefaultDomainMutex       sync.RWMutex
+	getDefaultDomainArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}
+	getDefaultDomainReturns struct {
+		result1 repositories.DomainRecord
+		result2 error
+	}
+	getDefaultDomainReturnsOnCall map[int]struct {
+		result1 repositories.DomainRecord
+		result2 error
+	}
 	GetDomainStub        func(context.Context, authorization.Info, string) (repositories.DomainRecord, error)
 	getDomainMutex       sync.RWMutex
 	getDomainArgsForCall []struct {
@@ -232,6 +246,71 @@ func (fake *CFDomainRepository) DeleteDomainReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *CFDomainRepository) GetDefaultDomain(arg1 context.Context, arg2 authorization.Info) (repositories.DomainRecord, error) {
+	fake.getDefaultDomainMutex.Lock()
+	ret, specificReturn := fake.getDefaultDomainReturnsOnCall[len(fake.getDefaultDomainArgsForCall)]
+	fake.getDefaultDomainArgsForCall = append(fake.getDefaultDomainArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}{arg1, arg2})
+	stub := fake.GetDefaultDomainStub
+	fakeReturns := fake.getDefaultDomainReturns
+	fake.recordInvocation("GetDefaultDomain", []interface{}{arg1, arg2})
+	fake.getDefaultDomainMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFDomainRepository) GetDefaultDomainCallCount() int {
+	fake.getDefaultDomainMutex.RLock()
+	defer fake.getDefaultDomainMutex.RUnlock()
+	return len(fake.getDefaultDomainArgsForCall)
+}
+
+func (fake *CFDomainRepository) GetDefaultDomainCalls(stub func(context.Context, authorization.Info) (repositories.DomainRecord, error)) {
+	fake.getDefaultDomainMutex.Lock()
+	defer fake.getDefaultDomainMutex.Unlock()
+	fake.GetDefaultDomainStub = stub
+}
+
+func (fake *CFDomainRepository) GetDefaultDomainArgsForCall(i int) (context.Context, authorization.Info) {
+	fake.getDefaultDomainMutex.RLock()
+	defer fake.getDefaultDomainMutex.RUnlock()
+	argsForCall := fake.getDefaultDomainArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *CFDomainRepository) GetDefaultDomainReturns(result1 repositories.DomainRecord, result2 error) {
+	fake.getDefaultDomainMutex.Lock()
+	defer fake.getDefaultDomainMutex.Unlock()
+	fake.GetDefaultDomainStub = nil
+	fake.getDefaultDomainReturns = struct {
+		result1 repositories.DomainRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFDomainRepository) GetDefaultDomainReturnsOnCall(i int, result1 repositories.DomainRecord, result2 error) {
+	fake.getDefaultDomainMutex.Lock()
+	defer fake.getDefaultDomainMutex.Unlock()
+	fake.GetDefaultDomainStub = nil
+	if fake.getDefaultDomainReturnsOnCall == nil {
+		fake.getDefaultDomainReturnsOnCall = make(map[int]struct {
+			result1 repositories.DomainRecord
+			result2 error
+		})
+	}
+	fake.getDefaultDomainReturnsOnCall[i] = struct {
+		result1 repositories.DomainRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFDomainRepository) GetDomain(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.DomainRecord, error) {
 	fake.getDomainMutex.Lock()
 	ret, specificReturn := fake.getDomainReturnsOnCall[len(fake.getDomainArgsForCall)]
@@ -503,6 +582,8 @@ func (fake *CFDomainRepository) Invocations() map[string][][]interface{} {
 	defer fake.createDomainMutex.RUnlock()
 	fake.deleteDomainMutex.RLock()
 	defer fake.deleteDomainMutex.RUnlock()
+	fake.getDefaultDomainMutex.RLock()
+	defer fake.getDefaultDomainMutex.RUnlock()
 	fake.getDomainMutex.RLock()
 	defer fake.getDomainMutex.RUnlock()
 	fake.getDomainByNameMutex.RLock()
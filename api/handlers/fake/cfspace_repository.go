@@ -100,6 +100,21 @@ type CFSpaceRepository struct {
 		result1 repositories.SpaceRecord
 		result2 error
 	}
+	UpdateSpaceStub        func(context.Context, authorization.Info, repositories.UpdateSpaceMessage) (repositories.SpaceRecord, error)
+	updateSpaceMutex       sync.RWMutex
+	updateSpaceArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateSpaceMessage
+	}
+	updateSpaceReturns struct {
+		result1 repositories.SpaceRecord
+		result2 error
+	}
+	updateSpaceReturnsOnCall map[int]struct {
+		result1 repositories.SpaceRecord
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -497,6 +512,72 @@ func (fake *CFSpaceRepository) PatchSpaceMetadataReturnsOnCall(i int, result1 re
 	}{result1, result2}
 }
 
+func (fake *CFSpaceRepository) UpdateSpace(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateSpaceMessage) (repositories.SpaceRecord, error) {
+	fake.updateSpaceMutex.Lock()
+	ret, specificReturn := fake.updateSpaceReturnsOnCall[len(fake.updateSpaceArgsForCall)]
+	fake.updateSpaceArgsForCall = append(fake.updateSpaceArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateSpaceMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateSpaceStub
+	fakeReturns := fake.updateSpaceReturns
+	fake.recordInvocation("UpdateSpace", []interface{}{arg1, arg2, arg3})
+	fake.updateSpaceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFSpaceRepository) UpdateSpaceCallCount() int {
+	fake.updateSpaceMutex.RLock()
+	defer fake.updateSpaceMutex.RUnlock()
+	return len(fake.updateSpaceArgsForCall)
+}
+
+func (fake *CFSpaceRepository) UpdateSpaceCalls(stub func(context.Context, authorization.Info, repositories.UpdateSpaceMessage) (repositories.SpaceRecord, error)) {
+	fake.updateSpaceMutex.Lock()
+	defer fake.updateSpaceMutex.Unlock()
+	fake.UpdateSpaceStub = stub
+}
+
+func (fake *CFSpaceRepository) UpdateSpaceArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateSpaceMessage) {
+	fake.updateSpaceMutex.RLock()
+	defer fake.updateSpaceMutex.RUnlock()
+	argsForCall := fake.updateSpaceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFSpaceRepository) UpdateSpaceReturns(result1 repositories.SpaceRecord, result2 error) {
+	fake.updateSpaceMutex.Lock()
+	defer fake.updateSpaceMutex.Unlock()
+	fake.UpdateSpaceStub = nil
+	fake.updateSpaceReturns = struct {
+		result1 repositories.SpaceRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSpaceRepository) UpdateSpaceReturnsOnCall(i int, result1 repositories.SpaceRecord, result2 error) {
+	fake.updateSpaceMutex.Lock()
+	defer fake.updateSpaceMutex.Unlock()
+	fake.UpdateSpaceStub = nil
+	if fake.updateSpaceReturnsOnCall == nil {
+		fake.updateSpaceReturnsOnCall = make(map[int]struct {
+			result1 repositories.SpaceRecord
+			result2 error
+		})
+	}
+	fake.updateSpaceReturnsOnCall[i] = struct {
+		result1 repositories.SpaceRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFSpaceRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -512,6 +593,8 @@ func (fake *CFSpaceRepository) Invocations() map[string][][]interface{} {
 	defer fake.listSpacesMutex.RUnlock()
 	fake.patchSpaceMetadataMutex.RLock()
 	defer fake.patchSpaceMetadataMutex.RUnlock()
+	fake.updateSpaceMutex.RLock()
+	defer fake.updateSpaceMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
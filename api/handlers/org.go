@@ -42,17 +42,15 @@ type Org struct {
 	domainRepo                               CFDomainRepository
 	requestValidator                         RequestValidator
 	userCertificateExpirationWarningDuration time.Duration
-	defaultDomainName                        string
 }
 
-func NewOrg(apiBaseURL url.URL, orgRepo CFOrgRepository, domainRepo CFDomainRepository, requestValidator RequestValidator, userCertificateExpirationWarningDuration time.Duration, defaultDomainName string) *Org {
+func NewOrg(apiBaseURL url.URL, orgRepo CFOrgRepository, domainRepo CFDomainRepository, requestValidator RequestValidator, userCertificateExpirationWarningDuration time.Duration) *Org {
 	return &Org{
 		apiBaseURL:                               apiBaseURL,
 		orgRepo:                                  orgRepo,
 		domainRepo:                               domainRepo,
 		requestValidator:                         requestValidator,
 		userCertificateExpirationWarningDuration: userCertificateExpirationWarningDuration,
-		defaultDomainName:                        defaultDomainName,
 	}
 }
 
@@ -173,7 +171,7 @@ func (h *Org) defaultDomain(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Unable to get organization")
 	}
 
-	domain, err := h.domainRepo.GetDomainByName(r.Context(), authInfo, h.defaultDomainName)
+	domain, err := h.domainRepo.GetDefaultDomain(r.Context(), authInfo)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Unable to get domain")
 	}
@@ -35,7 +35,7 @@ var _ = Describe("Org", func() {
 		domainRepo = new(fake.CFDomainRepository)
 		requestValidator = new(fake.RequestValidator)
 
-		apiHandler = handlers.NewOrg(*serverURL, orgRepo, domainRepo, requestValidator, time.Hour, "the-default.domain")
+		apiHandler = handlers.NewOrg(*serverURL, orgRepo, domainRepo, requestValidator, time.Hour)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -434,7 +434,7 @@ var _ = Describe("Org", func() {
 
 	Describe("Get the default domain", func() {
 		BeforeEach(func() {
-			domainRepo.GetDomainByNameReturns(repositories.DomainRecord{
+			domainRepo.GetDefaultDomainReturns(repositories.DomainRecord{
 				GUID: "the-default-domain-guid",
 				Name: "the-default.domain",
 			}, nil)
@@ -446,16 +446,15 @@ var _ = Describe("Org", func() {
 			routerBuilder.Build().ServeHTTP(rr, req)
 		})
 
-		It("returns the configured default domain", func() {
+		It("returns the default domain", func() {
 			Expect(orgRepo.GetOrgCallCount()).To(Equal(1))
 			_, info, orgGUID := orgRepo.GetOrgArgsForCall(0)
 			Expect(info).To(Equal(authInfo))
 			Expect(orgGUID).To(Equal("org-guid"))
 
-			Expect(domainRepo.GetDomainByNameCallCount()).To(Equal(1))
-			_, info, defaultDomainName := domainRepo.GetDomainByNameArgsForCall(0)
+			Expect(domainRepo.GetDefaultDomainCallCount()).To(Equal(1))
+			_, info = domainRepo.GetDefaultDomainArgsForCall(0)
 			Expect(info).To(Equal(authInfo))
-			Expect(defaultDomainName).To(Equal("the-default.domain"))
 
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
@@ -488,7 +487,7 @@ var _ = Describe("Org", func() {
 
 		When("getting the Domain fails", func() {
 			BeforeEach(func() {
-				domainRepo.GetDomainByNameReturns(repositories.DomainRecord{}, errors.New("failed to get domain"))
+				domainRepo.GetDefaultDomainReturns(repositories.DomainRecord{}, errors.New("failed to get domain"))
 			})
 
 			It("returns an unknown error", func() {
@@ -498,7 +497,7 @@ var _ = Describe("Org", func() {
 
 		When("getting the Domain is forbidden", func() {
 			BeforeEach(func() {
-				domainRepo.GetDomainByNameReturns(repositories.DomainRecord{}, apierrors.NewForbiddenError(errors.New("boom"), repositories.DomainResourceType))
+				domainRepo.GetDefaultDomainReturns(repositories.DomainRecord{}, apierrors.NewForbiddenError(errors.New("boom"), repositories.DomainResourceType))
 			})
 
 			It("returns an NotFound error", func() {
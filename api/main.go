@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -30,13 +31,22 @@ import (
 	"code.cloudfoundry.org/korifi/version"
 
 	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/cache"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/dynamic"
 	k8sclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -64,6 +74,7 @@ func main() {
 		panic(errorMessage)
 	}
 	payloads.DefaultLifecycleConfig = cfg.DefaultLifecycleConfig
+	payloads.EnforceMetadataLimits = cfg.EnforceMetadataLimits
 	k8sClientConfig := cfg.GenerateK8sClientConfig(ctrl.GetConfigOrDie())
 
 	logger, atomicLevel, err := tools.NewZapLogger(cfg.LogLevel)
@@ -116,6 +127,10 @@ func main() {
 	cachingIdentityProvider := authorization.NewCachingIdentityProvider(identityProvider, cache.NewExpiring())
 	nsPermissions := authorization.NewNamespacePermissions(privilegedCRClient, cachingIdentityProvider)
 
+	eventRecorder := wireEventRecorder(cfg, privilegedK8sClient)
+	tracer, shutdownTracer := wireTracer(cfg)
+	defer shutdownTracer()
+
 	serverURL, err := url.Parse(cfg.ServerURL)
 	if err != nil {
 		panic(fmt.Sprintf("could not parse server URL: %v", err))
@@ -126,19 +141,43 @@ func main() {
 		privilegedCRClient,
 		userClientFactory,
 		nsPermissions,
-		conditions.NewConditionAwaiter[*korifiv1alpha1.CFOrg, korifiv1alpha1.CFOrgList](createTimeout),
+		cachingIdentityProvider,
+		conditions.NewConditionAwaiter[*korifiv1alpha1.CFOrg, korifiv1alpha1.CFOrgList](createTimeout, cfg.GetWatchResyncPeriod()),
+		eventRecorder,
+		tracer,
+		repositories.OrgRepoConfig{
+			CreatorGroups:               cfg.OrgCreationAllowedGroups,
+			MaxConcurrentSpaceCreations: cfg.MaxConcurrentSpaceCreationsPerOrg,
+			RejectTerminatingOrgNames:   cfg.RejectTerminatingOrgNames,
+		},
 	)
 	spaceRepo := repositories.NewSpaceRepo(
 		namespaceRetriever,
 		orgRepo,
 		userClientFactory,
 		nsPermissions,
-		conditions.NewConditionAwaiter[*korifiv1alpha1.CFSpace, korifiv1alpha1.CFSpaceList](createTimeout),
+		cachingIdentityProvider,
+		conditions.NewConditionAwaiter[*korifiv1alpha1.CFSpace, korifiv1alpha1.CFSpaceList](createTimeout, cfg.GetWatchResyncPeriod()),
+		eventRecorder,
+		tracer,
+		repositories.SpaceRepoConfig{
+			ValidateSpaceOrg: cfg.ValidateSpaceOrg,
+		},
 	)
 	processRepo := repositories.NewProcessRepo(
 		namespaceRetriever,
 		userClientFactory,
 		nsPermissions,
+		repositories.ProcessRepoConfig{
+			InheritedMetadataKeys:     cfg.InheritedAppMetadataKeys,
+			ReconcileFailureThreshold: cfg.GetReconcileFailureThreshold(),
+			MaxInstances:              cfg.MaxProcessInstances,
+			MaxDiskQuotaMB:            cfg.MaxProcessDiskQuotaMB,
+			DefaultDiskQuotaMB:        cfg.DefaultProcessDiskQuotaMB,
+			MaxMemoryMB:               cfg.MaxProcessMemoryMB,
+			HPAIntegration:            cfg.HPAIntegration,
+			PropagateProcessTypeEnv:   cfg.PropagateProcessTypeEnv,
+		},
 	)
 	podRepo := repositories.NewPodRepo(
 		userClientFactory,
@@ -147,30 +186,43 @@ func main() {
 		namespaceRetriever,
 		userClientFactory,
 		nsPermissions,
-		conditions.NewConditionAwaiter[*korifiv1alpha1.CFApp, korifiv1alpha1.CFAppList](createTimeout),
+		privilegedCRClient,
+		conditions.NewConditionAwaiter[*korifiv1alpha1.CFApp, korifiv1alpha1.CFAppList](createTimeout, cfg.GetWatchResyncPeriod()),
+		cachingIdentityProvider,
+		tracer,
 	)
 	dropletRepo := repositories.NewDropletRepo(
 		userClientFactory,
 		namespaceRetriever,
 		nsPermissions,
+		privilegedCRClient,
 	)
 	routeRepo := repositories.NewRouteRepo(
 		namespaceRetriever,
 		userClientFactory,
 		nsPermissions,
+		repositories.RouteRepoConfig{
+			InheritedMetadataKeys: cfg.InheritedAppMetadataKeys,
+			ValidateHostnames:     cfg.ValidateRouteHostnames,
+			FeatureFlags:          cfg.FeatureFlags,
+			MaxRoutesPerApp:       cfg.MaxRoutesPerApp,
+		},
 	)
 	domainRepo := repositories.NewDomainRepo(
 		userClientFactory,
 		namespaceRetriever,
 		cfg.RootNamespace,
+		cfg.DefaultDomainName,
 	)
 	deploymentRepo := repositories.NewDeploymentRepo(
 		userClientFactory,
 		namespaceRetriever,
+		nsPermissions,
 	)
 	buildRepo := repositories.NewBuildRepo(
 		namespaceRetriever,
 		userClientFactory,
+		privilegedK8sClient,
 	)
 	runnerInfoRepo := repositories.NewRunnerInfoRepository(
 		userClientFactory,
@@ -183,7 +235,8 @@ func main() {
 		nsPermissions,
 		toolsregistry.NewRepositoryCreator(cfg.ContainerRegistryType),
 		cfg.ContainerRepositoryPrefix,
-		conditions.NewConditionAwaiter[*korifiv1alpha1.CFPackage, korifiv1alpha1.CFPackageList](createTimeout),
+		conditions.NewConditionAwaiter[*korifiv1alpha1.CFPackage, korifiv1alpha1.CFPackageList](createTimeout, cfg.GetWatchResyncPeriod()),
+		tracer,
 	)
 	serviceInstanceRepo := repositories.NewServiceInstanceRepo(
 		namespaceRetriever,
@@ -194,7 +247,9 @@ func main() {
 		namespaceRetriever,
 		userClientFactory,
 		nsPermissions,
-		conditions.NewConditionAwaiter[*korifiv1alpha1.CFServiceBinding, korifiv1alpha1.CFServiceBindingList](createTimeout),
+		conditions.NewConditionAwaiter[*korifiv1alpha1.CFServiceBinding, korifiv1alpha1.CFServiceBindingList](createTimeout, cfg.GetWatchResyncPeriod()),
+		cfg.GetReconcileFailureThreshold(),
+		tracer,
 	)
 	buildpackRepo := repositories.NewBuildpackRepository(cfg.BuilderName,
 		userClientFactory,
@@ -214,6 +269,7 @@ func main() {
 		privilegedK8sClient,
 		userClientFactory,
 		imageClient,
+		imageClient,
 		cfg.PackageRegistrySecretNames,
 		cfg.RootNamespace,
 	)
@@ -221,17 +277,18 @@ func main() {
 		userClientFactory,
 		namespaceRetriever,
 		nsPermissions,
-		conditions.NewConditionAwaiter[*korifiv1alpha1.CFTask, korifiv1alpha1.CFTaskList](createTimeout),
+		conditions.NewConditionAwaiter[*korifiv1alpha1.CFTask, korifiv1alpha1.CFTaskList](createTimeout, cfg.GetWatchResyncPeriod()),
+		tracer,
 	)
 	metricsRepo := repositories.NewMetricsRepo(userClientFactory)
 
 	processStats := actions.NewProcessStats(processRepo, appRepo, metricsRepo)
 	manifest := actions.NewManifest(
+		appRepo,
 		domainRepo,
-		cfg.DefaultDomainName,
 		manifest.NewStateCollector(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo),
-		manifest.NewNormalizer(cfg.DefaultDomainName),
-		manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo),
+		manifest.NewNormalizer(),
+		manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo, cfg.RollbackAppsOnFailedManifest),
 	)
 	appLogs := actions.NewAppLogs(appRepo, buildRepo, podRepo)
 
@@ -347,7 +404,6 @@ func main() {
 			domainRepo,
 			requestValidator,
 			cfg.GetUserCertificateDuration(),
-			cfg.DefaultDomainName,
 		),
 		handlers.NewSpace(
 			*serverURL,
@@ -415,6 +471,14 @@ func main() {
 		ErrorLog:          log.New(&tools.LogrWriter{Logger: ctrl.Log, Message: "HTTP server error"}, "", 0),
 	}
 
+	go func() {
+		<-ctrl.SetupSignalHandler().Done()
+		ctrl.Log.Info("shutting down Korifi API")
+		if err2 := srv.Shutdown(context.Background()); err2 != nil {
+			ctrl.Log.Error(err2, "error shutting down the HTTP server")
+		}
+	}()
+
 	if tlsFound {
 		ctrl.Log.Info("listening with TLS on " + portString)
 		certPath := filepath.Join(tlsPath, "tls.crt")
@@ -440,20 +504,77 @@ func main() {
 			GetCertificate: certWatcher.GetCertificate,
 		}
 		err = srv.ListenAndServeTLS("", "")
-		if err != nil {
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			ctrl.Log.Error(err, "error serving TLS")
+			shutdownTracer()
 			os.Exit(1)
 		}
 	} else {
 		ctrl.Log.Info("listening without TLS on " + portString)
 		err := srv.ListenAndServe()
-		if err != nil {
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			ctrl.Log.Error(err, "error serving HTTP")
+			shutdownTracer()
 			os.Exit(1)
 		}
 	}
 }
 
+// wireEventRecorder returns nil, disabling repository events, unless they
+// have been enabled in the config
+func wireEventRecorder(cfg *config.APIConfig, k8sClient k8sclient.Interface) record.EventRecorder {
+	if !cfg.EmitRepositoryEvents {
+		return nil
+	}
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: k8sClient.CoreV1().Events("")})
+	return eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "korifi-api"})
+}
+
+// wireTracer returns nil, disabling repository tracing, unless it has been
+// enabled in the config. Spans are exported over OTLP/HTTP to the configured
+// endpoint, or to the one in the standard OTEL_EXPORTER_OTLP_* environment
+// variables if no endpoint is configured. Tracing is also disabled if the
+// exporter cannot be created. The returned function flushes any pending spans
+// and must be called on exit.
+func wireTracer(cfg *config.APIConfig) (trace.Tracer, func()) {
+	if !cfg.TraceRepositoryOperations {
+		return nil, func() {}
+	}
+
+	exporterOptions := []otlptracehttp.Option{}
+	if cfg.TraceExporterEndpoint != "" {
+		exporterOptions = append(exporterOptions, otlptracehttp.WithEndpoint(cfg.TraceExporterEndpoint))
+	}
+	if cfg.TraceExporterInsecure {
+		exporterOptions = append(exporterOptions, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(), exporterOptions...)
+	if err != nil {
+		ctrl.Log.Error(err, "could not create trace exporter, repository tracing is disabled")
+		return nil, func() {}
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName("korifi-api"))),
+	)
+	otel.SetTracerProvider(tracerProvider)
+
+	shutdown := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := tracerProvider.Shutdown(ctx); err != nil {
+			ctrl.Log.Error(err, "error shutting down the tracer provider")
+		}
+	}
+
+	return tracerProvider.Tracer("code.cloudfoundry.org/korifi/api/repositories"), shutdown
+}
+
 func wireIdentityProvider(client client.Client, restConfig *rest.Config) authorization.IdentityProvider {
 	tokenReviewer := authorization.NewTokenReviewer(client)
 	certInspector := authorization.NewCertInspector(restConfig)
@@ -49,7 +49,7 @@ func (a *authentication) middleware(next http.Handler) http.Handler {
 			return
 		}
 
-		r = r.WithContext(authorization.NewContext(r.Context(), &authInfo))
+		r = r.WithContext(authorization.NewNamespacesCacheContext(authorization.NewContext(r.Context(), &authInfo)))
 
 		_, err = a.identityProvider.GetIdentity(r.Context(), authInfo)
 		if err != nil {
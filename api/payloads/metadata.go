@@ -4,11 +4,19 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/jellydator/validation"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 )
 
+const metadataKeyNameMaxLength = 63
+
+// EnforceMetadataLimits is overwritten by main.go
+var EnforceMetadataLimits = false
+
 type BuildMetadata struct {
 	Annotations map[string]string `json:"annotations"`
 	Labels      map[string]string `json:"labels"`
@@ -28,8 +36,14 @@ type Metadata struct {
 
 func (m Metadata) Validate() error {
 	return validation.ValidateStruct(&m,
-		validation.Field(&m.Annotations, validation.Map().Keys(validation.By(cloudfoundryKeyCheck)).AllowExtraKeys()),
-		validation.Field(&m.Labels, validation.Map().Keys(validation.By(cloudfoundryKeyCheck)).AllowExtraKeys()),
+		validation.Field(&m.Annotations,
+			validation.Map().Keys(validation.By(cloudfoundryKeyCheck)).AllowExtraKeys(),
+			validation.By(func(any) error { return metadataLimitsCheck(m.Annotations, annotationLimitsCheck) }),
+		),
+		validation.Field(&m.Labels,
+			validation.Map().Keys(validation.By(cloudfoundryKeyCheck)).AllowExtraKeys(),
+			validation.By(func(any) error { return metadataLimitsCheck(m.Labels, labelLimitsCheck) }),
+		),
 	)
 }
 
@@ -40,11 +54,101 @@ type MetadataPatch struct {
 
 func (p MetadataPatch) Validate() error {
 	return validation.ValidateStruct(&p,
-		validation.Field(&p.Annotations, validation.Map().Keys(validation.By(cloudfoundryKeyCheck)).AllowExtraKeys()),
-		validation.Field(&p.Labels, validation.Map().Keys(validation.By(cloudfoundryKeyCheck)).AllowExtraKeys()),
+		validation.Field(&p.Annotations,
+			validation.Map().Keys(validation.By(cloudfoundryKeyCheck)).AllowExtraKeys(),
+			validation.By(func(any) error { return metadataLimitsCheck(setValues(p.Annotations), annotationLimitsCheck) }),
+		),
+		validation.Field(&p.Labels,
+			validation.Map().Keys(validation.By(cloudfoundryKeyCheck)).AllowExtraKeys(),
+			validation.By(func(any) error { return metadataLimitsCheck(setValues(p.Labels), labelLimitsCheck) }),
+		),
 	)
 }
 
+// setValues drops the keys that a patch removes, as their values are not
+// going to be stored
+func setValues(patch map[string]*string) map[string]string {
+	values := map[string]string{}
+	for key, value := range patch {
+		if value != nil {
+			values[key] = *value
+		}
+	}
+
+	return values
+}
+
+// metadataLimitsCheck catches the metadata that kubernetes would reject
+// because of its key or value lengths, so that users get an error that names
+// the offending key. It only runs when EnforceMetadataLimits is set.
+func metadataLimitsCheck(metadata map[string]string, valuesCheck func(map[string]string) error) error {
+	if !EnforceMetadataLimits {
+		return nil
+	}
+
+	for _, key := range sortedKeys(metadata) {
+		if err := keyLengthCheck(key); err != nil {
+			return err
+		}
+	}
+
+	return valuesCheck(metadata)
+}
+
+func keyLengthCheck(key string) error {
+	prefix, name, found := strings.Cut(key, "/")
+	if !found {
+		prefix, name = "", key
+	}
+
+	if len(prefix) > k8svalidation.DNS1123SubdomainMaxLength {
+		return fmt.Errorf("key %q: prefix must be no more than %d characters", key, k8svalidation.DNS1123SubdomainMaxLength)
+	}
+
+	if len(name) > metadataKeyNameMaxLength {
+		return fmt.Errorf("key %q: name must be no more than %d characters", key, metadataKeyNameMaxLength)
+	}
+
+	return nil
+}
+
+func labelLimitsCheck(labels map[string]string) error {
+	for _, key := range sortedKeys(labels) {
+		if len(labels[key]) > k8svalidation.LabelValueMaxLength {
+			return fmt.Errorf("key %q: value must be no more than %d characters", key, k8svalidation.LabelValueMaxLength)
+		}
+	}
+
+	return nil
+}
+
+func annotationLimitsCheck(annotations map[string]string) error {
+	totalSize := 0
+	largestKey := ""
+	for key, value := range annotations {
+		totalSize += len(key) + len(value)
+		if largestKey == "" || len(key)+len(value) > len(largestKey)+len(annotations[largestKey]) {
+			largestKey = key
+		}
+	}
+
+	if totalSize > apivalidation.TotalAnnotationSizeLimitB {
+		return fmt.Errorf("total size must be no more than %d bytes, the largest annotation is %q", apivalidation.TotalAnnotationSizeLimitB, largestKey)
+	}
+
+	return nil
+}
+
+func sortedKeys(metadata map[string]string) []string {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
 func cloudfoundryKeyCheck(key any) error {
 	keyStr, ok := key.(string)
 	if !ok {
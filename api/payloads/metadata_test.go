@@ -1,6 +1,8 @@
 package payloads_test
 
 import (
+	"strings"
+
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
@@ -55,6 +57,55 @@ var _ = Describe("Metadata", func() {
 			expectUnprocessableEntityError(validatorErr, "cannot use the cloudfoundry.org domain")
 		})
 	})
+
+	When("a label value is too long", func() {
+		BeforeEach(func() {
+			metadataPayload.Labels["long-value"] = strings.Repeat("a", 64)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+		})
+	})
+
+	When("metadata limits are enforced", func() {
+		BeforeEach(func() {
+			payloads.EnforceMetadataLimits = true
+			DeferCleanup(func() {
+				payloads.EnforceMetadataLimits = false
+			})
+		})
+
+		When("a label value is too long", func() {
+			BeforeEach(func() {
+				metadataPayload.Labels["long-value"] = strings.Repeat("a", 64)
+			})
+
+			It("returns an error naming the key", func() {
+				expectUnprocessableEntityError(validatorErr, `key "long-value": value must be no more than 63 characters`)
+			})
+		})
+
+		When("a label key name is too long", func() {
+			BeforeEach(func() {
+				metadataPayload.Labels["example.org/"+strings.Repeat("a", 64)] = "bar"
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "name must be no more than 63 characters")
+			})
+		})
+
+		When("the annotations are too large", func() {
+			BeforeEach(func() {
+				metadataPayload.Annotations["big-annotation"] = strings.Repeat("a", 256*1024)
+			})
+
+			It("returns an error naming the largest annotation", func() {
+				expectUnprocessableEntityError(validatorErr, `the largest annotation is "big-annotation"`)
+			})
+		})
+	})
 })
 
 var _ = Describe("MetadataPatch", func() {
@@ -104,4 +155,43 @@ var _ = Describe("MetadataPatch", func() {
 			expectUnprocessableEntityError(validatorErr, "cannot use the cloudfoundry.org domain")
 		})
 	})
+
+	When("metadata.labels contains a value that is too long", func() {
+		BeforeEach(func() {
+			metadataPatchPayload.Labels["long-value"] = tools.PtrTo(strings.Repeat("a", 64))
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+		})
+	})
+
+	When("metadata limits are enforced", func() {
+		BeforeEach(func() {
+			payloads.EnforceMetadataLimits = true
+			DeferCleanup(func() {
+				payloads.EnforceMetadataLimits = false
+			})
+		})
+
+		When("metadata.labels contains a value that is too long", func() {
+			BeforeEach(func() {
+				metadataPatchPayload.Labels["long-value"] = tools.PtrTo(strings.Repeat("a", 64))
+			})
+
+			It("returns an error naming the key", func() {
+				expectUnprocessableEntityError(validatorErr, `key "long-value": value must be no more than 63 characters`)
+			})
+		})
+
+		When("metadata.labels removes a key with a name that is too long", func() {
+			BeforeEach(func() {
+				metadataPatchPayload.Labels[strings.Repeat("a", 64)] = nil
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+			})
+		})
+	})
 })
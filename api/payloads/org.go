@@ -50,20 +50,23 @@ func (p OrgPatch) ToMessage(orgGUID string) repositories.PatchOrgMetadataMessage
 }
 
 type OrgList struct {
-	Names string
+	Names     string
+	CreatedBy string
 }
 
 func (d *OrgList) ToMessage() repositories.ListOrgsMessage {
 	return repositories.ListOrgsMessage{
-		Names: parse.ArrayParam(d.Names),
+		Names:     parse.ArrayParam(d.Names),
+		CreatedBy: d.CreatedBy,
 	}
 }
 
 func (d *OrgList) SupportedKeys() []string {
-	return []string{"names", "order_by", "per_page", "page"}
+	return []string{"names", "created_by", "order_by", "per_page", "page"}
 }
 
 func (d *OrgList) DecodeFromURLValues(values url.Values) error {
 	d.Names = values.Get("names")
+	d.CreatedBy = values.Get("created_by")
 	return nil
 }
@@ -111,6 +111,16 @@ var _ = Describe("Org", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(orgList.Names).To(Equal("foo,bar"))
 			})
+
+			It("gets the created_by param", func() {
+				orgList := payloads.OrgList{}
+				req, err := http.NewRequest("GET", "http://foo.com/bar?created_by=alice", nil)
+				Expect(err).NotTo(HaveOccurred())
+				err = validator.DecodeAndValidateURLValues(req, &orgList)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(orgList.CreatedBy).To(Equal("alice"))
+			})
 		})
 
 		Describe("ToMessage", func() {
@@ -120,6 +130,11 @@ var _ = Describe("Org", func() {
 				}
 				Expect(orgList.ToMessage().Names).To(ConsistOf("foo", "bar"))
 			})
+
+			It("passes the created_by filter through", func() {
+				orgList := payloads.OrgList{CreatedBy: "alice"}
+				Expect(orgList.ToMessage().CreatedBy).To(Equal("alice"))
+			})
 		})
 	})
 })
@@ -13,6 +13,7 @@ type ServiceBindingCreate struct {
 	Relationships *ServiceBindingRelationships `json:"relationships"`
 	Type          string                       `json:"type"`
 	Name          *string                      `json:"name"`
+	Parameters    map[string]any               `json:"parameters"`
 }
 
 func (p ServiceBindingCreate) ToMessage(spaceGUID string) repositories.CreateServiceBindingMessage {
@@ -21,6 +22,7 @@ func (p ServiceBindingCreate) ToMessage(spaceGUID string) repositories.CreateSer
 		ServiceInstanceGUID: p.Relationships.ServiceInstance.Data.GUID,
 		AppGUID:             p.Relationships.App.Data.GUID,
 		SpaceGUID:           spaceGUID,
+		Parameters:          p.Parameters,
 	}
 }
 
@@ -46,6 +48,7 @@ func (r ServiceBindingRelationships) Validate() error {
 type ServiceBindingList struct {
 	AppGUIDs             string
 	ServiceInstanceGUIDs string
+	SpaceGUIDs           string
 	Include              string
 	LabelSelector        string
 }
@@ -54,23 +57,26 @@ func (l *ServiceBindingList) ToMessage() repositories.ListServiceBindingsMessage
 	return repositories.ListServiceBindingsMessage{
 		ServiceInstanceGUIDs: parse.ArrayParam(l.ServiceInstanceGUIDs),
 		AppGUIDs:             parse.ArrayParam(l.AppGUIDs),
+		SpaceGUIDs:           parse.ArrayParam(l.SpaceGUIDs),
 		LabelSelector:        l.LabelSelector,
 	}
 }
 
 func (l *ServiceBindingList) SupportedKeys() []string {
-	return []string{"app_guids", "service_instance_guids", "include", "type", "per_page", "page", "label_selector"}
+	return []string{"app_guids", "service_instance_guids", "space_guids", "include", "type", "per_page", "page", "label_selector"}
 }
 
 func (l *ServiceBindingList) DecodeFromURLValues(values url.Values) error {
 	l.AppGUIDs = values.Get("app_guids")
 	l.ServiceInstanceGUIDs = values.Get("service_instance_guids")
+	l.SpaceGUIDs = values.Get("space_guids")
 	l.Include = values.Get("include")
 	l.LabelSelector = values.Get("label_selector")
 	return nil
 }
 
 type ServiceBindingUpdate struct {
+	Name     *string       `json:"name"`
 	Metadata MetadataPatch `json:"metadata"`
 }
 
@@ -83,6 +89,7 @@ func (u ServiceBindingUpdate) Validate() error {
 func (c *ServiceBindingUpdate) ToMessage(serviceBindingGUID string) repositories.UpdateServiceBindingMessage {
 	return repositories.UpdateServiceBindingMessage{
 		GUID: serviceBindingGUID,
+		Name: c.Name,
 		MetadataPatch: repositories.MetadataPatch{
 			Labels:      c.Metadata.Labels,
 			Annotations: c.Metadata.Annotations,
@@ -20,6 +20,7 @@ var _ = Describe("ServiceBindingList", func() {
 		},
 		Entry("app_guids", "app_guids=app_guid", payloads.ServiceBindingList{AppGUIDs: "app_guid"}),
 		Entry("service_instance_guids", "service_instance_guids=si_guid", payloads.ServiceBindingList{ServiceInstanceGUIDs: "si_guid"}),
+		Entry("space_guids", "space_guids=space_guid", payloads.ServiceBindingList{SpaceGUIDs: "space_guid"}),
 		Entry("include", "include=include", payloads.ServiceBindingList{Include: "include"}),
 		Entry("label_selector=foo", "label_selector=foo", payloads.ServiceBindingList{LabelSelector: "foo"}),
 	)
@@ -34,6 +35,7 @@ var _ = Describe("ServiceBindingList", func() {
 			payload = payloads.ServiceBindingList{
 				AppGUIDs:             "app1,app2",
 				ServiceInstanceGUIDs: "s1,s2",
+				SpaceGUIDs:           "space1,space2",
 				Include:              "include",
 				LabelSelector:        "foo=bar",
 			}
@@ -47,6 +49,7 @@ var _ = Describe("ServiceBindingList", func() {
 			Expect(message).To(Equal(repositories.ListServiceBindingsMessage{
 				AppGUIDs:             []string{"app1", "app2"},
 				ServiceInstanceGUIDs: []string{"s1", "s2"},
+				SpaceGUIDs:           []string{"space1", "space2"},
 				LabelSelector:        "foo=bar",
 			}))
 		})
@@ -90,6 +93,21 @@ var _ = Describe("ServiceBindingCreate", func() {
 		Expect(serviceBindingCreate).To(gstruct.PointTo(Equal(createPayload)))
 	})
 
+	When("parameters are specified", func() {
+		BeforeEach(func() {
+			createPayload.Parameters = map[string]any{"foo": "bar"}
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(serviceBindingCreate.Parameters).To(Equal(map[string]any{"foo": "bar"}))
+		})
+
+		It("passes them to the message", func() {
+			Expect(serviceBindingCreate.ToMessage("space-guid").Parameters).To(Equal(map[string]any{"foo": "bar"}))
+		})
+	})
+
 	When(`the type is "key"`, func() {
 		BeforeEach(func() {
 			createPayload.Type = "key"
@@ -168,6 +186,7 @@ var _ = Describe("ServiceBindingUpdate", func() {
 	BeforeEach(func() {
 		serviceBindingPatch = new(payloads.ServiceBindingUpdate)
 		patchPayload = payloads.ServiceBindingUpdate{
+			Name: tools.PtrTo("new-name"),
 			Metadata: payloads.MetadataPatch{
 				Annotations: map[string]*string{"a": tools.PtrTo("av")},
 				Labels:      map[string]*string{"l": tools.PtrTo("lv")},
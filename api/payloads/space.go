@@ -64,6 +64,7 @@ type SpaceList struct {
 	Names             string
 	GUIDs             string
 	OrganizationGUIDs string
+	CreatedBy         string
 }
 
 func (l *SpaceList) ToMessage() repositories.ListSpacesMessage {
@@ -71,16 +72,18 @@ func (l *SpaceList) ToMessage() repositories.ListSpacesMessage {
 		Names:             parse.ArrayParam(l.Names),
 		GUIDs:             parse.ArrayParam(l.GUIDs),
 		OrganizationGUIDs: parse.ArrayParam(l.OrganizationGUIDs),
+		CreatedBy:         l.CreatedBy,
 	}
 }
 
 func (l *SpaceList) SupportedKeys() []string {
-	return []string{"names", "guids", "organization_guids", "order_by", "per_page", "page"}
+	return []string{"names", "guids", "organization_guids", "created_by", "order_by", "per_page", "page"}
 }
 
 func (l *SpaceList) DecodeFromURLValues(values url.Values) error {
 	l.Names = values.Get("names")
 	l.GUIDs = values.Get("guids")
 	l.OrganizationGUIDs = values.Get("organization_guids")
+	l.CreatedBy = values.Get("created_by")
 	return nil
 }
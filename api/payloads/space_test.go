@@ -148,6 +148,7 @@ var _ = Describe("Space", func() {
 				Entry("names", "names=name", payloads.SpaceList{Names: "name"}),
 				Entry("guids", "guids=guid", payloads.SpaceList{GUIDs: "guid"}),
 				Entry("organization_guids", "organization_guids=org-guid", payloads.SpaceList{OrganizationGUIDs: "org-guid"}),
+				Entry("created_by", "created_by=alice", payloads.SpaceList{CreatedBy: "alice"}),
 				Entry("order_by", "order_by=something", payloads.SpaceList{}),
 				Entry("per_page", "per_page=few", payloads.SpaceList{}),
 				Entry("page", "page=3", payloads.SpaceList{}),
@@ -160,11 +161,13 @@ var _ = Describe("Space", func() {
 					Names:             "foo,bar",
 					GUIDs:             "g1,g2",
 					OrganizationGUIDs: "org1,org2",
+					CreatedBy:         "alice",
 				}
 				Expect(spaceList.ToMessage()).To(Equal(repositories.ListSpacesMessage{
 					Names:             []string{"foo", "bar"},
 					GUIDs:             []string{"g1", "g2"},
 					OrganizationGUIDs: []string{"org1", "org2"},
+					CreatedBy:         "alice",
 				}))
 			})
 		})
@@ -112,4 +112,19 @@ var _ = Describe("Process Stats", func() {
 			Expect(output).ToNot(ContainSubstring("instance_ports"))
 		})
 	})
+
+	When("an instance is crash-looping", func() {
+		BeforeEach(func() {
+			records[0].State = "CRASHED"
+			records[0].CrashLooping = true
+			records[0].LastCrashReason = tools.PtrTo("OOMKilled (exit code 137)")
+		})
+
+		It("reports the last crash reason in the details", func() {
+			var response map[string][]map[string]any
+			Expect(json.Unmarshal(output, &response)).To(Succeed())
+			Expect(response["resources"][0]).To(HaveKeyWithValue("details", "crash-looping: OOMKilled (exit code 137)"))
+			Expect(response["resources"][1]).To(HaveKeyWithValue("details", BeNil()))
+		})
+	})
 })
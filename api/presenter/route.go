@@ -117,7 +117,7 @@ func forDestination(destination repositories.DestinationRecord) routeDestination
 				Type: destination.ProcessType,
 			},
 		},
-		Weight:   nil,
+		Weight:   destination.Weight,
 		Port:     destination.Port,
 		Protocol: destination.Protocol,
 	}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,7 +17,9 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
+	"github.com/go-logr/logr"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -36,26 +39,60 @@ const (
 	CFAppGUIDLabel     string = "korifi.cloudfoundry.org/app-guid"
 	AppResourceType    string = "App"
 	AppEnvResourceType string = "App Env"
+
+	// MaxAppsAnnotation can be set on a space namespace to limit the number
+	// of apps that can be created in the space
+	MaxAppsAnnotation string = "korifi.cloudfoundry.org/max-apps"
+
+	// The last-started and last-stopped annotations record who last changed
+	// the desired state of an app and when, for incident review
+	LastStartedByAnnotation string = "korifi.cloudfoundry.org/last-started-by"
+	LastStartedAtAnnotation string = "korifi.cloudfoundry.org/last-started-at"
+	LastStoppedByAnnotation string = "korifi.cloudfoundry.org/last-stopped-by"
+	LastStoppedAtAnnotation string = "korifi.cloudfoundry.org/last-stopped-at"
+
+	// CrashEventsAcknowledgedAtAnnotation records when the crash events of an
+	// app were last cleared. Crashes that happened before then are no longer
+	// listed.
+	CrashEventsAcknowledgedAtAnnotation string = "korifi.cloudfoundry.org/crash-events-acknowledged-at"
+
+	// RestartOnConfigChangeAnnotation makes changes to the environment or the
+	// service bindings of an app restart it by bumping its app-rev
+	RestartOnConfigChangeAnnotation string = "korifi.cloudfoundry.org/restart-on-config-change"
+
+	// The current droplet state of an app is STAGED when the build it
+	// references as its current droplet has succeeded, and NONE otherwise
+	CurrentDropletStateStaged string = "STAGED"
+	CurrentDropletStateNone   string = "NONE"
 )
 
 type AppRepo struct {
 	namespaceRetriever   NamespaceRetriever
 	userClientFactory    authorization.UserK8sClientFactory
 	namespacePermissions *authorization.NamespacePermissions
+	privilegedClient     client.Client
 	appConditionAwaiter  ConditionAwaiter[*korifiv1alpha1.CFApp]
+	identityProvider     authorization.IdentityProvider
+	tracer               trace.Tracer
 }
 
 func NewAppRepo(
 	namespaceRetriever NamespaceRetriever,
 	userClientFactory authorization.UserK8sClientFactory,
 	authPerms *authorization.NamespacePermissions,
+	privilegedClient client.Client,
 	appConditionAwaiter ConditionAwaiter[*korifiv1alpha1.CFApp],
+	identityProvider authorization.IdentityProvider,
+	tracer trace.Tracer,
 ) *AppRepo {
 	return &AppRepo{
 		namespaceRetriever:   namespaceRetriever,
 		userClientFactory:    userClientFactory,
 		namespacePermissions: authPerms,
+		privilegedClient:     privilegedClient,
 		appConditionAwaiter:  appConditionAwaiter,
+		identityProvider:     identityProvider,
+		tracer:               tracer,
 	}
 }
 
@@ -74,11 +111,26 @@ type AppRecord struct {
 	UpdatedAt             *time.Time
 	DeletedAt             *time.Time
 	IsStaged              bool
+	LastStartedBy         string
+	LastStartedAt         *time.Time
+	LastStoppedBy         string
+	LastStoppedAt         *time.Time
+	RouteURLs             []string
+	CurrentDropletState   string
 	envSecretName         string
 	vcapServiceSecretName string
 	vcapAppSecretName     string
 }
 
+type AppCrashEventRecord struct {
+	AppGUID     string
+	ProcessType string
+	Index       int
+	Reason      string
+	ExitCode    int32
+	CrashedAt   time.Time
+}
+
 type DesiredState string
 
 type Lifecycle struct {
@@ -163,6 +215,22 @@ type SetCurrentDropletMessage struct {
 	SpaceGUID   string
 }
 
+// AppQuotaRecord holds the caps on the resources used by all the processes
+// of an app together. Nil fields are unlimited.
+type AppQuotaRecord struct {
+	AppGUID   string
+	MemoryMB  *int64
+	Instances *int
+}
+
+// SetAppQuotaMessage replaces the quota of an app. Nil fields are unlimited.
+type SetAppQuotaMessage struct {
+	AppGUID   string
+	SpaceGUID string
+	MemoryMB  *int64
+	Instances *int
+}
+
 type SetAppDesiredStateMessage struct {
 	AppGUID      string
 	SpaceGUID    string
@@ -170,10 +238,15 @@ type SetAppDesiredStateMessage struct {
 }
 
 type ListAppsMessage struct {
-	Names         []string
-	Guids         []string
-	SpaceGuids    []string
-	LabelSelector string
+	Names                 []string
+	Guids                 []string
+	SpaceGuids            []string
+	LabelSelector         string
+	IncludeRoutes         bool
+	IncludeCurrentDroplet bool
+	// OnlyRunning restricts the list to started apps with at least one
+	// running instance
+	OnlyRunning bool
 }
 
 type byName []AppRecord
@@ -190,7 +263,10 @@ func (a byName) Swap(i, j int) {
 	a[i], a[j] = a[j], a[i]
 }
 
-func (f *AppRepo) GetApp(ctx context.Context, authInfo authorization.Info, appGUID string) (AppRecord, error) {
+func (f *AppRepo) GetApp(ctx context.Context, authInfo authorization.Info, appGUID string) (_ AppRecord, err error) {
+	ctx, span := startSpan(ctx, f.tracer, "GetApp", AppResourceType, appGUID)
+	defer func() { endSpan(span, err) }()
+
 	ns, err := f.namespaceRetriever.NamespaceFor(ctx, appGUID, AppResourceType)
 	if err != nil {
 		return AppRecord{}, err
@@ -210,6 +286,28 @@ func (f *AppRepo) GetApp(ctx context.Context, authInfo authorization.Info, appGU
 	return cfAppToAppRecord(app), nil
 }
 
+// GetAppWithRoutes returns the app like GetApp does, with the URLs of the
+// routes mapped to it populated
+func (f *AppRepo) GetAppWithRoutes(ctx context.Context, authInfo authorization.Info, appGUID string) (AppRecord, error) {
+	appRecord, err := f.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		return AppRecord{}, err
+	}
+
+	userClient, err := f.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return AppRecord{}, fmt.Errorf("get-app failed to build user client: %w", err)
+	}
+
+	routeURLs, err := listRouteURLsByApp(ctx, userClient, appRecord.SpaceGUID)
+	if err != nil {
+		return AppRecord{}, err
+	}
+	appRecord.RouteURLs = routeURLs[appRecord.GUID]
+
+	return appRecord, nil
+}
+
 func (f *AppRepo) GetAppByNameAndSpace(ctx context.Context, authInfo authorization.Info, appName string, spaceGUID string) (AppRecord, error) {
 	userClient, err := f.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -239,13 +337,23 @@ func (f *AppRepo) GetAppByNameAndSpace(ctx context.Context, authInfo authorizati
 	return cfAppToAppRecord(matchingApps[0]), nil
 }
 
-func (f *AppRepo) CreateApp(ctx context.Context, authInfo authorization.Info, appCreateMessage CreateAppMessage) (AppRecord, error) {
+func (f *AppRepo) CreateApp(ctx context.Context, authInfo authorization.Info, appCreateMessage CreateAppMessage) (_ AppRecord, err error) {
+	ctx, span := startSpan(ctx, f.tracer, "CreateApp", AppResourceType, "")
+	defer func() { endSpan(span, err) }()
+
 	userClient, err := f.userClientFactory.BuildClient(authInfo)
 	if err != nil {
 		return AppRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	err = f.checkAppLimit(ctx, userClient, appCreateMessage.SpaceGUID)
+	if err != nil {
+		return AppRecord{}, err
+	}
+
 	cfApp := appCreateMessage.toCFApp()
+	span.SetAttributes(TraceGUIDKey.String(cfApp.Name))
+
 	err = userClient.Create(ctx, &cfApp)
 	if err != nil {
 		if validationError, ok := webhooks.WebhookErrorToValidationError(err); ok {
@@ -270,6 +378,43 @@ func (f *AppRepo) CreateApp(ctx context.Context, authInfo authorization.Info, ap
 	return cfAppToAppRecord(cfApp), nil
 }
 
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+
+func (f *AppRepo) checkAppLimit(ctx context.Context, userClient client.Client, spaceGUID string) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("repo.app.checkAppLimit")
+
+	// list the apps with the user client first, so that the namespace is only
+	// read with the privileged client once the user is known to have access
+	appList := &korifiv1alpha1.CFAppList{}
+	err := userClient.List(ctx, appList, client.InNamespace(spaceGUID))
+	if err != nil {
+		return apierrors.FromK8sError(err, AppResourceType)
+	}
+
+	namespace := &corev1.Namespace{}
+	err = f.privilegedClient.Get(ctx, client.ObjectKey{Name: spaceGUID}, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get namespace %q: %w", spaceGUID, apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	maxAppsValue, ok := namespace.Annotations[MaxAppsAnnotation]
+	if !ok {
+		return nil
+	}
+
+	maxApps, err := strconv.Atoi(maxAppsValue)
+	if err != nil {
+		log.Info("ignoring invalid app limit", "namespace", spaceGUID, "annotation", MaxAppsAnnotation, "value", maxAppsValue)
+		return nil
+	}
+
+	if len(appList.Items) >= maxApps {
+		return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("You have exceeded the app limit of %d for this space.", maxApps))
+	}
+
+	return nil
+}
+
 func (f *AppRepo) PatchApp(ctx context.Context, authInfo authorization.Info, appPatchMessage PatchAppMessage) (AppRecord, error) {
 	userClient, err := f.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -302,8 +447,13 @@ func (f *AppRepo) PatchApp(ctx context.Context, authInfo authorization.Info, app
 	return cfAppToAppRecord(*app), nil
 }
 
-func (f *AppRepo) ListApps(ctx context.Context, authInfo authorization.Info, message ListAppsMessage) ([]AppRecord, error) {
-	nsList, err := f.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+func (f *AppRepo) ListApps(ctx context.Context, authInfo authorization.Info, message ListAppsMessage) (_ []AppRecord, err error) {
+	ctx, span := startSpan(ctx, f.tracer, "ListApps", AppResourceType, "")
+	defer func() { endSpan(span, err) }()
+
+	permsCtx, permsSpan := startSpan(ctx, f.tracer, "permissions", AppResourceType, "")
+	nsList, err := f.namespacePermissions.GetAuthorizedSpaceNamespaces(permsCtx, authInfo)
+	endSpan(permsSpan, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
 	}
@@ -345,12 +495,160 @@ func (f *AppRepo) ListApps(ctx context.Context, authInfo authorization.Info, mes
 
 	appRecords := returnAppList(filteredApps)
 
+	if message.OnlyRunning {
+		if appRecords, err = filterRunningApps(ctx, userClient, appRecords); err != nil {
+			return []AppRecord{}, err
+		}
+	}
+
+	if message.IncludeRoutes {
+		if err = populateRouteURLs(ctx, userClient, appRecords); err != nil {
+			return []AppRecord{}, err
+		}
+	}
+
+	if message.IncludeCurrentDroplet {
+		if err = populateCurrentDropletStates(ctx, userClient, appRecords); err != nil {
+			return []AppRecord{}, err
+		}
+	}
+
 	// By default sort it by App.DisplayName
 	sort.Sort(byName(appRecords))
 
 	return appRecords, nil
 }
 
+func populateRouteURLs(ctx context.Context, userClient client.Client, appRecords []AppRecord) error {
+	routeURLsBySpace := map[string]map[string][]string{}
+	for i := range appRecords {
+		spaceGUID := appRecords[i].SpaceGUID
+		if _, ok := routeURLsBySpace[spaceGUID]; !ok {
+			routeURLs, err := listRouteURLsByApp(ctx, userClient, spaceGUID)
+			if err != nil {
+				return err
+			}
+			routeURLsBySpace[spaceGUID] = routeURLs
+		}
+
+		appRecords[i].RouteURLs = routeURLsBySpace[spaceGUID][appRecords[i].GUID]
+	}
+
+	return nil
+}
+
+// listRouteURLsByApp returns the URLs of the routes in a space keyed by the
+// guids of the apps they are mapped to. Routes without a URL yet are skipped.
+func listRouteURLsByApp(ctx context.Context, userClient client.Client, spaceGUID string) (map[string][]string, error) {
+	routeList := &korifiv1alpha1.CFRouteList{}
+	if err := userClient.List(ctx, routeList, client.InNamespace(spaceGUID)); err != nil {
+		return nil, fmt.Errorf("failed to list routes in namespace %s: %w", spaceGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	routeURLs := map[string][]string{}
+	for _, route := range routeList.Items {
+		if route.Status.URI == "" {
+			continue
+		}
+
+		appGUIDs := NewSet[string]()
+		for _, destination := range route.Spec.Destinations {
+			appGUIDs[destination.AppRef.Name] = struct{}{}
+		}
+
+		for appGUID := range appGUIDs {
+			routeURLs[appGUID] = append(routeURLs[appGUID], route.Status.URI)
+		}
+	}
+
+	for appGUID := range routeURLs {
+		sort.Strings(routeURLs[appGUID])
+	}
+
+	return routeURLs, nil
+}
+
+func populateCurrentDropletStates(ctx context.Context, userClient client.Client, appRecords []AppRecord) error {
+	stagedBuildsBySpace := map[string]Set[string]{}
+	for i := range appRecords {
+		spaceGUID := appRecords[i].SpaceGUID
+		if _, ok := stagedBuildsBySpace[spaceGUID]; !ok {
+			stagedBuilds, err := listStagedBuilds(ctx, userClient, spaceGUID)
+			if err != nil {
+				return err
+			}
+			stagedBuildsBySpace[spaceGUID] = stagedBuilds
+		}
+
+		appRecords[i].CurrentDropletState = CurrentDropletStateNone
+		if appRecords[i].DropletGUID != "" && stagedBuildsBySpace[spaceGUID].Includes(appRecords[i].DropletGUID) {
+			appRecords[i].CurrentDropletState = CurrentDropletStateStaged
+		}
+	}
+
+	return nil
+}
+
+func filterRunningApps(ctx context.Context, userClient client.Client, appRecords []AppRecord) ([]AppRecord, error) {
+	runningAppsBySpace := map[string]Set[string]{}
+	runningApps := []AppRecord{}
+	for _, app := range appRecords {
+		if app.State != StartedState {
+			continue
+		}
+
+		if _, ok := runningAppsBySpace[app.SpaceGUID]; !ok {
+			runningAppGUIDs, err := listRunningAppGUIDs(ctx, userClient, app.SpaceGUID)
+			if err != nil {
+				return nil, err
+			}
+			runningAppsBySpace[app.SpaceGUID] = runningAppGUIDs
+		}
+
+		if runningAppsBySpace[app.SpaceGUID].Includes(app.GUID) {
+			runningApps = append(runningApps, app)
+		}
+	}
+
+	return runningApps, nil
+}
+
+// listRunningAppGUIDs returns the guids of the apps in a space that have at
+// least one instance whose application container is running
+func listRunningAppGUIDs(ctx context.Context, userClient client.Client, spaceGUID string) (Set[string], error) {
+	podList := corev1.PodList{}
+	if err := userClient.List(ctx, &podList, client.InNamespace(spaceGUID), client.HasLabels{korifiv1alpha1.CFAppGUIDLabelKey}); err != nil {
+		return nil, fmt.Errorf("failed to list pods in namespace %s: %w", spaceGUID, apierrors.FromK8sError(err, PodResourceType))
+	}
+
+	runningAppGUIDs := NewSet[string]()
+	for _, pod := range podList.Items {
+		if appContainerRunning(pod) {
+			runningAppGUIDs[pod.Labels[korifiv1alpha1.CFAppGUIDLabelKey]] = struct{}{}
+		}
+	}
+
+	return runningAppGUIDs, nil
+}
+
+// listStagedBuilds returns the guids of the builds in a space that have
+// succeeded and produced a droplet
+func listStagedBuilds(ctx context.Context, userClient client.Client, spaceGUID string) (Set[string], error) {
+	buildList := &korifiv1alpha1.CFBuildList{}
+	if err := userClient.List(ctx, buildList, client.InNamespace(spaceGUID)); err != nil {
+		return nil, fmt.Errorf("failed to list builds in namespace %s: %w", spaceGUID, apierrors.FromK8sError(err, BuildResourceType))
+	}
+
+	stagedBuilds := NewSet[string]()
+	for _, build := range buildList.Items {
+		if build.Status.Droplet != nil && meta.IsStatusConditionTrue(build.Status.Conditions, SucceededConditionType) {
+			stagedBuilds[build.Name] = struct{}{}
+		}
+	}
+
+	return stagedBuilds, nil
+}
+
 func returnAppList(appList []korifiv1alpha1.CFApp) []AppRecord {
 	appRecords := make([]AppRecord, 0, len(appList))
 
@@ -388,6 +686,10 @@ func (f *AppRepo) PatchAppEnvVars(ctx context.Context, authInfo authorization.In
 		return AppEnvVarsRecord{}, apierrors.FromK8sError(err, AppEnvResourceType)
 	}
 
+	if err = restartAppOnConfigChange(ctx, userClient, message.SpaceGUID, message.AppGUID); err != nil {
+		return AppEnvVarsRecord{}, err
+	}
+
 	return appEnvVarsSecretToRecord(secretObj), nil
 }
 
@@ -407,9 +709,119 @@ func (f *AppRepo) CreateOrPatchAppEnvVars(ctx context.Context, authInfo authoriz
 	if err != nil {
 		return AppEnvVarsRecord{}, apierrors.FromK8sError(err, AppEnvResourceType)
 	}
+
+	if err = restartAppOnConfigChange(ctx, userClient, envVariables.SpaceGUID, envVariables.AppGUID); err != nil {
+		return AppEnvVarsRecord{}, err
+	}
+
 	return appEnvVarsSecretToRecord(secretObj), nil
 }
 
+// GetAppRestartOnConfigChange returns whether changes to the environment or
+// the service bindings of the app restart it
+func (f *AppRepo) GetAppRestartOnConfigChange(ctx context.Context, authInfo authorization.Info, appGUID string) (bool, error) {
+	app, err := f.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		return false, err
+	}
+
+	return app.Annotations[RestartOnConfigChangeAnnotation] == "true", nil
+}
+
+// SetAppRestartOnConfigChange opts the app in to (or out of) being restarted
+// whenever its environment or its service bindings change
+func (f *AppRepo) SetAppRestartOnConfigChange(ctx context.Context, authInfo authorization.Info, appGUID string, enabled bool) error {
+	app, err := f.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		return err
+	}
+
+	userClient, err := f.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfApp := &korifiv1alpha1.CFApp{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: app.SpaceGUID, Name: app.GUID}, cfApp)
+	if err != nil {
+		return fmt.Errorf("failed to get app: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	// the app is fetched first, so that removing the annotation shows up in
+	// the merge patch
+	err = k8s.PatchResource(ctx, userClient, cfApp, func() {
+		if !enabled {
+			delete(cfApp.Annotations, RestartOnConfigChangeAnnotation)
+			return
+		}
+
+		if cfApp.Annotations == nil {
+			cfApp.Annotations = map[string]string{}
+		}
+		cfApp.Annotations[RestartOnConfigChangeAnnotation] = "true"
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set restart on config change: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	return nil
+}
+
+// restartAppOnConfigChange bumps the app-rev of the app when it has opted in
+// to being restarted on configuration changes. Apps that do not exist (yet)
+// are left alone.
+func restartAppOnConfigChange(ctx context.Context, userClient client.Client, spaceGUID, appGUID string) error {
+	cfApp, err := getAppToRestartOnConfigChange(ctx, userClient, spaceGUID, appGUID)
+	if err != nil || cfApp == nil {
+		return err
+	}
+
+	return restartAppForConfigChange(ctx, userClient, cfApp)
+}
+
+// getAppToRestartOnConfigChange returns the app when it has opted in to being
+// restarted on configuration changes, and nil when it has not or does not
+// exist
+func getAppToRestartOnConfigChange(ctx context.Context, userClient client.Client, spaceGUID, appGUID string) (*korifiv1alpha1.CFApp, error) {
+	cfApp := new(korifiv1alpha1.CFApp)
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: spaceGUID, Name: appGUID}, cfApp)
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app %q: %w", appGUID, apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	if cfApp.Annotations[RestartOnConfigChangeAnnotation] != "true" {
+		return nil, nil
+	}
+
+	return cfApp, nil
+}
+
+func restartAppForConfigChange(ctx context.Context, userClient client.Client, cfApp *korifiv1alpha1.CFApp) error {
+	appRev := cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey]
+	if appRev == "" {
+		appRev = korifiv1alpha1.CFAppRevisionKeyDefault
+	}
+	newRev, err := bumpAppRev(appRev)
+	if err != nil {
+		return fmt.Errorf("expected app-rev to be an integer: %w", err)
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfApp, func() {
+		cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = newRev
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restart app %q: %w", cfApp.Name, apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	return nil
+}
+
+// SetCurrentDroplet only patches the droplet reference of the app. Everything
+// else, in particular the env secret and its contents, carries over to the
+// new droplet.
 func (f *AppRepo) SetCurrentDroplet(ctx context.Context, authInfo authorization.Info, message SetCurrentDropletMessage) (CurrentDropletRecord, error) {
 	userClient, err := f.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -454,8 +866,23 @@ func (f *AppRepo) SetAppDesiredState(ctx context.Context, authInfo authorization
 		},
 	}
 
+	identity, err := f.identityProvider.GetIdentity(ctx, authInfo)
+	if err != nil {
+		return AppRecord{}, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	byAnnotation, atAnnotation := LastStartedByAnnotation, LastStartedAtAnnotation
+	if DesiredState(message.DesiredState) == StoppedState {
+		byAnnotation, atAnnotation = LastStoppedByAnnotation, LastStoppedAtAnnotation
+	}
+
 	err = k8s.PatchResource(ctx, userClient, cfApp, func() {
 		cfApp.Spec.DesiredState = korifiv1alpha1.DesiredState(message.DesiredState)
+		if cfApp.Annotations == nil {
+			cfApp.Annotations = map[string]string{}
+		}
+		cfApp.Annotations[byAnnotation] = identity.Name
+		cfApp.Annotations[atAnnotation] = time.Now().UTC().Format(time.RFC3339)
 	})
 	if err != nil {
 		return AppRecord{}, fmt.Errorf("failed to set app desired state: %w", apierrors.FromK8sError(err, AppResourceType))
@@ -464,6 +891,74 @@ func (f *AppRepo) SetAppDesiredState(ctx context.Context, authInfo authorization
 	return cfAppToAppRecord(*cfApp), nil
 }
 
+func (f *AppRepo) GetAppQuota(ctx context.Context, authInfo authorization.Info, appGUID string) (AppQuotaRecord, error) {
+	ns, err := f.namespaceRetriever.NamespaceFor(ctx, appGUID, AppResourceType)
+	if err != nil {
+		return AppQuotaRecord{}, err
+	}
+
+	userClient, err := f.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return AppQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfApp := new(korifiv1alpha1.CFApp)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: appGUID}, cfApp)
+	if err != nil {
+		return AppQuotaRecord{}, fmt.Errorf("failed to get app: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	return cfAppToAppQuotaRecord(cfApp), nil
+}
+
+// SetAppQuota caps the resources used by all the processes of the app
+// together. The quota is enforced when scaling the processes.
+func (f *AppRepo) SetAppQuota(ctx context.Context, authInfo authorization.Info, message SetAppQuotaMessage) (AppQuotaRecord, error) {
+	if message.MemoryMB != nil && *message.MemoryMB <= 0 {
+		return AppQuotaRecord{}, apierrors.NewUnprocessableEntityError(nil, "memory quota must be greater than 0")
+	}
+
+	if message.Instances != nil && *message.Instances < 0 {
+		return AppQuotaRecord{}, apierrors.NewUnprocessableEntityError(nil, "instances quota must not be negative")
+	}
+
+	userClient, err := f.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return AppQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfApp := new(korifiv1alpha1.CFApp)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.AppGUID}, cfApp)
+	if err != nil {
+		return AppQuotaRecord{}, fmt.Errorf("failed to get app: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfApp, func() {
+		cfApp.Spec.Quota = nil
+		if message.MemoryMB != nil || message.Instances != nil {
+			cfApp.Spec.Quota = &korifiv1alpha1.CFAppQuota{
+				MemoryMB:  message.MemoryMB,
+				Instances: message.Instances,
+			}
+		}
+	})
+	if err != nil {
+		return AppQuotaRecord{}, fmt.Errorf("failed to set app quota: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	return cfAppToAppQuotaRecord(cfApp), nil
+}
+
+func cfAppToAppQuotaRecord(cfApp *korifiv1alpha1.CFApp) AppQuotaRecord {
+	record := AppQuotaRecord{AppGUID: cfApp.Name}
+	if cfApp.Spec.Quota != nil {
+		record.MemoryMB = cfApp.Spec.Quota.MemoryMB
+		record.Instances = cfApp.Spec.Quota.Instances
+	}
+
+	return record
+}
+
 func (f *AppRepo) DeleteApp(ctx context.Context, authInfo authorization.Info, message DeleteAppMessage) error {
 	cfApp := &korifiv1alpha1.CFApp{
 		ObjectMeta: metav1.ObjectMeta{
@@ -535,6 +1030,133 @@ func (f *AppRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info,
 	return app.DeletedAt, nil
 }
 
+// ListAppCrashEvents returns the crashes of the application containers of
+// the app instances that have not been acknowledged yet, most recent first.
+// Crashes are read from the current and last termination state of the
+// containers, so at most two crashes are reported per running instance.
+func (f *AppRepo) ListAppCrashEvents(ctx context.Context, authInfo authorization.Info, appGUID string) ([]AppCrashEventRecord, error) {
+	app, err := f.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	userClient, err := f.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	podList := corev1.PodList{}
+	err = userClient.List(ctx, &podList,
+		client.InNamespace(app.SpaceGUID),
+		client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: appGUID},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", apierrors.FromK8sError(err, PodResourceType))
+	}
+
+	acknowledgedAt := parseAnnotationTime(app.Annotations, CrashEventsAcknowledgedAtAnnotation)
+
+	crashEvents := []AppCrashEventRecord{}
+	for _, pod := range podList.Items {
+		index, err := korifiv1alpha1.AppWorkloadInstanceIndex(pod)
+		if err != nil {
+			continue
+		}
+
+		for _, terminated := range appContainerTerminations(pod) {
+			if acknowledgedAt != nil && !terminated.FinishedAt.Time.After(*acknowledgedAt) {
+				continue
+			}
+
+			crashEvents = append(crashEvents, AppCrashEventRecord{
+				AppGUID:     appGUID,
+				ProcessType: pod.Labels[korifiv1alpha1.CFProcessTypeLabelKey],
+				Index:       index,
+				Reason:      terminated.Reason,
+				ExitCode:    terminated.ExitCode,
+				CrashedAt:   terminated.FinishedAt.Time,
+			})
+		}
+	}
+
+	sort.SliceStable(crashEvents, func(i, j int) bool {
+		return crashEvents[i].CrashedAt.After(crashEvents[j].CrashedAt)
+	})
+
+	return crashEvents, nil
+}
+
+// AcknowledgeAppCrashEvents clears the crash events of the app, so that
+// ListAppCrashEvents only returns crashes that happen from now on
+func (f *AppRepo) AcknowledgeAppCrashEvents(ctx context.Context, authInfo authorization.Info, appGUID string) error {
+	app, err := f.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		return err
+	}
+
+	userClient, err := f.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfApp := &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.GUID,
+			Namespace: app.SpaceGUID,
+		},
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfApp, func() {
+		if cfApp.Annotations == nil {
+			cfApp.Annotations = map[string]string{}
+		}
+		cfApp.Annotations[CrashEventsAcknowledgedAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to acknowledge app crash events: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	return nil
+}
+
+func appContainerTerminations(pod corev1.Pod) []corev1.ContainerStateTerminated {
+	terminations := []corev1.ContainerStateTerminated{}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != korifiv1alpha1.AppWorkloadContainerName {
+			continue
+		}
+
+		if status.State.Terminated != nil {
+			terminations = append(terminations, *status.State.Terminated)
+		}
+		if status.LastTerminationState.Terminated != nil {
+			terminations = append(terminations, *status.LastTerminationState.Terminated)
+		}
+	}
+
+	return terminations
+}
+
+func appContainerRunning(pod corev1.Pod) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == korifiv1alpha1.AppWorkloadContainerName && status.State.Running != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func appContainerReady(pod corev1.Pod) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == korifiv1alpha1.AppWorkloadContainerName && status.Ready {
+			return true
+		}
+	}
+
+	return false
+}
+
 func getSystemEnv(ctx context.Context, userClient client.Client, app AppRecord) (map[string]any, error) {
 	systemEnvMap := map[string]any{}
 	if app.vcapServiceSecretName != "" {
@@ -664,12 +1286,30 @@ func cfAppToAppRecord(cfApp korifiv1alpha1.CFApp) AppRecord {
 		UpdatedAt:             getLastUpdatedTime(&cfApp),
 		DeletedAt:             golangTime(cfApp.DeletionTimestamp),
 		IsStaged:              meta.IsStatusConditionTrue(cfApp.Status.Conditions, shared.StatusConditionReady),
+		LastStartedBy:         cfApp.Annotations[LastStartedByAnnotation],
+		LastStartedAt:         parseAnnotationTime(cfApp.Annotations, LastStartedAtAnnotation),
+		LastStoppedBy:         cfApp.Annotations[LastStoppedByAnnotation],
+		LastStoppedAt:         parseAnnotationTime(cfApp.Annotations, LastStoppedAtAnnotation),
 		envSecretName:         cfApp.Spec.EnvSecretName,
 		vcapServiceSecretName: cfApp.Status.VCAPServicesSecretName,
 		vcapAppSecretName:     cfApp.Status.VCAPApplicationSecretName,
 	}
 }
 
+func parseAnnotationTime(annotations map[string]string, key string) *time.Time {
+	value, ok := annotations[key]
+	if !ok {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
+
 func appEnvVarsRecordToSecret(envVars CreateOrPatchAppEnvVarsMessage) corev1.Secret {
 	labels := make(map[string]string, 1)
 	labels[CFAppGUIDLabel] = envVars.AppGUID
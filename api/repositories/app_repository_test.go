@@ -54,7 +54,7 @@ var _ = Describe("AppRepository", func() {
 			korifiv1alpha1.CFAppList,
 			*korifiv1alpha1.CFAppList,
 		]{}
-		appRepo = NewAppRepo(namespaceRetriever, userClientFactory, nsPerms, conditionAwaiter)
+		appRepo = NewAppRepo(namespaceRetriever, userClientFactory, nsPerms, k8sClient, conditionAwaiter, idProvider, nil)
 
 		cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
 		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space1"))
@@ -180,6 +180,37 @@ var _ = Describe("AppRepository", func() {
 		})
 	})
 
+	Describe("GetAppWithRoutes", func() {
+		var (
+			app    AppRecord
+			getErr error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			createMappedRoute(cfSpace.Name, "my-app.example.com", cfApp.Name)
+			createMappedRoute(cfSpace.Name, "my-app.example.com/api", cfApp.Name)
+			createMappedRoute(cfSpace.Name, "another-app.example.com", "another-app-guid")
+		})
+
+		JustBeforeEach(func() {
+			app, getErr = appRepo.GetAppWithRoutes(ctx, authInfo, cfApp.Name)
+		})
+
+		It("returns the app with the URLs of its routes", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(app.GUID).To(Equal(cfApp.Name))
+			Expect(app.RouteURLs).To(ConsistOf("my-app.example.com", "my-app.example.com/api"))
+		})
+
+		When("the app does not exist", func() {
+			It("returns a not found error", func() {
+				_, err := appRepo.GetAppWithRoutes(ctx, authInfo, "does-not-exist")
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
 	Describe("GetAppByNameAndSpace", func() {
 		var (
 			appRecord      AppRecord
@@ -270,6 +301,140 @@ var _ = Describe("AppRepository", func() {
 			Expect(sortedByName).To(BeTrue(), fmt.Sprintf("AppList was not sorted by Name : App1 : %s , App2: %s", appList[0].Name, appList[1].Name))
 		})
 
+		It("does not include the route URLs", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			for _, app := range appList {
+				Expect(app.RouteURLs).To(BeEmpty())
+			}
+		})
+
+		When("routes are included", func() {
+			BeforeEach(func() {
+				message.IncludeRoutes = true
+				createMappedRoute(cfSpace.Name, "my-app.example.com", cfApp.Name)
+				createMappedRoute(cfSpace.Name, "my-app.example.com/api", cfApp.Name)
+			})
+
+			It("populates the URLs of the routes mapped to each app", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(appList).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":      Equal(cfApp.Name),
+						"RouteURLs": ConsistOf("my-app.example.com", "my-app.example.com/api"),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":      Equal(cfApp2.Name),
+						"RouteURLs": BeEmpty(),
+					}),
+				))
+			})
+		})
+
+		When("the current droplet is included", func() {
+			BeforeEach(func() {
+				message.IncludeCurrentDroplet = true
+
+				build := createBuild(ctx, k8sClient, cfSpace.Name, cfApp.Spec.CurrentDropletRef.Name, "a-package-guid", cfApp.Name)
+				Expect(k8s.Patch(ctx, k8sClient, build, func() {
+					meta.SetStatusCondition(&build.Status.Conditions, metav1.Condition{
+						Type:   SucceededConditionType,
+						Status: metav1.ConditionTrue,
+						Reason: "Staged",
+					})
+					build.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+						Registry: korifiv1alpha1.Registry{Image: "my-image"},
+					}
+				})).To(Succeed())
+
+				Expect(k8s.Patch(ctx, k8sClient, cfApp2, func() {
+					cfApp2.Spec.CurrentDropletRef = corev1.LocalObjectReference{}
+				})).To(Succeed())
+			})
+
+			It("reports the current droplet and whether it is staged", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(appList).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":                Equal(cfApp.Name),
+						"DropletGUID":         Equal(cfApp.Spec.CurrentDropletRef.Name),
+						"CurrentDropletState": Equal(CurrentDropletStateStaged),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":                Equal(cfApp2.Name),
+						"DropletGUID":         BeEmpty(),
+						"CurrentDropletState": Equal(CurrentDropletStateNone),
+					}),
+				))
+			})
+
+			When("the current droplet has not finished staging", func() {
+				BeforeEach(func() {
+					build := &korifiv1alpha1.CFBuild{}
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: cfSpace.Name, Name: cfApp.Spec.CurrentDropletRef.Name}, build)).To(Succeed())
+					Expect(k8s.Patch(ctx, k8sClient, build, func() {
+						meta.SetStatusCondition(&build.Status.Conditions, metav1.Condition{
+							Type:   SucceededConditionType,
+							Status: metav1.ConditionUnknown,
+							Reason: "Staging",
+						})
+					})).To(Succeed())
+				})
+
+				It("reports no staged droplet", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(appList).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"GUID":                Equal(cfApp.Name),
+						"CurrentDropletState": Equal(CurrentDropletStateNone),
+					})))
+				})
+			})
+		})
+
+		When("only running apps are requested", func() {
+			var runningApp *korifiv1alpha1.CFApp
+
+			BeforeEach(func() {
+				message.OnlyRunning = true
+
+				runningContainer := corev1.ContainerStatus{
+					Name: "application",
+					State: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+				}
+
+				runningApp = createApp(cfSpace.Name)
+				Expect(k8s.PatchResource(ctx, k8sClient, runningApp, func() {
+					runningApp.Spec.DesiredState = korifiv1alpha1.StartedState
+				})).To(Succeed())
+				createAppPod(cfSpace.Name, runningApp.Name, "web", "0", runningContainer)
+
+				zeroInstancesApp := createApp(cfSpace.Name)
+				Expect(k8s.PatchResource(ctx, k8sClient, zeroInstancesApp, func() {
+					zeroInstancesApp.Spec.DesiredState = korifiv1alpha1.StartedState
+				})).To(Succeed())
+
+				// the stopped app instance is still shutting down
+				createAppPod(cfSpace.Name, cfApp.Name, "web", "0", runningContainer)
+			})
+
+			It("returns only the started apps with running instances", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(appList).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(runningApp.Name)}),
+				))
+			})
+		})
+
+		When("the current droplet is not included", func() {
+			It("does not report the current droplet state", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				for _, app := range appList {
+					Expect(app.CurrentDropletState).To(BeEmpty())
+				}
+			})
+		})
+
 		When("there are apps in non-cf namespaces", func() {
 			var nonCFApp *korifiv1alpha1.CFApp
 
@@ -584,6 +749,52 @@ var _ = Describe("AppRepository", func() {
 				})
 			})
 
+			When("the space has an app limit", func() {
+				var maxApps string
+
+				BeforeEach(func() {
+					maxApps = "2"
+				})
+
+				JustBeforeEach(func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					namespace := &corev1.Namespace{}
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Name: cfSpace.Name}, namespace)).To(Succeed())
+					Expect(k8s.PatchResource(ctx, k8sClient, namespace, func() {
+						namespace.Annotations = map[string]string{MaxAppsAnnotation: maxApps}
+					})).To(Succeed())
+
+					// the space now contains cfApp and the app created above
+					_, createErr = appRepo.CreateApp(ctx, authInfo, initializeAppCreateMessage("another-app", cfSpace.Name))
+				})
+
+				It("rejects creating apps beyond the limit", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(createErr).To(MatchError(ContainSubstring("app limit of 2")))
+				})
+
+				When("the limit has not been reached", func() {
+					BeforeEach(func() {
+						maxApps = "3"
+					})
+
+					It("creates the app", func() {
+						Expect(createErr).NotTo(HaveOccurred())
+					})
+				})
+
+				When("the limit is not a number", func() {
+					BeforeEach(func() {
+						maxApps = "lots"
+					})
+
+					It("ignores the limit and creates the app", func() {
+						Expect(createErr).NotTo(HaveOccurred())
+					})
+				})
+			})
+
 			When("the lifecycle is docker", func() {
 				BeforeEach(func() {
 					appCreateMessage.Lifecycle = Lifecycle{
@@ -935,6 +1146,28 @@ var _ = Describe("AppRepository", func() {
 					HaveKeyWithValue(key2, "VAL2"),
 				))
 			})
+
+			It("does not bump the app-rev", func() {
+				updatedApp := &korifiv1alpha1.CFApp{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), updatedApp)).To(Succeed())
+				Expect(updatedApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppRevisionKey, CFAppRevisionValue))
+			})
+
+			When("the app opts in to restarting on config changes", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Annotations[RestartOnConfigChangeAnnotation] = "true"
+					})).To(Succeed())
+				})
+
+				It("bumps the app-rev", func() {
+					Expect(patchErr).NotTo(HaveOccurred())
+
+					updatedApp := &korifiv1alpha1.CFApp{}
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), updatedApp)).To(Succeed())
+					Expect(updatedApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppRevisionKey, "2"))
+				})
+			})
 		})
 
 		When("the user is not authorized", func() {
@@ -944,6 +1177,70 @@ var _ = Describe("AppRepository", func() {
 		})
 	})
 
+	Describe("SetAppRestartOnConfigChange", func() {
+		var (
+			enabled bool
+			setErr  error
+		)
+
+		BeforeEach(func() {
+			enabled = true
+		})
+
+		JustBeforeEach(func() {
+			setErr = appRepo.SetAppRestartOnConfigChange(ctx, authInfo, cfApp.Name, enabled)
+		})
+
+		When("the user is authorized", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("annotates the app", func() {
+				Expect(setErr).NotTo(HaveOccurred())
+
+				updatedApp := &korifiv1alpha1.CFApp{}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), updatedApp)).To(Succeed())
+				Expect(updatedApp.Annotations).To(HaveKeyWithValue(RestartOnConfigChangeAnnotation, "true"))
+			})
+
+			It("is reported by GetAppRestartOnConfigChange", func() {
+				restartOnConfigChange, err := appRepo.GetAppRestartOnConfigChange(ctx, authInfo, cfApp.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(restartOnConfigChange).To(BeTrue())
+			})
+
+			When("disabling it", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Annotations[RestartOnConfigChangeAnnotation] = "true"
+					})).To(Succeed())
+					enabled = false
+				})
+
+				It("removes the annotation", func() {
+					Expect(setErr).NotTo(HaveOccurred())
+
+					updatedApp := &korifiv1alpha1.CFApp{}
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), updatedApp)).To(Succeed())
+					Expect(updatedApp.Annotations).NotTo(HaveKey(RestartOnConfigChangeAnnotation))
+				})
+
+				It("is reported by GetAppRestartOnConfigChange", func() {
+					restartOnConfigChange, err := appRepo.GetAppRestartOnConfigChange(ctx, authInfo, cfApp.Name)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(restartOnConfigChange).To(BeFalse())
+				})
+			})
+		})
+
+		When("the user is not authorized in the space", func() {
+			It("returns a not found error", func() {
+				Expect(setErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
 	Describe("CreateOrPatchAppEnvVars", func() {
 		const (
 			key1 = "KEY1"
@@ -1137,6 +1434,32 @@ var _ = Describe("AppRepository", func() {
 				Expect(updatedApp.Spec.CurrentDropletRef.Name).To(Equal(dropletGUID))
 			})
 
+			When("the app has environment variables", func() {
+				var envSecret *corev1.Secret
+
+				BeforeEach(func() {
+					envSecret = &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      cfApp.Spec.EnvSecretName,
+							Namespace: cfSpace.Name,
+						},
+						StringData: map[string]string{"FOO": "bar"},
+					}
+					Expect(k8sClient.Create(ctx, envSecret)).To(Succeed())
+				})
+
+				It("keeps the env secret referenced and unchanged", func() {
+					updatedApp := new(korifiv1alpha1.CFApp)
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), updatedApp)).To(Succeed())
+					Expect(updatedApp.Spec.EnvSecretName).To(Equal(envSecret.Name))
+
+					updatedSecret := new(corev1.Secret)
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(envSecret), updatedSecret)).To(Succeed())
+					Expect(updatedSecret.UID).To(Equal(envSecret.UID))
+					Expect(updatedSecret.Data).To(Equal(map[string][]byte{"FOO": []byte("bar")}))
+				})
+			})
+
 			When("the app never becomes ready", func() {
 				BeforeEach(func() {
 					conditionAwaiter.AwaitConditionReturns(&korifiv1alpha1.CFApp{}, errors.New("time-out-err"))
@@ -1224,6 +1547,19 @@ var _ = Describe("AppRepository", func() {
 					Expect(k8sClient.Get(ctx, cfAppLookupKey, updatedCFApp)).To(Succeed())
 					Expect(string(updatedCFApp.Spec.DesiredState)).To(Equal(appStartedValue))
 				})
+
+				It("records who started the app and when", func() {
+					cfAppLookupKey := types.NamespacedName{Name: appGUID, Namespace: cfSpace.Name}
+					updatedCFApp := new(korifiv1alpha1.CFApp)
+					Expect(k8sClient.Get(ctx, cfAppLookupKey, updatedCFApp)).To(Succeed())
+					Expect(updatedCFApp.Annotations).To(HaveKeyWithValue(LastStartedByAnnotation, userName))
+					Expect(updatedCFApp.Annotations).To(HaveKey(LastStartedAtAnnotation))
+					Expect(updatedCFApp.Annotations).NotTo(HaveKey(LastStoppedByAnnotation))
+
+					Expect(returnedAppRecord.LastStartedBy).To(Equal(userName))
+					Expect(returnedAppRecord.LastStartedAt).To(PointTo(BeTemporally("~", time.Now(), 5*time.Second)))
+					Expect(returnedAppRecord.LastStoppedAt).To(BeNil())
+				})
 			})
 
 			When("stopping an app", func() {
@@ -1248,6 +1584,17 @@ var _ = Describe("AppRepository", func() {
 					Expect(k8sClient.Get(ctx, cfAppLookupKey, updatedCFApp)).To(Succeed())
 					Expect(string(updatedCFApp.Spec.DesiredState)).To(Equal(appStoppedValue))
 				})
+
+				It("records who stopped the app and when", func() {
+					cfAppLookupKey := types.NamespacedName{Name: appGUID, Namespace: cfSpace.Name}
+					updatedCFApp := new(korifiv1alpha1.CFApp)
+					Expect(k8sClient.Get(ctx, cfAppLookupKey, updatedCFApp)).To(Succeed())
+					Expect(updatedCFApp.Annotations).To(HaveKeyWithValue(LastStoppedByAnnotation, userName))
+					Expect(updatedCFApp.Annotations).To(HaveKey(LastStoppedAtAnnotation))
+
+					Expect(returnedAppRecord.LastStoppedBy).To(Equal(userName))
+					Expect(returnedAppRecord.LastStoppedAt).To(PointTo(BeTemporally("~", time.Now(), 5*time.Second)))
+				})
 			})
 
 			When("the app doesn't exist", func() {
@@ -1270,6 +1617,88 @@ var _ = Describe("AppRepository", func() {
 		})
 	})
 
+	Describe("SetAppQuota and GetAppQuota", func() {
+		var (
+			message     SetAppQuotaMessage
+			quotaRecord AppQuotaRecord
+			setErr      error
+		)
+
+		BeforeEach(func() {
+			message = SetAppQuotaMessage{
+				AppGUID:   cfApp.Name,
+				SpaceGUID: cfSpace.Name,
+				MemoryMB:  tools.PtrTo[int64](2048),
+				Instances: tools.PtrTo(4),
+			}
+		})
+
+		JustBeforeEach(func() {
+			quotaRecord, setErr = appRepo.SetAppQuota(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error to unauthorized users", func() {
+			Expect(setErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("sets the quota on the app", func() {
+				Expect(setErr).NotTo(HaveOccurred())
+				Expect(quotaRecord).To(Equal(AppQuotaRecord{
+					AppGUID:   cfApp.Name,
+					MemoryMB:  tools.PtrTo[int64](2048),
+					Instances: tools.PtrTo(4),
+				}))
+
+				updatedApp := new(korifiv1alpha1.CFApp)
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), updatedApp)).To(Succeed())
+				Expect(updatedApp.Spec.Quota).To(Equal(&korifiv1alpha1.CFAppQuota{
+					MemoryMB:  tools.PtrTo[int64](2048),
+					Instances: tools.PtrTo(4),
+				}))
+			})
+
+			It("can be read back", func() {
+				record, err := appRepo.GetAppQuota(ctx, authInfo, cfApp.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(record).To(Equal(quotaRecord))
+			})
+
+			When("the quota is cleared", func() {
+				JustBeforeEach(func() {
+					Expect(setErr).NotTo(HaveOccurred())
+					quotaRecord, setErr = appRepo.SetAppQuota(ctx, authInfo, SetAppQuotaMessage{
+						AppGUID:   cfApp.Name,
+						SpaceGUID: cfSpace.Name,
+					})
+				})
+
+				It("removes the quota from the app", func() {
+					Expect(setErr).NotTo(HaveOccurred())
+					Expect(quotaRecord).To(Equal(AppQuotaRecord{AppGUID: cfApp.Name}))
+
+					updatedApp := new(korifiv1alpha1.CFApp)
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), updatedApp)).To(Succeed())
+					Expect(updatedApp.Spec.Quota).To(BeNil())
+				})
+			})
+
+			When("the memory quota is not positive", func() {
+				BeforeEach(func() {
+					message.MemoryMB = tools.PtrTo[int64](0)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(setErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
 	Describe("DeleteApp", func() {
 		var (
 			appGUID      string
@@ -1586,6 +2015,160 @@ var _ = Describe("AppRepository", func() {
 			})
 		})
 	})
+
+	Describe("ListAppCrashEvents", func() {
+		var (
+			crashTime   time.Time
+			crashEvents []AppCrashEventRecord
+			listErr     error
+		)
+
+		BeforeEach(func() {
+			crashTime = time.Now().Add(-time.Hour).Truncate(time.Second)
+
+			createAppPod(cfSpace.Name, cfApp.Name, "web", "0", corev1.ContainerStatus{
+				Name: "application",
+				State: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{},
+				},
+				LastTerminationState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Reason:     "OOMKilled",
+						ExitCode:   137,
+						FinishedAt: metav1.NewTime(crashTime),
+					},
+				},
+			})
+			createAppPod(cfSpace.Name, cfApp.Name, "web", "1", corev1.ContainerStatus{
+				Name: "application",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Reason:     "Error",
+						ExitCode:   1,
+						FinishedAt: metav1.NewTime(crashTime.Add(time.Minute)),
+					},
+				},
+			})
+			createAppPod(cfSpace.Name, cfApp.Name, "web", "2", corev1.ContainerStatus{
+				Name: "application",
+				State: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{},
+				},
+			})
+			createAppPod(cfSpace.Name, uuid.NewString(), "web", "0", corev1.ContainerStatus{
+				Name: "application",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Reason:     "Error",
+						ExitCode:   1,
+						FinishedAt: metav1.NewTime(crashTime),
+					},
+				},
+			})
+		})
+
+		JustBeforeEach(func() {
+			crashEvents, listErr = appRepo.ListAppCrashEvents(ctx, authInfo, cfApp.Name)
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the crashes of the app instances, most recent first", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(crashEvents).To(HaveLen(2))
+
+				Expect(crashEvents[0].AppGUID).To(Equal(cfApp.Name))
+				Expect(crashEvents[0].ProcessType).To(Equal("web"))
+				Expect(crashEvents[0].Index).To(Equal(1))
+				Expect(crashEvents[0].Reason).To(Equal("Error"))
+				Expect(crashEvents[0].ExitCode).To(BeEquivalentTo(1))
+				Expect(crashEvents[0].CrashedAt).To(BeTemporally("==", crashTime.Add(time.Minute)))
+
+				Expect(crashEvents[1].AppGUID).To(Equal(cfApp.Name))
+				Expect(crashEvents[1].ProcessType).To(Equal("web"))
+				Expect(crashEvents[1].Index).To(Equal(0))
+				Expect(crashEvents[1].Reason).To(Equal("OOMKilled"))
+				Expect(crashEvents[1].ExitCode).To(BeEquivalentTo(137))
+				Expect(crashEvents[1].CrashedAt).To(BeTemporally("==", crashTime))
+			})
+
+			When("the crash events have been acknowledged", func() {
+				BeforeEach(func() {
+					Expect(appRepo.AcknowledgeAppCrashEvents(ctx, authInfo, cfApp.Name)).To(Succeed())
+				})
+
+				It("returns no crash events", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(crashEvents).To(BeEmpty())
+				})
+
+				When("an instance crashes after the acknowledgement", func() {
+					BeforeEach(func() {
+						createAppPod(cfSpace.Name, cfApp.Name, "worker", "0", corev1.ContainerStatus{
+							Name: "application",
+							State: corev1.ContainerState{
+								Terminated: &corev1.ContainerStateTerminated{
+									Reason:     "Error",
+									ExitCode:   2,
+									FinishedAt: metav1.NewTime(time.Now().Add(time.Hour)),
+								},
+							},
+						})
+					})
+
+					It("returns the new crash only", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(crashEvents).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"ProcessType": Equal("worker"),
+							"Index":       Equal(0),
+							"ExitCode":    BeEquivalentTo(2),
+						})))
+					})
+				})
+			})
+		})
+
+		When("the user is not authorized in the space", func() {
+			It("returns a forbidden error", func() {
+				Expect(listErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
+	Describe("AcknowledgeAppCrashEvents", func() {
+		var ackErr error
+
+		JustBeforeEach(func() {
+			ackErr = appRepo.AcknowledgeAppCrashEvents(ctx, authInfo, cfApp.Name)
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("records the acknowledgement time on the app", func() {
+				Expect(ackErr).NotTo(HaveOccurred())
+
+				updatedCFApp := new(korifiv1alpha1.CFApp)
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), updatedCFApp)).To(Succeed())
+				Expect(updatedCFApp.Annotations).To(HaveKey(CrashEventsAcknowledgedAtAnnotation))
+
+				acknowledgedAt, err := time.Parse(time.RFC3339, updatedCFApp.Annotations[CrashEventsAcknowledgedAtAnnotation])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(acknowledgedAt).To(BeTemporally("~", time.Now(), time.Minute))
+			})
+		})
+
+		When("the user is not authorized in the space", func() {
+			It("returns a forbidden error", func() {
+				Expect(ackErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
 })
 
 func generateVcapServiceSecretDataByte() (map[string][]byte, error) {
@@ -1628,3 +2211,62 @@ func asMapOfStrings(data map[string][]byte) map[string]string {
 
 	return result
 }
+
+func createMappedRoute(spaceGUID, uri, appGUID string) {
+	cfRoute := &korifiv1alpha1.CFRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: spaceGUID,
+		},
+		Spec: korifiv1alpha1.CFRouteSpec{
+			Host:     uuid.NewString(),
+			Protocol: "http",
+			DomainRef: corev1.ObjectReference{
+				Name:      uuid.NewString(),
+				Namespace: rootNamespace,
+			},
+			Destinations: []korifiv1alpha1.Destination{{
+				GUID:        uuid.NewString(),
+				AppRef:      corev1.LocalObjectReference{Name: appGUID},
+				ProcessType: "web",
+			}},
+		},
+	}
+	Expect(k8sClient.Create(ctx, cfRoute)).To(Succeed())
+
+	Expect(k8s.Patch(ctx, k8sClient, cfRoute, func() {
+		cfRoute.Status.CurrentStatus = korifiv1alpha1.ValidStatus
+		cfRoute.Status.Description = "ok"
+		cfRoute.Status.URI = uri
+	})).To(Succeed())
+}
+
+func createAppPod(spaceGUID, appGUID, processType, index string, appContainerStatus corev1.ContainerStatus) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: spaceGUID,
+			Labels: map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey:     appGUID,
+				korifiv1alpha1.CFProcessTypeLabelKey: processType,
+				"korifi.cloudfoundry.org/version":    CFAppRevisionValue,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  "application",
+				Image: "some-image",
+				Env: []corev1.EnvVar{{
+					Name:  "CF_INSTANCE_INDEX",
+					Value: index,
+				}},
+			}},
+		},
+	}
+	Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{appContainerStatus}
+	Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+
+	return pod
+}
@@ -58,18 +58,30 @@ type LogRecord struct {
 	Tags      map[string]string
 }
 
+type BuildLogsMessage struct {
+	BuildGUID string
+	// Follow keeps reading the logs until staging completes
+	Follow bool
+	// Limit is the number of most recent lines to read from each staging
+	// container. Zero reads all lines.
+	Limit int64
+}
+
 type BuildRepo struct {
-	namespaceRetriever NamespaceRetriever
-	userClientFactory  authorization.UserK8sClientFactory
+	namespaceRetriever  NamespaceRetriever
+	userClientFactory   authorization.UserK8sClientFactory
+	privilegedK8sClient k8sclient.Interface
 }
 
 func NewBuildRepo(
 	namespaceRetriever NamespaceRetriever,
 	userClientFactory authorization.UserK8sClientFactory,
+	privilegedK8sClient k8sclient.Interface,
 ) *BuildRepo {
 	return &BuildRepo{
-		namespaceRetriever: namespaceRetriever,
-		userClientFactory:  userClientFactory,
+		namespaceRetriever:  namespaceRetriever,
+		userClientFactory:   userClientFactory,
+		privilegedK8sClient: privilegedK8sClient,
 	}
 }
 
@@ -128,20 +140,41 @@ func orderBuilds(builds []korifiv1alpha1.CFBuild) []korifiv1alpha1.CFBuild {
 	return builds
 }
 
-func (b *BuildRepo) GetBuildLogs(ctx context.Context, authInfo authorization.Info, spaceGUID string, buildGUID string) ([]LogRecord, error) {
-	userClient, err := b.userClientFactory.BuildK8sClient(authInfo)
-	if err != nil { // Untested
-		return []LogRecord{}, err
+//+kubebuilder:rbac:groups="",resources=pods,verbs=list
+//+kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+
+// GetBuildLogs reads the logs of the staging pods of a build. Users who can
+// get the build can read its logs, even though they may not be allowed to
+// read the logs of the staging pods themselves. A build that has not started
+// staging yet has no logs.
+func (b *BuildRepo) GetBuildLogs(ctx context.Context, authInfo authorization.Info, message BuildLogsMessage) ([]LogRecord, error) {
+	ns, err := b.namespaceRetriever.NamespaceFor(ctx, message.BuildGUID, BuildResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	userClient, err := b.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("get-build-logs failed to build user client: %w", err)
+	}
+
+	build := korifiv1alpha1.CFBuild{}
+	if err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: message.BuildGUID}, &build); err != nil {
+		return nil, fmt.Errorf("failed to get build: %w", apierrors.FromK8sError(err, BuildResourceType))
 	}
+
 	logWriter := new(strings.Builder)
-	err = NewBuildLogsClient(userClient).GetImageLogs(ctx, logWriter, buildGUID, spaceGUID)
+	err = NewBuildLogsClient(b.privilegedK8sClient).GetImageLogs(ctx, logWriter, message.BuildGUID, ns, message.Follow, message.Limit)
 	if err != nil {
 		return nil, err
 	}
-	buildLogs := strings.Split(strings.Trim(logWriter.String(), "\n"), "\n")
-	toReturn := make([]LogRecord, 0, len(buildLogs))
 
-	for _, log := range buildLogs {
+	toReturn := []LogRecord{}
+	if logWriter.Len() == 0 {
+		return toReturn, nil
+	}
+
+	for _, log := range strings.Split(strings.Trim(logWriter.String(), "\n"), "\n") {
 		logLine, logTime, _ := parseRFC3339NanoTime(log)
 
 		toReturn = append(toReturn, LogRecord{
@@ -281,21 +314,29 @@ func NewBuildLogsClient(k8sClient k8sclient.Interface) *BuildLogsClient {
 
 const BuildWorkloadLabelKey = "korifi.cloudfoundry.org/build-workload-name"
 
-func (c *BuildLogsClient) GetImageLogs(ctx context.Context, writer io.Writer, buildGUID, namespace string) error {
+func (c *BuildLogsClient) GetImageLogs(ctx context.Context, writer io.Writer, buildGUID, namespace string, follow bool, limit int64) error {
+	logOptions := corev1.PodLogOptions{
+		Follow:     follow,
+		Timestamps: true,
+	}
+	if limit > 0 {
+		logOptions.TailLines = &limit
+	}
+
 	return c.getPodLogs(ctx, writer, namespace, metav1.ListOptions{
 		Watch:         false,
 		LabelSelector: fmt.Sprintf("%s=%s", BuildWorkloadLabelKey, buildGUID),
-	}, false)
+	}, logOptions)
 }
 
-func (c *BuildLogsClient) getPodLogs(ctx context.Context, writer io.Writer, namespace string, listOptions metav1.ListOptions, follow bool) error {
+func (c *BuildLogsClient) getPodLogs(ctx context.Context, writer io.Writer, namespace string, listOptions metav1.ListOptions, logOptions corev1.PodLogOptions) error {
 	readyContainers, err := c.getContainers(ctx, namespace, listOptions)
 	if err != nil {
 		return err
 	}
 
 	for _, container := range readyContainers {
-		err := c.streamLogsForContainer(ctx, writer, container, follow)
+		err := c.streamLogsForContainer(ctx, writer, container, logOptions)
 		if err != nil {
 			return err
 		}
@@ -336,17 +377,14 @@ func (c *BuildLogsClient) getContainers(ctx context.Context, namespace string, l
 	return readyContainers, nil
 }
 
-func (c *BuildLogsClient) streamLogsForContainer(ctx context.Context, writer io.Writer, readyContainer readyContainer, follow bool) error {
+func (c *BuildLogsClient) streamLogsForContainer(ctx context.Context, writer io.Writer, readyContainer readyContainer, logOptions corev1.PodLogOptions) error {
 	if _, alreadyProcessed := c.processed[readyContainer]; alreadyProcessed {
 		return nil
 	}
 	c.processed[readyContainer] = nil
 
-	logReadCloser, err := c.k8sClient.CoreV1().Pods(readyContainer.namespace).GetLogs(readyContainer.podName, &corev1.PodLogOptions{
-		Container:  readyContainer.containerName,
-		Follow:     follow,
-		Timestamps: true,
-	}).Stream(ctx)
+	logOptions.Container = readyContainer.containerName
+	logReadCloser, err := c.k8sClient.CoreV1().Pods(readyContainer.namespace).GetLogs(readyContainer.podName, &logOptions).Stream(ctx)
 	if err != nil {
 		return err
 	}
@@ -360,10 +398,15 @@ func (c *BuildLogsClient) streamLogsForContainer(ctx context.Context, writer io.
 		default:
 			line, err := r.ReadBytes('\n')
 			if err != nil {
-				if err == io.EOF {
-					return nil
+				if err != io.EOF {
+					return err
 				}
 
+				// the last line may not be terminated by a newline
+				if len(line) == 0 {
+					return nil
+				}
+				_, err = writer.Write(append(line, '\n'))
 				return err
 			}
 
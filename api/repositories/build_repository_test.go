@@ -12,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
@@ -20,12 +21,17 @@ import (
 )
 
 var _ = Describe("BuildRepository", func() {
-	var buildRepo *repositories.BuildRepo
+	var (
+		buildRepo           *repositories.BuildRepo
+		privilegedK8sClient *k8sfake.Clientset
+	)
 
 	BeforeEach(func() {
+		privilegedK8sClient = k8sfake.NewSimpleClientset()
 		buildRepo = repositories.NewBuildRepo(
 			namespaceRetriever,
 			userClientFactory,
+			privilegedK8sClient,
 		)
 	})
 
@@ -354,6 +360,106 @@ var _ = Describe("BuildRepository", func() {
 		})
 	})
 
+	Describe("GetBuildLogs", func() {
+		var (
+			space      *korifiv1alpha1.CFSpace
+			buildGUID  string
+			logRecords []repositories.LogRecord
+			logsErr    error
+		)
+
+		BeforeEach(func() {
+			org := createOrgWithCleanup(ctx, prefixedGUID("org"))
+			space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+			buildGUID = prefixedGUID("build")
+			createBuild(ctx, k8sClient, space.Name, buildGUID, "package-guid", "app-guid")
+		})
+
+		JustBeforeEach(func() {
+			logRecords, logsErr = buildRepo.GetBuildLogs(ctx, authInfo, repositories.BuildLogsMessage{
+				BuildGUID: buildGUID,
+				Limit:     10,
+			})
+		})
+
+		When("the user has space developer role", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns no logs while staging has not started", func() {
+				Expect(logsErr).NotTo(HaveOccurred())
+				Expect(logRecords).To(BeEmpty())
+			})
+
+			When("the staging pod is running", func() {
+				BeforeEach(func() {
+					_, err := privilegedK8sClient.CoreV1().Pods(space.Name).Create(ctx, &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      buildGUID + "-build-pod",
+							Namespace: space.Name,
+							Labels: map[string]string{
+								repositories.BuildWorkloadLabelKey: buildGUID,
+							},
+						},
+						Status: corev1.PodStatus{
+							ContainerStatuses: []corev1.ContainerStatus{{
+								Name: "build",
+								State: corev1.ContainerState{
+									Running: &corev1.ContainerStateRunning{},
+								},
+							}},
+						},
+					}, metav1.CreateOptions{})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns the staging logs", func() {
+					Expect(logsErr).NotTo(HaveOccurred())
+					// the fake clientset always returns "fake logs" as the pod logs
+					Expect(logRecords).To(ConsistOf(repositories.LogRecord{
+						Message: "fake logs",
+						Tags:    map[string]string{"source_type": "STG"},
+					}))
+				})
+			})
+
+			When("the staging pod containers are still waiting", func() {
+				BeforeEach(func() {
+					_, err := privilegedK8sClient.CoreV1().Pods(space.Name).Create(ctx, &corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      buildGUID + "-build-pod",
+							Namespace: space.Name,
+							Labels: map[string]string{
+								repositories.BuildWorkloadLabelKey: buildGUID,
+							},
+						},
+						Status: corev1.PodStatus{
+							ContainerStatuses: []corev1.ContainerStatus{{
+								Name: "build",
+								State: corev1.ContainerState{
+									Waiting: &corev1.ContainerStateWaiting{},
+								},
+							}},
+						},
+					}, metav1.CreateOptions{})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns no logs", func() {
+					Expect(logsErr).NotTo(HaveOccurred())
+					Expect(logRecords).To(BeEmpty())
+				})
+			})
+		})
+
+		When("the user is not authorized to get the build", func() {
+			It("returns a forbidden error", func() {
+				Expect(logsErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
 	Describe("CreateBuild", func() {
 		const (
 			appGUID     = "the-app-guid"
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"time"
 
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiwatch "k8s.io/apimachinery/pkg/watch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -21,12 +23,19 @@ type ObjectList[L any] interface {
 }
 
 type Awaiter[T RuntimeObjectWithStatusConditions, L any, PL ObjectList[L]] struct {
-	timeout time.Duration
+	timeout      time.Duration
+	resyncPeriod time.Duration
 }
 
-func NewConditionAwaiter[T RuntimeObjectWithStatusConditions, L any, PL ObjectList[L]](timeout time.Duration) *Awaiter[T, L, PL] {
+// NewConditionAwaiter creates an awaiter that waits up to timeout for a
+// condition to become true. When resyncPeriod is positive the object is also
+// periodically re-read, in case the watch has gone stale and misses events.
+// The interval between re-reads starts at resyncPeriod and doubles after each
+// one, up to timeout, so that slow conditions do not hammer the API server.
+func NewConditionAwaiter[T RuntimeObjectWithStatusConditions, L any, PL ObjectList[L]](timeout, resyncPeriod time.Duration) *Awaiter[T, L, PL] {
 	return &Awaiter[T, L, PL]{
-		timeout: timeout,
+		timeout:      timeout,
+		resyncPeriod: resyncPeriod,
 	}
 }
 
@@ -47,18 +56,106 @@ func (a *Awaiter[T, L, PL]) AwaitCondition(ctx context.Context, k8sClient client
 	}
 	defer watch.Stop()
 
-	for e := range watch.ResultChan() {
-		obj, ok := e.Object.(T)
-		if !ok {
-			continue
+	var resync <-chan time.Time
+	resyncInterval := a.resyncPeriod
+	resyncTimer := time.NewTimer(resyncInterval)
+	defer resyncTimer.Stop()
+	if resyncInterval > 0 {
+		resync = resyncTimer.C
+	}
+
+	for {
+		select {
+		case <-ctxWithTimeout.Done():
+			return empty, a.timeoutError(object, conditionType)
+		case e, ok := <-watch.ResultChan():
+			if !ok {
+				return empty, a.timeoutError(object, conditionType)
+			}
+
+			obj, ok := e.Object.(T)
+			if !ok {
+				continue
+			}
+
+			if meta.IsStatusConditionTrue(obj.StatusConditions(), conditionType) {
+				return obj, nil
+			}
+		case <-resync:
+			resyncInterval = min(2*resyncInterval, a.timeout)
+			resyncTimer.Reset(resyncInterval)
+
+			obj, ok := object.DeepCopyObject().(T)
+			if !ok {
+				continue
+			}
+
+			if err := k8sClient.Get(ctxWithTimeout, client.ObjectKeyFromObject(object), obj); err != nil {
+				continue
+			}
+
+			if meta.IsStatusConditionTrue(obj.StatusConditions(), conditionType) {
+				return obj, nil
+			}
 		}
+	}
+}
 
-		if meta.IsStatusConditionTrue(obj.StatusConditions(), conditionType) {
-			return obj, nil
+// AwaitDeletion waits up to the awaiter timeout for the object to be gone,
+// i.e. for all of its finalizers to have run.
+func (a *Awaiter[T, L, PL]) AwaitDeletion(ctx context.Context, k8sClient client.WithWatch, object client.Object) error {
+	objList := PL(new(L))
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
+
+	watch, err := k8sClient.Watch(ctxWithTimeout,
+		objList,
+		client.InNamespace(object.GetNamespace()),
+		client.MatchingFields{"metadata.name": object.GetName()},
+	)
+	if err != nil {
+		return err
+	}
+	defer watch.Stop()
+
+	// The object may have gone before the watch was established
+	obj, ok := object.DeepCopyObject().(T)
+	if !ok {
+		return fmt.Errorf("unexpected object type %T", object)
+	}
+	err = k8sClient.Get(ctxWithTimeout, client.ObjectKeyFromObject(object), obj)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctxWithTimeout.Done():
+			return a.deletionTimeoutError(object)
+		case e, ok := <-watch.ResultChan():
+			if !ok {
+				return a.deletionTimeoutError(object)
+			}
+
+			if e.Type == apiwatch.Deleted {
+				return nil
+			}
 		}
 	}
+}
+
+func (a *Awaiter[T, L, PL]) deletionTimeoutError(object client.Object) error {
+	return fmt.Errorf("object %s:%s was not deleted within timeout period %d ms: %w",
+		object.GetNamespace(), object.GetName(), a.timeout.Milliseconds(), context.DeadlineExceeded,
+	)
+}
 
-	return empty, fmt.Errorf("object %s:%s did not get the %s condition within timeout period %d ms",
-		object.GetNamespace(), object.GetName(), conditionType, a.timeout.Milliseconds(),
+func (a *Awaiter[T, L, PL]) timeoutError(object client.Object, conditionType string) error {
+	return fmt.Errorf("object %s:%s did not get the %s condition within timeout period %d ms: %w",
+		object.GetNamespace(), object.GetName(), conditionType, a.timeout.Milliseconds(), context.DeadlineExceeded,
 	)
 }
@@ -2,28 +2,34 @@ package conditions_test
 
 import (
 	"context"
+	"errors"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/repositories/conditions"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("Await", func() {
 	var (
 		awaiter     *conditions.Awaiter[*korifiv1alpha1.CFTask, korifiv1alpha1.CFTaskList, *korifiv1alpha1.CFTaskList]
+		awaitClient client.WithWatch
 		task        *korifiv1alpha1.CFTask
 		awaitedTask *korifiv1alpha1.CFTask
 		awaitErr    error
 	)
 
 	BeforeEach(func() {
-		awaiter = conditions.NewConditionAwaiter[*korifiv1alpha1.CFTask, korifiv1alpha1.CFTaskList](time.Second)
+		awaiter = conditions.NewConditionAwaiter[*korifiv1alpha1.CFTask, korifiv1alpha1.CFTaskList](time.Second, 0)
+		awaitClient = k8sClient
 		awaitedTask = nil
 		awaitErr = nil
 
@@ -38,11 +44,12 @@ var _ = Describe("Await", func() {
 	})
 
 	JustBeforeEach(func() {
-		awaitedTask, awaitErr = awaiter.AwaitCondition(context.Background(), k8sClient, task, korifiv1alpha1.TaskInitializedConditionType)
+		awaitedTask, awaitErr = awaiter.AwaitCondition(context.Background(), awaitClient, task, korifiv1alpha1.TaskInitializedConditionType)
 	})
 
 	It("returns an error as the condition never becomes true", func() {
 		Expect(awaitErr).To(MatchError(ContainSubstring("did not get the Initialized condition")))
+		Expect(errors.Is(awaitErr, context.DeadlineExceeded)).To(BeTrue())
 	})
 
 	When("the condition becomes true", func() {
@@ -78,4 +85,147 @@ var _ = Describe("Await", func() {
 			Expect(meta.IsStatusConditionTrue(awaitedTask.Status.Conditions, korifiv1alpha1.TaskInitializedConditionType)).To(BeTrue())
 		})
 	})
+
+	When("the watch does not deliver any events", func() {
+		BeforeEach(func() {
+			awaitClient = &staleWatchClient{WithWatch: k8sClient}
+
+			taskCopy := task.DeepCopy()
+			meta.SetStatusCondition(&taskCopy.Status.Conditions, metav1.Condition{
+				Type:   korifiv1alpha1.TaskInitializedConditionType,
+				Status: metav1.ConditionTrue,
+				Reason: "initialized",
+			})
+			Expect(k8sClient.Status().Patch(context.Background(), taskCopy, client.MergeFrom(task))).To(Succeed())
+		})
+
+		It("returns an error as the condition change is never observed", func() {
+			Expect(awaitErr).To(MatchError(ContainSubstring("did not get the Initialized condition")))
+		})
+
+		When("a resync period is configured", func() {
+			BeforeEach(func() {
+				awaiter = conditions.NewConditionAwaiter[*korifiv1alpha1.CFTask, korifiv1alpha1.CFTaskList](time.Second, 100*time.Millisecond)
+			})
+
+			It("discovers the condition by re-reading the object", func() {
+				Expect(awaitErr).NotTo(HaveOccurred())
+				Expect(awaitedTask).NotTo(BeNil())
+				Expect(meta.IsStatusConditionTrue(awaitedTask.Status.Conditions, korifiv1alpha1.TaskInitializedConditionType)).To(BeTrue())
+			})
+		})
+	})
+
+	When("a resync period is configured and the condition never becomes true", func() {
+		var countingClient *getCountingClient
+
+		BeforeEach(func() {
+			countingClient = &getCountingClient{WithWatch: &staleWatchClient{WithWatch: k8sClient}}
+			awaitClient = countingClient
+			awaiter = conditions.NewConditionAwaiter[*korifiv1alpha1.CFTask, korifiv1alpha1.CFTaskList](time.Second, 100*time.Millisecond)
+		})
+
+		It("backs off exponentially between re-reads", func() {
+			Expect(awaitErr).To(MatchError(ContainSubstring("did not get the Initialized condition")))
+			// re-reads happen after 100ms, 300ms and 700ms, instead of every 100ms
+			Expect(countingClient.gets.Load()).To(BeNumerically("<=", 4))
+			Expect(countingClient.gets.Load()).To(BeNumerically(">=", 2))
+		})
+	})
+})
+
+var _ = Describe("AwaitDeletion", func() {
+	var (
+		awaiter   *conditions.Awaiter[*korifiv1alpha1.CFTask, korifiv1alpha1.CFTaskList, *korifiv1alpha1.CFTaskList]
+		task      *korifiv1alpha1.CFTask
+		awaitErr  error
+		finalizer string
+	)
+
+	BeforeEach(func() {
+		awaiter = conditions.NewConditionAwaiter[*korifiv1alpha1.CFTask, korifiv1alpha1.CFTaskList](time.Second, 0)
+		finalizer = "test.korifi.cloudfoundry.org/block-deletion"
+
+		task = &korifiv1alpha1.CFTask{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  namespace,
+				Name:       "task-being-deleted",
+				Finalizers: []string{finalizer},
+			},
+		}
+		Expect(k8sClient.Create(context.Background(), task)).To(Succeed())
+		Expect(k8sClient.Delete(context.Background(), task)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		awaitErr = awaiter.AwaitDeletion(context.Background(), k8sClient, task)
+	})
+
+	AfterEach(func() {
+		Expect(client.IgnoreNotFound(k8s.PatchResource(context.Background(), k8sClient, task, func() {
+			task.Finalizers = nil
+		}))).To(Succeed())
+	})
+
+	It("returns a timeout error as the object is never gone", func() {
+		Expect(awaitErr).To(MatchError(ContainSubstring("was not deleted")))
+		Expect(errors.Is(awaitErr, context.DeadlineExceeded)).To(BeTrue())
+	})
+
+	When("the object goes away", func() {
+		var wg sync.WaitGroup
+
+		BeforeEach(func() {
+			wg.Add(1)
+
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				time.Sleep(100 * time.Millisecond)
+				taskCopy := task.DeepCopy()
+				Expect(k8s.PatchResource(context.Background(), k8sClient, taskCopy, func() {
+					taskCopy.Finalizers = nil
+				})).To(Succeed())
+			}()
+		})
+
+		AfterEach(func() {
+			wg.Wait()
+		})
+
+		It("succeeds", func() {
+			Expect(awaitErr).NotTo(HaveOccurred())
+		})
+	})
+
+	When("the object is already gone", func() {
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(context.Background(), k8sClient, task, func() {
+				task.Finalizers = nil
+			})).To(Succeed())
+		})
+
+		It("succeeds", func() {
+			Expect(awaitErr).NotTo(HaveOccurred())
+		})
+	})
 })
+
+type getCountingClient struct {
+	client.WithWatch
+	gets atomic.Int32
+}
+
+func (c *getCountingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	c.gets.Add(1)
+	return c.WithWatch.Get(ctx, key, obj, opts...)
+}
+
+type staleWatchClient struct {
+	client.WithWatch
+}
+
+func (c *staleWatchClient) Watch(ctx context.Context, obj client.ObjectList, opts ...client.ListOption) (watch.Interface, error) {
+	return watch.NewFake(), nil
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"code.cloudfoundry.org/korifi/version"
 	"github.com/go-logr/logr"
 
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -21,8 +23,9 @@ import (
 const DeploymentResourceType = "Deployment"
 
 type DeploymentRepo struct {
-	userClientFactory  authorization.UserK8sClientFactory
-	namespaceRetriever NamespaceRetriever
+	userClientFactory    authorization.UserK8sClientFactory
+	namespaceRetriever   NamespaceRetriever
+	namespacePermissions *authorization.NamespacePermissions
 }
 
 type DeploymentRecord struct {
@@ -30,6 +33,7 @@ type DeploymentRecord struct {
 	CreatedAt   time.Time
 	UpdatedAt   *time.Time
 	DropletGUID string
+	Revision    string
 	Status      DeploymentStatus
 }
 
@@ -57,13 +61,20 @@ type CreateDeploymentMessage struct {
 	DropletGUID string
 }
 
+type ListDeploymentsMessage struct {
+	AppGUIDs     []string
+	StatusValues []DeploymentStatusValue
+}
+
 func NewDeploymentRepo(
 	userClientFactory authorization.UserK8sClientFactory,
 	namespaceRetriever NamespaceRetriever,
+	namespacePermissions *authorization.NamespacePermissions,
 ) *DeploymentRepo {
 	return &DeploymentRepo{
-		userClientFactory:  userClientFactory,
-		namespaceRetriever: namespaceRetriever,
+		userClientFactory:    userClientFactory,
+		namespaceRetriever:   namespaceRetriever,
+		namespacePermissions: namespacePermissions,
 	}
 }
 
@@ -87,6 +98,45 @@ func (r *DeploymentRepo) GetDeployment(ctx context.Context, authInfo authorizati
 	return appToDeploymentRecord(app), nil
 }
 
+// ListDeployments returns the deployments of the apps the user can see,
+// newest first. Deployments are backed by their app, so every app has a
+// single deployment reflecting its current revision.
+func (r *DeploymentRepo) ListDeployments(ctx context.Context, authInfo authorization.Info, message ListDeploymentsMessage) ([]DeploymentRecord, error) {
+	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("list-deployments failed to create user client: %w", err)
+	}
+
+	deploymentRecords := []DeploymentRecord{}
+	for ns := range nsList {
+		appList := &korifiv1alpha1.CFAppList{}
+		err = userClient.List(ctx, appList, client.InNamespace(ns))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list apps in namespace %s: %w", ns, apierrors.FromK8sError(err, DeploymentResourceType))
+		}
+
+		for _, app := range Filter(appList.Items, SetPredicate(message.AppGUIDs, func(a korifiv1alpha1.CFApp) string { return a.Name })) {
+			deploymentRecords = append(deploymentRecords, appToDeploymentRecord(&app))
+		}
+	}
+
+	deploymentRecords = Filter(deploymentRecords, SetPredicate(message.StatusValues, func(d DeploymentRecord) DeploymentStatusValue { return d.Status.Value }))
+
+	sort.SliceStable(deploymentRecords, func(i, j int) bool {
+		return deploymentRecords[i].CreatedAt.After(deploymentRecords[j].CreatedAt)
+	})
+
+	return deploymentRecords, nil
+}
+
 func (r *DeploymentRepo) CreateDeployment(ctx context.Context, authInfo authorization.Info, message CreateDeploymentMessage) (DeploymentRecord, error) {
 	ns, err := r.namespaceRetriever.NamespaceFor(ctx, message.AppGUID, AppResourceType)
 	if err != nil {
@@ -149,6 +199,7 @@ func appToDeploymentRecord(cfApp *korifiv1alpha1.CFApp) DeploymentRecord {
 		CreatedAt:   cfApp.CreationTimestamp.Time,
 		UpdatedAt:   getLastUpdatedTime(cfApp),
 		DropletGUID: cfApp.Spec.CurrentDropletRef.Name,
+		Revision:    cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey],
 		Status: DeploymentStatus{
 			Value:  DeploymentStatusValueActive,
 			Reason: DeploymentStatusReasonDeploying,
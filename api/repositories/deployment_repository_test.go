@@ -45,7 +45,7 @@ var _ = Describe("DeploymentRepository", func() {
 			},
 		})).To(Succeed())
 
-		deploymentRepo = repositories.NewDeploymentRepo(userClientFactory, namespaceRetriever)
+		deploymentRepo = repositories.NewDeploymentRepo(userClientFactory, namespaceRetriever, nsPerms)
 	})
 
 	Describe("GetDeployment", func() {
@@ -115,6 +115,85 @@ var _ = Describe("DeploymentRepository", func() {
 		})
 	})
 
+	Describe("ListDeployments", func() {
+		var (
+			completedApp *korifiv1alpha1.CFApp
+			message      repositories.ListDeploymentsMessage
+			deployments  []repositories.DeploymentRecord
+			listErr      error
+		)
+
+		BeforeEach(func() {
+			// make sure the apps have different creation timestamps
+			time.Sleep(1001 * time.Millisecond)
+			completedApp = createApp(cfSpace.Name)
+			Expect(k8s.Patch(ctx, k8sClient, completedApp, func() {
+				meta.SetStatusCondition(&completedApp.Status.Conditions, metav1.Condition{
+					Type:   shared.StatusConditionReady,
+					Status: metav1.ConditionTrue,
+					Reason: "ready",
+				})
+			})).To(Succeed())
+
+			message = repositories.ListDeploymentsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			deployments, listErr = deploymentRepo.ListDeployments(ctx, authInfo, message)
+		})
+
+		It("returns an empty list as the user is not authorized in any space", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(deployments).To(BeEmpty())
+		})
+
+		When("authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the deployments newest first", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(deployments).To(HaveLen(2))
+
+				Expect(deployments[0].GUID).To(Equal(completedApp.Name))
+				Expect(deployments[0].DropletGUID).To(Equal(completedApp.Spec.CurrentDropletRef.Name))
+				Expect(deployments[0].Revision).To(Equal(CFAppRevisionValue))
+				Expect(deployments[0].Status.Value).To(Equal(repositories.DeploymentStatusValueFinalized))
+				Expect(deployments[0].Status.Reason).To(Equal(repositories.DeploymentStatusReasonDeployed))
+
+				Expect(deployments[1].GUID).To(Equal(cfApp.Name))
+				Expect(deployments[1].Status.Value).To(Equal(repositories.DeploymentStatusValueActive))
+				Expect(deployments[1].Status.Reason).To(Equal(repositories.DeploymentStatusReasonDeploying))
+			})
+
+			When("filtering by app guid", func() {
+				BeforeEach(func() {
+					message.AppGUIDs = []string{cfApp.Name}
+				})
+
+				It("returns the deployment of the app only", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(deployments).To(HaveLen(1))
+					Expect(deployments[0].GUID).To(Equal(cfApp.Name))
+				})
+			})
+
+			When("filtering by status value", func() {
+				BeforeEach(func() {
+					message.StatusValues = []repositories.DeploymentStatusValue{repositories.DeploymentStatusValueActive}
+				})
+
+				It("returns the deployments in that state only", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(deployments).To(HaveLen(1))
+					Expect(deployments[0].GUID).To(Equal(cfApp.Name))
+				})
+			})
+		})
+	})
+
 	Describe("CreateDeployment", func() {
 		var (
 			createDeploymentMessage repositories.CreateDeploymentMessage
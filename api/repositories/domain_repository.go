@@ -12,30 +12,42 @@ import (
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"github.com/google/uuid"
 
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 const (
 	DomainResourceType = "Domain"
+
+	// DefaultDomainConfigName is the config map in the root namespace that
+	// records the platform-wide default domain in its DefaultDomainAnnotation
+	DefaultDomainConfigName = "korifi-default-domain"
+	DefaultDomainAnnotation = "korifi.cloudfoundry.org/default-domain"
 )
 
 type DomainRepo struct {
 	userClientFactory  authorization.UserK8sClientFactory
 	namespaceRetriever NamespaceRetriever
 	rootNamespace      string
+	// defaultDomainName is the name of the default domain the platform was
+	// configured with. It only applies until a default domain is set.
+	defaultDomainName string
 }
 
 func NewDomainRepo(
 	userClientFactory authorization.UserK8sClientFactory,
 	namespaceRetriever NamespaceRetriever,
 	rootNamespace string,
+	defaultDomainName string,
 ) *DomainRepo {
 	return &DomainRepo{
 		userClientFactory:  userClientFactory,
 		namespaceRetriever: namespaceRetriever,
 		rootNamespace:      rootNamespace,
+		defaultDomainName:  defaultDomainName,
 	}
 }
 
@@ -48,6 +60,23 @@ type DomainRecord struct {
 	CreatedAt   time.Time
 	UpdatedAt   *time.Time
 	DeletedAt   *time.Time
+	// Ready reports whether the ingress backing the domain is configured,
+	// as observed by the domain controller
+	Ready bool
+	// Internal domains are only reachable from other apps, without going
+	// through the ingress
+	Internal bool
+}
+
+// Scheme returns the URL scheme of the routes on the domain. The ingress
+// terminates TLS for external domains, while internal routes are reached
+// directly over plain http.
+func (d DomainRecord) Scheme() string {
+	if d.Internal {
+		return "http"
+	}
+
+	return "https"
 }
 
 type CreateDomainMessage struct {
@@ -199,6 +228,92 @@ func (r *DomainRepo) DeleteDomain(ctx context.Context, authInfo authorization.In
 	return nil
 }
 
+// GetDefaultDomain returns the domain set as the platform-wide default. Until
+// one is set, or when it has been deleted, the domain the platform was
+// configured with is the default.
+func (r *DomainRepo) GetDefaultDomain(ctx context.Context, authInfo authorization.Info) (DomainRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return DomainRecord{}, fmt.Errorf("get-default-domain failed to create user client: %w", err)
+	}
+
+	defaultDomainConfig := &corev1.ConfigMap{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: DefaultDomainConfigName}, defaultDomainConfig)
+	if client.IgnoreNotFound(err) != nil {
+		return DomainRecord{}, fmt.Errorf("failed to get the default domain config: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	if domainGUID := defaultDomainConfig.Annotations[DefaultDomainAnnotation]; domainGUID != "" {
+		cfDomain := &korifiv1alpha1.CFDomain{}
+		err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: domainGUID}, cfDomain)
+		if err == nil {
+			return cfDomainToDomainRecord(cfDomain), nil
+		}
+		if !k8serrors.IsNotFound(err) {
+			return DomainRecord{}, fmt.Errorf("failed to get default domain %q: %w", domainGUID, apierrors.FromK8sError(err, DomainResourceType))
+		}
+	}
+
+	if r.defaultDomainName != "" {
+		cfdomainList := &korifiv1alpha1.CFDomainList{}
+		err = userClient.List(ctx, cfdomainList, client.InNamespace(r.rootNamespace))
+		if err != nil {
+			return DomainRecord{}, fmt.Errorf("failed to list domains in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, DomainResourceType))
+		}
+
+		for i := range cfdomainList.Items {
+			if cfdomainList.Items[i].Spec.Name == r.defaultDomainName {
+				return cfDomainToDomainRecord(&cfdomainList.Items[i]), nil
+			}
+		}
+	}
+
+	return DomainRecord{}, apierrors.NewNotFoundError(fmt.Errorf("no default domain has been set"), DomainResourceType)
+}
+
+// SetDefaultDomain sets the shared domain with the given guid as the
+// platform-wide default. The default is recorded in a single config map, so
+// that setting it is atomic and can safely be retried.
+func (r *DomainRepo) SetDefaultDomain(ctx context.Context, authInfo authorization.Info, domainGUID string) (DomainRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return DomainRecord{}, fmt.Errorf("set-default-domain failed to create user client: %w", err)
+	}
+
+	domain := &korifiv1alpha1.CFDomain{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      domainGUID,
+			Namespace: r.rootNamespace,
+		},
+	}
+	err = userClient.Get(ctx, client.ObjectKeyFromObject(domain), domain)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return DomainRecord{}, apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("Domain %q does not exist or is not a shared domain.", domainGUID))
+		}
+		return DomainRecord{}, fmt.Errorf("set-default-domain failed: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	defaultDomainConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DefaultDomainConfigName,
+			Namespace: r.rootNamespace,
+		},
+	}
+	_, err = controllerutil.CreateOrPatch(ctx, userClient, defaultDomainConfig, func() error {
+		if defaultDomainConfig.Annotations == nil {
+			defaultDomainConfig.Annotations = map[string]string{}
+		}
+		defaultDomainConfig.Annotations[DefaultDomainAnnotation] = domainGUID
+		return nil
+	})
+	if err != nil {
+		return DomainRecord{}, fmt.Errorf("failed to set default domain %q: %w", domainGUID, apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	return cfDomainToDomainRecord(domain), nil
+}
+
 func (r *DomainRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, domainGUID string) (*time.Time, error) {
 	domain, err := r.GetDomain(ctx, authInfo, domainGUID)
 	return domain.DeletedAt, err
@@ -223,5 +338,15 @@ func cfDomainToDomainRecord(cfDomain *korifiv1alpha1.CFDomain) DomainRecord {
 		DeletedAt:   golangTime(cfDomain.DeletionTimestamp),
 		Labels:      cfDomain.Labels,
 		Annotations: cfDomain.Annotations,
+		Ready:       isDomainReady(cfDomain),
+		Internal:    cfDomain.Spec.Internal,
+	}
+}
+
+func isDomainReady(cfDomain *korifiv1alpha1.CFDomain) bool {
+	if cfDomain.Status.ObservedGeneration != cfDomain.Generation {
+		return false
 	}
+
+	return getConditionValue(&cfDomain.Status.Conditions, korifiv1alpha1.DomainRoutingReadyConditionType) == metav1.ConditionTrue
 }
@@ -17,6 +17,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -44,13 +45,25 @@ var _ = Describe("DomainRepository", func() {
 		}
 		Expect(k8sClient.Create(ctx, cfDomain)).To(Succeed())
 
-		domainRepo = NewDomainRepo(userClientFactory, namespaceRetriever, rootNamespace)
+		domainRepo = NewDomainRepo(userClientFactory, namespaceRetriever, rootNamespace, "")
 	})
 
 	AfterEach(func() {
 		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, cfDomain))).To(Succeed())
 	})
 
+	setDefaultDomain := func(domainGUID string) {
+		GinkgoHelper()
+
+		Expect(k8sClient.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        DefaultDomainConfigName,
+				Namespace:   rootNamespace,
+				Annotations: map[string]string{DefaultDomainAnnotation: domainGUID},
+			},
+		})).To(Succeed())
+	}
+
 	Describe("GetDomain", func() {
 		var (
 			searchGUID string
@@ -73,6 +86,26 @@ var _ = Describe("DomainRepository", func() {
 			Expect(domain.Name).To(Equal("my-domain.com"))
 		})
 
+		It("serves the routes of the domain over https", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(domain.Internal).To(BeFalse())
+			Expect(domain.Scheme()).To(Equal("https"))
+		})
+
+		When("the domain is internal", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, cfDomain, func() {
+					cfDomain.Spec.Internal = true
+				})).To(Succeed())
+			})
+
+			It("serves the routes of the domain over http", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(domain.Internal).To(BeTrue())
+				Expect(domain.Scheme()).To(Equal("http"))
+			})
+		})
+
 		When("no CFDomain exists", func() {
 			BeforeEach(func() {
 				searchGUID = "i-dont-exist"
@@ -258,6 +291,51 @@ var _ = Describe("DomainRepository", func() {
 			Expect(domainRecords[0].CreatedAt).To(BeTemporally("<=", domainRecords[1].CreatedAt))
 		})
 
+		It("reports domains that have not been reconciled as not ready", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(domainRecords).To(ContainElement(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(domainGUID1), "Ready": BeFalse()}),
+			))
+		})
+
+		When("the domain routing is ready", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, k8sClient, cfDomain1, func() {
+					cfDomain1.Status.ObservedGeneration = cfDomain1.Generation
+					cfDomain1.Status.Conditions = []metav1.Condition{{
+						Type:               korifiv1alpha1.DomainRoutingReadyConditionType,
+						Status:             metav1.ConditionTrue,
+						Reason:             "RoutingReady",
+						ObservedGeneration: cfDomain1.Generation,
+						LastTransitionTime: metav1.Now(),
+					}}
+				})).To(Succeed())
+			})
+
+			It("reports the domain as ready", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(domainRecords).To(ContainElements(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(domainGUID1), "Ready": BeTrue()}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(domainGUID), "Ready": BeFalse()}),
+				))
+			})
+
+			When("the domain has changed since it was reconciled", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfDomain1, func() {
+						cfDomain1.Spec.Name = "domain-1-changed"
+					})).To(Succeed())
+				})
+
+				It("reports the domain as not ready", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(domainRecords).To(ContainElement(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(domainGUID1), "Ready": BeFalse()}),
+					))
+				})
+			})
+		})
+
 		When("no CFDomains exist", func() {
 			BeforeEach(func() {
 				Expect(k8sClient.Delete(ctx, cfDomain)).To(Succeed())
@@ -378,6 +456,196 @@ var _ = Describe("DomainRepository", func() {
 		})
 	})
 
+	Describe("SetDefaultDomain", func() {
+		var (
+			defaultGUID   string
+			defaultDomain DomainRecord
+			setErr        error
+		)
+
+		BeforeEach(func() {
+			defaultGUID = domainGUID
+		})
+
+		JustBeforeEach(func() {
+			defaultDomain, setErr = domainRepo.SetDefaultDomain(ctx, authInfo, defaultGUID)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(setErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("records the domain as default", func() {
+				Expect(setErr).NotTo(HaveOccurred())
+				Expect(defaultDomain.GUID).To(Equal(domainGUID))
+
+				defaultDomainConfig := &corev1.ConfigMap{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: DefaultDomainConfigName}, defaultDomainConfig)).To(Succeed())
+				Expect(defaultDomainConfig.Annotations).To(HaveKeyWithValue(DefaultDomainAnnotation, domainGUID))
+			})
+
+			It("can be read back as the default domain", func() {
+				Expect(setErr).NotTo(HaveOccurred())
+
+				domain, err := domainRepo.GetDefaultDomain(ctx, authInfo)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(domain.GUID).To(Equal(domainGUID))
+				Expect(domain.Name).To(Equal(domainName))
+			})
+
+			When("another domain is already the default", func() {
+				BeforeEach(func() {
+					setDefaultDomain("previous-default-guid")
+				})
+
+				It("replaces it", func() {
+					Expect(setErr).NotTo(HaveOccurred())
+
+					domain, err := domainRepo.GetDefaultDomain(ctx, authInfo)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(domain.GUID).To(Equal(domainGUID))
+				})
+			})
+
+			When("the domain is already the default", func() {
+				BeforeEach(func() {
+					setDefaultDomain(domainGUID)
+				})
+
+				It("succeeds", func() {
+					Expect(setErr).NotTo(HaveOccurred())
+					Expect(defaultDomain.GUID).To(Equal(domainGUID))
+				})
+			})
+
+			When("the domain does not exist", func() {
+				BeforeEach(func() {
+					defaultGUID = "i-dont-exist"
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(setErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("the domain is private", func() {
+				BeforeEach(func() {
+					org := createOrgWithCleanup(ctx, prefixedGUID("org"))
+					space := createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+
+					defaultGUID = uuid.NewString()
+					Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFDomain{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      defaultGUID,
+							Namespace: space.Name,
+						},
+						Spec: korifiv1alpha1.CFDomainSpec{
+							Name: "private.com",
+						},
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(setErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("GetDefaultDomain", func() {
+		var (
+			defaultDomain DomainRecord
+			getErr        error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+		})
+
+		JustBeforeEach(func() {
+			defaultDomain, getErr = domainRepo.GetDefaultDomain(ctx, authInfo)
+		})
+
+		It("returns a not found error when no default domain is set", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("a default domain is set", func() {
+			BeforeEach(func() {
+				setDefaultDomain(domainGUID)
+			})
+
+			It("returns it", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(defaultDomain.GUID).To(Equal(domainGUID))
+			})
+		})
+
+		When("the default domain that is set does not exist anymore", func() {
+			BeforeEach(func() {
+				setDefaultDomain("i-dont-exist")
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+
+		When("the platform is configured with a default domain", func() {
+			var otherDomain *korifiv1alpha1.CFDomain
+
+			BeforeEach(func() {
+				domainRepo = NewDomainRepo(userClientFactory, namespaceRetriever, rootNamespace, domainName)
+
+				otherDomain = &korifiv1alpha1.CFDomain{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: rootNamespace,
+					},
+					Spec: korifiv1alpha1.CFDomainSpec{
+						Name: "other-domain.com",
+					},
+				}
+				Expect(k8sClient.Create(ctx, otherDomain)).To(Succeed())
+				DeferCleanup(func() {
+					Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, otherDomain))).To(Succeed())
+				})
+			})
+
+			It("returns the configured domain", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(defaultDomain.GUID).To(Equal(domainGUID))
+			})
+
+			When("another default domain is set", func() {
+				BeforeEach(func() {
+					setDefaultDomain(otherDomain.Name)
+				})
+
+				It("returns the domain that has been set", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(defaultDomain.GUID).To(Equal(otherDomain.Name))
+				})
+			})
+
+			When("the default domain that is set does not exist anymore", func() {
+				BeforeEach(func() {
+					setDefaultDomain("i-dont-exist")
+				})
+
+				It("returns the configured domain", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(defaultDomain.GUID).To(Equal(domainGUID))
+				})
+			})
+		})
+	})
+
 	Describe("GetDeletedAt", func() {
 		var (
 			deletionTime *time.Time
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,12 +12,16 @@ import (
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// No kubebuilder RBAC tags required, because Build and Droplet are the same CR
+// Droplets are copied into the status of CFBuilds created by the user, which
+// users cannot write themselves
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfbuilds/status,verbs=patch
 
 const (
 	DropletResourceType = "Droplet"
@@ -26,17 +31,20 @@ type DropletRepo struct {
 	userClientFactory    authorization.UserK8sClientFactory
 	namespaceRetriever   NamespaceRetriever
 	namespacePermissions *authorization.NamespacePermissions
+	privilegedClient     client.Client
 }
 
 func NewDropletRepo(
 	userClientFactory authorization.UserK8sClientFactory,
 	namespaceRetriever NamespaceRetriever,
 	namespacePermissions *authorization.NamespacePermissions,
+	privilegedClient client.Client,
 ) *DropletRepo {
 	return &DropletRepo{
 		userClientFactory:    userClientFactory,
 		namespaceRetriever:   namespaceRetriever,
 		namespacePermissions: namespacePermissions,
+		privilegedClient:     privilegedClient,
 	}
 }
 
@@ -57,6 +65,14 @@ type DropletRecord struct {
 	Ports           []int32
 }
 
+// DropletProcessTypeRecord is a process type defined by a droplet, along with
+// its default start command and the ports exposed by the droplet
+type DropletProcessTypeRecord struct {
+	Type    string
+	Command string
+	Ports   []int32
+}
+
 type ListDropletsMessage struct {
 	PackageGUIDs []string
 }
@@ -70,6 +86,30 @@ func (r *DropletRepo) GetDroplet(ctx context.Context, authInfo authorization.Inf
 	return returnDroplet(*build)
 }
 
+// ListDropletProcessTypes returns the process types defined by the droplet,
+// in the order they were detected during staging
+func (r *DropletRepo) ListDropletProcessTypes(ctx context.Context, authInfo authorization.Info, dropletGUID string) ([]DropletProcessTypeRecord, error) {
+	build, _, err := r.getBuildAssociatedWithDroplet(ctx, authInfo, dropletGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = returnDroplet(*build); err != nil {
+		return nil, err
+	}
+
+	processTypes := []DropletProcessTypeRecord{}
+	for _, processType := range build.Status.Droplet.ProcessTypes {
+		processTypes = append(processTypes, DropletProcessTypeRecord{
+			Type:    processType.Type,
+			Command: processType.Command,
+			Ports:   build.Status.Droplet.Ports,
+		})
+	}
+
+	return processTypes, nil
+}
+
 func (r *DropletRepo) getBuildAssociatedWithDroplet(ctx context.Context, authInfo authorization.Info, dropletGUID string) (*korifiv1alpha1.CFBuild, client.WithWatch, error) {
 	// A droplet is a subset of a build
 	ns, err := r.namespaceRetriever.NamespaceFor(ctx, dropletGUID, DropletResourceType)
@@ -193,6 +233,121 @@ func (r *DropletRepo) UpdateDroplet(ctx context.Context, authInfo authorization.
 	return returnDroplet(*build)
 }
 
+type CopyAppDropletMessage struct {
+	SourceAppGUID string
+	TargetAppGUID string
+}
+
+// CopyAppDroplet creates a new build for the target app holding the droplet of
+// the source app's current droplet, and makes it the target app's current
+// droplet. The source build is read and the copy is created with the user
+// client, so the user needs to be able to read the space of the source app and
+// to write to the space of the target app. Only then is the droplet copied
+// into the status of the new build, with the privileged client.
+func (r *DropletRepo) CopyAppDroplet(ctx context.Context, authInfo authorization.Info, message CopyAppDropletMessage) (DropletRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	sourceApp, err := r.getApp(ctx, userClient, message.SourceAppGUID)
+	if err != nil {
+		return DropletRecord{}, err
+	}
+
+	if sourceApp.Spec.CurrentDropletRef.Name == "" {
+		return DropletRecord{}, apierrors.NewUnprocessableEntityError(nil, "Source app does not have a current droplet")
+	}
+
+	var sourceBuild korifiv1alpha1.CFBuild
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: sourceApp.Namespace, Name: sourceApp.Spec.CurrentDropletRef.Name}, &sourceBuild)
+	if err != nil {
+		return DropletRecord{}, apierrors.FromK8sError(err, DropletResourceType)
+	}
+
+	if sourceBuild.Status.Droplet == nil {
+		return DropletRecord{}, apierrors.NewUnprocessableEntityError(nil, "Source app droplet is not staged")
+	}
+
+	targetApp, err := r.getApp(ctx, userClient, message.TargetAppGUID)
+	if err != nil {
+		return DropletRecord{}, err
+	}
+
+	if err = checkImagePullSecretsExist(ctx, userClient, targetApp.Namespace, sourceBuild.Status.Droplet.Registry.ImagePullSecrets); err != nil {
+		return DropletRecord{}, err
+	}
+
+	copiedBuild := korifiv1alpha1.CFBuild{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: targetApp.Namespace,
+			Annotations: map[string]string{
+				korifiv1alpha1.CFBuildCopiedFromAnnotationKey: sourceBuild.Namespace + "/" + sourceBuild.Name,
+			},
+		},
+		Spec: korifiv1alpha1.CFBuildSpec{
+			PackageRef:      sourceBuild.Spec.PackageRef,
+			AppRef:          corev1.LocalObjectReference{Name: targetApp.Name},
+			StagingMemoryMB: sourceBuild.Spec.StagingMemoryMB,
+			StagingDiskMB:   sourceBuild.Spec.StagingDiskMB,
+			Lifecycle:       sourceBuild.Spec.Lifecycle,
+		},
+	}
+	if err = userClient.Create(ctx, &copiedBuild); err != nil {
+		return DropletRecord{}, apierrors.FromK8sError(err, DropletResourceType)
+	}
+
+	originalBuild := copiedBuild.DeepCopy()
+	copiedBuild.Status.Droplet = sourceBuild.Status.Droplet.DeepCopy()
+	if err = r.privilegedClient.Status().Patch(ctx, &copiedBuild, client.MergeFrom(originalBuild)); err != nil {
+		if deleteErr := userClient.Delete(ctx, &copiedBuild); deleteErr != nil {
+			err = errors.Join(err, deleteErr)
+		}
+		return DropletRecord{}, fmt.Errorf("failed to copy droplet %q: %w", sourceBuild.Name, err)
+	}
+
+	err = k8s.PatchResource(ctx, userClient, targetApp, func() {
+		targetApp.Spec.CurrentDropletRef = corev1.LocalObjectReference{Name: copiedBuild.Name}
+	})
+	if err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to set current droplet of app %q: %w", targetApp.Name, apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	return cfBuildToDropletRecord(copiedBuild), nil
+}
+
+// checkImagePullSecretsExist makes sure a droplet copied into another space
+// can still be pulled there, as its image pull secrets are namespaced
+func checkImagePullSecretsExist(ctx context.Context, userClient client.Client, namespace string, secretRefs []corev1.LocalObjectReference) error {
+	for _, secretRef := range secretRefs {
+		err := userClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretRef.Name}, &corev1.Secret{})
+		if k8serrors.IsNotFound(err) {
+			return apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("Image pull secret %q of the droplet does not exist in the target space", secretRef.Name))
+		}
+		if err != nil {
+			return apierrors.FromK8sError(err, DropletResourceType)
+		}
+	}
+
+	return nil
+}
+
+func (r *DropletRepo) getApp(ctx context.Context, userClient client.Client, appGUID string) (*korifiv1alpha1.CFApp, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, appGUID, AppResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	app := new(korifiv1alpha1.CFApp)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: appGUID}, app)
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, AppResourceType)
+	}
+
+	return app, nil
+}
+
 func returnDropletList(droplets []korifiv1alpha1.CFBuild) []DropletRecord {
 	dropletRecords := make([]DropletRecord, 0, len(droplets))
 
@@ -49,7 +49,7 @@ var _ = Describe("DropletRepository", func() {
 		org = createOrgWithCleanup(testCtx, orgName)
 		space = createSpaceWithCleanup(testCtx, org.Name, spaceName)
 
-		dropletRepo = repositories.NewDropletRepo(userClientFactory, namespaceRetriever, nsPerms)
+		dropletRepo = repositories.NewDropletRepo(userClientFactory, namespaceRetriever, nsPerms, k8sClient)
 
 		build = &korifiv1alpha1.CFBuild{
 			ObjectMeta: metav1.ObjectMeta{
@@ -309,6 +309,87 @@ var _ = Describe("DropletRepository", func() {
 		})
 	})
 
+	Describe("ListDropletProcessTypes", func() {
+		var (
+			processTypes []repositories.DropletProcessTypeRecord
+			listErr      error
+		)
+
+		JustBeforeEach(func() {
+			processTypes, listErr = dropletRepo.ListDropletProcessTypes(testCtx, authInfo, buildGUID)
+		})
+
+		When("the user is authorized to get the droplet", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			When("the droplet is staged", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(testCtx, k8sClient, build, func() {
+						meta.SetStatusCondition(&build.Status.Conditions, metav1.Condition{
+							Type:    "Staging",
+							Status:  metav1.ConditionFalse,
+							Reason:  "kpack",
+							Message: "kpack",
+						})
+						meta.SetStatusCondition(&build.Status.Conditions, metav1.Condition{
+							Type:    "Succeeded",
+							Status:  metav1.ConditionTrue,
+							Reason:  "Unknown",
+							Message: "Unknown",
+						})
+						build.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+							Stack: dropletStack,
+							Registry: korifiv1alpha1.Registry{
+								Image: registryImage,
+							},
+							ProcessTypes: []korifiv1alpha1.ProcessType{
+								{
+									Type:    "web",
+									Command: "bundle exec rackup config.ru -p $PORT",
+								},
+								{
+									Type:    "worker",
+									Command: "bundle exec sidekiq",
+								},
+							},
+							Ports: []int32{8080, 8443},
+						}
+					})).To(Succeed())
+				})
+
+				It("returns the process types with their commands and ports", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(processTypes).To(Equal([]repositories.DropletProcessTypeRecord{
+						{
+							Type:    "web",
+							Command: "bundle exec rackup config.ru -p $PORT",
+							Ports:   []int32{8080, 8443},
+						},
+						{
+							Type:    "worker",
+							Command: "bundle exec sidekiq",
+							Ports:   []int32{8080, 8443},
+						},
+					}))
+				})
+			})
+
+			When("the droplet is not staged yet", func() {
+				It("returns a not found error", func() {
+					Expect(listErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+
+		When("the user is not authorized to get the droplet", func() {
+			It("returns a forbidden error", func() {
+				Expect(listErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
 	Describe("ListDroplets", func() {
 		var (
 			dropletRecords []repositories.DropletRecord
@@ -658,4 +739,180 @@ var _ = Describe("DropletRepository", func() {
 			})
 		})
 	})
+
+	Describe("CopyAppDroplet", func() {
+		var (
+			targetSpace   *korifiv1alpha1.CFSpace
+			sourceApp     *korifiv1alpha1.CFApp
+			targetApp     *korifiv1alpha1.CFApp
+			dropletRecord repositories.DropletRecord
+			copyErr       error
+		)
+
+		BeforeEach(func() {
+			targetSpace = createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("target-space-"))
+
+			Expect(k8s.Patch(testCtx, k8sClient, build, func() {
+				meta.SetStatusCondition(&build.Status.Conditions, metav1.Condition{
+					Type:   "Staging",
+					Status: metav1.ConditionFalse,
+					Reason: "kpack",
+				})
+				meta.SetStatusCondition(&build.Status.Conditions, metav1.Condition{
+					Type:   "Succeeded",
+					Status: metav1.ConditionTrue,
+					Reason: "kpack",
+				})
+				build.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+					Stack:    dropletStack,
+					Registry: korifiv1alpha1.Registry{Image: registryImage},
+					ProcessTypes: []korifiv1alpha1.ProcessType{{
+						Type:    "web",
+						Command: "bundle exec rackup config.ru -p $PORT",
+					}},
+				}
+			})).To(Succeed())
+
+			sourceApp = createApp(space.Name)
+			Expect(k8s.PatchResource(testCtx, k8sClient, sourceApp, func() {
+				sourceApp.Spec.CurrentDropletRef.Name = build.Name
+			})).To(Succeed())
+
+			targetApp = createApp(space.Name)
+		})
+
+		JustBeforeEach(func() {
+			dropletRecord, copyErr = dropletRepo.CopyAppDroplet(testCtx, authInfo, repositories.CopyAppDropletMessage{
+				SourceAppGUID: sourceApp.Name,
+				TargetAppGUID: targetApp.Name,
+			})
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("creates a copy of the droplet build for the target app", func() {
+				Expect(copyErr).NotTo(HaveOccurred())
+				Expect(dropletRecord.GUID).NotTo(Equal(build.Name))
+				Expect(dropletRecord.AppGUID).To(Equal(targetApp.Name))
+				Expect(dropletRecord.Stack).To(Equal(dropletStack))
+				Expect(dropletRecord.ProcessTypes).To(Equal(map[string]string{
+					"web": "bundle exec rackup config.ru -p $PORT",
+				}))
+
+				copiedBuild := new(korifiv1alpha1.CFBuild)
+				Expect(k8sClient.Get(testCtx, client.ObjectKey{Namespace: space.Name, Name: dropletRecord.GUID}, copiedBuild)).To(Succeed())
+				Expect(copiedBuild.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFBuildCopiedFromAnnotationKey, space.Name+"/"+build.Name))
+				Expect(copiedBuild.Spec.AppRef.Name).To(Equal(targetApp.Name))
+				Expect(copiedBuild.Spec.PackageRef.Name).To(Equal(packageGUID))
+				Expect(copiedBuild.Spec.StagingMemoryMB).To(Equal(stagingMemory))
+				Expect(copiedBuild.Spec.StagingDiskMB).To(Equal(stagingDisk))
+			})
+
+			It("points the target app droplet ref at the copied build", func() {
+				Expect(copyErr).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(targetApp), targetApp)).To(Succeed())
+				Expect(targetApp.Spec.CurrentDropletRef.Name).To(Equal(dropletRecord.GUID))
+			})
+
+			When("the source app has no current droplet", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(testCtx, k8sClient, sourceApp, func() {
+						sourceApp.Spec.CurrentDropletRef.Name = ""
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(copyErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			It("copies the droplet into the status of the copied build", func() {
+				Expect(copyErr).NotTo(HaveOccurred())
+
+				copiedBuild := new(korifiv1alpha1.CFBuild)
+				Expect(k8sClient.Get(testCtx, client.ObjectKey{Namespace: space.Name, Name: dropletRecord.GUID}, copiedBuild)).To(Succeed())
+				Expect(copiedBuild.Status.Droplet).NotTo(BeNil())
+				Expect(copiedBuild.Status.Droplet.Registry.Image).To(Equal(registryImage))
+			})
+
+			When("the target app is in another space", func() {
+				BeforeEach(func() {
+					targetApp = createApp(targetSpace.Name)
+				})
+
+				It("returns a forbidden error", func() {
+					Expect(copyErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+				})
+
+				When("the user can write to the target space", func() {
+					BeforeEach(func() {
+						createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, targetSpace.Name)
+					})
+
+					It("copies the droplet into the target space", func() {
+						Expect(copyErr).NotTo(HaveOccurred())
+
+						copiedBuild := new(korifiv1alpha1.CFBuild)
+						Expect(k8sClient.Get(testCtx, client.ObjectKey{Namespace: targetSpace.Name, Name: dropletRecord.GUID}, copiedBuild)).To(Succeed())
+						Expect(copiedBuild.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFBuildCopiedFromAnnotationKey, space.Name+"/"+build.Name))
+						Expect(copiedBuild.Status.Droplet).NotTo(BeNil())
+						Expect(copiedBuild.Status.Droplet.Registry.Image).To(Equal(registryImage))
+
+						Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(targetApp), targetApp)).To(Succeed())
+						Expect(targetApp.Spec.CurrentDropletRef.Name).To(Equal(dropletRecord.GUID))
+					})
+
+					When("the droplet image pull secrets do not exist in the target space", func() {
+						BeforeEach(func() {
+							Expect(k8s.Patch(testCtx, k8sClient, build, func() {
+								build.Status.Droplet.Registry.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "source-space-secret"}}
+							})).To(Succeed())
+						})
+
+						It("returns an unprocessable entity error", func() {
+							Expect(copyErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						})
+
+						It("does not create a copy", func() {
+							var builds korifiv1alpha1.CFBuildList
+							Expect(k8sClient.List(testCtx, &builds, client.InNamespace(targetSpace.Name))).To(Succeed())
+							Expect(builds.Items).To(BeEmpty())
+						})
+					})
+				})
+			})
+
+			When("the source droplet is not staged", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(testCtx, k8sClient, build, func() {
+						build.Status.Droplet = nil
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(copyErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+
+		When("the user is not authorized in the source app space", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, targetSpace.Name)
+				targetApp = createApp(targetSpace.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(copyErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			It("does not change the target app droplet", func() {
+				currentDroplet := targetApp.Spec.CurrentDropletRef.Name
+				Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(targetApp), targetApp)).To(Succeed())
+				Expect(targetApp.Spec.CurrentDropletRef.Name).To(Equal(currentDroplet))
+			})
+		})
+	})
 })
@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// NamespaceProvisionTimeoutError is returned when an org or space does not
+// become ready within the creation timeout, e.g. because its namespace or
+// the permissions in it have not been provisioned yet. It is presented as a
+// 503, as retrying later may well succeed.
+type NamespaceProvisionTimeoutError struct {
+	ResourceType string
+	Elapsed      time.Duration
+	Err          error
+}
+
+func (e NamespaceProvisionTimeoutError) Error() string {
+	return fmt.Sprintf("%s was not provisioned after %s: %v", e.ResourceType, e.Elapsed, e.Err)
+}
+
+func (e NamespaceProvisionTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+func (e NamespaceProvisionTimeoutError) Detail() string {
+	return fmt.Sprintf("%s was not provisioned after %s. Please try again later.", e.ResourceType, e.Elapsed.Round(time.Second))
+}
+
+func (e NamespaceProvisionTimeoutError) Title() string {
+	return "CF-ServiceUnavailable"
+}
+
+func (e NamespaceProvisionTimeoutError) Code() int {
+	return 10015
+}
+
+func (e NamespaceProvisionTimeoutError) HttpStatus() int {
+	return http.StatusServiceUnavailable
+}
+
+// SpaceCreationThrottledError is returned when a space creation gives up
+// waiting for one of the concurrent space creation slots of its org to free
+// up. Like NamespaceProvisionTimeoutError, it is presented as a 503 so that
+// clients retry later.
+type SpaceCreationThrottledError struct {
+	OrgGUID string
+	Err     error
+}
+
+func (e SpaceCreationThrottledError) Error() string {
+	return fmt.Sprintf("timed out waiting to create a space in org %q: %v", e.OrgGUID, e.Err)
+}
+
+func (e SpaceCreationThrottledError) Unwrap() error {
+	return e.Err
+}
+
+func (e SpaceCreationThrottledError) Detail() string {
+	return "Too many spaces are being created in the organization. Please try again later."
+}
+
+func (e SpaceCreationThrottledError) Title() string {
+	return "CF-ServiceUnavailable"
+}
+
+func (e SpaceCreationThrottledError) Code() int {
+	return 10015
+}
+
+func (e SpaceCreationThrottledError) HttpStatus() int {
+	return http.StatusServiceUnavailable
+}
@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"strconv"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// FeatureFlagRouteCreation controls whether routes can be created
+	FeatureFlagRouteCreation = "route_creation"
+
+	// FeatureFlagAnnotationPrefix prefixes the org annotations overriding
+	// the platform value of a feature flag, e.g.
+	// "korifi.cloudfoundry.org/feature-flag.route_creation: false"
+	FeatureFlagAnnotationPrefix = "korifi.cloudfoundry.org/feature-flag."
+)
+
+// FeatureFlags holds the platform wide values of feature flags. Flags that are
+// not set are enabled.
+type FeatureFlags map[string]bool
+
+// Enabled returns whether the flag is enabled in an org, giving precedence to
+// the override set on the org, if any
+func (f FeatureFlags) Enabled(org client.Object, flag string) bool {
+	if override := featureFlagOverride(org, flag); override != nil {
+		return *override
+	}
+
+	enabled, ok := f[flag]
+	return !ok || enabled
+}
+
+// featureFlagOverride returns the value the object overrides the flag with,
+// or nil if it does not override it with a valid boolean
+func featureFlagOverride(obj client.Object, flag string) *bool {
+	value, ok := obj.GetAnnotations()[FeatureFlagAnnotationPrefix+flag]
+	if !ok {
+		return nil
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil
+	}
+
+	return &enabled
+}
@@ -26,10 +26,17 @@ type ImagePusher interface {
 	Push(ctx context.Context, creds image.Creds, repoRef string, zipReader io.Reader, tags ...string) (string, error)
 }
 
+//counterfeiter:generate -o fake -fake-name ImageConfigGetter . ImageConfigGetter
+
+type ImageConfigGetter interface {
+	Config(ctx context.Context, creds image.Creds, imageRef string) (image.Config, error)
+}
+
 type ImageRepository struct {
 	privilegedK8sClient k8sclient.Interface
 	userClientFactory   authorization.UserK8sClientFactory
 	pusher              ImagePusher
+	configGetter        ImageConfigGetter
 	pushSecretNames     []string
 	pushSecretNamespace string
 }
@@ -38,6 +45,7 @@ func NewImageRepository(
 	privilegedK8sClient k8sclient.Interface,
 	userClientFactory authorization.UserK8sClientFactory,
 	pusher ImagePusher,
+	configGetter ImageConfigGetter,
 	pushSecretNames []string,
 	pushSecretNamespace string,
 ) *ImageRepository {
@@ -45,6 +53,7 @@ func NewImageRepository(
 		privilegedK8sClient: privilegedK8sClient,
 		userClientFactory:   userClientFactory,
 		pusher:              pusher,
+		configGetter:        configGetter,
 		pushSecretNames:     pushSecretNames,
 		pushSecretNamespace: pushSecretNamespace,
 	}
@@ -76,6 +85,20 @@ func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo author
 	return pushedRef, nil
 }
 
+// GetImageSize returns the number of bytes a package or droplet image takes
+// up in the registry
+func (r *ImageRepository) GetImageSize(ctx context.Context, imageRef string) (int64, error) {
+	config, err := r.configGetter.Config(ctx, image.Creds{
+		Namespace:   r.pushSecretNamespace,
+		SecretNames: r.pushSecretNames,
+	}, imageRef)
+	if err != nil {
+		return 0, apierrors.NewBlobstoreUnavailableError(fmt.Errorf("getting config of image ref '%s' failed: %w", imageRef, err))
+	}
+
+	return config.Size, nil
+}
+
 func (r *ImageRepository) canIPatchCFPackage(ctx context.Context, authInfo authorization.Info, spaceGUID string) (bool, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
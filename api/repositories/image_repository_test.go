@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tests/helpers"
+	"code.cloudfoundry.org/korifi/tools/image"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -20,22 +21,24 @@ import (
 
 var _ = Describe("ImageRepository", func() {
 	var (
-		imagePusher *fake.ImagePusher
-		k8sClient   k8sclient.Interface
-		imageSource io.Reader
-		imageRepo   *repositories.ImageRepository
-		imageName   string
-		imageRef    string
-		tags        []string
-		uploadErr   error
-		org         *korifiv1alpha1.CFOrg
-		space       *korifiv1alpha1.CFSpace
+		imagePusher       *fake.ImagePusher
+		imageConfigGetter *fake.ImageConfigGetter
+		k8sClient         k8sclient.Interface
+		imageSource       io.Reader
+		imageRepo         *repositories.ImageRepository
+		imageName         string
+		imageRef          string
+		tags              []string
+		uploadErr         error
+		org               *korifiv1alpha1.CFOrg
+		space             *korifiv1alpha1.CFSpace
 	)
 
 	BeforeEach(func() {
 		imageName = "my-image"
 		imagePusher = new(fake.ImagePusher)
 		imagePusher.PushReturns("my-pushed-image", nil)
+		imageConfigGetter = new(fake.ImageConfigGetter)
 
 		imageSource = bytes.NewBufferString("")
 
@@ -52,61 +55,103 @@ var _ = Describe("ImageRepository", func() {
 			k8sClient,
 			userClientFactory,
 			imagePusher,
+			imageConfigGetter,
 			[]string{"push-secret-name"},
 			rootNamespace,
 		)
 	})
 
-	JustBeforeEach(func() {
-		imageRef, uploadErr = imageRepo.UploadSourceImage(context.Background(), authInfo, imageName, imageSource, space.Name, tags...)
-	})
+	Describe("UploadSourceImage", func() {
+		JustBeforeEach(func() {
+			imageRef, uploadErr = imageRepo.UploadSourceImage(context.Background(), authInfo, imageName, imageSource, space.Name, tags...)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(context.Background(), userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("succeeds", func() {
+				Expect(uploadErr).NotTo(HaveOccurred())
+				Expect(imageRef).To(Equal("my-pushed-image"))
+			})
+
+			It("uploads the image to the registry", func() {
+				Expect(imagePusher.PushCallCount()).To(Equal(1))
+				_, creds, actualRef, zipReader, actualTags := imagePusher.PushArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualRef).To(Equal("my-image"))
+				Expect(zipReader).To(Equal(imageSource))
+				Expect(actualTags).To(Equal(tags))
+			})
+
+			When("the image name is invalid", func() {
+				BeforeEach(func() {
+					imageName = "invAlid-image"
+				})
+
+				It("fails with an easy to understand unprocessible entity error ", func() {
+					var apiError apierrors.UnprocessableEntityError
+					Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
+					Expect(apiError.Detail()).To(Equal(`invalid image ref: "invAlid-image"`))
+				})
+			})
 
-	It("fails with unauthorized error without a valid role in the space", func() {
-		Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+			When("pushing the image fails", func() {
+				BeforeEach(func() {
+					imagePusher.PushReturns("", errors.New("push-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(uploadErr).To(MatchError(ContainSubstring("push-error")))
+					var apiError apierrors.BlobstoreUnavailableError
+					Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
+					Expect(apiError.Detail()).To(Equal("Error uploading source package to the container registry"))
+				})
+			})
+		})
 	})
 
-	When("user has role SpaceDeveloper", func() {
+	Describe("GetImageSize", func() {
+		var (
+			size    int64
+			sizeErr error
+		)
+
 		BeforeEach(func() {
-			createRoleBinding(context.Background(), userName, spaceDeveloperRole.Name, space.Name)
+			imageConfigGetter.ConfigReturns(image.Config{Size: 1234}, nil)
 		})
 
-		It("succeeds", func() {
-			Expect(uploadErr).NotTo(HaveOccurred())
-			Expect(imageRef).To(Equal("my-pushed-image"))
+		JustBeforeEach(func() {
+			size, sizeErr = imageRepo.GetImageSize(context.Background(), "my-image-ref")
 		})
 
-		It("uploads the image to the registry", func() {
-			Expect(imagePusher.PushCallCount()).To(Equal(1))
-			_, creds, actualRef, zipReader, actualTags := imagePusher.PushArgsForCall(0)
-			Expect(creds.Namespace).To(Equal(rootNamespace))
-			Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
-			Expect(actualRef).To(Equal("my-image"))
-			Expect(zipReader).To(Equal(imageSource))
-			Expect(actualTags).To(Equal(tags))
+		It("returns the image size", func() {
+			Expect(sizeErr).NotTo(HaveOccurred())
+			Expect(size).To(BeEquivalentTo(1234))
 		})
 
-		When("the image name is invalid", func() {
-			BeforeEach(func() {
-				imageName = "invAlid-image"
-			})
-
-			It("fails with an easy to understand unprocessible entity error ", func() {
-				var apiError apierrors.UnprocessableEntityError
-				Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
-				Expect(apiError.Detail()).To(Equal(`invalid image ref: "invAlid-image"`))
-			})
+		It("reads the image config from the registry", func() {
+			Expect(imageConfigGetter.ConfigCallCount()).To(Equal(1))
+			_, creds, actualRef := imageConfigGetter.ConfigArgsForCall(0)
+			Expect(creds.Namespace).To(Equal(rootNamespace))
+			Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+			Expect(actualRef).To(Equal("my-image-ref"))
 		})
 
-		When("pushing the image fails", func() {
+		When("getting the image config fails", func() {
 			BeforeEach(func() {
-				imagePusher.PushReturns("", errors.New("push-error"))
+				imageConfigGetter.ConfigReturns(image.Config{}, errors.New("config-error"))
 			})
 
 			It("fails with a blobstore unavailable error", func() {
-				Expect(uploadErr).To(MatchError(ContainSubstring("push-error")))
-				var apiError apierrors.BlobstoreUnavailableError
-				Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
-				Expect(apiError.Detail()).To(Equal("Error uploading source package to the container registry"))
+				Expect(sizeErr).To(MatchError(ContainSubstring("config-error")))
+				Expect(sizeErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
 			})
 		})
 	})
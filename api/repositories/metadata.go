@@ -34,3 +34,33 @@ func patchMap(original map[string]string, patch map[string]*string) {
 		}
 	}
 }
+
+// inheritMetadata copies the labels and annotations of parent whose keys are
+// in the allowlist onto child. Keys already set on child are left untouched.
+func inheritMetadata(parent, child client.Object, allowedKeys []string) {
+	if len(allowedKeys) == 0 {
+		return
+	}
+
+	child.SetLabels(inheritKeys(parent.GetLabels(), child.GetLabels(), allowedKeys))
+	child.SetAnnotations(inheritKeys(parent.GetAnnotations(), child.GetAnnotations(), allowedKeys))
+}
+
+func inheritKeys(from, to map[string]string, allowedKeys []string) map[string]string {
+	for _, key := range allowedKeys {
+		value, ok := from[key]
+		if !ok {
+			continue
+		}
+
+		if to == nil {
+			to = map[string]string{}
+		}
+
+		if _, exists := to[key]; !exists {
+			to[key] = value
+		}
+	}
+
+	return to
+}
@@ -140,3 +140,19 @@ func (nr NamespaceRetriever) NamespaceFor(ctx context.Context, resourceGUID, res
 
 	return ns, nil
 }
+
+// SpaceGUIDsFor returns the guids of the spaces of an org, which are also the
+// names of their namespaces
+func (nr NamespaceRetriever) SpaceGUIDsFor(ctx context.Context, orgGUID string) ([]string, error) {
+	list, err := nr.client.Resource(CFSpacesGVR).Namespace(orgGUID).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list spaces of org %q: %w", orgGUID, apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	spaceGUIDs := make([]string, 0, len(list.Items))
+	for _, space := range list.Items {
+		spaceGUIDs = append(spaceGUIDs, space.GetName())
+	}
+
+	return spaceGUIDs, nil
+}
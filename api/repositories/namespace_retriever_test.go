@@ -67,4 +67,25 @@ var _ = Describe("NamespaceRetriever", func() {
 			Expect(retErr).To(MatchError(ContainSubstring("duplicate records exist")))
 		})
 	})
+
+	Describe("SpaceGUIDsFor", func() {
+		var (
+			spaceGUIDs []string
+			listErr    error
+		)
+
+		BeforeEach(func() {
+			otherOrg := createOrgWithCleanup(ctx, prefixedGUID("other-org"))
+			_ = createSpaceWithCleanup(ctx, otherOrg.Name, prefixedGUID("other-space"))
+		})
+
+		JustBeforeEach(func() {
+			spaceGUIDs, listErr = namespaceRetriever.SpaceGUIDsFor(ctx, orgGUID)
+		})
+
+		It("returns the guids of the spaces of the org", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(spaceGUIDs).To(ConsistOf(spaceGUID))
+		})
+	})
 })
@@ -2,7 +2,11 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
@@ -11,8 +15,12 @@ import (
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -26,15 +34,31 @@ type CreateOrgMessage struct {
 	Suspended   bool
 	Labels      map[string]string
 	Annotations map[string]string
+	// Async makes CreateOrg return as soon as the CFOrg is accepted instead of
+	// waiting for it to become ready. Use GetOrgProvisionStatus to find out
+	// when the org can be used.
+	Async bool
 }
 
 type ListOrgsMessage struct {
-	Names []string
-	GUIDs []string
+	Names         []string
+	GUIDs         []string
+	CreatedBy     string
+	LabelSelector string
+	// AnnotationSelector filters orgs by their annotations, using the label
+	// selector syntax, e.g. "key" or "key=value"
+	AnnotationSelector string
+	// Page and PerPage select a page of the orgs sorted by guid. A zero
+	// PerPage lists all orgs.
+	Page    int
+	PerPage int
 }
 
 type DeleteOrgMessage struct {
 	GUID string
+	// Wait makes DeleteOrg block until the org is gone, so that an org with
+	// the same name can be created straight away
+	Wait bool
 }
 
 type PatchOrgMetadataMessage struct {
@@ -42,6 +66,12 @@ type PatchOrgMetadataMessage struct {
 	GUID string
 }
 
+type UpdateOrgMessage struct {
+	MetadataPatch
+	GUID string
+	Name *string
+}
+
 type OrgRecord struct {
 	Name        string
 	GUID        string
@@ -53,12 +83,52 @@ type OrgRecord struct {
 	DeletedAt   *time.Time
 }
 
+// OrgHierarchyRecord summarises the spaces of an org. Spaces that are not
+// ready are reported as stuck.
+type OrgHierarchyRecord struct {
+	GUID            string
+	SpaceCount      int
+	StuckSpaceGUIDs []string
+	HasStuckSpaces  bool
+}
+
+type OrgProvisionStatusRecord struct {
+	GUID             string
+	NamespaceReady   bool
+	PermissionsReady bool
+	Ready            bool
+}
+
 type OrgRepo struct {
 	rootNamespace     string
 	privilegedClient  client.WithWatch
 	userClientFactory authorization.UserK8sClientFactory
 	nsPerms           *authorization.NamespacePermissions
+	identityProvider  authorization.IdentityProvider
 	conditionAwaiter  ConditionAwaiter[*korifiv1alpha1.CFOrg]
+	eventRecorder     record.EventRecorder
+	tracer            trace.Tracer
+	creatorGroups     []string
+
+	maxConcurrentSpaceCreations int
+	spaceCreationSlotsMutex     sync.Mutex
+	spaceCreationSlots          map[string]*spaceCreationSlots
+
+	rejectTerminatingOrgNames bool
+}
+
+// OrgRepoConfig holds the configurable limits and behaviours of the org
+// repository
+type OrgRepoConfig struct {
+	// CreatorGroups restricts org creation to members of these groups.
+	// Empty means anyone allowed by the feature flags can create orgs.
+	CreatorGroups []string
+	// MaxConcurrentSpaceCreations caps the number of spaces being created
+	// at the same time in each org by this replica. Zero means unlimited.
+	MaxConcurrentSpaceCreations int
+	// RejectTerminatingOrgNames prevents creating an org with the name of
+	// an org that is still being deleted
+	RejectTerminatingOrgNames bool
 }
 
 func NewOrgRepo(
@@ -66,41 +136,89 @@ func NewOrgRepo(
 	privilegedClient client.WithWatch,
 	userClientFactory authorization.UserK8sClientFactory,
 	nsPerms *authorization.NamespacePermissions,
+	identityProvider authorization.IdentityProvider,
 	conditionAwaiter ConditionAwaiter[*korifiv1alpha1.CFOrg],
+	eventRecorder record.EventRecorder,
+	tracer trace.Tracer,
+	config OrgRepoConfig,
 ) *OrgRepo {
 	return &OrgRepo{
 		rootNamespace:     rootNamespace,
 		privilegedClient:  privilegedClient,
 		userClientFactory: userClientFactory,
 		nsPerms:           nsPerms,
+		identityProvider:  identityProvider,
 		conditionAwaiter:  conditionAwaiter,
+		eventRecorder:     eventRecorder,
+		tracer:            tracer,
+		creatorGroups:     config.CreatorGroups,
+
+		maxConcurrentSpaceCreations: config.MaxConcurrentSpaceCreations,
+		spaceCreationSlots:          map[string]*spaceCreationSlots{},
+
+		rejectTerminatingOrgNames: config.RejectTerminatingOrgNames,
 	}
 }
 
-func (r *OrgRepo) CreateOrg(ctx context.Context, info authorization.Info, message CreateOrgMessage) (OrgRecord, error) {
+func (r *OrgRepo) CreateOrg(ctx context.Context, info authorization.Info, message CreateOrgMessage) (_ OrgRecord, err error) {
+	ctx, span := startSpan(ctx, r.tracer, "CreateOrg", OrgResourceType, "")
+	defer func() { endSpan(span, err) }()
+
 	userClient, err := r.userClientFactory.BuildClient(info)
 	if err != nil {
 		return OrgRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	identity, err := r.identityProvider.GetIdentity(ctx, info)
+	if err != nil {
+		return OrgRecord{}, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	if !r.mayCreateOrgs(identity) {
+		return OrgRecord{}, apierrors.NewForbiddenError(
+			fmt.Errorf("%s is not a member of any of the groups allowed to create orgs: %s", identity.Name, strings.Join(r.creatorGroups, ", ")),
+			OrgResourceType,
+		)
+	}
+
+	if r.rejectTerminatingOrgNames {
+		if err = r.checkOrgNameNotTerminating(ctx, message.Name); err != nil {
+			return OrgRecord{}, err
+		}
+	}
+
 	cfOrg := &korifiv1alpha1.CFOrg{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        OrgPrefix + uuid.NewString(),
 			Namespace:   r.rootNamespace,
-			Labels:      message.Labels,
-			Annotations: message.Annotations,
+			Labels:      withoutReservedOrgKeys(message.Labels),
+			Annotations: withCreatedBy(withoutReservedOrgKeys(message.Annotations), identity),
 		},
 		Spec: korifiv1alpha1.CFOrgSpec{
 			DisplayName: message.Name,
+			Suspended:   message.Suspended,
 		},
 	}
 
+	span.SetAttributes(TraceGUIDKey.String(cfOrg.Name))
+
 	err = userClient.Create(ctx, cfOrg)
 	if err != nil {
 		return OrgRecord{}, fmt.Errorf("failed to create cf org: %w", apierrors.FromK8sError(err, OrgResourceType))
 	}
+	recordEvent(r.eventRecorder, cfOrg, "OrgCreated", "Org %q created by %s", message.Name, identity.Name)
+
+	if message.Async {
+		return cfOrgToOrgRecord(*cfOrg), nil
+	}
 
-	cfOrg, err = r.conditionAwaiter.AwaitCondition(ctx, userClient, cfOrg, StatusConditionReady)
+	watchCtx, watchSpan := startSpan(ctx, r.tracer, "watch", OrgResourceType, cfOrg.Name)
+	awaitStart := time.Now()
+	cfOrg, err = r.conditionAwaiter.AwaitCondition(watchCtx, userClient, cfOrg, StatusConditionReady)
+	endSpan(watchSpan, err)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return OrgRecord{}, NamespaceProvisionTimeoutError{ResourceType: OrgResourceType, Elapsed: time.Since(awaitStart), Err: err}
+	}
 	if err != nil {
 		return OrgRecord{}, apierrors.FromK8sError(err, OrgResourceType)
 	}
@@ -108,21 +226,129 @@ func (r *OrgRepo) CreateOrg(ctx context.Context, info authorization.Info, messag
 	return cfOrgToOrgRecord(*cfOrg), nil
 }
 
-func (r *OrgRepo) ListOrgs(ctx context.Context, info authorization.Info, filter ListOrgsMessage) ([]OrgRecord, error) {
+// checkOrgNameNotTerminating rejects the name of an org that is still being
+// deleted. All orgs are checked, including the ones the user cannot see.
+func (r *OrgRepo) checkOrgNameNotTerminating(ctx context.Context, name string) error {
+	cfOrgList := new(korifiv1alpha1.CFOrgList)
+	err := r.privilegedClient.List(ctx, cfOrgList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return fmt.Errorf("failed to list orgs: %w", apierrors.FromK8sError(err, OrgResourceType))
+	}
+
+	for _, cfOrg := range cfOrgList.Items {
+		if cfOrg.Spec.DisplayName == name && !cfOrg.DeletionTimestamp.IsZero() {
+			return apierrors.NewUnprocessableEntityError(
+				fmt.Errorf("org %q is still being deleted", cfOrg.Name),
+				fmt.Sprintf("Org '%s' is still being deleted. Try again once the deletion has completed.", name),
+			)
+		}
+	}
+
+	return nil
+}
+
+// withoutReservedOrgKeys drops the keys korifi uses to identify orgs from user
+// supplied metadata, so that they cannot be spoofed
+func withoutReservedOrgKeys(metadata map[string]string) map[string]string {
+	if metadata == nil {
+		return nil
+	}
+
+	result := map[string]string{}
+	for key, value := range metadata {
+		if key == korifiv1alpha1.OrgNameKey || key == korifiv1alpha1.OrgGUIDKey {
+			continue
+		}
+		result[key] = value
+	}
+
+	return result
+}
+
+// mayCreateOrgs returns whether the identity belongs to one of the groups
+// allowed to create orgs. When no groups are configured anyone may try.
+func (r *OrgRepo) mayCreateOrgs(identity authorization.Identity) bool {
+	if len(r.creatorGroups) == 0 {
+		return true
+	}
+
+	allowedGroups := NewSet(r.creatorGroups...)
+	for _, group := range identity.Groups {
+		if allowedGroups.Includes(group) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetOrgProvisionStatus reports whether the namespace of an org has been set up
+// and whether the user has been granted access to it yet
+func (r *OrgRepo) GetOrgProvisionStatus(ctx context.Context, info authorization.Info, orgGUID string) (OrgProvisionStatusRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(info)
+	if err != nil {
+		return OrgProvisionStatusRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrg := new(korifiv1alpha1.CFOrg)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: orgGUID}, cfOrg)
+	if err != nil {
+		return OrgProvisionStatusRecord{}, apierrors.FromK8sError(err, OrgResourceType)
+	}
+
 	authorizedNamespaces, err := r.nsPerms.GetAuthorizedOrgNamespaces(ctx, info)
 	if err != nil {
-		return nil, err
+		return OrgProvisionStatusRecord{}, err
+	}
+
+	namespaceReady := meta.IsStatusConditionTrue(cfOrg.Status.Conditions, StatusConditionReady)
+	permissionsReady := authorizedNamespaces[orgGUID]
+
+	return OrgProvisionStatusRecord{
+		GUID:             orgGUID,
+		NamespaceReady:   namespaceReady,
+		PermissionsReady: permissionsReady,
+		Ready:            namespaceReady && permissionsReady,
+	}, nil
+}
+
+func (r *OrgRepo) ListOrgs(ctx context.Context, info authorization.Info, filter ListOrgsMessage) ([]OrgRecord, error) {
+	records, _, err := r.ListOrgsPage(ctx, info, filter)
+	return records, err
+}
+
+// ListOrgsPage lists the orgs like ListOrgs and also describes the returned
+// page, so that pagination links can be built
+func (r *OrgRepo) ListOrgsPage(ctx context.Context, info authorization.Info, filter ListOrgsMessage) (_ []OrgRecord, _ PageInfo, err error) {
+	ctx, span := startSpan(ctx, r.tracer, "ListOrgs", OrgResourceType, "")
+	defer func() { endSpan(span, err) }()
+
+	labelSelector, err := labels.Parse(filter.LabelSelector)
+	if err != nil {
+		return nil, PageInfo{}, apierrors.NewUnprocessableEntityError(err, "invalid label selector")
+	}
+
+	annotationPredicate, err := AnnotationSelectorPredicate(filter.AnnotationSelector, func(o korifiv1alpha1.CFOrg) map[string]string { return o.Annotations })
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	permsCtx, permsSpan := startSpan(ctx, r.tracer, "permissions", OrgResourceType, "")
+	authorizedNamespaces, err := r.nsPerms.GetAuthorizedOrgNamespaces(permsCtx, info)
+	endSpan(permsSpan, err)
+	if err != nil {
+		return nil, PageInfo{}, err
 	}
 
 	userClient, err := r.userClientFactory.BuildClient(info)
 	if err != nil {
-		return []OrgRecord{}, fmt.Errorf("failed to build user client: %w", err)
+		return []OrgRecord{}, PageInfo{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
 	cfOrgList := new(korifiv1alpha1.CFOrgList)
-	err = userClient.List(ctx, cfOrgList, client.InNamespace(r.rootNamespace))
+	err = userClient.List(ctx, cfOrgList, client.InNamespace(r.rootNamespace), client.MatchingLabelsSelector{Selector: labelSelector})
 	if err != nil {
-		return nil, apierrors.FromK8sError(err, OrgResourceType)
+		return nil, PageInfo{}, apierrors.FromK8sError(err, OrgResourceType)
 	}
 
 	preds := []func(korifiv1alpha1.CFOrg) bool{
@@ -134,6 +360,10 @@ func (r *OrgRepo) ListOrgs(ctx context.Context, info authorization.Info, filter
 		},
 		SetPredicate(filter.GUIDs, func(s korifiv1alpha1.CFOrg) string { return s.Name }),
 		SetPredicate(filter.Names, func(s korifiv1alpha1.CFOrg) string { return s.Spec.DisplayName }),
+		func(o korifiv1alpha1.CFOrg) bool {
+			return filter.CreatedBy == "" || o.Annotations[CreatedByAnnotation] == filter.CreatedBy
+		},
+		annotationPredicate,
 	}
 
 	var records []OrgRecord
@@ -141,10 +371,58 @@ func (r *OrgRepo) ListOrgs(ctx context.Context, info authorization.Info, filter
 		records = append(records, cfOrgToOrgRecord(o))
 	}
 
+	records, pageInfo := Paginate(records, func(o OrgRecord) string { return o.GUID }, filter.Page, filter.PerPage)
+
+	return records, pageInfo, nil
+}
+
+// ListProvisioningOrgs returns the orgs that are still being created, i.e.
+// whose CFOrg exists but is not ready yet. Besides the orgs the user has been
+// granted access to, it includes the ones the user created, as access is only
+// granted once the org namespace has been set up.
+func (r *OrgRepo) ListProvisioningOrgs(ctx context.Context, info authorization.Info) ([]OrgRecord, error) {
+	identity, err := r.identityProvider.GetIdentity(ctx, info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	authorizedNamespaces, err := r.nsPerms.GetAuthorizedOrgNamespaces(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrgList := new(korifiv1alpha1.CFOrgList)
+	err = userClient.List(ctx, cfOrgList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, OrgResourceType)
+	}
+
+	provisioningOrgs := Filter(cfOrgList.Items,
+		func(o korifiv1alpha1.CFOrg) bool {
+			return !meta.IsStatusConditionTrue(o.Status.Conditions, StatusConditionReady)
+		},
+		func(o korifiv1alpha1.CFOrg) bool {
+			return authorizedNamespaces[o.Name] || o.Annotations[CreatedByAnnotation] == identity.Name
+		},
+	)
+
+	records := []OrgRecord{}
+	for _, o := range provisioningOrgs {
+		records = append(records, cfOrgToOrgRecord(o))
+	}
+
 	return records, nil
 }
 
-func (r *OrgRepo) GetOrg(ctx context.Context, info authorization.Info, orgGUID string) (OrgRecord, error) {
+func (r *OrgRepo) GetOrg(ctx context.Context, info authorization.Info, orgGUID string) (_ OrgRecord, err error) {
+	ctx, span := startSpan(ctx, r.tracer, "GetOrg", OrgResourceType, orgGUID)
+	defer func() { endSpan(span, err) }()
+
 	orgRecords, err := r.ListOrgs(ctx, info, ListOrgsMessage{GUIDs: []string{orgGUID}})
 	if err != nil {
 		return OrgRecord{}, err
@@ -157,19 +435,75 @@ func (r *OrgRepo) GetOrg(ctx context.Context, info authorization.Info, orgGUID s
 	return orgRecords[0], nil
 }
 
+// ListOrgHierarchies returns the number of spaces in each org the user can see
+// and which of them are stuck, i.e. not ready
+func (r *OrgRepo) ListOrgHierarchies(ctx context.Context, info authorization.Info) ([]OrgHierarchyRecord, error) {
+	orgs, err := r.ListOrgs(ctx, info, ListOrgsMessage{})
+	if err != nil {
+		return nil, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	records := []OrgHierarchyRecord{}
+	for _, org := range orgs {
+		cfSpaceList := new(korifiv1alpha1.CFSpaceList)
+		err = userClient.List(ctx, cfSpaceList, client.InNamespace(org.GUID))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return nil, apierrors.FromK8sError(err, SpaceResourceType)
+		}
+
+		record := OrgHierarchyRecord{
+			GUID:            org.GUID,
+			SpaceCount:      len(cfSpaceList.Items),
+			StuckSpaceGUIDs: []string{},
+		}
+		for _, cfSpace := range cfSpaceList.Items {
+			if !meta.IsStatusConditionTrue(cfSpace.Status.Conditions, StatusConditionReady) {
+				record.StuckSpaceGUIDs = append(record.StuckSpaceGUIDs, cfSpace.Name)
+			}
+		}
+		record.HasStuckSpaces = len(record.StuckSpaceGUIDs) > 0
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
 func (r *OrgRepo) DeleteOrg(ctx context.Context, info authorization.Info, message DeleteOrgMessage) error {
 	userClient, err := r.userClientFactory.BuildClient(info)
 	if err != nil {
 		return fmt.Errorf("failed to build user client: %w", err)
 	}
-	err = userClient.Delete(ctx, &korifiv1alpha1.CFOrg{
+	cfOrg := &korifiv1alpha1.CFOrg{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      message.GUID,
 			Namespace: r.rootNamespace,
 		},
-	})
+	}
+	err = userClient.Delete(ctx, cfOrg)
+	if err != nil {
+		return apierrors.FromK8sError(err, OrgResourceType)
+	}
+	recordEvent(r.eventRecorder, cfOrg, "OrgDeleted", "Org deletion requested")
+
+	if !message.Wait {
+		return nil
+	}
 
-	return apierrors.FromK8sError(err, OrgResourceType)
+	err = r.conditionAwaiter.AwaitDeletion(ctx, userClient, cfOrg)
+	if err != nil {
+		return fmt.Errorf("failed to await org deletion: %w", apierrors.FromK8sError(err, OrgResourceType))
+	}
+
+	return nil
 }
 
 func (r *OrgRepo) PatchOrgMetadata(ctx context.Context, authInfo authorization.Info, message PatchOrgMetadataMessage) (OrgRecord, error) {
@@ -194,6 +528,35 @@ func (r *OrgRepo) PatchOrgMetadata(ctx context.Context, authInfo authorization.I
 	return cfOrgToOrgRecord(*cfOrg), nil
 }
 
+// UpdateOrg renames the org when a name is given and applies the metadata patch
+func (r *OrgRepo) UpdateOrg(ctx context.Context, authInfo authorization.Info, message UpdateOrgMessage) (_ OrgRecord, err error) {
+	ctx, span := startSpan(ctx, r.tracer, "UpdateOrg", OrgResourceType, message.GUID)
+	defer func() { endSpan(span, err) }()
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return OrgRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrg := new(korifiv1alpha1.CFOrg)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: message.GUID}, cfOrg)
+	if err != nil {
+		return OrgRecord{}, fmt.Errorf("failed to get org: %w", apierrors.FromK8sError(err, OrgResourceType))
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfOrg, func() {
+		if message.Name != nil {
+			cfOrg.Spec.DisplayName = *message.Name
+		}
+		message.Apply(cfOrg)
+	})
+	if err != nil {
+		return OrgRecord{}, apierrors.FromK8sError(err, OrgResourceType)
+	}
+
+	return cfOrgToOrgRecord(*cfOrg), nil
+}
+
 func (r *OrgRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, orgGUID string) (*time.Time, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -216,11 +579,60 @@ func (r *OrgRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info,
 	return nil, err
 }
 
+// GetOrgFeatureFlag returns the value the org overrides the feature flag
+// with, or nil when the org uses the platform value
+func (r *OrgRepo) GetOrgFeatureFlag(ctx context.Context, authInfo authorization.Info, orgGUID, flag string) (*bool, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrg := new(korifiv1alpha1.CFOrg)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: orgGUID}, cfOrg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get org: %w", apierrors.FromK8sError(err, OrgResourceType))
+	}
+
+	return featureFlagOverride(cfOrg, flag), nil
+}
+
+// SetOrgFeatureFlag overrides the platform value of the feature flag in the
+// org. A nil value removes the override.
+func (r *OrgRepo) SetOrgFeatureFlag(ctx context.Context, authInfo authorization.Info, orgGUID, flag string, enabled *bool) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrg := new(korifiv1alpha1.CFOrg)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: orgGUID}, cfOrg)
+	if err != nil {
+		return fmt.Errorf("failed to get org: %w", apierrors.FromK8sError(err, OrgResourceType))
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfOrg, func() {
+		if enabled == nil {
+			delete(cfOrg.Annotations, FeatureFlagAnnotationPrefix+flag)
+			return
+		}
+
+		if cfOrg.Annotations == nil {
+			cfOrg.Annotations = map[string]string{}
+		}
+		cfOrg.Annotations[FeatureFlagAnnotationPrefix+flag] = strconv.FormatBool(*enabled)
+	})
+	if err != nil {
+		return apierrors.FromK8sError(err, OrgResourceType)
+	}
+
+	return nil
+}
+
 func cfOrgToOrgRecord(cfOrg korifiv1alpha1.CFOrg) OrgRecord {
 	return OrgRecord{
 		GUID:        cfOrg.Name,
 		Name:        cfOrg.Spec.DisplayName,
-		Suspended:   false,
+		Suspended:   cfOrg.Spec.Suspended,
 		Labels:      cfOrg.Labels,
 		Annotations: cfOrg.Annotations,
 		CreatedAt:   cfOrg.CreationTimestamp.Time,
@@ -228,3 +640,67 @@ func cfOrgToOrgRecord(cfOrg korifiv1alpha1.CFOrg) OrgRecord {
 		DeletedAt:   golangTime(cfOrg.DeletionTimestamp),
 	}
 }
+
+// acquireSpaceCreationSlot blocks until fewer than the maximum number of
+// spaces are being created in the org, so that bursts of space creations are
+// queued rather than all hitting the cluster at once. The returned function
+// releases the slot.
+//
+// The slots are kept in memory, so the limit applies to each API replica
+// separately rather than to the cluster as a whole.
+func (r *OrgRepo) acquireSpaceCreationSlot(ctx context.Context, orgGUID string) (func(), error) {
+	if r.maxConcurrentSpaceCreations <= 0 {
+		return func() {}, nil
+	}
+
+	slots := r.referenceSpaceCreationSlots(orgGUID)
+	select {
+	case slots.ch <- struct{}{}:
+		return func() {
+			<-slots.ch
+			r.unreferenceSpaceCreationSlots(orgGUID)
+		}, nil
+	case <-ctx.Done():
+		r.unreferenceSpaceCreationSlots(orgGUID)
+		return nil, SpaceCreationThrottledError{OrgGUID: orgGUID, Err: ctx.Err()}
+	}
+}
+
+// spaceCreationSlots are the creation slots of an org, along with the number
+// of requests holding or waiting for one of them
+type spaceCreationSlots struct {
+	ch   chan struct{}
+	refs int
+}
+
+func (r *OrgRepo) referenceSpaceCreationSlots(orgGUID string) *spaceCreationSlots {
+	r.spaceCreationSlotsMutex.Lock()
+	defer r.spaceCreationSlotsMutex.Unlock()
+
+	slots, ok := r.spaceCreationSlots[orgGUID]
+	if !ok {
+		slots = &spaceCreationSlots{ch: make(chan struct{}, r.maxConcurrentSpaceCreations)}
+		r.spaceCreationSlots[orgGUID] = slots
+	}
+	slots.refs++
+
+	return slots
+}
+
+// unreferenceSpaceCreationSlots drops the slots of an org once no request
+// uses them anymore, so that the map does not grow with every org that ever
+// had a space created, including deleted ones
+func (r *OrgRepo) unreferenceSpaceCreationSlots(orgGUID string) {
+	r.spaceCreationSlotsMutex.Lock()
+	defer r.spaceCreationSlotsMutex.Unlock()
+
+	slots, ok := r.spaceCreationSlots[orgGUID]
+	if !ok {
+		return
+	}
+
+	slots.refs--
+	if slots.refs <= 0 {
+		delete(r.spaceCreationSlots, orgGUID)
+	}
+}
@@ -3,9 +3,13 @@ package repositories_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"sort"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/authorization/testhelpers"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -17,9 +21,13 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -39,7 +47,7 @@ var _ = Describe("OrgRepository", func() {
 			korifiv1alpha1.CFOrgList,
 			*korifiv1alpha1.CFOrgList,
 		]{}
-		orgRepo = repositories.NewOrgRepo(rootNamespace, k8sClient, userClientFactory, nsPerms, conditionAwaiter)
+		orgRepo = repositories.NewOrgRepo(rootNamespace, k8sClient, userClientFactory, nsPerms, idProvider, conditionAwaiter, nil, nil, repositories.OrgRepoConfig{})
 	})
 
 	Describe("CreateOrg", func() {
@@ -49,6 +57,10 @@ var _ = Describe("OrgRepository", func() {
 			orgRecord        repositories.OrgRecord
 			conditionStatus  metav1.ConditionStatus
 			conditionMessage string
+			async            bool
+			suspended        bool
+			labels           map[string]string
+			annotations      map[string]string
 		)
 
 		BeforeEach(func() {
@@ -80,17 +92,23 @@ var _ = Describe("OrgRepository", func() {
 			orgGUID = prefixedGUID("org")
 			conditionStatus = metav1.ConditionTrue
 			conditionMessage = ""
+			async = false
+			suspended = false
+			labels = map[string]string{
+				"test-label-key": "test-label-val",
+			}
+			annotations = map[string]string{
+				"test-annotation-key": "test-annotation-val",
+			}
 		})
 
 		JustBeforeEach(func() {
 			orgRecord, createErr = orgRepo.CreateOrg(ctx, authInfo, repositories.CreateOrgMessage{
-				Name: orgGUID,
-				Labels: map[string]string{
-					"test-label-key": "test-label-val",
-				},
-				Annotations: map[string]string{
-					"test-annotation-key": "test-annotation-val",
-				},
+				Name:        orgGUID,
+				Labels:      labels,
+				Annotations: annotations,
+				Async:       async,
+				Suspended:   suspended,
 			})
 		})
 
@@ -112,7 +130,10 @@ var _ = Describe("OrgRepository", func() {
 				Expect(orgRecord.UpdatedAt).To(PointTo(BeTemporally("~", time.Now(), timeCheckThreshold)))
 				Expect(orgRecord.DeletedAt).To(BeNil())
 				Expect(orgRecord.Labels).To(Equal(map[string]string{"test-label-key": "test-label-val"}))
-				Expect(orgRecord.Annotations).To(Equal(map[string]string{"test-annotation-key": "test-annotation-val"}))
+				Expect(orgRecord.Annotations).To(Equal(map[string]string{
+					"test-annotation-key":            "test-annotation-val",
+					repositories.CreatedByAnnotation: userName,
+				}))
 			})
 
 			It("creates a CFOrg resource in the root namespace", func() {
@@ -123,7 +144,108 @@ var _ = Describe("OrgRepository", func() {
 
 				Expect(cfOrg.Spec.DisplayName).To(Equal(orgGUID))
 				Expect(cfOrg.Labels).To(Equal(map[string]string{"test-label-key": "test-label-val"}))
-				Expect(cfOrg.Annotations).To(Equal(map[string]string{"test-annotation-key": "test-annotation-val"}))
+				Expect(cfOrg.Annotations).To(Equal(map[string]string{
+					"test-annotation-key":            "test-annotation-val",
+					repositories.CreatedByAnnotation: userName,
+				}))
+			})
+
+			When("the org is suspended", func() {
+				BeforeEach(func() {
+					suspended = true
+				})
+
+				It("suspends the CFOrg", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(orgRecord.Suspended).To(BeTrue())
+
+					cfOrg := new(korifiv1alpha1.CFOrg)
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: orgRecord.GUID}, cfOrg)).To(Succeed())
+					Expect(cfOrg.Spec.Suspended).To(BeTrue())
+				})
+
+				It("returns the suspended org from GetOrg", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					createRoleBinding(ctx, userName, orgUserRole.Name, orgRecord.GUID)
+
+					fetchedOrg, err := orgRepo.GetOrg(ctx, authInfo, orgRecord.GUID)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fetchedOrg.Suspended).To(BeTrue())
+				})
+			})
+
+			When("the labels and annotations contain reserved keys", func() {
+				BeforeEach(func() {
+					labels[korifiv1alpha1.OrgNameKey] = "not-the-org-name"
+					labels[korifiv1alpha1.OrgGUIDKey] = "not-the-org-guid"
+					annotations[korifiv1alpha1.OrgNameKey] = "not-the-org-name"
+					annotations[repositories.CreatedByAnnotation] = "someone-else"
+				})
+
+				It("does not set them on the CFOrg", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					cfOrg := new(korifiv1alpha1.CFOrg)
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: orgRecord.GUID}, cfOrg)).To(Succeed())
+
+					Expect(cfOrg.Labels).To(Equal(map[string]string{"test-label-key": "test-label-val"}))
+					Expect(cfOrg.Annotations).To(Equal(map[string]string{
+						"test-annotation-key":            "test-annotation-val",
+						repositories.CreatedByAnnotation: userName,
+					}))
+				})
+			})
+
+			When("an org with the same name is still being deleted", func() {
+				BeforeEach(func() {
+					terminatingOrg := &korifiv1alpha1.CFOrg{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:       prefixedGUID("terminating-org"),
+							Namespace:  rootNamespace,
+							Finalizers: []string{"korifi.cloudfoundry.org/test"},
+						},
+						Spec: korifiv1alpha1.CFOrgSpec{
+							DisplayName: orgGUID,
+						},
+					}
+					Expect(k8sClient.Create(ctx, terminatingOrg)).To(Succeed())
+					Expect(k8sClient.Delete(ctx, terminatingOrg)).To(Succeed())
+					DeferCleanup(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, terminatingOrg, func() {
+							terminatingOrg.Finalizers = nil
+						})).To(Succeed())
+					})
+				})
+
+				It("creates the org", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(orgRecord.Name).To(Equal(orgGUID))
+				})
+
+				When("terminating org names are rejected", func() {
+					BeforeEach(func() {
+						orgRepo = repositories.NewOrgRepo(rootNamespace, k8sClient, userClientFactory, nsPerms, idProvider, conditionAwaiter, nil, nil, repositories.OrgRepoConfig{RejectTerminatingOrgNames: true})
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						Expect(createErr.(apierrors.UnprocessableEntityError).Detail()).To(Equal(
+							fmt.Sprintf("Org '%s' is still being deleted. Try again once the deletion has completed.", orgGUID),
+						))
+						Expect(conditionAwaiter.AwaitConditionCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			When("terminating org names are rejected but no org with the same name is being deleted", func() {
+				BeforeEach(func() {
+					orgRepo = repositories.NewOrgRepo(rootNamespace, k8sClient, userClientFactory, nsPerms, idProvider, conditionAwaiter, nil, nil, repositories.OrgRepoConfig{RejectTerminatingOrgNames: true})
+				})
+
+				It("creates the org", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(orgRecord.Name).To(Equal(orgGUID))
+				})
 			})
 
 			It("awaits the ready condition", func() {
@@ -139,6 +261,70 @@ var _ = Describe("OrgRepository", func() {
 				Expect(conditionType).To(Equal(shared.StatusConditionReady))
 			})
 
+			When("event recording is enabled", func() {
+				var eventRecorder *record.FakeRecorder
+
+				BeforeEach(func() {
+					eventRecorder = record.NewFakeRecorder(10)
+					orgRepo = repositories.NewOrgRepo(rootNamespace, k8sClient, userClientFactory, nsPerms, idProvider, conditionAwaiter, eventRecorder, nil, repositories.OrgRepoConfig{})
+				})
+
+				It("records an OrgCreated event", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(eventRecorder.Events).To(Receive(Equal(
+						fmt.Sprintf("Normal OrgCreated Org %q created by %s", orgGUID, userName),
+					)))
+				})
+			})
+
+			When("tracing is enabled", func() {
+				var spanRecorder *tracetest.SpanRecorder
+
+				BeforeEach(func() {
+					spanRecorder = tracetest.NewSpanRecorder()
+					tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+					orgRepo = repositories.NewOrgRepo(rootNamespace, k8sClient, userClientFactory, nsPerms, idProvider, conditionAwaiter, nil, tracerProvider.Tracer("test"), repositories.OrgRepoConfig{})
+				})
+
+				It("records a span for the create with a child span for the watch", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					spans := spanRecorder.Ended()
+					Expect(spans).To(HaveLen(2))
+
+					watchSpan, createSpan := spans[0], spans[1]
+					Expect(createSpan.Name()).To(Equal("CreateOrg"))
+					Expect(createSpan.Parent().IsValid()).To(BeFalse())
+					Expect(createSpan.Attributes()).To(ConsistOf(
+						repositories.TraceOperationKey.String("CreateOrg"),
+						repositories.TraceResourceTypeKey.String(repositories.OrgResourceType),
+						repositories.TraceGUIDKey.String(orgRecord.GUID),
+					))
+
+					Expect(watchSpan.Name()).To(Equal("watch"))
+					Expect(watchSpan.Parent().SpanID()).To(Equal(createSpan.SpanContext().SpanID()))
+					Expect(watchSpan.Attributes()).To(ContainElement(repositories.TraceGUIDKey.String(orgRecord.GUID)))
+				})
+
+				When("the org does not become ready", func() {
+					BeforeEach(func() {
+						conditionAwaiter.AwaitConditionReturns(&korifiv1alpha1.CFOrg{}, errors.New("time-out-err"))
+					})
+
+					It("records the error on both spans", func() {
+						Expect(createErr).To(HaveOccurred())
+
+						spans := spanRecorder.Ended()
+						Expect(spans).To(HaveLen(2))
+						for _, span := range spans {
+							Expect(span.Status().Code).To(Equal(codes.Error))
+							Expect(span.Status().Description).To(ContainSubstring("time-out-err"))
+							Expect(span.Events()).To(ContainElement(HaveField("Name", "exception")))
+						}
+					})
+				})
+			})
+
 			When("the org does not become ready", func() {
 				BeforeEach(func() {
 					conditionAwaiter.AwaitConditionReturns(&korifiv1alpha1.CFOrg{}, errors.New("time-out-err"))
@@ -149,6 +335,111 @@ var _ = Describe("OrgRepository", func() {
 				})
 			})
 
+			When("the org is not provisioned within the timeout", func() {
+				BeforeEach(func() {
+					conditionAwaiter.AwaitConditionReturns(&korifiv1alpha1.CFOrg{}, fmt.Errorf("time-out-err: %w", context.DeadlineExceeded))
+				})
+
+				It("returns a namespace provision timeout error", func() {
+					var timeoutErr repositories.NamespaceProvisionTimeoutError
+					Expect(errors.As(createErr, &timeoutErr)).To(BeTrue())
+					Expect(timeoutErr.ResourceType).To(Equal(repositories.OrgResourceType))
+					Expect(timeoutErr.Elapsed).To(BeNumerically(">=", 0))
+					Expect(timeoutErr.HttpStatus()).To(Equal(http.StatusServiceUnavailable))
+					Expect(createErr).To(MatchError(ContainSubstring("time-out-err")))
+				})
+			})
+
+			When("the org is created asynchronously", func() {
+				BeforeEach(func() {
+					async = true
+				})
+
+				It("returns the org without awaiting the ready condition", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(orgRecord.Name).To(Equal(orgGUID))
+					Expect(orgRecord.GUID).To(HavePrefix("cf-org-"))
+					Expect(conditionAwaiter.AwaitConditionCallCount()).To(BeZero())
+				})
+
+				It("reports the org as not provisioned yet", func() {
+					status, err := orgRepo.GetOrgProvisionStatus(ctx, authInfo, orgRecord.GUID)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(status).To(Equal(repositories.OrgProvisionStatusRecord{GUID: orgRecord.GUID}))
+				})
+
+				When("the org namespace and permissions become ready", func() {
+					JustBeforeEach(func() {
+						Expect(createErr).NotTo(HaveOccurred())
+
+						cfOrg := &korifiv1alpha1.CFOrg{
+							ObjectMeta: metav1.ObjectMeta{Namespace: rootNamespace, Name: orgRecord.GUID},
+						}
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfOrg), cfOrg)).To(Succeed())
+						Expect(k8sClient.Create(ctx, &corev1.Namespace{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:   cfOrg.Name,
+								Labels: map[string]string{korifiv1alpha1.OrgNameKey: cfOrg.Spec.DisplayName},
+							},
+						})).To(Succeed())
+						Expect(k8s.Patch(ctx, k8sClient, cfOrg, func() {
+							cfOrg.Status.GUID = cfOrg.Name
+							meta.SetStatusCondition(&cfOrg.Status.Conditions, metav1.Condition{
+								Type:   shared.StatusConditionReady,
+								Status: metav1.ConditionTrue,
+								Reason: "blah",
+							})
+						})).To(Succeed())
+					})
+
+					It("reports the namespace as ready but the permissions as pending", func() {
+						status, err := orgRepo.GetOrgProvisionStatus(ctx, authInfo, orgRecord.GUID)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(status.NamespaceReady).To(BeTrue())
+						Expect(status.PermissionsReady).To(BeFalse())
+						Expect(status.Ready).To(BeFalse())
+					})
+
+					It("eventually reports the org as ready once the user is bound to it", func() {
+						createRoleBinding(ctx, userName, orgUserRole.Name, orgRecord.GUID)
+
+						Eventually(func(g Gomega) {
+							status, err := orgRepo.GetOrgProvisionStatus(ctx, authInfo, orgRecord.GUID)
+							g.Expect(err).NotTo(HaveOccurred())
+							g.Expect(status).To(Equal(repositories.OrgProvisionStatusRecord{
+								GUID:             orgRecord.GUID,
+								NamespaceReady:   true,
+								PermissionsReady: true,
+								Ready:            true,
+							}))
+						}).Should(Succeed())
+					})
+				})
+			})
+
+			When("org creation is restricted to groups", func() {
+				BeforeEach(func() {
+					orgRepo = repositories.NewOrgRepo(rootNamespace, k8sClient, userClientFactory, nsPerms, idProvider, conditionAwaiter, nil, nil, repositories.OrgRepoConfig{CreatorGroups: []string{"platform-admins"}})
+				})
+
+				It("fails because the user is not a member of an allowed group", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+					Expect(createErr).To(MatchError(ContainSubstring("not a member of any of the groups allowed to create orgs")))
+				})
+
+				When("the user is a member of an allowed group", func() {
+					BeforeEach(func() {
+						cert, key := testhelpers.ObtainClientCert(testEnv, userName, "platform-admins")
+						authInfo.CertData = testhelpers.JoinCertAndKey(cert, key)
+					})
+
+					It("creates the org", func() {
+						Expect(createErr).NotTo(HaveOccurred())
+						Expect(orgRecord.Name).To(Equal(orgGUID))
+					})
+				})
+			})
+
 			When("the client fails to create the org", func() {
 				BeforeEach(func() {
 					orgGUID = "this-string-has-illegal-characters-ц"
@@ -196,6 +487,44 @@ var _ = Describe("OrgRepository", func() {
 			))
 		})
 
+		It("populates the updated at time from the last update of the org", func() {
+			orgs, err := orgRepo.ListOrgs(ctx, authInfo, repositories.ListOrgsMessage{})
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, org := range orgs {
+				Expect(org.UpdatedAt).To(PointTo(BeTemporally(">=", org.CreatedAt)))
+				Expect(org.UpdatedAt).To(PointTo(BeTemporally("~", time.Now(), timeCheckThreshold)))
+			}
+		})
+
+		When("a page is requested", func() {
+			var sortedGUIDs []string
+
+			BeforeEach(func() {
+				sortedGUIDs = []string{cfOrg1.Name, cfOrg2.Name, cfOrg3.Name}
+				sort.Strings(sortedGUIDs)
+			})
+
+			It("returns the orgs of the page sorted by guid", func() {
+				orgs, pageInfo, err := orgRepo.ListOrgsPage(ctx, authInfo, repositories.ListOrgsMessage{Page: 1, PerPage: 2})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(orgs).To(HaveExactElements(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(sortedGUIDs[0])}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(sortedGUIDs[1])}),
+				))
+				Expect(pageInfo).To(Equal(repositories.PageInfo{TotalResults: 3, HasNextPage: true}))
+			})
+
+			It("returns the last page", func() {
+				orgs, pageInfo, err := orgRepo.ListOrgsPage(ctx, authInfo, repositories.ListOrgsMessage{Page: 2, PerPage: 2})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(orgs).To(HaveExactElements(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(sortedGUIDs[2])}),
+				))
+				Expect(pageInfo).To(Equal(repositories.PageInfo{TotalResults: 3, HasNextPage: false}))
+			})
+		})
+
 		When("the org is not ready", func() {
 			BeforeEach(func() {
 				meta.SetStatusCondition(&(cfOrg1.Status.Conditions), metav1.Condition{
@@ -273,6 +602,92 @@ var _ = Describe("OrgRepository", func() {
 			})
 		})
 
+		When("we filter for orgs created by the user", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, cfOrg2, func() {
+					cfOrg2.Annotations = map[string]string{repositories.CreatedByAnnotation: userName}
+				})).To(Succeed())
+				Expect(k8s.PatchResource(ctx, k8sClient, cfOrg3, func() {
+					cfOrg3.Annotations = map[string]string{repositories.CreatedByAnnotation: "someone-else"}
+				})).To(Succeed())
+			})
+
+			It("returns just those, excluding orgs without the annotation", func() {
+				orgs, err := orgRepo.ListOrgs(ctx, authInfo, repositories.ListOrgsMessage{CreatedBy: userName})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(orgs).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(cfOrg2.Name),
+					}),
+				))
+			})
+		})
+
+		When("we filter for orgs by label", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, cfOrg1, func() {
+					cfOrg1.Labels = map[string]string{"environment": "prod"}
+				})).To(Succeed())
+				Expect(k8s.PatchResource(ctx, k8sClient, cfOrg2, func() {
+					cfOrg2.Labels = map[string]string{"environment": "staging"}
+				})).To(Succeed())
+				Expect(k8s.PatchResource(ctx, k8sClient, cfOrg3, func() {
+					cfOrg3.Labels = map[string]string{"environment": "dev"}
+				})).To(Succeed())
+			})
+
+			It("returns the orgs matching the selector", func() {
+				orgs, err := orgRepo.ListOrgs(ctx, authInfo, repositories.ListOrgsMessage{LabelSelector: "environment in (prod,staging)"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(orgs).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfOrg1.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfOrg2.Name)}),
+				))
+			})
+
+			It("returns an unprocessable entity error for an invalid selector", func() {
+				_, err := orgRepo.ListOrgs(ctx, authInfo, repositories.ListOrgsMessage{LabelSelector: "environment in prod"})
+				Expect(err).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+
+		When("we filter for orgs by annotation", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, cfOrg1, func() {
+					cfOrg1.Annotations = map[string]string{"lifecycle": "retired"}
+				})).To(Succeed())
+				Expect(k8s.PatchResource(ctx, k8sClient, cfOrg2, func() {
+					cfOrg2.Annotations = map[string]string{"lifecycle": "active"}
+				})).To(Succeed())
+			})
+
+			It("returns the orgs having the annotation", func() {
+				orgs, err := orgRepo.ListOrgs(ctx, authInfo, repositories.ListOrgsMessage{AnnotationSelector: "lifecycle"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(orgs).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfOrg1.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfOrg2.Name)}),
+				))
+			})
+
+			It("returns the orgs having the annotation value", func() {
+				orgs, err := orgRepo.ListOrgs(ctx, authInfo, repositories.ListOrgsMessage{AnnotationSelector: "lifecycle=active"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(orgs).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfOrg2.Name)}),
+				))
+			})
+
+			It("returns an unprocessable entity error for an invalid selector", func() {
+				_, err := orgRepo.ListOrgs(ctx, authInfo, repositories.ListOrgsMessage{AnnotationSelector: "=active"})
+				Expect(err).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+
 		When("fetching authorized namespaces fails", func() {
 			var listErr error
 
@@ -286,6 +701,53 @@ var _ = Describe("OrgRepository", func() {
 		})
 	})
 
+	Describe("ListProvisioningOrgs", func() {
+		var (
+			provisioningOrg, createdOrg *korifiv1alpha1.CFOrg
+			orgs                        []repositories.OrgRecord
+			listErr                     error
+		)
+
+		setNotReady := func(cfOrg *korifiv1alpha1.CFOrg) {
+			meta.SetStatusCondition(&(cfOrg.Status.Conditions), metav1.Condition{
+				Type:    "Ready",
+				Status:  metav1.ConditionFalse,
+				Reason:  "Provisioning",
+				Message: "Provisioning",
+			})
+			Expect(k8sClient.Status().Update(ctx, cfOrg)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			readyOrg := createOrgWithCleanup(ctx, prefixedGUID("ready-org"))
+			createRoleBinding(ctx, userName, orgUserRole.Name, readyOrg.Name)
+
+			provisioningOrg = createOrgWithCleanup(ctx, prefixedGUID("provisioning-org"))
+			createRoleBinding(ctx, userName, orgUserRole.Name, provisioningOrg.Name)
+			setNotReady(provisioningOrg)
+
+			createdOrg = createOrgWithCleanup(ctx, prefixedGUID("created-org"))
+			Expect(k8s.PatchResource(ctx, k8sClient, createdOrg, func() {
+				createdOrg.Annotations = map[string]string{repositories.CreatedByAnnotation: userName}
+			})).To(Succeed())
+			setNotReady(createdOrg)
+
+			setNotReady(createOrgWithCleanup(ctx, prefixedGUID("other-org")))
+		})
+
+		JustBeforeEach(func() {
+			orgs, listErr = orgRepo.ListProvisioningOrgs(ctx, authInfo)
+		})
+
+		It("returns the provisioning orgs the user can see or has created", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(orgs).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(provisioningOrg.Name)}),
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(createdOrg.Name)}),
+			))
+		})
+	})
+
 	Describe("GetOrg", func() {
 		var cfOrg *korifiv1alpha1.CFOrg
 
@@ -412,6 +874,77 @@ var _ = Describe("OrgRepository", func() {
 		})
 	})
 
+	Describe("ListOrgHierarchies", func() {
+		var (
+			cfOrg, emptyOrg    *korifiv1alpha1.CFOrg
+			stuckSpace         *korifiv1alpha1.CFSpace
+			hierarchies        []repositories.OrgHierarchyRecord
+			listHierarchiesErr error
+		)
+
+		BeforeEach(func() {
+			cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+			createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+			emptyOrg = createOrgWithCleanup(ctx, prefixedGUID("empty-org"))
+			createRoleBinding(ctx, userName, orgUserRole.Name, emptyOrg.Name)
+			createOrgWithCleanup(ctx, prefixedGUID("other-org"))
+
+			createSpaceWithCleanup(ctx, cfOrg.Name, "ready-space")
+			stuckSpace = createSpaceWithCleanup(ctx, cfOrg.Name, "stuck-space")
+			meta.SetStatusCondition(&(stuckSpace.Status.Conditions), metav1.Condition{
+				Type:    "Ready",
+				Status:  metav1.ConditionFalse,
+				Reason:  "because",
+				Message: "because",
+			})
+			Expect(k8sClient.Status().Update(ctx, stuckSpace)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			hierarchies, listHierarchiesErr = orgRepo.ListOrgHierarchies(ctx, authInfo)
+		})
+
+		It("returns the space counts and stuck spaces of the orgs the user can see", func() {
+			Expect(listHierarchiesErr).NotTo(HaveOccurred())
+			Expect(hierarchies).To(ConsistOf(
+				repositories.OrgHierarchyRecord{
+					GUID:            cfOrg.Name,
+					SpaceCount:      2,
+					StuckSpaceGUIDs: []string{stuckSpace.Name},
+					HasStuckSpaces:  true,
+				},
+				repositories.OrgHierarchyRecord{
+					GUID:            emptyOrg.Name,
+					SpaceCount:      0,
+					StuckSpaceGUIDs: []string{},
+					HasStuckSpaces:  false,
+				},
+			))
+		})
+
+		When("all spaces are ready", func() {
+			BeforeEach(func() {
+				meta.SetStatusCondition(&(stuckSpace.Status.Conditions), metav1.Condition{
+					Type:    "Ready",
+					Status:  metav1.ConditionTrue,
+					Reason:  "because",
+					Message: "because",
+				})
+				Expect(k8sClient.Status().Update(ctx, stuckSpace)).To(Succeed())
+			})
+
+			It("does not flag the org", func() {
+				Expect(listHierarchiesErr).NotTo(HaveOccurred())
+				Expect(hierarchies).To(ContainElement(repositories.OrgHierarchyRecord{
+					GUID:            cfOrg.Name,
+					SpaceCount:      2,
+					StuckSpaceGUIDs: []string{},
+					HasStuckSpaces:  false,
+				}))
+			})
+		})
+	})
+
 	Describe("DeleteOrg", func() {
 		var cfOrg *korifiv1alpha1.CFOrg
 
@@ -438,6 +971,42 @@ var _ = Describe("OrgRepository", func() {
 					err = k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: cfOrg.Name}, foundCFOrg)
 					Expect(err).To(MatchError(ContainSubstring("not found")))
 				})
+
+				It("does not wait for the org to be gone", func() {
+					Expect(orgRepo.DeleteOrg(ctx, authInfo, repositories.DeleteOrgMessage{
+						GUID: cfOrg.Name,
+					})).To(Succeed())
+					Expect(conditionAwaiter.AwaitDeletionCallCount()).To(BeZero())
+				})
+			})
+
+			When("waiting for the deletion", func() {
+				It("awaits the org to be gone", func() {
+					Expect(orgRepo.DeleteOrg(ctx, authInfo, repositories.DeleteOrgMessage{
+						GUID: cfOrg.Name,
+						Wait: true,
+					})).To(Succeed())
+
+					Expect(conditionAwaiter.AwaitDeletionCallCount()).To(Equal(1))
+					obj := conditionAwaiter.AwaitDeletionArgsForCall(0)
+					Expect(obj.GetName()).To(Equal(cfOrg.Name))
+					Expect(obj.GetNamespace()).To(Equal(rootNamespace))
+				})
+
+				When("the org is not gone within the timeout", func() {
+					BeforeEach(func() {
+						conditionAwaiter.AwaitDeletionReturns(fmt.Errorf("deletion-time-out: %w", context.DeadlineExceeded))
+					})
+
+					It("returns the timeout error", func() {
+						err := orgRepo.DeleteOrg(ctx, authInfo, repositories.DeleteOrgMessage{
+							GUID: cfOrg.Name,
+							Wait: true,
+						})
+						Expect(err).To(MatchError(ContainSubstring("deletion-time-out")))
+						Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+					})
+				})
 			})
 
 			When("the org doesn't exist", func() {
@@ -674,4 +1243,177 @@ var _ = Describe("OrgRepository", func() {
 			})
 		})
 	})
+
+	Describe("UpdateOrg", func() {
+		var (
+			orgGUID   string
+			cfOrg     *korifiv1alpha1.CFOrg
+			message   repositories.UpdateOrgMessage
+			updateErr error
+			orgRecord repositories.OrgRecord
+		)
+
+		BeforeEach(func() {
+			cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org-name"))
+			Expect(k8s.PatchResource(ctx, k8sClient, cfOrg, func() {
+				cfOrg.Labels = map[string]string{
+					"key-one": "value-one",
+					"key-two": "value-two",
+				}
+			})).To(Succeed())
+			orgGUID = cfOrg.Name
+
+			message = repositories.UpdateOrgMessage{
+				Name: tools.PtrTo("new-org-name"),
+				MetadataPatch: repositories.MetadataPatch{
+					Labels: map[string]*string{
+						"key-one":   tools.PtrTo("value-one-updated"),
+						"key-two":   nil,
+						"key-three": tools.PtrTo("value-three"),
+					},
+					Annotations: map[string]*string{
+						"an-annotation": tools.PtrTo("a-value"),
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			message.GUID = orgGUID
+			orgRecord, updateErr = orgRepo.UpdateOrg(ctx, authInfo, message)
+		})
+
+		When("the user is authorized", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the updated org record", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(orgRecord.GUID).To(Equal(orgGUID))
+				Expect(orgRecord.Name).To(Equal("new-org-name"))
+				Expect(orgRecord.Labels).To(Equal(map[string]string{
+					"key-one":   "value-one-updated",
+					"key-three": "value-three",
+				}))
+				Expect(orgRecord.Annotations).To(HaveKeyWithValue("an-annotation", "a-value"))
+			})
+
+			It("updates the CFOrg", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				updatedCFOrg := new(korifiv1alpha1.CFOrg)
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfOrg), updatedCFOrg)).To(Succeed())
+				Expect(updatedCFOrg.Spec.DisplayName).To(Equal("new-org-name"))
+				Expect(updatedCFOrg.Labels).To(Equal(map[string]string{
+					"key-one":   "value-one-updated",
+					"key-three": "value-three",
+				}))
+				Expect(updatedCFOrg.Annotations).To(HaveKeyWithValue("an-annotation", "a-value"))
+			})
+
+			When("the name is not set", func() {
+				BeforeEach(func() {
+					message.Name = nil
+				})
+
+				It("keeps the org name", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+					Expect(orgRecord.Name).To(Equal(cfOrg.Spec.DisplayName))
+				})
+			})
+
+			When("the org does not exist", func() {
+				BeforeEach(func() {
+					orgGUID = "does-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+
+		When("the user is not authorized", func() {
+			It("returns a forbidden error", func() {
+				Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
+	Describe("org feature flags", func() {
+		var (
+			cfOrg    *korifiv1alpha1.CFOrg
+			enabled  *bool
+			getErr   error
+			override *bool
+		)
+
+		BeforeEach(func() {
+			cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+			enabled = tools.PtrTo(false)
+		})
+
+		JustBeforeEach(func() {
+			override, getErr = orgRepo.GetOrgFeatureFlag(ctx, authInfo, cfOrg.Name, repositories.FeatureFlagRouteCreation)
+		})
+
+		It("does not override flags by default", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(override).To(BeNil())
+		})
+
+		When("the user is an admin", func() {
+			var setErr error
+
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			JustBeforeEach(func() {
+				setErr = orgRepo.SetOrgFeatureFlag(ctx, authInfo, cfOrg.Name, repositories.FeatureFlagRouteCreation, enabled)
+				Expect(setErr).NotTo(HaveOccurred())
+				override, getErr = orgRepo.GetOrgFeatureFlag(ctx, authInfo, cfOrg.Name, repositories.FeatureFlagRouteCreation)
+			})
+
+			It("overrides the flag in the org", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(override).To(PointTo(BeFalse()))
+
+				updatedCFOrg := new(korifiv1alpha1.CFOrg)
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfOrg), updatedCFOrg)).To(Succeed())
+				Expect(updatedCFOrg.Annotations).To(HaveKeyWithValue("korifi.cloudfoundry.org/feature-flag.route_creation", "false"))
+			})
+
+			When("the override is removed", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfOrg, func() {
+						cfOrg.Annotations = map[string]string{"korifi.cloudfoundry.org/feature-flag.route_creation": "true"}
+					})).To(Succeed())
+					enabled = nil
+				})
+
+				It("no longer overrides the flag", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(override).To(BeNil())
+				})
+			})
+		})
+
+		When("the user is not an admin", func() {
+			It("does not allow overriding flags", func() {
+				err := orgRepo.SetOrgFeatureFlag(ctx, authInfo, cfOrg.Name, repositories.FeatureFlagRouteCreation, enabled)
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+
+		When("the org does not exist", func() {
+			BeforeEach(func() {
+				cfOrg = &korifiv1alpha1.CFOrg{ObjectMeta: metav1.ObjectMeta{Name: "does-not-exist"}}
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
 })
@@ -14,6 +14,7 @@ import (
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -46,6 +47,7 @@ type PackageRepo struct {
 	repositoryCreator    RepositoryCreator
 	repositoryPrefix     string
 	awaiter              ConditionAwaiter[*korifiv1alpha1.CFPackage]
+	tracer               trace.Tracer
 }
 
 func NewPackageRepo(
@@ -55,6 +57,7 @@ func NewPackageRepo(
 	repositoryCreator RepositoryCreator,
 	repositoryPrefix string,
 	awaiter ConditionAwaiter[*korifiv1alpha1.CFPackage],
+	tracer trace.Tracer,
 ) *PackageRepo {
 	return &PackageRepo{
 		userClientFactory:    userClientFactory,
@@ -63,6 +66,7 @@ func NewPackageRepo(
 		repositoryCreator:    repositoryCreator,
 		repositoryPrefix:     repositoryPrefix,
 		awaiter:              awaiter,
+		tracer:               tracer,
 	}
 }
 
@@ -139,7 +143,10 @@ type UpdatePackageSourceMessage struct {
 	RegistrySecretNames []string
 }
 
-func (r *PackageRepo) CreatePackage(ctx context.Context, authInfo authorization.Info, message CreatePackageMessage) (PackageRecord, error) {
+func (r *PackageRepo) CreatePackage(ctx context.Context, authInfo authorization.Info, message CreatePackageMessage) (_ PackageRecord, err error) {
+	ctx, span := startSpan(ctx, r.tracer, "CreatePackage", PackageResourceType, "")
+	defer func() { endSpan(span, err) }()
+
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
 		return PackageRecord{}, fmt.Errorf("failed to build user client: %w", err)
@@ -164,6 +171,8 @@ func (r *PackageRepo) CreatePackage(ctx context.Context, authInfo authorization.
 	}
 
 	cfPackage := message.toCFPackage()
+	span.SetAttributes(TraceGUIDKey.String(cfPackage.Name))
+
 	err = userClient.Create(ctx, cfPackage)
 	if err != nil {
 		return PackageRecord{}, apierrors.FromK8sError(err, PackageResourceType)
@@ -187,7 +196,9 @@ func (r *PackageRepo) CreatePackage(ctx context.Context, authInfo authorization.
 		}
 	}
 
-	cfPackage, err = r.awaiter.AwaitCondition(ctx, userClient, cfPackage, workloads.InitializedConditionType)
+	watchCtx, watchSpan := startSpan(ctx, r.tracer, "watch", PackageResourceType, cfPackage.Name)
+	cfPackage, err = r.awaiter.AwaitCondition(watchCtx, userClient, cfPackage, workloads.InitializedConditionType)
+	endSpan(watchSpan, err)
 	if err != nil {
 		return PackageRecord{}, fmt.Errorf("failed waiting for Initialized condition: %w", err)
 	}
@@ -268,7 +279,10 @@ func (r *PackageRepo) UpdatePackage(ctx context.Context, authInfo authorization.
 	return r.cfPackageToPackageRecord(cfPackage), nil
 }
 
-func (r *PackageRepo) GetPackage(ctx context.Context, authInfo authorization.Info, guid string) (PackageRecord, error) {
+func (r *PackageRepo) GetPackage(ctx context.Context, authInfo authorization.Info, guid string) (_ PackageRecord, err error) {
+	ctx, span := startSpan(ctx, r.tracer, "GetPackage", PackageResourceType, guid)
+	defer func() { endSpan(span, err) }()
+
 	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, PackageResourceType)
 	if err != nil {
 		return PackageRecord{}, err
@@ -287,8 +301,13 @@ func (r *PackageRepo) GetPackage(ctx context.Context, authInfo authorization.Inf
 	return r.cfPackageToPackageRecord(cfPackage), nil
 }
 
-func (r *PackageRepo) ListPackages(ctx context.Context, authInfo authorization.Info, message ListPackagesMessage) ([]PackageRecord, error) {
-	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+func (r *PackageRepo) ListPackages(ctx context.Context, authInfo authorization.Info, message ListPackagesMessage) (_ []PackageRecord, err error) {
+	ctx, span := startSpan(ctx, r.tracer, "ListPackages", PackageResourceType, "")
+	defer func() { endSpan(span, err) }()
+
+	permsCtx, permsSpan := startSpan(ctx, r.tracer, "permissions", PackageResourceType, "")
+	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(permsCtx, authInfo)
+	endSpan(permsSpan, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
 	}
@@ -54,6 +54,7 @@ var _ = Describe("PackageRepository", func() {
 			repoCreator,
 			"container.registry/foo/my/prefix-",
 			conditionAwaiter,
+			nil,
 		)
 		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
 		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	corev1 "k8s.io/api/core/v1"
@@ -21,18 +22,65 @@ const (
 	ProcessResourceType = "Process"
 )
 
-func NewProcessRepo(namespaceRetriever NamespaceRetriever, userClientFactory authorization.UserK8sClientFactory, namespacePermissions *authorization.NamespacePermissions) *ProcessRepo {
+// ProcessRepoConfig holds the configurable limits and behaviours of the
+// process repository
+type ProcessRepoConfig struct {
+	// InheritedMetadataKeys lists the app labels and annotations that
+	// processes inherit from their app
+	InheritedMetadataKeys     []string
+	ReconcileFailureThreshold time.Duration
+	// MaxInstances caps the instances a process can be scaled to. Zero
+	// means unlimited.
+	MaxInstances int
+	// MaxDiskQuotaMB caps the disk quota of a process. Zero means
+	// unlimited. It does not apply to DefaultDiskQuotaMB.
+	MaxDiskQuotaMB int64
+	// DefaultDiskQuotaMB is the disk quota of processes created without
+	// one. Zero leaves it to the process defaulting webhook.
+	DefaultDiskQuotaMB int64
+	// MaxMemoryMB caps the memory of a process. Zero means unlimited.
+	MaxMemoryMB int64
+	// HPAIntegration tells whether the instances of hpa-managed processes
+	// are owned by a horizontal pod autoscaler
+	HPAIntegration bool
+	// PropagateProcessTypeEnv tells whether the process env includes
+	// CF_PROCESS_TYPE, as set by the process controller
+	PropagateProcessTypeEnv bool
+}
+
+func NewProcessRepo(
+	namespaceRetriever NamespaceRetriever,
+	userClientFactory authorization.UserK8sClientFactory,
+	namespacePermissions *authorization.NamespacePermissions,
+	config ProcessRepoConfig,
+) *ProcessRepo {
 	return &ProcessRepo{
-		namespaceRetriever:   namespaceRetriever,
-		clientFactory:        userClientFactory,
-		namespacePermissions: namespacePermissions,
+		namespaceRetriever:        namespaceRetriever,
+		clientFactory:             userClientFactory,
+		namespacePermissions:      namespacePermissions,
+		inheritedMetadataKeys:     config.InheritedMetadataKeys,
+		reconcileFailureThreshold: config.ReconcileFailureThreshold,
+		maxInstances:              config.MaxInstances,
+		maxDiskQuotaMB:            config.MaxDiskQuotaMB,
+		defaultDiskQuotaMB:        config.DefaultDiskQuotaMB,
+		maxMemoryMB:               config.MaxMemoryMB,
+		hpaIntegration:            config.HPAIntegration,
+		propagateProcessTypeEnv:   config.PropagateProcessTypeEnv,
 	}
 }
 
 type ProcessRepo struct {
-	namespaceRetriever   NamespaceRetriever
-	clientFactory        authorization.UserK8sClientFactory
-	namespacePermissions *authorization.NamespacePermissions
+	namespaceRetriever        NamespaceRetriever
+	clientFactory             authorization.UserK8sClientFactory
+	namespacePermissions      *authorization.NamespacePermissions
+	inheritedMetadataKeys     []string
+	reconcileFailureThreshold time.Duration
+	maxInstances              int
+	maxDiskQuotaMB            int64
+	defaultDiskQuotaMB        int64
+	maxMemoryMB               int64
+	hpaIntegration            bool
+	propagateProcessTypeEnv   bool
 }
 
 type ProcessRecord struct {
@@ -49,6 +97,7 @@ type ProcessRecord struct {
 	Annotations      map[string]string
 	CreatedAt        time.Time
 	UpdatedAt        *time.Time
+	ReconcileFailure *ReconcileFailure
 }
 
 type HealthCheck struct {
@@ -99,6 +148,28 @@ type PatchProcessMessage struct {
 	MetadataPatch                       *MetadataPatch
 }
 
+// ProcessEnvRecord holds the environment variables the application
+// container of the process instances is started with
+type ProcessEnvRecord struct {
+	ProcessGUID string
+	AppGUID     string
+	SpaceGUID   string
+	Env         map[string]string
+}
+
+// ProcessConvergenceRecord compares the instances of a process that are
+// desired with the ones that are ready
+type ProcessConvergenceRecord struct {
+	ProcessGUID      string
+	DesiredInstances int
+	ReadyInstances   int
+	Converged        bool
+	// UnconvergedFor is how long the process has been waiting for its
+	// instances to match the desired ones since it was last changed. It is
+	// zero when the process has converged.
+	UnconvergedFor time.Duration
+}
+
 type ListProcessesMessage struct {
 	AppGUIDs  []string
 	SpaceGUID string
@@ -121,7 +192,131 @@ func (r *ProcessRepo) GetProcess(ctx context.Context, authInfo authorization.Inf
 		return ProcessRecord{}, fmt.Errorf("failed to get process %q: %w", processGUID, apierrors.FromK8sError(err, ProcessResourceType))
 	}
 
-	return cfProcessToProcessRecord(process), nil
+	return r.cfProcessToProcessRecord(process), nil
+}
+
+// GetProcessConvergence reports whether the instances of the process have
+// caught up with its desired instances. A stopped app desires no instances.
+// Ready instances are counted from the pods of the current revision of the
+// process whose application container is ready, so that instances of a
+// previous revision that are still draining do not count.
+func (r *ProcessRepo) GetProcessConvergence(ctx context.Context, authInfo authorization.Info, processGUID string) (ProcessConvergenceRecord, error) {
+	process, err := r.GetProcess(ctx, authInfo, processGUID)
+	if err != nil {
+		return ProcessConvergenceRecord{}, err
+	}
+
+	userClient, err := r.clientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ProcessConvergenceRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfApp := new(korifiv1alpha1.CFApp)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: process.SpaceGUID, Name: process.AppGUID}, cfApp)
+	if err != nil {
+		return ProcessConvergenceRecord{}, fmt.Errorf("failed to get app %q: %w", process.AppGUID, apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	desiredInstances := process.DesiredInstances
+	if cfApp.Spec.DesiredState == korifiv1alpha1.StoppedState {
+		desiredInstances = 0
+	}
+
+	appRev := cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey]
+	if appRev == "" {
+		appRev = korifiv1alpha1.CFAppRevisionKeyDefault
+	}
+
+	podList := corev1.PodList{}
+	err = userClient.List(ctx, &podList, client.InNamespace(process.SpaceGUID), client.MatchingLabels{
+		korifiv1alpha1.CFAppGUIDLabelKey:     process.AppGUID,
+		korifiv1alpha1.CFProcessTypeLabelKey: process.Type,
+		"korifi.cloudfoundry.org/version":    appRev,
+	})
+	if err != nil {
+		return ProcessConvergenceRecord{}, fmt.Errorf("failed to list pods for process %q: %w", processGUID, apierrors.FromK8sError(err, PodResourceType))
+	}
+
+	readyInstances := len(Filter(podList.Items, appContainerReady))
+	record := ProcessConvergenceRecord{
+		ProcessGUID:      process.GUID,
+		DesiredInstances: desiredInstances,
+		ReadyInstances:   readyInstances,
+		Converged:        readyInstances == desiredInstances,
+	}
+
+	if !record.Converged {
+		changedAt := process.CreatedAt
+		if process.UpdatedAt != nil {
+			changedAt = *process.UpdatedAt
+		}
+		record.UnconvergedFor = time.Since(changedAt)
+	}
+
+	return record, nil
+}
+
+// GetProcessEnv returns the environment of the process instances as the
+// container sees it. It is built by the same helpers the process controller
+// uses for the workload env, with the values of the secret references
+// resolved, and does not include the instance specific CF_INSTANCE_*
+// variables set by the runner.
+func (r *ProcessRepo) GetProcessEnv(ctx context.Context, authInfo authorization.Info, processGUID string) (ProcessEnvRecord, error) {
+	process, err := r.GetProcess(ctx, authInfo, processGUID)
+	if err != nil {
+		return ProcessEnvRecord{}, err
+	}
+
+	userClient, err := r.clientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ProcessEnvRecord{}, fmt.Errorf("get-process-env: failed to build user k8s client: %w", err)
+	}
+
+	cfApp := new(korifiv1alpha1.CFApp)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: process.SpaceGUID, Name: process.AppGUID}, cfApp)
+	if err != nil {
+		return ProcessEnvRecord{}, fmt.Errorf("failed to get app %q: %w", process.AppGUID, apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	appEnv, err := env.NewWorkloadEnvBuilder(userClient).BuildEnv(ctx, cfApp)
+	if err != nil {
+		return ProcessEnvRecord{}, apierrors.FromK8sError(err, AppEnvResourceType)
+	}
+
+	routeList := new(korifiv1alpha1.CFRouteList)
+	err = userClient.List(ctx, routeList, client.InNamespace(process.SpaceGUID))
+	if err != nil {
+		return ProcessEnvRecord{}, fmt.Errorf("failed to list routes: %w", apierrors.FromK8sError(err, RouteResourceType))
+	}
+	ports := env.ProcessPorts(routeList.Items, process.AppGUID, process.Type)
+
+	processEnv := map[string]string{}
+	secrets := map[string]*corev1.Secret{}
+	for _, envVar := range env.ProcessEnv(process.Type, ports, appEnv, r.propagateProcessTypeEnv) {
+		if envVar.ValueFrom == nil || envVar.ValueFrom.SecretKeyRef == nil {
+			processEnv[envVar.Name] = envVar.Value
+			continue
+		}
+
+		secretName := envVar.ValueFrom.SecretKeyRef.Name
+		secret, ok := secrets[secretName]
+		if !ok {
+			secret = new(corev1.Secret)
+			err = userClient.Get(ctx, client.ObjectKey{Namespace: process.SpaceGUID, Name: secretName}, secret)
+			if err != nil {
+				return ProcessEnvRecord{}, fmt.Errorf("error finding Secret %q for App %q: %w", secretName, process.AppGUID, apierrors.FromK8sError(err, AppEnvResourceType))
+			}
+			secrets[secretName] = secret
+		}
+		processEnv[envVar.Name] = string(secret.Data[envVar.ValueFrom.SecretKeyRef.Key])
+	}
+
+	return ProcessEnvRecord{
+		ProcessGUID: process.GUID,
+		AppGUID:     process.AppGUID,
+		SpaceGUID:   process.SpaceGUID,
+		Env:         processEnv,
+	}, nil
 }
 
 func (r *ProcessRepo) ListProcesses(ctx context.Context, authInfo authorization.Info, message ListProcessesMessage) ([]ProcessRecord, error) {
@@ -156,10 +351,14 @@ func (r *ProcessRepo) ListProcesses(ctx context.Context, authInfo authorization.
 		matches = append(matches, Filter(allProcesses, preds...)...)
 	}
 
-	return returnProcesses(matches)
+	return r.returnProcesses(matches)
 }
 
 func (r *ProcessRepo) ScaleProcess(ctx context.Context, authInfo authorization.Info, scaleProcessMessage ScaleProcessMessage) (ProcessRecord, error) {
+	if err := r.validateScaleValues(scaleProcessMessage.Instances, scaleProcessMessage.MemoryMB, scaleProcessMessage.DiskMB); err != nil {
+		return ProcessRecord{}, err
+	}
+
 	userClient, err := r.clientFactory.BuildClient(authInfo)
 	if err != nil {
 		return ProcessRecord{}, fmt.Errorf("get-process: failed to build user k8s client: %w", err)
@@ -171,25 +370,42 @@ func (r *ProcessRepo) ScaleProcess(ctx context.Context, authInfo authorization.I
 			Namespace: scaleProcessMessage.SpaceGUID,
 		},
 	}
+	err = userClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)
+	if err != nil {
+		return ProcessRecord{}, fmt.Errorf("failed to get process %q: %w", scaleProcessMessage.GUID, apierrors.FromK8sError(err, ProcessResourceType))
+	}
+
+	if r.isAutoscaled(cfProcess) {
+		scaleProcessMessage.Instances = nil
+	}
+
+	scaledProcess := cfProcess.DeepCopy()
+	applyScaleValues(scaledProcess, scaleProcessMessage.ProcessScaleValues)
+	err = r.validateAppQuota(ctx, userClient, scaledProcess)
+	if err != nil {
+		return ProcessRecord{}, err
+	}
+
 	err = k8s.PatchResource(ctx, userClient, cfProcess, func() {
-		if scaleProcessMessage.Instances != nil {
-			cfProcess.Spec.DesiredInstances = scaleProcessMessage.Instances
-		}
-		if scaleProcessMessage.MemoryMB != nil {
-			cfProcess.Spec.MemoryMB = *scaleProcessMessage.MemoryMB
-		}
-		if scaleProcessMessage.DiskMB != nil {
-			cfProcess.Spec.DiskQuotaMB = *scaleProcessMessage.DiskMB
-		}
+		applyScaleValues(cfProcess, scaleProcessMessage.ProcessScaleValues)
 	})
 	if err != nil {
 		return ProcessRecord{}, fmt.Errorf("failed to scale process %q: %w", scaleProcessMessage.GUID, apierrors.FromK8sError(err, ProcessResourceType))
 	}
 
-	return cfProcessToProcessRecord(*cfProcess), nil
+	return r.cfProcessToProcessRecord(*cfProcess), nil
 }
 
 func (r *ProcessRepo) CreateProcess(ctx context.Context, authInfo authorization.Info, message CreateProcessMessage) error {
+	// zero memory and disk quota are defaulted by the process webhook
+	if err := r.validateScaleValues(message.DesiredInstances, nonZero(message.MemoryMB), nonZero(message.DiskQuotaMB)); err != nil {
+		return err
+	}
+
+	if message.DiskQuotaMB == 0 {
+		message.DiskQuotaMB = r.defaultDiskQuotaMB
+	}
+
 	userClient, err := r.clientFactory.BuildClient(authInfo)
 	if err != nil {
 		return fmt.Errorf("get-process: failed to build user k8s client: %w", err)
@@ -213,6 +429,21 @@ func (r *ProcessRepo) CreateProcess(ctx context.Context, authInfo authorization.
 		},
 	}
 	process.SetStableName(message.AppGUID)
+
+	if len(r.inheritedMetadataKeys) > 0 {
+		cfApp := new(korifiv1alpha1.CFApp)
+		err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.AppGUID}, cfApp)
+		if err != nil {
+			return fmt.Errorf("failed to get app %q: %w", message.AppGUID, apierrors.FromK8sError(err, AppResourceType))
+		}
+		inheritMetadata(cfApp, process, r.inheritedMetadataKeys)
+	}
+
+	err = r.validateAppQuota(ctx, userClient, process)
+	if err != nil {
+		return err
+	}
+
 	err = userClient.Create(ctx, process)
 	return apierrors.FromK8sError(err, ProcessResourceType)
 }
@@ -238,10 +469,14 @@ func (r *ProcessRepo) GetProcessByAppTypeAndSpace(ctx context.Context, authInfo
 		}
 	}
 
-	return returnProcess(matches)
+	return r.returnProcess(matches)
 }
 
 func (r *ProcessRepo) PatchProcess(ctx context.Context, authInfo authorization.Info, message PatchProcessMessage) (ProcessRecord, error) {
+	if err := r.validateScaleValues(message.DesiredInstances, message.MemoryMB, message.DiskQuotaMB); err != nil {
+		return ProcessRecord{}, err
+	}
+
 	userClient, err := r.clientFactory.BuildClient(authInfo)
 	if err != nil {
 		return ProcessRecord{}, fmt.Errorf("failed to build user client: %w", err)
@@ -253,6 +488,28 @@ func (r *ProcessRepo) PatchProcess(ctx context.Context, authInfo authorization.I
 			Namespace: message.SpaceGUID,
 		},
 	}
+
+	if message.DesiredInstances != nil || message.MemoryMB != nil {
+		err = userClient.Get(ctx, client.ObjectKeyFromObject(updatedProcess), updatedProcess)
+		if err != nil {
+			return ProcessRecord{}, apierrors.FromK8sError(err, ProcessResourceType)
+		}
+
+		if r.isAutoscaled(updatedProcess) {
+			message.DesiredInstances = nil
+		}
+
+		patchedProcess := updatedProcess.DeepCopy()
+		applyScaleValues(patchedProcess, ProcessScaleValues{
+			Instances: message.DesiredInstances,
+			MemoryMB:  message.MemoryMB,
+		})
+		err = r.validateAppQuota(ctx, userClient, patchedProcess)
+		if err != nil {
+			return ProcessRecord{}, err
+		}
+	}
+
 	err = k8s.PatchResource(ctx, userClient, updatedProcess, func() {
 		if message.Command != nil {
 			updatedProcess.Spec.Command = *message.Command
@@ -287,10 +544,10 @@ func (r *ProcessRepo) PatchProcess(ctx context.Context, authInfo authorization.I
 		return ProcessRecord{}, apierrors.FromK8sError(err, ProcessResourceType)
 	}
 
-	return cfProcessToProcessRecord(*updatedProcess), nil
+	return r.cfProcessToProcessRecord(*updatedProcess), nil
 }
 
-func returnProcess(processes []korifiv1alpha1.CFProcess) (ProcessRecord, error) {
+func (r *ProcessRepo) returnProcess(processes []korifiv1alpha1.CFProcess) (ProcessRecord, error) {
 	if len(processes) == 0 {
 		return ProcessRecord{}, apierrors.NewNotFoundError(nil, ProcessResourceType)
 	}
@@ -298,20 +555,20 @@ func returnProcess(processes []korifiv1alpha1.CFProcess) (ProcessRecord, error)
 		return ProcessRecord{}, errors.New("duplicate processes exist")
 	}
 
-	return cfProcessToProcessRecord(processes[0]), nil
+	return r.cfProcessToProcessRecord(processes[0]), nil
 }
 
-func returnProcesses(processes []korifiv1alpha1.CFProcess) ([]ProcessRecord, error) {
+func (r *ProcessRepo) returnProcesses(processes []korifiv1alpha1.CFProcess) ([]ProcessRecord, error) {
 	processRecords := make([]ProcessRecord, 0, len(processes))
 	for _, process := range processes {
-		processRecord := cfProcessToProcessRecord(process)
+		processRecord := r.cfProcessToProcessRecord(process)
 		processRecords = append(processRecords, processRecord)
 	}
 
 	return processRecords, nil
 }
 
-func cfProcessToProcessRecord(cfProcess korifiv1alpha1.CFProcess) ProcessRecord {
+func (r *ProcessRepo) cfProcessToProcessRecord(cfProcess korifiv1alpha1.CFProcess) ProcessRecord {
 	cmd := cfProcess.Spec.Command
 	if cmd == "" {
 		cmd = cfProcess.Spec.DetectedCommand
@@ -338,5 +595,164 @@ func cfProcessToProcessRecord(cfProcess korifiv1alpha1.CFProcess) ProcessRecord
 		Annotations: cfProcess.Annotations,
 		CreatedAt:   cfProcess.CreationTimestamp.Time,
 		UpdatedAt:   getLastUpdatedTime(&cfProcess),
+		ReconcileFailure: getReconcileFailure(
+			cfProcess.Status.Conditions,
+			r.reconcileFailureThreshold,
+			StatusConditionReady,
+		),
+	}
+}
+
+// applyScaleValues sets the instances, memory and disk quota of the process
+// to the values being set
+func applyScaleValues(cfProcess *korifiv1alpha1.CFProcess, values ProcessScaleValues) {
+	if values.Instances != nil {
+		cfProcess.Spec.DesiredInstances = values.Instances
+	}
+	if values.MemoryMB != nil {
+		cfProcess.Spec.MemoryMB = *values.MemoryMB
+	}
+	if values.DiskMB != nil {
+		cfProcess.Spec.DiskQuotaMB = *values.DiskMB
+	}
+}
+
+// validateAppQuota checks that the processes of the app together stay within
+// the quota of the app once the given process is created or changed
+func (r *ProcessRepo) validateAppQuota(ctx context.Context, userClient client.Client, desiredProcess *korifiv1alpha1.CFProcess) error {
+	cfApp := new(korifiv1alpha1.CFApp)
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: desiredProcess.Namespace, Name: desiredProcess.Spec.AppRef.Name}, cfApp)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get app %q: %w", desiredProcess.Spec.AppRef.Name, apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	if cfApp.Spec.Quota == nil {
+		return nil
+	}
+
+	var processList korifiv1alpha1.CFProcessList
+	err = userClient.List(ctx, &processList, client.InNamespace(desiredProcess.Namespace))
+	if err != nil {
+		return apierrors.FromK8sError(err, ProcessResourceType)
+	}
+
+	processes := []korifiv1alpha1.CFProcess{*desiredProcess}
+	for _, process := range processList.Items {
+		if process.Spec.AppRef.Name == cfApp.Name && process.Name != desiredProcess.Name {
+			processes = append(processes, process)
+		}
+	}
+
+	var totalInstances int
+	var totalMemoryMB int64
+	for _, process := range processes {
+		instances := desiredInstances(process)
+		totalInstances += instances
+		totalMemoryMB += int64(instances) * process.Spec.MemoryMB
 	}
+
+	if cfApp.Spec.Quota.Instances != nil && totalInstances > *cfApp.Spec.Quota.Instances {
+		return apierrors.NewUnprocessableEntityError(
+			nil,
+			fmt.Sprintf("app instances cannot exceed the app quota of %d", *cfApp.Spec.Quota.Instances),
+		)
+	}
+
+	if cfApp.Spec.Quota.MemoryMB != nil && totalMemoryMB > *cfApp.Spec.Quota.MemoryMB {
+		return apierrors.NewUnprocessableEntityError(
+			nil,
+			fmt.Sprintf("app memory cannot exceed the app quota of %dMB", *cfApp.Spec.Quota.MemoryMB),
+		)
+	}
+
+	return nil
+}
+
+// desiredInstances returns the instances the process is going to run,
+// defaulting unset instances the way the process webhook does
+func desiredInstances(process korifiv1alpha1.CFProcess) int {
+	if process.Spec.DesiredInstances != nil {
+		return *process.Spec.DesiredInstances
+	}
+
+	if process.Spec.ProcessType == korifiv1alpha1.ProcessTypeWeb {
+		return 1
+	}
+
+	return 0
+}
+
+// validateScaleValues checks that the instances, memory and disk quota a
+// process is created with or changed to are within the configured maxima, and
+// that the memory and disk quota are positive. Nil values are not being set
+// and are not checked.
+func (r *ProcessRepo) validateScaleValues(instances *int, memoryMB, diskQuotaMB *int64) error {
+	if err := r.validateInstances(instances); err != nil {
+		return err
+	}
+
+	if err := validateResourceMB("memory", memoryMB, r.maxMemoryMB); err != nil {
+		return err
+	}
+
+	return validateResourceMB("disk quota", diskQuotaMB, r.maxDiskQuotaMB)
+}
+
+// isAutoscaled tells whether the instances of the process are owned by a
+// horizontal pod autoscaler. Setting the instances of such processes is
+// ignored, while their memory and disk quota can still be changed. The
+// hpa-managed annotation is only honoured when the hpa integration is
+// enabled, as the runners keep using the desired instances of the process
+// otherwise.
+func (r *ProcessRepo) isAutoscaled(cfProcess *korifiv1alpha1.CFProcess) bool {
+	return r.hpaIntegration && cfProcess.Annotations[korifiv1alpha1.CFProcessHPAManagedAnnotationKey] == "true"
+}
+
+func (r *ProcessRepo) validateInstances(instances *int) error {
+	if instances == nil {
+		return nil
+	}
+
+	if *instances < 0 {
+		return apierrors.NewUnprocessableEntityError(nil, "instances must be greater than or equal to 0")
+	}
+
+	if r.maxInstances > 0 && *instances > r.maxInstances {
+		return apierrors.NewUnprocessableEntityError(
+			nil,
+			fmt.Sprintf("instances cannot exceed the maximum of %d", r.maxInstances),
+		)
+	}
+
+	return nil
+}
+
+func validateResourceMB(resource string, valueMB *int64, maxMB int64) error {
+	if valueMB == nil {
+		return nil
+	}
+
+	if *valueMB <= 0 {
+		return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("%s must be greater than 0", resource))
+	}
+
+	if maxMB > 0 && *valueMB > maxMB {
+		return apierrors.NewUnprocessableEntityError(
+			nil,
+			fmt.Sprintf("%s cannot exceed the maximum of %dMB", resource, maxMB),
+		)
+	}
+
+	return nil
+}
+
+func nonZero(value int64) *int64 {
+	if value == 0 {
+		return nil
+	}
+
+	return &value
 }
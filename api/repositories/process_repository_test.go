@@ -9,6 +9,7 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -29,7 +30,7 @@ var _ = Describe("ProcessRepo", func() {
 	)
 
 	BeforeEach(func() {
-		processRepo = repositories.NewProcessRepo(namespaceRetriever, userClientFactory, nsPerms)
+		processRepo = repositories.NewProcessRepo(namespaceRetriever, userClientFactory, nsPerms, repositories.ProcessRepoConfig{})
 		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
 		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
 		app1GUID = prefixedGUID("app1")
@@ -79,6 +80,44 @@ var _ = Describe("ProcessRepo", func() {
 				Expect(processRecord.HealthCheck.Data.InvocationTimeoutSeconds).To(Equal(cfProcess1.Spec.HealthCheck.Data.InvocationTimeoutSeconds))
 				Expect(processRecord.HealthCheck.Data.TimeoutSeconds).To(Equal(cfProcess1.Spec.HealthCheck.Data.TimeoutSeconds))
 				Expect(processRecord.HealthCheck.Data.HTTPEndpoint).To(Equal(cfProcess1.Spec.HealthCheck.Data.HTTPEndpoint))
+				Expect(processRecord.ReconcileFailure).To(BeNil())
+			})
+
+			When("the controller keeps failing to reconcile the process", func() {
+				var failingSince time.Time
+
+				BeforeEach(func() {
+					failingSince = time.Now().Add(-5 * time.Minute).Truncate(time.Second)
+					Expect(k8s.Patch(ctx, k8sClient, cfProcess1, func() {
+						cfProcess1.Status.Conditions = []metav1.Condition{{
+							Type:               "Ready",
+							Status:             metav1.ConditionFalse,
+							Reason:             "ReconcileFailed",
+							Message:            "something went wrong",
+							LastTransitionTime: metav1.NewTime(failingSince),
+						}}
+					})).To(Succeed())
+				})
+
+				It("reports the reconcile failure", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(processRecord.ReconcileFailure).To(PointTo(Equal(repositories.ReconcileFailure{
+						Reason:  "ReconcileFailed",
+						Message: "something went wrong",
+						Since:   failingSince,
+					})))
+				})
+
+				When("the failure is more recent than the reconcile failure threshold", func() {
+					BeforeEach(func() {
+						processRepo = repositories.NewProcessRepo(namespaceRetriever, userClientFactory, nsPerms, repositories.ProcessRepoConfig{ReconcileFailureThreshold: time.Hour})
+					})
+
+					It("does not report it yet", func() {
+						Expect(getErr).NotTo(HaveOccurred())
+						Expect(processRecord.ReconcileFailure).To(BeNil())
+					})
+				})
 			})
 		})
 
@@ -127,6 +166,240 @@ var _ = Describe("ProcessRepo", func() {
 		})
 	})
 
+	Describe("GetProcessConvergence", func() {
+		var (
+			convergence repositories.ProcessConvergenceRecord
+			getErr      error
+		)
+
+		var cfApp *korifiv1alpha1.CFApp
+
+		BeforeEach(func() {
+			cfApp = createAppWithGUID(space.Name, app1GUID)
+			Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+				cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+			})).To(Succeed())
+
+			cfProcess := createProcessCR(ctx, k8sClient, process1GUID, space.Name, app1GUID)
+			Expect(k8s.PatchResource(ctx, k8sClient, cfProcess, func() {
+				cfProcess.Spec.DesiredInstances = tools.PtrTo(2)
+			})).To(Succeed())
+
+			createAppPod(space.Name, app1GUID, "web", "0", corev1.ContainerStatus{Name: "application", Ready: true})
+			createAppPod(space.Name, app1GUID, "web", "1", corev1.ContainerStatus{Name: "application", Ready: false})
+			createAppPod(space.Name, app1GUID, "worker", "0", corev1.ContainerStatus{Name: "application", Ready: true})
+		})
+
+		JustBeforeEach(func() {
+			convergence, getErr = processRepo.GetProcessConvergence(ctx, authInfo, process1GUID)
+		})
+
+		It("returns a forbidden error to users not authorized in the space", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("reports the process as not converged while its instances scale up", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(convergence.ProcessGUID).To(Equal(process1GUID))
+				Expect(convergence.DesiredInstances).To(Equal(2))
+				Expect(convergence.ReadyInstances).To(Equal(1))
+				Expect(convergence.Converged).To(BeFalse())
+				Expect(convergence.UnconvergedFor).To(BeNumerically(">", 0))
+			})
+
+			When("all the desired instances are ready", func() {
+				BeforeEach(func() {
+					createAppPod(space.Name, app1GUID, "web", "2", corev1.ContainerStatus{Name: "application", Ready: true})
+				})
+
+				It("reports the process as converged", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(convergence.ReadyInstances).To(Equal(2))
+					Expect(convergence.Converged).To(BeTrue())
+					Expect(convergence.UnconvergedFor).To(BeZero())
+				})
+			})
+
+			When("a ready instance belongs to a previous revision of the app", func() {
+				BeforeEach(func() {
+					pod := createAppPod(space.Name, app1GUID, "web", "2", corev1.ContainerStatus{Name: "application", Ready: true})
+					Expect(k8s.PatchResource(ctx, k8sClient, pod, func() {
+						pod.Labels["korifi.cloudfoundry.org/version"] = "0"
+					})).To(Succeed())
+				})
+
+				It("does not count it", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(convergence.ReadyInstances).To(Equal(1))
+					Expect(convergence.Converged).To(BeFalse())
+				})
+			})
+
+			When("the app is stopped", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Spec.DesiredState = korifiv1alpha1.StoppedState
+					})).To(Succeed())
+				})
+
+				It("desires no instances", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(convergence.DesiredInstances).To(BeZero())
+					Expect(convergence.ReadyInstances).To(Equal(1))
+					Expect(convergence.Converged).To(BeFalse())
+				})
+			})
+		})
+	})
+
+	Describe("GetProcessEnv", func() {
+		var (
+			cfApp            *korifiv1alpha1.CFApp
+			vcapServicesJSON string
+			envRecord        repositories.ProcessEnvRecord
+			getErr           error
+		)
+
+		BeforeEach(func() {
+			cfApp = createAppWithGUID(space.Name, app1GUID)
+			createProcessCR(ctx, k8sClient, process1GUID, space.Name, app1GUID)
+
+			Expect(k8sClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      cfApp.Spec.EnvSecretName,
+					Namespace: space.Name,
+				},
+				StringData: map[string]string{"RAILS_ENV": "production"},
+			})).To(Succeed())
+
+			vcapServicesJSON = `{"user-provided":[{"name":"my-upsi","credentials":{"foo":"bar"}}]}`
+			vcapServicesSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      prefixedGUID("vcap-services"),
+					Namespace: space.Name,
+				},
+				StringData: map[string]string{"VCAP_SERVICES": vcapServicesJSON},
+			}
+			Expect(k8sClient.Create(ctx, vcapServicesSecret)).To(Succeed())
+
+			Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+				cfApp.Status.VCAPServicesSecretName = vcapServicesSecret.Name
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			envRecord, getErr = processRepo.GetProcessEnv(ctx, authInfo, process1GUID)
+		})
+
+		When("the user is not authorized in the space", func() {
+			It("returns a forbidden error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the env of the process instances as the container sees it", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(envRecord.ProcessGUID).To(Equal(process1GUID))
+				Expect(envRecord.AppGUID).To(Equal(app1GUID))
+				Expect(envRecord.SpaceGUID).To(Equal(space.Name))
+				Expect(envRecord.Env).To(Equal(map[string]string{
+					"VCAP_APP_HOST": "0.0.0.0",
+					"RAILS_ENV":     "production",
+					"VCAP_SERVICES": vcapServicesJSON,
+				}))
+			})
+
+			When("a route destination of the process has a port", func() {
+				BeforeEach(func() {
+					cfRoute := &korifiv1alpha1.CFRoute{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      prefixedGUID("route"),
+							Namespace: space.Name,
+						},
+						Spec: korifiv1alpha1.CFRouteSpec{
+							Host:     prefixedGUID("host"),
+							Protocol: "http",
+							DomainRef: corev1.ObjectReference{
+								Name:      prefixedGUID("domain"),
+								Namespace: rootNamespace,
+							},
+						},
+					}
+					Expect(k8sClient.Create(ctx, cfRoute)).To(Succeed())
+
+					Expect(k8s.Patch(ctx, k8sClient, cfRoute, func() {
+						cfRoute.Status.CurrentStatus = korifiv1alpha1.ValidStatus
+						cfRoute.Status.Description = "ok"
+						cfRoute.Status.Destinations = []korifiv1alpha1.Destination{
+							{
+								GUID:        prefixedGUID("worker-dest"),
+								AppRef:      corev1.LocalObjectReference{Name: app1GUID},
+								ProcessType: "worker",
+								Port:        tools.PtrTo(9000),
+							},
+							{
+								GUID:        prefixedGUID("web-dest"),
+								AppRef:      corev1.LocalObjectReference{Name: app1GUID},
+								ProcessType: "web",
+								Port:        tools.PtrTo(8080),
+							},
+						}
+					})).To(Succeed())
+				})
+
+				It("sets the port variables to the port of the process", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(envRecord.Env).To(HaveKeyWithValue("PORT", "8080"))
+					Expect(envRecord.Env).To(HaveKeyWithValue("VCAP_APP_PORT", "8080"))
+				})
+			})
+
+			When("the process type is propagated to the env", func() {
+				BeforeEach(func() {
+					processRepo = repositories.NewProcessRepo(namespaceRetriever, userClientFactory, nsPerms, repositories.ProcessRepoConfig{PropagateProcessTypeEnv: true})
+				})
+
+				It("includes CF_PROCESS_TYPE as the process controller does", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(envRecord.Env).To(HaveKeyWithValue("CF_PROCESS_TYPE", "web"))
+				})
+			})
+
+			When("the service bindings of the app change", func() {
+				BeforeEach(func() {
+					vcapServicesJSON = `{}`
+					vcapServicesSecret := &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      prefixedGUID("vcap-services"),
+							Namespace: space.Name,
+						},
+						StringData: map[string]string{"VCAP_SERVICES": vcapServicesJSON},
+					}
+					Expect(k8sClient.Create(ctx, vcapServicesSecret)).To(Succeed())
+
+					Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+						cfApp.Status.VCAPServicesSecretName = vcapServicesSecret.Name
+					})).To(Succeed())
+				})
+
+				It("returns the current VCAP_SERVICES", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(envRecord.Env).To(HaveKeyWithValue("VCAP_SERVICES", "{}"))
+				})
+			})
+		})
+	})
+
 	Describe("ListProcesses", func() {
 		var (
 			app2GUID       string
@@ -301,6 +574,181 @@ var _ = Describe("ProcessRepo", func() {
 				Expect(updatedCFProcess.Spec.MemoryMB).To(Equal(memoryScaleMB))
 			})
 
+			It("allows scaling down to zero instances", func() {
+				scaleProcessMessage.ProcessScaleValues = repositories.ProcessScaleValues{Instances: tools.PtrTo(0)}
+				scaleProcessRecord, scaleProcessErr := processRepo.ScaleProcess(ctx, authInfo, *scaleProcessMessage)
+				Expect(scaleProcessErr).NotTo(HaveOccurred())
+				Expect(scaleProcessRecord.DesiredInstances).To(Equal(0))
+			})
+
+			It("rejects a negative number of instances", func() {
+				scaleProcessMessage.ProcessScaleValues = repositories.ProcessScaleValues{Instances: tools.PtrTo(-1)}
+				_, scaleProcessErr := processRepo.ScaleProcess(ctx, authInfo, *scaleProcessMessage)
+				Expect(scaleProcessErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				Expect(scaleProcessErr).To(MatchError(ContainSubstring("instances must be greater than or equal to 0")))
+
+				var updatedCFProcess korifiv1alpha1.CFProcess
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Name: process1GUID, Namespace: space1.Name}, &updatedCFProcess)).To(Succeed())
+				Expect(updatedCFProcess.Spec.DesiredInstances).To(Equal(cfProcess.Spec.DesiredInstances))
+			})
+
+			When("a maximum instance count is configured", func() {
+				BeforeEach(func() {
+					processRepo = repositories.NewProcessRepo(namespaceRetriever, userClientFactory, nsPerms, repositories.ProcessRepoConfig{MaxInstances: 5})
+				})
+
+				It("allows scaling within the maximum", func() {
+					scaleProcessMessage.ProcessScaleValues = repositories.ProcessScaleValues{Instances: tools.PtrTo(5)}
+					scaleProcessRecord, scaleProcessErr := processRepo.ScaleProcess(ctx, authInfo, *scaleProcessMessage)
+					Expect(scaleProcessErr).NotTo(HaveOccurred())
+					Expect(scaleProcessRecord.DesiredInstances).To(Equal(5))
+				})
+
+				It("rejects scaling above the maximum", func() {
+					scaleProcessMessage.ProcessScaleValues = repositories.ProcessScaleValues{Instances: tools.PtrTo(6)}
+					_, scaleProcessErr := processRepo.ScaleProcess(ctx, authInfo, *scaleProcessMessage)
+					Expect(scaleProcessErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(scaleProcessErr).To(MatchError(ContainSubstring("instances cannot exceed the maximum of 5")))
+
+					var updatedCFProcess korifiv1alpha1.CFProcess
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Name: process1GUID, Namespace: space1.Name}, &updatedCFProcess)).To(Succeed())
+					Expect(updatedCFProcess.Spec.DesiredInstances).To(Equal(cfProcess.Spec.DesiredInstances))
+				})
+			})
+
+			When("a maximum disk quota is configured", func() {
+				BeforeEach(func() {
+					processRepo = repositories.NewProcessRepo(namespaceRetriever, userClientFactory, nsPerms, repositories.ProcessRepoConfig{MaxDiskQuotaMB: 1024})
+				})
+
+				It("allows scaling within the maximum", func() {
+					scaleProcessMessage.ProcessScaleValues = repositories.ProcessScaleValues{DiskMB: tools.PtrTo[int64](1024)}
+					scaleProcessRecord, scaleProcessErr := processRepo.ScaleProcess(ctx, authInfo, *scaleProcessMessage)
+					Expect(scaleProcessErr).NotTo(HaveOccurred())
+					Expect(scaleProcessRecord.DiskQuotaMB).To(BeEquivalentTo(1024))
+				})
+
+				It("rejects scaling above the maximum", func() {
+					scaleProcessMessage.ProcessScaleValues = repositories.ProcessScaleValues{DiskMB: tools.PtrTo[int64](1025)}
+					_, scaleProcessErr := processRepo.ScaleProcess(ctx, authInfo, *scaleProcessMessage)
+					Expect(scaleProcessErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(scaleProcessErr).To(MatchError(ContainSubstring("disk quota cannot exceed the maximum of 1024MB")))
+
+					var updatedCFProcess korifiv1alpha1.CFProcess
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Name: process1GUID, Namespace: space1.Name}, &updatedCFProcess)).To(Succeed())
+					Expect(updatedCFProcess.Spec.DiskQuotaMB).To(Equal(cfProcess.Spec.DiskQuotaMB))
+				})
+			})
+
+			When("memory and disk maxima are configured", func() {
+				BeforeEach(func() {
+					processRepo = repositories.NewProcessRepo(namespaceRetriever, userClientFactory, nsPerms, repositories.ProcessRepoConfig{MaxDiskQuotaMB: 1024, MaxMemoryMB: 2048})
+				})
+
+				DescribeTable("validating the memory and disk quota",
+					func(memoryMB, diskMB *int64, expectedErr string) {
+						scaleProcessMessage.ProcessScaleValues = repositories.ProcessScaleValues{
+							MemoryMB: memoryMB,
+							DiskMB:   diskMB,
+						}
+						_, scaleProcessErr := processRepo.ScaleProcess(ctx, authInfo, *scaleProcessMessage)
+						if expectedErr == "" {
+							Expect(scaleProcessErr).NotTo(HaveOccurred())
+							return
+						}
+
+						Expect(scaleProcessErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						Expect(scaleProcessErr).To(MatchError(ContainSubstring(expectedErr)))
+					},
+					Entry("zero memory", tools.PtrTo[int64](0), nil, "memory must be greater than 0"),
+					Entry("negative memory", tools.PtrTo[int64](-1), nil, "memory must be greater than 0"),
+					Entry("memory over the maximum", tools.PtrTo[int64](2049), nil, "memory cannot exceed the maximum of 2048MB"),
+					Entry("zero disk quota", nil, tools.PtrTo[int64](0), "disk quota must be greater than 0"),
+					Entry("negative disk quota", nil, tools.PtrTo[int64](-1), "disk quota must be greater than 0"),
+					Entry("disk quota over the maximum", nil, tools.PtrTo[int64](1025), "disk quota cannot exceed the maximum of 1024MB"),
+					Entry("values within the maxima", tools.PtrTo[int64](2048), tools.PtrTo[int64](1024), ""),
+					Entry("unset values", nil, nil, ""),
+				)
+			})
+
+			When("the app has a quota", func() {
+				BeforeEach(func() {
+					cfApp := createAppWithGUID(space1.Name, app1GUID)
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Spec.Quota = &korifiv1alpha1.CFAppQuota{
+							MemoryMB:  tools.PtrTo[int64](2500),
+							Instances: tools.PtrTo(4),
+						}
+					})).To(Succeed())
+
+					// a second process of the app, with one 500MB instance
+					_ = createProcessCR(ctx, k8sClient, prefixedGUID("worker"), space1.Name, app1GUID)
+				})
+
+				It("allows scaling within the app quota", func() {
+					scaleProcessMessage.ProcessScaleValues = repositories.ProcessScaleValues{Instances: tools.PtrTo(3)}
+					scaleProcessRecord, scaleProcessErr := processRepo.ScaleProcess(ctx, authInfo, *scaleProcessMessage)
+					Expect(scaleProcessErr).NotTo(HaveOccurred())
+					Expect(scaleProcessRecord.DesiredInstances).To(Equal(3))
+				})
+
+				It("rejects scaling above the app instances quota", func() {
+					scaleProcessMessage.ProcessScaleValues = repositories.ProcessScaleValues{Instances: tools.PtrTo(4)}
+					_, scaleProcessErr := processRepo.ScaleProcess(ctx, authInfo, *scaleProcessMessage)
+					Expect(scaleProcessErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(scaleProcessErr).To(MatchError(ContainSubstring("app instances cannot exceed the app quota of 4")))
+
+					var updatedCFProcess korifiv1alpha1.CFProcess
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Name: process1GUID, Namespace: space1.Name}, &updatedCFProcess)).To(Succeed())
+					Expect(updatedCFProcess.Spec.DesiredInstances).To(Equal(cfProcess.Spec.DesiredInstances))
+				})
+
+				It("rejects scaling above the app memory quota", func() {
+					scaleProcessMessage.ProcessScaleValues = repositories.ProcessScaleValues{MemoryMB: tools.PtrTo[int64](2100)}
+					_, scaleProcessErr := processRepo.ScaleProcess(ctx, authInfo, *scaleProcessMessage)
+					Expect(scaleProcessErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(scaleProcessErr).To(MatchError(ContainSubstring("app memory cannot exceed the app quota of 2500MB")))
+				})
+			})
+
+			When("the process is HPA-managed", func() {
+				BeforeEach(func() {
+					processRepo = repositories.NewProcessRepo(namespaceRetriever, userClientFactory, nsPerms, repositories.ProcessRepoConfig{HPAIntegration: true})
+					Expect(k8s.PatchResource(ctx, k8sClient, cfProcess, func() {
+						cfProcess.Annotations = map[string]string{korifiv1alpha1.CFProcessHPAManagedAnnotationKey: "true"}
+					})).To(Succeed())
+				})
+
+				It("ignores the desired instances and changes the other scale values", func() {
+					scaleProcessMessage.ProcessScaleValues = repositories.ProcessScaleValues{
+						Instances: &instanceScale,
+						MemoryMB:  &memoryScaleMB,
+					}
+					scaleProcessRecord, scaleProcessErr := processRepo.ScaleProcess(ctx, authInfo, *scaleProcessMessage)
+					Expect(scaleProcessErr).NotTo(HaveOccurred())
+					Expect(scaleProcessRecord.DesiredInstances).To(Equal(*cfProcess.Spec.DesiredInstances))
+					Expect(scaleProcessRecord.MemoryMB).To(Equal(memoryScaleMB))
+
+					var updatedCFProcess korifiv1alpha1.CFProcess
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Name: process1GUID, Namespace: space1.Name}, &updatedCFProcess)).To(Succeed())
+					Expect(updatedCFProcess.Spec.DesiredInstances).To(Equal(cfProcess.Spec.DesiredInstances))
+					Expect(updatedCFProcess.Spec.MemoryMB).To(Equal(memoryScaleMB))
+				})
+
+				When("the hpa integration is disabled", func() {
+					BeforeEach(func() {
+						processRepo = repositories.NewProcessRepo(namespaceRetriever, userClientFactory, nsPerms, repositories.ProcessRepoConfig{})
+					})
+
+					It("changes the desired instances", func() {
+						scaleProcessMessage.ProcessScaleValues = repositories.ProcessScaleValues{Instances: &instanceScale}
+						scaleProcessRecord, scaleProcessErr := processRepo.ScaleProcess(ctx, authInfo, *scaleProcessMessage)
+						Expect(scaleProcessErr).NotTo(HaveOccurred())
+						Expect(scaleProcessRecord.DesiredInstances).To(Equal(instanceScale))
+					})
+				})
+			})
+
 			When("scaling down a process to 0 instances", func() {
 				It("works", func() {
 					scaleProcessMessage.ProcessScaleValues = repositories.ProcessScaleValues{Instances: tools.PtrTo(0)}
@@ -327,14 +775,22 @@ var _ = Describe("ProcessRepo", func() {
 	})
 
 	Describe("CreateProcess", func() {
-		var createErr error
+		var (
+			createErr   error
+			diskQuotaMB int64
+		)
+
+		BeforeEach(func() {
+			diskQuotaMB = 123
+		})
+
 		JustBeforeEach(func() {
 			createErr = processRepo.CreateProcess(ctx, authInfo, repositories.CreateProcessMessage{
 				AppGUID:     app1GUID,
 				SpaceGUID:   space.Name,
 				Type:        "web",
 				Command:     "start-web",
-				DiskQuotaMB: 123,
+				DiskQuotaMB: diskQuotaMB,
 				HealthCheck: repositories.HealthCheck{
 					Type: "http",
 					Data: repositories.HealthCheckData{
@@ -379,6 +835,149 @@ var _ = Describe("ProcessRepo", func() {
 					DiskQuotaMB:      123,
 				}))
 			})
+
+			When("the process is created without a disk quota", func() {
+				BeforeEach(func() {
+					diskQuotaMB = 0
+					processRepo = repositories.NewProcessRepo(namespaceRetriever, userClientFactory, nsPerms, repositories.ProcessRepoConfig{
+						MaxDiskQuotaMB:     100,
+						DefaultDiskQuotaMB: 1024,
+					})
+				})
+
+				It("applies the configured default disk quota", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					var list korifiv1alpha1.CFProcessList
+					Expect(k8sClient.List(ctx, &list, client.InNamespace(space.Name))).To(Succeed())
+					Expect(list.Items).To(HaveLen(1))
+					Expect(list.Items[0].Spec.DiskQuotaMB).To(BeEquivalentTo(1024))
+				})
+			})
+
+			When("the disk quota exceeds the configured maximum", func() {
+				BeforeEach(func() {
+					processRepo = repositories.NewProcessRepo(namespaceRetriever, userClientFactory, nsPerms, repositories.ProcessRepoConfig{MaxDiskQuotaMB: 100})
+				})
+
+				It("rejects the process", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(createErr).To(MatchError(ContainSubstring("disk quota cannot exceed the maximum of 100MB")))
+
+					var list korifiv1alpha1.CFProcessList
+					Expect(k8sClient.List(ctx, &list, client.InNamespace(space.Name))).To(Succeed())
+					Expect(list.Items).To(BeEmpty())
+				})
+			})
+
+			When("the instances exceed the configured maximum", func() {
+				BeforeEach(func() {
+					processRepo = repositories.NewProcessRepo(namespaceRetriever, userClientFactory, nsPerms, repositories.ProcessRepoConfig{MaxInstances: 10})
+				})
+
+				It("rejects the process", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(createErr).To(MatchError(ContainSubstring("instances cannot exceed the maximum of 10")))
+
+					var list korifiv1alpha1.CFProcessList
+					Expect(k8sClient.List(ctx, &list, client.InNamespace(space.Name))).To(Succeed())
+					Expect(list.Items).To(BeEmpty())
+				})
+			})
+
+			When("the memory exceeds the configured maximum", func() {
+				BeforeEach(func() {
+					processRepo = repositories.NewProcessRepo(namespaceRetriever, userClientFactory, nsPerms, repositories.ProcessRepoConfig{MaxMemoryMB: 100})
+				})
+
+				It("rejects the process", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(createErr).To(MatchError(ContainSubstring("memory cannot exceed the maximum of 100MB")))
+				})
+			})
+
+			When("the app has a quota", func() {
+				var cfApp *korifiv1alpha1.CFApp
+
+				BeforeEach(func() {
+					cfApp = createAppWithGUID(space.Name, app1GUID)
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Spec.Quota = &korifiv1alpha1.CFAppQuota{Instances: tools.PtrTo(42)}
+					})).To(Succeed())
+				})
+
+				It("creates processes within the app quota", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+				})
+
+				When("the process exceeds the app quota", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+							cfApp.Spec.Quota.Instances = tools.PtrTo(41)
+						})).To(Succeed())
+					})
+
+					It("rejects the process", func() {
+						Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						Expect(createErr).To(MatchError(ContainSubstring("app instances cannot exceed the app quota of 41")))
+
+						var list korifiv1alpha1.CFProcessList
+						Expect(k8sClient.List(ctx, &list, client.InNamespace(space.Name))).To(Succeed())
+						Expect(list.Items).To(BeEmpty())
+					})
+				})
+			})
+
+			When("inherited app metadata keys are configured", func() {
+				BeforeEach(func() {
+					processRepo = repositories.NewProcessRepo(namespaceRetriever, userClientFactory, nsPerms, repositories.ProcessRepoConfig{InheritedMetadataKeys: []string{"inherited-label", "inherited-annotation"}})
+
+					Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFApp{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      app1GUID,
+							Namespace: space.Name,
+							Labels: map[string]string{
+								"inherited-label": "foo",
+								"other-label":     "bar",
+							},
+							Annotations: map[string]string{
+								"inherited-annotation": "baz",
+								"other-annotation":     "qux",
+							},
+						},
+						Spec: korifiv1alpha1.CFAppSpec{
+							DisplayName:  "app1",
+							DesiredState: "STOPPED",
+							Lifecycle: korifiv1alpha1.Lifecycle{
+								Type: "buildpack",
+							},
+						},
+					})).To(Succeed())
+				})
+
+				It("copies the allowlisted app metadata onto the process", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					var list korifiv1alpha1.CFProcessList
+					Expect(k8sClient.List(ctx, &list, client.InNamespace(space.Name))).To(Succeed())
+					Expect(list.Items).To(HaveLen(1))
+
+					process := list.Items[0]
+					Expect(process.Labels).To(HaveKeyWithValue("inherited-label", "foo"))
+					Expect(process.Labels).NotTo(HaveKey("other-label"))
+					Expect(process.Annotations).To(HaveKeyWithValue("inherited-annotation", "baz"))
+					Expect(process.Annotations).NotTo(HaveKey("other-annotation"))
+				})
+			})
+
+			When("inherited app metadata keys are configured and the app does not exist", func() {
+				BeforeEach(func() {
+					processRepo = repositories.NewProcessRepo(namespaceRetriever, userClientFactory, nsPerms, repositories.ProcessRepoConfig{InheritedMetadataKeys: []string{"inherited-label"}})
+				})
+
+				It("returns a not found error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
 		})
 
 		When("the user is not authorized in the space", func() {
@@ -576,6 +1175,117 @@ var _ = Describe("ProcessRepo", func() {
 					})
 				})
 
+				When("the disk quota exceeds the configured maximum", func() {
+					BeforeEach(func() {
+						processRepo = repositories.NewProcessRepo(namespaceRetriever, userClientFactory, nsPerms, repositories.ProcessRepoConfig{MaxDiskQuotaMB: 100})
+						message = repositories.PatchProcessMessage{
+							ProcessGUID: process1GUID,
+							SpaceGUID:   space.Name,
+							DiskQuotaMB: tools.PtrTo(int64(123)),
+						}
+					})
+
+					It("rejects the patch", func() {
+						_, err := processRepo.PatchProcess(ctx, authInfo, message)
+						Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						Expect(err).To(MatchError(ContainSubstring("disk quota cannot exceed the maximum of 100MB")))
+
+						var process korifiv1alpha1.CFProcess
+						Expect(k8sClient.Get(ctx, types.NamespacedName{Name: process1GUID, Namespace: space.Name}, &process)).To(Succeed())
+						Expect(process.Spec.DiskQuotaMB).To(BeEquivalentTo(3))
+					})
+				})
+
+				When("the instances exceed the configured maximum", func() {
+					BeforeEach(func() {
+						processRepo = repositories.NewProcessRepo(namespaceRetriever, userClientFactory, nsPerms, repositories.ProcessRepoConfig{MaxInstances: 10})
+						message = repositories.PatchProcessMessage{
+							ProcessGUID:      process1GUID,
+							SpaceGUID:        space.Name,
+							DesiredInstances: tools.PtrTo(11),
+						}
+					})
+
+					It("rejects the patch", func() {
+						_, err := processRepo.PatchProcess(ctx, authInfo, message)
+						Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						Expect(err).To(MatchError(ContainSubstring("instances cannot exceed the maximum of 10")))
+
+						var process korifiv1alpha1.CFProcess
+						Expect(k8sClient.Get(ctx, types.NamespacedName{Name: process1GUID, Namespace: space.Name}, &process)).To(Succeed())
+						Expect(process.Spec.DesiredInstances).To(PointTo(Equal(1)))
+					})
+				})
+
+				When("the process is HPA-managed", func() {
+					BeforeEach(func() {
+						processRepo = repositories.NewProcessRepo(namespaceRetriever, userClientFactory, nsPerms, repositories.ProcessRepoConfig{HPAIntegration: true})
+						Expect(k8s.PatchResource(ctx, k8sClient, cfProcess, func() {
+							cfProcess.Annotations = map[string]string{korifiv1alpha1.CFProcessHPAManagedAnnotationKey: "true"}
+						})).To(Succeed())
+
+						message = repositories.PatchProcessMessage{
+							ProcessGUID:      process1GUID,
+							SpaceGUID:        space.Name,
+							DesiredInstances: tools.PtrTo(42),
+							MemoryMB:         tools.PtrTo(int64(456)),
+							DiskQuotaMB:      tools.PtrTo(int64(123)),
+						}
+					})
+
+					It("ignores the desired instances and patches the memory and disk quota", func() {
+						updatedProcessRecord, err := processRepo.PatchProcess(ctx, authInfo, message)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(updatedProcessRecord.DesiredInstances).To(Equal(1))
+
+						var process korifiv1alpha1.CFProcess
+						Expect(k8sClient.Get(ctx, types.NamespacedName{Name: process1GUID, Namespace: space.Name}, &process)).To(Succeed())
+						Expect(process.Spec.DesiredInstances).To(PointTo(Equal(1)))
+						Expect(process.Spec.MemoryMB).To(BeEquivalentTo(456))
+						Expect(process.Spec.DiskQuotaMB).To(BeEquivalentTo(123))
+					})
+				})
+
+				When("the patch exceeds the app quota", func() {
+					BeforeEach(func() {
+						cfApp := createAppWithGUID(space.Name, app1GUID)
+						Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+							cfApp.Spec.Quota = &korifiv1alpha1.CFAppQuota{MemoryMB: tools.PtrTo[int64](100)}
+						})).To(Succeed())
+
+						message = repositories.PatchProcessMessage{
+							ProcessGUID:      process1GUID,
+							SpaceGUID:        space.Name,
+							DesiredInstances: tools.PtrTo(2),
+							MemoryMB:         tools.PtrTo(int64(60)),
+						}
+					})
+
+					It("rejects the patch", func() {
+						_, err := processRepo.PatchProcess(ctx, authInfo, message)
+						Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						Expect(err).To(MatchError(ContainSubstring("app memory cannot exceed the app quota of 100MB")))
+
+						var process korifiv1alpha1.CFProcess
+						Expect(k8sClient.Get(ctx, types.NamespacedName{Name: process1GUID, Namespace: space.Name}, &process)).To(Succeed())
+						Expect(process.Spec.DesiredInstances).To(PointTo(Equal(1)))
+						Expect(process.Spec.MemoryMB).To(BeEquivalentTo(2))
+					})
+
+					When("the patch stays within the app quota", func() {
+						BeforeEach(func() {
+							message.MemoryMB = tools.PtrTo(int64(50))
+						})
+
+						It("patches the process", func() {
+							updatedProcessRecord, err := processRepo.PatchProcess(ctx, authInfo, message)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(updatedProcessRecord.DesiredInstances).To(Equal(2))
+							Expect(updatedProcessRecord.MemoryMB).To(BeEquivalentTo(50))
+						})
+					})
+				})
+
 				When("only some fields are set", func() {
 					BeforeEach(func() {
 						message = repositories.PatchProcessMessage{
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -139,14 +140,19 @@ var _ = AfterEach(func() {
 })
 
 type FakeAwaiter[T conditions.RuntimeObjectWithStatusConditions, L any, PL conditions.ObjectList[L]] struct {
+	mu          sync.Mutex
 	invocations []struct {
 		obj           client.Object
 		conditionType string
 	}
 	AwaitConditionStub func(context.Context, client.WithWatch, client.Object, string) (T, error)
+
+	deletionInvocations []client.Object
+	AwaitDeletionStub   func(context.Context, client.WithWatch, client.Object) error
 }
 
 func (a *FakeAwaiter[T, L, PL]) AwaitCondition(ctx context.Context, k8sClient client.WithWatch, object client.Object, conditionType string) (T, error) {
+	a.mu.Lock()
 	a.invocations = append(a.invocations, struct {
 		obj           client.Object
 		conditionType string
@@ -154,6 +160,7 @@ func (a *FakeAwaiter[T, L, PL]) AwaitCondition(ctx context.Context, k8sClient cl
 		object,
 		conditionType,
 	})
+	a.mu.Unlock()
 
 	if a.AwaitConditionStub == nil {
 		return object.(T), nil
@@ -169,13 +176,47 @@ func (a *FakeAwaiter[T, L, PL]) AwaitConditionReturns(object T, err error) {
 }
 
 func (a *FakeAwaiter[T, L, PL]) AwaitConditionCallCount() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	return len(a.invocations)
 }
 
 func (a *FakeAwaiter[T, L, PL]) AwaitConditionArgsForCall(i int) (client.Object, string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	return a.invocations[i].obj, a.invocations[i].conditionType
 }
 
+func (a *FakeAwaiter[T, L, PL]) AwaitDeletion(ctx context.Context, k8sClient client.WithWatch, object client.Object) error {
+	a.mu.Lock()
+	a.deletionInvocations = append(a.deletionInvocations, object)
+	a.mu.Unlock()
+
+	if a.AwaitDeletionStub == nil {
+		return nil
+	}
+
+	return a.AwaitDeletionStub(ctx, k8sClient, object)
+}
+
+func (a *FakeAwaiter[T, L, PL]) AwaitDeletionReturns(err error) {
+	a.AwaitDeletionStub = func(context.Context, client.WithWatch, client.Object) error {
+		return err
+	}
+}
+
+func (a *FakeAwaiter[T, L, PL]) AwaitDeletionCallCount() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.deletionInvocations)
+}
+
+func (a *FakeAwaiter[T, L, PL]) AwaitDeletionArgsForCall(i int) client.Object {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.deletionInvocations[i]
+}
+
 func createOrgWithCleanup(ctx context.Context, displayName string) *korifiv1alpha1.CFOrg {
 	guid := uuid.NewString()
 	cfOrg := &korifiv1alpha1.CFOrg{
@@ -455,6 +496,17 @@ func createServiceBindingCR(ctx context.Context, k8sClient client.Client, servic
 	Expect(
 		k8sClient.Create(ctx, toReturn),
 	).To(Succeed())
+
+	// as if the binding controller had made the secrets available
+	for _, conditionType := range []string{repositories.BindingSecretAvailableCondition, repositories.VCAPServicesSecretAvailableCondition} {
+		meta.SetStatusCondition(&toReturn.Status.Conditions, metav1.Condition{
+			Type:   conditionType,
+			Status: metav1.ConditionTrue,
+			Reason: "SecretFound",
+		})
+	}
+	Expect(k8sClient.Status().Update(ctx, toReturn)).To(Succeed())
+
 	return toReturn
 }
 
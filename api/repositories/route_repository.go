@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
@@ -14,6 +15,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -23,16 +25,42 @@ const (
 )
 
 type RouteRepo struct {
-	namespaceRetriever   NamespaceRetriever
-	userClientFactory    authorization.UserK8sClientFactory
-	namespacePermissions *authorization.NamespacePermissions
-}
-
-func NewRouteRepo(namespaceRetriever NamespaceRetriever, userClientFactory authorization.UserK8sClientFactory, authPerms *authorization.NamespacePermissions) *RouteRepo {
+	namespaceRetriever    NamespaceRetriever
+	userClientFactory     authorization.UserK8sClientFactory
+	namespacePermissions  *authorization.NamespacePermissions
+	inheritedMetadataKeys []string
+	validateHostnames     bool
+	featureFlags          FeatureFlags
+	maxRoutesPerApp       int
+}
+
+// RouteRepoConfig holds the configurable limits and behaviours of the route
+// repository
+type RouteRepoConfig struct {
+	// InheritedMetadataKeys lists the app labels and annotations that
+	// routes inherit from the apps they are created for
+	InheritedMetadataKeys []string
+	ValidateHostnames     bool
+	FeatureFlags          FeatureFlags
+	// MaxRoutesPerApp caps the number of routes an app can be mapped to.
+	// Zero means unlimited
+	MaxRoutesPerApp int
+}
+
+func NewRouteRepo(
+	namespaceRetriever NamespaceRetriever,
+	userClientFactory authorization.UserK8sClientFactory,
+	authPerms *authorization.NamespacePermissions,
+	config RouteRepoConfig,
+) *RouteRepo {
 	return &RouteRepo{
-		namespaceRetriever:   namespaceRetriever,
-		userClientFactory:    userClientFactory,
-		namespacePermissions: authPerms,
+		namespaceRetriever:    namespaceRetriever,
+		userClientFactory:     userClientFactory,
+		namespacePermissions:  authPerms,
+		inheritedMetadataKeys: config.InheritedMetadataKeys,
+		validateHostnames:     config.ValidateHostnames,
+		featureFlags:          config.FeatureFlags,
+		maxRoutesPerApp:       config.MaxRoutesPerApp,
 	}
 }
 
@@ -42,7 +70,7 @@ type DestinationRecord struct {
 	ProcessType string
 	Port        *int
 	Protocol    *string
-	// Weight intentionally omitted as experimental features
+	Weight      *int
 }
 
 type RouteRecord struct {
@@ -60,6 +88,18 @@ type RouteRecord struct {
 	DeletedAt    *time.Time
 }
 
+// URL returns the absolute URL of the route, using the scheme of its domain.
+// The Domain of the record has to be fully populated for the scheme and name
+// to be right.
+func (r RouteRecord) URL() string {
+	hostname := r.Domain.Name
+	if r.Host != "" {
+		hostname = r.Host + "." + hostname
+	}
+
+	return r.Domain.Scheme() + "://" + hostname + r.Path
+}
+
 type AddDestinationsToRouteMessage struct {
 	RouteGUID            string
 	SpaceGUID            string
@@ -78,7 +118,15 @@ type DestinationMessage struct {
 	ProcessType string
 	Port        *int
 	Protocol    *string
-	// Weight intentionally omitted as experimental features
+	Weight      *int
+}
+
+type UpdateRouteDestinationWeightsMessage struct {
+	RouteGUID string
+	SpaceGUID string
+	// Weights maps destination guids to their new weight, a nil weight clears
+	// the weight of the destination
+	Weights map[string]*int
 }
 
 type PatchRouteMetadataMessage struct {
@@ -96,6 +144,7 @@ func (m DestinationMessage) toCFDestination() korifiv1alpha1.Destination {
 		},
 		ProcessType: m.ProcessType,
 		Protocol:    m.Protocol,
+		Weight:      m.Weight,
 	}
 }
 
@@ -114,6 +163,7 @@ type CreateRouteMessage struct {
 	DomainGUID      string
 	DomainName      string
 	DomainNamespace string
+	AppGUID         string
 	Labels          map[string]string
 	Annotations     map[string]string
 }
@@ -288,6 +338,7 @@ func cfRouteDestinationsToDestinationRecords(cfRoute korifiv1alpha1.CFRoute) []D
 			ProcessType: specDestination.ProcessType,
 			Port:        specDestination.Port,
 			Protocol:    specDestination.Protocol,
+			Weight:      specDestination.Weight,
 		}
 
 		if record.Port == nil {
@@ -315,12 +366,36 @@ func findEffectiveDestination(destGUID string, effectiveDestinations []korifiv1a
 }
 
 func (r *RouteRepo) CreateRoute(ctx context.Context, authInfo authorization.Info, message CreateRouteMessage) (RouteRecord, error) {
+	if r.validateHostnames {
+		if err := validateRouteHost(message.Host, message.DomainName); err != nil {
+			return RouteRecord{}, apierrors.NewUnprocessableEntityError(err, err.Error())
+		}
+	}
+
 	cfRoute := message.toCFRoute()
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
 		return RouteRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	cfOrg, err := r.getSpaceOrg(ctx, userClient, message.SpaceGUID)
+	if err != nil {
+		return RouteRecord{}, err
+	}
+
+	if !r.featureFlags.Enabled(cfOrg, FeatureFlagRouteCreation) {
+		return RouteRecord{}, apierrors.NewFeatureDisabledError(FeatureFlagRouteCreation)
+	}
+
+	if message.AppGUID != "" && len(r.inheritedMetadataKeys) > 0 {
+		cfApp := new(korifiv1alpha1.CFApp)
+		err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.AppGUID}, cfApp)
+		if err != nil {
+			return RouteRecord{}, fmt.Errorf("failed to get app %q: %w", message.AppGUID, apierrors.FromK8sError(err, AppResourceType))
+		}
+		inheritMetadata(cfApp, &cfRoute, r.inheritedMetadataKeys)
+	}
+
 	err = userClient.Create(ctx, &cfRoute)
 	if err != nil {
 		return RouteRecord{}, apierrors.FromK8sError(err, RouteResourceType)
@@ -329,17 +404,80 @@ func (r *RouteRepo) CreateRoute(ctx context.Context, authInfo authorization.Info
 	return cfRouteToRouteRecord(cfRoute), nil
 }
 
+// getSpaceOrg returns the org of the space, whose namespace is named after
+// the org guid
+func (r *RouteRepo) getSpaceOrg(ctx context.Context, userClient client.Client, spaceGUID string) (*korifiv1alpha1.CFOrg, error) {
+	orgGUID, err := r.namespaceRetriever.NamespaceFor(ctx, spaceGUID, SpaceResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	orgNamespace, err := r.namespaceRetriever.NamespaceFor(ctx, orgGUID, OrgResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	cfOrg := new(korifiv1alpha1.CFOrg)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: orgNamespace, Name: orgGUID}, cfOrg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get org %q: %w", orgGUID, apierrors.FromK8sError(err, OrgResourceType))
+	}
+
+	return cfOrg, nil
+}
+
+// validateRouteHost checks that the host is either empty, "*" or a valid RFC
+// 1123 label, and that "<host>.<domain>" is a valid length for a hostname. An
+// empty host is valid as the route is then for the domain itself.
+func validateRouteHost(host, domainName string) error {
+	if host == "" {
+		return nil
+	}
+
+	if domainName != "" && len(host+"."+domainName) > validation.DNS1123SubdomainMaxLength {
+		return fmt.Errorf("host %q is not valid: the hostname must not exceed %d characters", host, validation.DNS1123SubdomainMaxLength)
+	}
+
+	if host == "*" {
+		return nil
+	}
+
+	if errs := validation.IsDNS1123Label(host); len(errs) > 0 {
+		return fmt.Errorf("host %q is not valid: %s", host, strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
 func (r *RouteRepo) DeleteRoute(ctx context.Context, authInfo authorization.Info, message DeleteRouteMessage) error {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
 		return fmt.Errorf("failed to build user client: %w", err)
 	}
-	err = userClient.Delete(ctx, &korifiv1alpha1.CFRoute{
+
+	cfRoute := &korifiv1alpha1.CFRoute{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      message.GUID,
 			Namespace: message.SpaceGUID,
 		},
-	})
+	}
+	err = userClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)
+	if err != nil {
+		return apierrors.FromK8sError(err, RouteResourceType)
+	}
+
+	// Detach the route from its apps first, so that nothing keeps routing
+	// traffic to them while the route is being finalized
+	if len(cfRoute.Spec.Destinations) > 0 {
+		err = k8s.PatchResource(ctx, userClient, cfRoute, func() {
+			cfRoute.Spec.Destinations = nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to clear destinations of route %q: %w", message.GUID, apierrors.FromK8sError(err, RouteResourceType))
+		}
+	}
+
+	err = userClient.Delete(ctx, cfRoute)
 
 	return apierrors.FromK8sError(err, RouteResourceType)
 }
@@ -363,6 +501,10 @@ func (r *RouteRepo) AddDestinationsToRoute(ctx context.Context, authInfo authori
 		return RouteRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	if err = r.checkMaxRoutesPerApp(ctx, userClient, message); err != nil {
+		return RouteRecord{}, err
+	}
+
 	cfRoute := &korifiv1alpha1.CFRoute{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      message.RouteGUID,
@@ -379,6 +521,45 @@ func (r *RouteRepo) AddDestinationsToRoute(ctx context.Context, authInfo authori
 	return cfRouteToRouteRecord(*cfRoute), err
 }
 
+// checkMaxRoutesPerApp rejects adding destinations that would map an app to
+// more routes than the configured maximum. Apps already mapped to the route
+// are not counted again.
+func (r *RouteRepo) checkMaxRoutesPerApp(ctx context.Context, userClient client.Client, message AddDestinationsToRouteMessage) error {
+	if r.maxRoutesPerApp <= 0 {
+		return nil
+	}
+
+	mappedApps := map[string]bool{}
+	for _, destination := range message.ExistingDestinations {
+		mappedApps[destination.AppGUID] = true
+	}
+
+	var cfRouteList *korifiv1alpha1.CFRouteList
+	for _, destination := range message.NewDestinations {
+		if mappedApps[destination.AppGUID] {
+			continue
+		}
+		mappedApps[destination.AppGUID] = true
+
+		if cfRouteList == nil {
+			cfRouteList = &korifiv1alpha1.CFRouteList{}
+			if err := userClient.List(ctx, cfRouteList, client.InNamespace(message.SpaceGUID)); err != nil {
+				return apierrors.FromK8sError(err, RouteResourceType)
+			}
+		}
+
+		appRoutes := filterByAppDestination(cfRouteList.Items, destination.AppGUID)
+		if len(appRoutes) >= r.maxRoutesPerApp {
+			return apierrors.NewUnprocessableEntityError(
+				nil,
+				fmt.Sprintf("App %q cannot be mapped to more than %d routes", destination.AppGUID, r.maxRoutesPerApp),
+			)
+		}
+	}
+
+	return nil
+}
+
 func (r *RouteRepo) RemoveDestinationFromRoute(ctx context.Context, authInfo authorization.Info, message RemoveDestinationFromRouteMessage) (RouteRecord, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -418,6 +599,47 @@ func (r *RouteRepo) RemoveDestinationFromRoute(ctx context.Context, authInfo aut
 	return cfRouteToRouteRecord(*cfRoute), err
 }
 
+// UpdateRouteDestinationWeights sets or clears the weights of existing route
+// destinations
+func (r *RouteRepo) UpdateRouteDestinationWeights(ctx context.Context, authInfo authorization.Info, message UpdateRouteDestinationWeightsMessage) (RouteRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfRoute := &korifiv1alpha1.CFRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      message.RouteGUID,
+			Namespace: message.SpaceGUID,
+		},
+	}
+	err = userClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to get route: %w", apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	oldCfRoute := cfRoute.DeepCopy()
+
+	updated := 0
+	for i, dest := range cfRoute.Spec.Destinations {
+		if weight, ok := message.Weights[dest.GUID]; ok {
+			cfRoute.Spec.Destinations[i].Weight = weight
+			updated++
+		}
+	}
+
+	if updated != len(message.Weights) {
+		return RouteRecord{}, apierrors.NewUnprocessableEntityError(nil, "Unable to update destination weights. Ensure the route has destinations with these guids.")
+	}
+
+	err = userClient.Patch(ctx, cfRoute, client.MergeFrom(oldCfRoute))
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to update destination weights of route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return cfRouteToRouteRecord(*cfRoute), nil
+}
+
 func mergeDestinations(existingDestinations []DestinationRecord, desiredDestinations []DestinationMessage) []korifiv1alpha1.Destination {
 	destinations := destinationRecordsToCFDestinations(existingDestinations)
 
@@ -486,6 +708,7 @@ func destinationRecordsToCFDestinations(destinationRecords []DestinationRecord)
 			},
 			ProcessType: destinationRecord.ProcessType,
 			Protocol:    destinationRecord.Protocol,
+			Weight:      destinationRecord.Weight,
 		})
 	}
 
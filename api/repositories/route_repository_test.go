@@ -3,6 +3,7 @@ package repositories_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/korifi/tools"
@@ -43,7 +44,7 @@ var _ = Describe("RouteRepository", func() {
 		route1GUID = prefixedGUID("route1")
 		route2GUID = prefixedGUID("route2")
 		domainGUID = prefixedGUID("domain")
-		routeRepo = NewRouteRepo(namespaceRetriever, userClientFactory, nsPerms)
+		routeRepo = NewRouteRepo(namespaceRetriever, userClientFactory, nsPerms, RouteRepoConfig{})
 
 		cfDomain := &korifiv1alpha1.CFDomain{
 			ObjectMeta: metav1.ObjectMeta{
@@ -197,6 +198,24 @@ var _ = Describe("RouteRepository", func() {
 		})
 	})
 
+	DescribeTable("RouteRecord URL",
+		func(route RouteRecord, expectedURL string) {
+			Expect(route.URL()).To(Equal(expectedURL))
+		},
+		Entry("a route on an external domain",
+			RouteRecord{Host: "my-app", Path: "/foo", Domain: DomainRecord{Name: "apps.example.com"}},
+			"https://my-app.apps.example.com/foo",
+		),
+		Entry("a route on an internal domain",
+			RouteRecord{Host: "my-app", Domain: DomainRecord{Name: "apps.internal", Internal: true}},
+			"http://my-app.apps.internal",
+		),
+		Entry("a route without a host",
+			RouteRecord{Path: "/foo", Domain: DomainRecord{Name: "apps.example.com"}},
+			"https://apps.example.com/foo",
+		),
+	)
+
 	Describe("ListRoutes", func() {
 		var (
 			cfRoute1A, cfRoute1B *korifiv1alpha1.CFRoute
@@ -533,10 +552,12 @@ var _ = Describe("RouteRepository", func() {
 			routeHost          string
 			routePath          string
 			routeNamespace     string
+			routeAppGUID       string
 		)
 
 		BeforeEach(func() {
 			routeNamespace = space.Name
+			routeAppGUID = ""
 			routeHost = prefixedGUID("route-host-")
 			routePath = prefixedGUID("/test/route/")
 			createdRouteRecord = RouteRecord{}
@@ -550,6 +571,7 @@ var _ = Describe("RouteRepository", func() {
 				SpaceGUID:       routeNamespace,
 				DomainGUID:      domainGUID,
 				DomainNamespace: rootNamespace,
+				AppGUID:         routeAppGUID,
 			})
 		})
 
@@ -591,8 +613,164 @@ var _ = Describe("RouteRepository", func() {
 				BeforeEach(func() {
 					routeNamespace = ""
 				})
-				It("returns an error", func() {
-					Expect(createdRouteErr).To(MatchError("an empty namespace may not be set during creation"))
+				It("returns a not found error for the space", func() {
+					Expect(createdRouteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+
+			When("route creation is disabled on the platform", func() {
+				BeforeEach(func() {
+					routeRepo = NewRouteRepo(namespaceRetriever, userClientFactory, nsPerms, RouteRepoConfig{FeatureFlags: FeatureFlags{
+						FeatureFlagRouteCreation: false,
+					}})
+				})
+
+				It("returns a feature disabled error", func() {
+					Expect(createdRouteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.FeatureDisabledError{}))
+				})
+
+				When("the org overrides the flag", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, org, func() {
+							org.Annotations = map[string]string{FeatureFlagAnnotationPrefix + FeatureFlagRouteCreation: "true"}
+						})).To(Succeed())
+					})
+
+					It("creates the route", func() {
+						Expect(createdRouteErr).NotTo(HaveOccurred())
+					})
+				})
+			})
+
+			When("the org disables route creation", func() {
+				var otherSpace *korifiv1alpha1.CFSpace
+
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, org, func() {
+						org.Annotations = map[string]string{FeatureFlagAnnotationPrefix + FeatureFlagRouteCreation: "false"}
+					})).To(Succeed())
+
+					otherOrg := createOrgWithCleanup(ctx, prefixedGUID("other-org"))
+					otherSpace = createSpaceWithCleanup(ctx, otherOrg.Name, prefixedGUID("other-space"))
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, otherSpace.Name)
+				})
+
+				It("returns a feature disabled error", func() {
+					Expect(createdRouteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.FeatureDisabledError{}))
+				})
+
+				It("still creates routes in other orgs", func() {
+					_, err := routeRepo.CreateRoute(ctx, authInfo, CreateRouteMessage{
+						Host:            prefixedGUID("other-host"),
+						SpaceGUID:       otherSpace.Name,
+						DomainGUID:      domainGUID,
+						DomainNamespace: rootNamespace,
+					})
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
+			When("hostname validation is enabled", func() {
+				BeforeEach(func() {
+					routeRepo = NewRouteRepo(namespaceRetriever, userClientFactory, nsPerms, RouteRepoConfig{ValidateHostnames: true})
+				})
+
+				It("creates routes with valid hostnames", func() {
+					Expect(createdRouteErr).NotTo(HaveOccurred())
+					Expect(createdRouteRecord.Host).To(Equal(routeHost))
+				})
+
+				When("the host is a wildcard", func() {
+					BeforeEach(func() {
+						routeHost = "*"
+					})
+
+					It("creates the route", func() {
+						Expect(createdRouteErr).NotTo(HaveOccurred())
+					})
+				})
+
+				When("the host is empty", func() {
+					BeforeEach(func() {
+						routeHost = ""
+					})
+
+					It("creates the route for the domain", func() {
+						Expect(createdRouteErr).NotTo(HaveOccurred())
+						Expect(createdRouteRecord.Host).To(BeEmpty())
+					})
+				})
+
+				When("the host is too long", func() {
+					BeforeEach(func() {
+						routeHost = strings.Repeat("a", 64)
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createdRouteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						Expect(createdRouteErr).To(MatchError(ContainSubstring("must be no more than 63 characters")))
+					})
+				})
+
+				When("the host contains illegal characters", func() {
+					BeforeEach(func() {
+						routeHost = "my_host!"
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createdRouteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						Expect(createdRouteErr).To(MatchError(ContainSubstring("RFC 1123")))
+					})
+				})
+
+				When("the host starts with a hyphen", func() {
+					BeforeEach(func() {
+						routeHost = "-my-host"
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createdRouteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+			})
+
+			When("inherited app metadata keys are configured and the route is created for an app", func() {
+				BeforeEach(func() {
+					routeRepo = NewRouteRepo(namespaceRetriever, userClientFactory, nsPerms, RouteRepoConfig{InheritedMetadataKeys: []string{"inherited-label", "inherited-annotation"}})
+
+					routeAppGUID = uuid.NewString()
+					Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFApp{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      routeAppGUID,
+							Namespace: space.Name,
+							Labels: map[string]string{
+								"inherited-label": "foo",
+								"other-label":     "bar",
+							},
+							Annotations: map[string]string{
+								"inherited-annotation": "baz",
+								"other-annotation":     "qux",
+							},
+						},
+						Spec: korifiv1alpha1.CFAppSpec{
+							DisplayName:  "some-app",
+							DesiredState: "STOPPED",
+							Lifecycle: korifiv1alpha1.Lifecycle{
+								Type: "buildpack",
+							},
+						},
+					})).To(Succeed())
+				})
+
+				It("copies the allowlisted app metadata onto the route", func() {
+					Expect(createdRouteErr).NotTo(HaveOccurred())
+					createdCFRoute := new(korifiv1alpha1.CFRoute)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdRouteRecord.GUID, Namespace: space.Name}, createdCFRoute)).To(Succeed())
+
+					Expect(createdCFRoute.Labels).To(HaveKeyWithValue("inherited-label", "foo"))
+					Expect(createdCFRoute.Labels).NotTo(HaveKey("other-label"))
+					Expect(createdCFRoute.Annotations).To(HaveKeyWithValue("inherited-annotation", "baz"))
+					Expect(createdCFRoute.Annotations).NotTo(HaveKey("other-annotation"))
 				})
 			})
 		})
@@ -660,6 +838,28 @@ var _ = Describe("RouteRepository", func() {
 				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: route1GUID}, &korifiv1alpha1.CFRoute{})).To(MatchError(ContainSubstring("not found")))
 			})
 
+			When("the route is held back by a finalizer", func() {
+				BeforeEach(func() {
+					originalRoute := cfRoute.DeepCopy()
+					cfRoute.Finalizers = []string{"korifi.cloudfoundry.org/test-finalizer"}
+					Expect(k8sClient.Patch(ctx, cfRoute, client.MergeFrom(originalRoute))).To(Succeed())
+
+					DeferCleanup(func() {
+						originalRoute := cfRoute.DeepCopy()
+						cfRoute.Finalizers = nil
+						Expect(k8sClient.Patch(ctx, cfRoute, client.MergeFrom(originalRoute))).To(Succeed())
+					})
+				})
+
+				It("removes the route destinations so that apps are no longer reachable through it", func() {
+					Expect(deleteErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+					Expect(cfRoute.DeletionTimestamp).NotTo(BeNil())
+					Expect(cfRoute.Spec.Destinations).To(BeEmpty())
+				})
+			})
+
 			When("the route doesn't exist", func() {
 				BeforeEach(func() {
 					routeGUID = "i-dont-exist"
@@ -861,6 +1061,68 @@ var _ = Describe("RouteRepository", func() {
 				))
 			})
 
+			When("the number of routes per app is capped", func() {
+				BeforeEach(func() {
+					routeRepo = NewRouteRepo(namespaceRetriever, userClientFactory, nsPerms, RouteRepoConfig{MaxRoutesPerApp: 1})
+				})
+
+				It("maps the app while it is within the cap", func() {
+					Expect(addDestinationErr).NotTo(HaveOccurred())
+					Expect(cfRoute.Spec.Destinations).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{
+							"AppRef": Equal(corev1.LocalObjectReference{Name: appGUID}),
+						}),
+					))
+				})
+
+				When("the app is already mapped to as many routes as allowed", func() {
+					BeforeEach(func() {
+						Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFRoute{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      route2GUID,
+								Namespace: space.Name,
+							},
+							Spec: korifiv1alpha1.CFRouteSpec{
+								Host: "other-route-host",
+								Path: routePath,
+								DomainRef: corev1.ObjectReference{
+									Name:      domainGUID,
+									Namespace: space.Name,
+								},
+								Destinations: []korifiv1alpha1.Destination{{
+									GUID:        uuid.NewString(),
+									AppRef:      corev1.LocalObjectReference{Name: appGUID},
+									ProcessType: "web",
+								}},
+							},
+						})).To(Succeed())
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(addDestinationErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+						Expect(addDestinationErr).To(MatchError(ContainSubstring("cannot be mapped to more than 1 routes")))
+					})
+
+					It("does not add the destination", func() {
+						Expect(cfRoute.Spec.Destinations).To(BeEmpty())
+					})
+
+					When("the app is already mapped to the route", func() {
+						BeforeEach(func() {
+							addDestinationsMessage.ExistingDestinations = []DestinationRecord{{
+								GUID:        uuid.NewString(),
+								AppGUID:     appGUID,
+								ProcessType: "worker",
+							}}
+						})
+
+						It("adds the destination", func() {
+							Expect(addDestinationErr).NotTo(HaveOccurred())
+						})
+					})
+				})
+			})
+
 			When("the destination has no port and protocol set", func() {
 				BeforeEach(func() {
 					addDestinationsMessage.NewDestinations[0].Port = nil
@@ -1135,6 +1397,125 @@ var _ = Describe("RouteRepository", func() {
 		})
 	})
 
+	Describe("UpdateRouteDestinationWeights", func() {
+		var (
+			destination1GUID string
+			destination2GUID string
+			weights          map[string]*int
+			routeRecord      RouteRecord
+			updateErr        error
+		)
+
+		BeforeEach(func() {
+			destination1GUID = uuid.NewString()
+			destination2GUID = uuid.NewString()
+			weights = map[string]*int{
+				destination1GUID: tools.PtrTo(80),
+				destination2GUID: tools.PtrTo(20),
+			}
+
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      route1GUID,
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFRouteSpec{
+					Host: "test-route-host",
+					DomainRef: corev1.ObjectReference{
+						Name:      domainGUID,
+						Namespace: space.Name,
+					},
+					Destinations: []korifiv1alpha1.Destination{
+						{
+							GUID:        destination1GUID,
+							AppRef:      corev1.LocalObjectReference{Name: "blue-app"},
+							ProcessType: "web",
+							Weight:      tools.PtrTo(50),
+						},
+						{
+							GUID:        destination2GUID,
+							AppRef:      corev1.LocalObjectReference{Name: "green-app"},
+							ProcessType: "web",
+							Weight:      tools.PtrTo(50),
+						},
+					},
+				},
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			routeRecord, updateErr = routeRepo.UpdateRouteDestinationWeights(ctx, authInfo, UpdateRouteDestinationWeightsMessage{
+				RouteGUID: route1GUID,
+				SpaceGUID: space.Name,
+				Weights:   weights,
+			})
+		})
+
+		It("returns a forbidden error as the user is not authorized", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer in this space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the destinations with their weights", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(routeRecord.Destinations).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(destination1GUID), "Weight": PointTo(Equal(80))}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(destination2GUID), "Weight": PointTo(Equal(20))}),
+				))
+			})
+
+			It("sets the weights on the CFRoute destinations", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+
+				cfRoute := new(korifiv1alpha1.CFRoute)
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: route1GUID, Namespace: space.Name}, cfRoute)).To(Succeed())
+				Expect(cfRoute.Spec.Destinations).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(destination1GUID), "Weight": PointTo(Equal(80))}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(destination2GUID), "Weight": PointTo(Equal(20))}),
+				))
+			})
+
+			When("a destination isn't on the route", func() {
+				BeforeEach(func() {
+					weights["some-bogus-guid"] = tools.PtrTo(50)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("a weight is out of range", func() {
+				BeforeEach(func() {
+					weights[destination1GUID] = tools.PtrTo(101)
+				})
+
+				It("returns an error", func() {
+					Expect(updateErr).To(HaveOccurred())
+				})
+			})
+
+			When("the weights are cleared", func() {
+				BeforeEach(func() {
+					weights[destination1GUID] = nil
+					weights[destination2GUID] = nil
+				})
+
+				It("removes the weights from the CFRoute destinations", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+
+					cfRoute := new(korifiv1alpha1.CFRoute)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: route1GUID, Namespace: space.Name}, cfRoute)).To(Succeed())
+					Expect(cfRoute.Spec.Destinations).To(HaveEach(MatchFields(IgnoreExtras, Fields{"Weight": BeNil()})))
+				})
+			})
+		})
+	})
+
 	Describe("PatchRouteMetadata", func() {
 		var (
 			cfRoute                       *korifiv1alpha1.CFRoute
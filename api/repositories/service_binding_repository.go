@@ -3,6 +3,8 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/labels"
@@ -13,11 +15,15 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/services"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/maps"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -26,13 +32,20 @@ const (
 	LabelServiceBindingProvisionedService = "servicebinding.io/provisioned-service"
 	ServiceBindingResourceType            = "Service Binding"
 	ServiceBindingTypeApp                 = "app"
+
+	ServiceBindingStateInitial    = "initial"
+	ServiceBindingStateInProgress = "in progress"
+	ServiceBindingStateSucceeded  = "succeeded"
+	ServiceBindingStateFailed     = "failed"
 )
 
 type ServiceBindingRepo struct {
-	userClientFactory       authorization.UserK8sClientFactory
-	namespacePermissions    *authorization.NamespacePermissions
-	namespaceRetriever      NamespaceRetriever
-	bindingConditionAwaiter ConditionAwaiter[*korifiv1alpha1.CFServiceBinding]
+	userClientFactory         authorization.UserK8sClientFactory
+	namespacePermissions      *authorization.NamespacePermissions
+	namespaceRetriever        NamespaceRetriever
+	bindingConditionAwaiter   ConditionAwaiter[*korifiv1alpha1.CFServiceBinding]
+	reconcileFailureThreshold time.Duration
+	tracer                    trace.Tracer
 }
 
 func NewServiceBindingRepo(
@@ -40,12 +53,16 @@ func NewServiceBindingRepo(
 	userClientFactory authorization.UserK8sClientFactory,
 	namespacePermissions *authorization.NamespacePermissions,
 	bindingConditionAwaiter ConditionAwaiter[*korifiv1alpha1.CFServiceBinding],
+	reconcileFailureThreshold time.Duration,
+	tracer trace.Tracer,
 ) *ServiceBindingRepo {
 	return &ServiceBindingRepo{
-		userClientFactory:       userClientFactory,
-		namespacePermissions:    namespacePermissions,
-		namespaceRetriever:      namespaceRetriever,
-		bindingConditionAwaiter: bindingConditionAwaiter,
+		userClientFactory:         userClientFactory,
+		namespacePermissions:      namespacePermissions,
+		namespaceRetriever:        namespaceRetriever,
+		bindingConditionAwaiter:   bindingConditionAwaiter,
+		reconcileFailureThreshold: reconcileFailureThreshold,
+		tracer:                    tracer,
 	}
 }
 
@@ -61,6 +78,12 @@ type ServiceBindingRecord struct {
 	CreatedAt           time.Time
 	UpdatedAt           *time.Time
 	LastOperation       ServiceBindingLastOperation
+	ReconcileFailure    *ReconcileFailure
+	// CredentialsUpToDate reports whether the binding has been refreshed
+	// since the credentials of its service instance were last rotated
+	CredentialsUpToDate bool
+	// Orphaned reports whether the app of the binding no longer exists
+	Orphaned bool
 }
 
 type ServiceBindingLastOperation struct {
@@ -76,6 +99,9 @@ type CreateServiceBindingMessage struct {
 	ServiceInstanceGUID string
 	AppGUID             string
 	SpaceGUID           string
+	// Parameters are only checked, as the only service instances in korifi
+	// are user-provided ones and those have no broker to pass them to
+	Parameters map[string]any
 }
 
 type DeleteServiceBindingMessage struct {
@@ -85,7 +111,15 @@ type DeleteServiceBindingMessage struct {
 type ListServiceBindingsMessage struct {
 	AppGUIDs             []string
 	ServiceInstanceGUIDs []string
+	SpaceGUIDs           []string
 	LabelSelector        string
+	// OrderBy sorts the bindings by "created_at" or "updated_at", descending
+	// when prefixed with "-". Bindings are sorted by guid when it is empty.
+	OrderBy string
+	// Page and PerPage select a page of the sorted bindings. A zero PerPage
+	// lists all bindings.
+	Page    int
+	PerPage int
 }
 
 func (m CreateServiceBindingMessage) toCFServiceBinding() *korifiv1alpha1.CFServiceBinding {
@@ -109,17 +143,23 @@ func (m CreateServiceBindingMessage) toCFServiceBinding() *korifiv1alpha1.CFServ
 }
 
 type UpdateServiceBindingMessage struct {
-	GUID          string
+	GUID string
+	// Name is left untouched when nil
+	Name          *string
 	MetadataPatch MetadataPatch
 }
 
-func (r *ServiceBindingRepo) CreateServiceBinding(ctx context.Context, authInfo authorization.Info, message CreateServiceBindingMessage) (ServiceBindingRecord, error) {
+func (r *ServiceBindingRepo) CreateServiceBinding(ctx context.Context, authInfo authorization.Info, message CreateServiceBindingMessage) (_ ServiceBindingRecord, err error) {
+	ctx, span := startSpan(ctx, r.tracer, "CreateServiceBinding", ServiceBindingResourceType, "")
+	defer func() { endSpan(span, err) }()
+
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
 		return ServiceBindingRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
 	cfServiceBinding := message.toCFServiceBinding()
+	span.SetAttributes(TraceGUIDKey.String(cfServiceBinding.Name))
 
 	cfApp := new(korifiv1alpha1.CFApp)
 	err = userClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.AppRef.Name, Namespace: cfServiceBinding.Namespace}, cfApp)
@@ -133,6 +173,12 @@ func (r *ServiceBindingRepo) CreateServiceBinding(ctx context.Context, authInfo
 			)
 	}
 
+	if len(message.Parameters) > 0 {
+		if err = checkServiceInstanceAcceptsParameters(ctx, userClient, cfServiceBinding); err != nil {
+			return ServiceBindingRecord{}, err
+		}
+	}
+
 	err = userClient.Create(ctx, cfServiceBinding)
 	if err != nil {
 		if validationError, ok := webhooks.WebhookErrorToValidationError(err); ok {
@@ -144,12 +190,42 @@ func (r *ServiceBindingRepo) CreateServiceBinding(ctx context.Context, authInfo
 		return ServiceBindingRecord{}, apierrors.FromK8sError(err, ServiceBindingResourceType)
 	}
 
-	cfServiceBinding, err = r.bindingConditionAwaiter.AwaitCondition(ctx, userClient, cfServiceBinding, VCAPServicesSecretAvailableCondition)
+	watchCtx, watchSpan := startSpan(ctx, r.tracer, "watch", ServiceBindingResourceType, cfServiceBinding.Name)
+	cfServiceBinding, err = r.bindingConditionAwaiter.AwaitCondition(watchCtx, userClient, cfServiceBinding, VCAPServicesSecretAvailableCondition)
+	endSpan(watchSpan, err)
 	if err != nil {
 		return ServiceBindingRecord{}, err
 	}
 
-	return cfServiceBindingToRecord(cfServiceBinding), err
+	if err = restartAppOnConfigChange(ctx, userClient, cfServiceBinding.Namespace, cfServiceBinding.Spec.AppRef.Name); err != nil {
+		return ServiceBindingRecord{}, err
+	}
+
+	return r.toServiceBindingRecord(ctx, userClient, cfServiceBinding)
+}
+
+// checkServiceInstanceAcceptsParameters rejects binding parameters for
+// user-provided service instances, as there is no broker to pass them to
+func checkServiceInstanceAcceptsParameters(ctx context.Context, userClient client.Client, cfServiceBinding *korifiv1alpha1.CFServiceBinding) error {
+	serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+	err := userClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.Namespace}, serviceInstance)
+	if err != nil {
+		return apierrors.AsUnprocessableEntity(
+			apierrors.FromK8sError(err, ServiceBindingResourceType),
+			"Unable to use service instance. Ensure that the service instance exists and you have access to it.",
+			apierrors.ForbiddenError{},
+			apierrors.NotFoundError{},
+		)
+	}
+
+	if serviceInstance.Spec.Type == korifiv1alpha1.UserProvidedType {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("service instance %q is user-provided", serviceInstance.Name),
+			"Binding parameters are not supported for user-provided service instances",
+		)
+	}
+
+	return nil
 }
 
 func (r *ServiceBindingRepo) DeleteServiceBinding(ctx context.Context, authInfo authorization.Info, guid string) error {
@@ -170,14 +246,36 @@ func (r *ServiceBindingRepo) DeleteServiceBinding(ctx context.Context, authInfo
 		return apierrors.ForbiddenAsNotFound(apierrors.FromK8sError(err, ServiceBindingResourceType))
 	}
 
+	cfApp, err := getAppToRestartOnConfigChange(ctx, userClient, binding.Namespace, binding.Spec.AppRef.Name)
+	if err != nil {
+		return err
+	}
+
 	err = userClient.Delete(ctx, binding)
 	if err != nil {
 		return apierrors.FromK8sError(err, ServiceBindingResourceType)
 	}
-	return nil
+
+	if cfApp == nil {
+		return nil
+	}
+
+	// restarting before the binding is gone would let the app come back up
+	// with the credentials of the binding still in its environment
+	err = r.bindingConditionAwaiter.AwaitDeletion(ctx, userClient, binding)
+	if err != nil {
+		return fmt.Errorf("failed to await service binding deletion: %w", apierrors.FromK8sError(err, ServiceBindingResourceType))
+	}
+
+	return restartAppForConfigChange(ctx, userClient, cfApp)
 }
 
-func (r *ServiceBindingRepo) GetServiceBinding(ctx context.Context, authInfo authorization.Info, guid string) (ServiceBindingRecord, error) {
+// GetServiceBinding returns the service binding with the given GUID. Bindings
+// the user is not allowed to see are reported as not found.
+func (r *ServiceBindingRepo) GetServiceBinding(ctx context.Context, authInfo authorization.Info, guid string) (_ ServiceBindingRecord, err error) {
+	ctx, span := startSpan(ctx, r.tracer, "GetServiceBinding", ServiceBindingResourceType, guid)
+	defer func() { endSpan(span, err) }()
+
 	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceBindingResourceType)
 	if err != nil {
 		return ServiceBindingRecord{}, err
@@ -191,10 +289,10 @@ func (r *ServiceBindingRepo) GetServiceBinding(ctx context.Context, authInfo aut
 	serviceBinding := &korifiv1alpha1.CFServiceBinding{}
 	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: guid}, serviceBinding)
 	if err != nil {
-		return ServiceBindingRecord{}, apierrors.FromK8sError(err, ServiceBindingResourceType)
+		return ServiceBindingRecord{}, apierrors.ForbiddenAsNotFound(apierrors.FromK8sError(err, ServiceBindingResourceType))
 	}
 
-	return cfServiceBindingToRecord(serviceBinding), nil
+	return r.toServiceBindingRecord(ctx, userClient, serviceBinding)
 }
 
 func (r *ServiceBindingRepo) UpdateServiceBinding(ctx context.Context, authInfo authorization.Info, updateMsg UpdateServiceBindingMessage) (ServiceBindingRecord, error) {
@@ -221,16 +319,59 @@ func (r *ServiceBindingRepo) UpdateServiceBinding(ctx context.Context, authInfo
 	}
 
 	err = k8s.PatchResource(ctx, userClient, serviceBinding, func() {
+		if updateMsg.Name != nil {
+			serviceBinding.Spec.DisplayName = updateMsg.Name
+		}
 		updateMsg.MetadataPatch.Apply(serviceBinding)
 	})
 	if err != nil {
-		return ServiceBindingRecord{}, fmt.Errorf("failed to patch service binding metadata: %w", apierrors.FromK8sError(err, ServiceBindingResourceType))
+		return ServiceBindingRecord{}, fmt.Errorf("failed to patch service binding: %w", apierrors.FromK8sError(err, ServiceBindingResourceType))
+	}
+
+	return r.toServiceBindingRecord(ctx, userClient, serviceBinding)
+}
+
+func (r *ServiceBindingRepo) toServiceBindingRecord(ctx context.Context, userClient client.Client, binding *korifiv1alpha1.CFServiceBinding) (ServiceBindingRecord, error) {
+	credentialsGenerations := map[string]string{}
+
+	serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+	err := userClient.Get(ctx, types.NamespacedName{Namespace: binding.Namespace, Name: binding.Spec.Service.Name}, serviceInstance)
+	if client.IgnoreNotFound(err) != nil {
+		return ServiceBindingRecord{}, fmt.Errorf("failed to get service instance %s: %w",
+			binding.Spec.Service.Name,
+			apierrors.FromK8sError(err, ServiceInstanceResourceType),
+		)
+	}
+	if err == nil {
+		credentialsGenerations[serviceInstance.Name] = serviceInstance.Annotations[korifiv1alpha1.CFServiceCredentialsGenerationAnnotationKey]
 	}
 
-	return cfServiceBindingToRecord(serviceBinding), nil
+	return r.cfServiceBindingToRecord(binding, credentialsGenerations), nil
 }
 
-func cfServiceBindingToRecord(binding *korifiv1alpha1.CFServiceBinding) ServiceBindingRecord {
+// getCredentialsGenerations returns the credentials generation of each service
+// instance in a namespace, keyed by service instance guid
+func getCredentialsGenerations(ctx context.Context, userClient client.Client, namespace string) (map[string]string, error) {
+	serviceInstanceList := new(korifiv1alpha1.CFServiceInstanceList)
+	err := userClient.List(ctx, serviceInstanceList, client.InNamespace(namespace))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service instances in namespace %s: %w",
+			namespace,
+			apierrors.FromK8sError(err, ServiceInstanceResourceType),
+		)
+	}
+
+	generations := map[string]string{}
+	for _, serviceInstance := range serviceInstanceList.Items {
+		generations[serviceInstance.Name] = serviceInstance.Annotations[korifiv1alpha1.CFServiceCredentialsGenerationAnnotationKey]
+	}
+
+	return generations, nil
+}
+
+func (r *ServiceBindingRepo) cfServiceBindingToRecord(binding *korifiv1alpha1.CFServiceBinding, credentialsGenerations map[string]string) ServiceBindingRecord {
+	lastOperationState, lastOperationDescription := serviceBindingLastOperationState(binding.Status.Conditions)
+
 	return ServiceBindingRecord{
 		GUID:                binding.Name,
 		Type:                ServiceBindingTypeApp,
@@ -244,24 +385,80 @@ func cfServiceBindingToRecord(binding *korifiv1alpha1.CFServiceBinding) ServiceB
 		UpdatedAt:           getLastUpdatedTime(binding),
 		LastOperation: ServiceBindingLastOperation{
 			Type:        "create",
-			State:       "succeeded",
-			Description: nil,
+			State:       lastOperationState,
+			Description: lastOperationDescription,
 			CreatedAt:   binding.CreationTimestamp.Time,
 			UpdatedAt:   getLastUpdatedTime(binding),
 		},
+		ReconcileFailure: getReconcileFailure(
+			binding.Status.Conditions,
+			r.reconcileFailureThreshold,
+			BindingSecretAvailableCondition,
+			VCAPServicesSecretAvailableCondition,
+		),
+		CredentialsUpToDate: binding.Annotations[korifiv1alpha1.CFServiceCredentialsGenerationAnnotationKey] == credentialsGenerations[binding.Spec.Service.Name],
+		Orphaned:            meta.IsStatusConditionFalse(binding.Status.Conditions, AppAvailableCondition),
 	}
 }
 
+// serviceBindingLastOperationState translates the status conditions of a
+// binding into the state of its create operation. The binding controller does
+// not set a Ready condition, so a binding whose secrets are both available
+// counts as ready too. While a secret is not available yet the binding is in
+// progress, described by the message of the pending condition.
+func serviceBindingLastOperationState(conditions []metav1.Condition) (string, *string) {
+	if meta.IsStatusConditionTrue(conditions, StatusConditionReady) ||
+		(meta.IsStatusConditionTrue(conditions, BindingSecretAvailableCondition) &&
+			meta.IsStatusConditionTrue(conditions, VCAPServicesSecretAvailableCondition)) {
+		return ServiceBindingStateSucceeded, nil
+	}
+
+	if readyCondition := meta.FindStatusCondition(conditions, StatusConditionReady); readyCondition != nil && readyCondition.Status == metav1.ConditionFalse {
+		return ServiceBindingStateFailed, tools.PtrTo(readyCondition.Message)
+	}
+
+	if len(conditions) == 0 {
+		return ServiceBindingStateInitial, nil
+	}
+
+	for _, conditionType := range []string{BindingSecretAvailableCondition, VCAPServicesSecretAvailableCondition} {
+		condition := meta.FindStatusCondition(conditions, conditionType)
+		if condition != nil && condition.Status != metav1.ConditionTrue && condition.Message != "" {
+			return ServiceBindingStateInProgress, tools.PtrTo(condition.Message)
+		}
+	}
+
+	return ServiceBindingStateInProgress, nil
+}
+
 // nolint:dupl
 func (r *ServiceBindingRepo) ListServiceBindings(ctx context.Context, authInfo authorization.Info, message ListServiceBindingsMessage) ([]ServiceBindingRecord, error) {
-	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	records, _, err := r.ListServiceBindingsPage(ctx, authInfo, message)
+	return records, err
+}
+
+// ListServiceBindingsPage lists the service bindings like ListServiceBindings
+// and also describes the returned page. As bindings are gathered from all the
+// authorized namespaces, they are sorted as a whole before being paged.
+func (r *ServiceBindingRepo) ListServiceBindingsPage(ctx context.Context, authInfo authorization.Info, message ListServiceBindingsMessage) (_ []ServiceBindingRecord, _ PageInfo, err error) {
+	ctx, span := startSpan(ctx, r.tracer, "ListServiceBindings", ServiceBindingResourceType, "")
+	defer func() { endSpan(span, err) }()
+
+	less, err := serviceBindingsOrder(message.OrderBy)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+		return []ServiceBindingRecord{}, PageInfo{}, err
+	}
+
+	permsCtx, permsSpan := startSpan(ctx, r.tracer, "permissions", ServiceBindingResourceType, "")
+	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(permsCtx, authInfo)
+	endSpan(permsSpan, err)
+	if err != nil {
+		return nil, PageInfo{}, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
 	}
 
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
-		return []ServiceBindingRecord{}, fmt.Errorf("failed to build user client: %w", err)
+		return []ServiceBindingRecord{}, PageInfo{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
 	preds := []func(korifiv1alpha1.CFServiceBinding) bool{
@@ -271,33 +468,158 @@ func (r *ServiceBindingRepo) ListServiceBindings(ctx context.Context, authInfo a
 
 	labelSelector, err := labels.Parse(message.LabelSelector)
 	if err != nil {
-		return []ServiceBindingRecord{}, apierrors.NewUnprocessableEntityError(err, "invalid label selector")
+		return []ServiceBindingRecord{}, PageInfo{}, apierrors.NewUnprocessableEntityError(err, "invalid label selector")
 	}
 
+	spaceGUIDSet := NewSet(message.SpaceGUIDs...)
 	var filteredServiceBindings []korifiv1alpha1.CFServiceBinding
+	credentialsGenerations := map[string]string{}
 	for ns := range nsList {
+		if len(spaceGUIDSet) > 0 && !spaceGUIDSet.Includes(ns) {
+			continue
+		}
+
 		serviceBindingList := new(korifiv1alpha1.CFServiceBindingList)
 		err = userClient.List(ctx, serviceBindingList, client.InNamespace(ns), &client.ListOptions{LabelSelector: labelSelector})
 		if k8serrors.IsForbidden(err) {
 			continue
 		}
 		if err != nil {
-			return []ServiceBindingRecord{}, fmt.Errorf("failed to list service instances in namespace %s: %w",
+			return []ServiceBindingRecord{}, PageInfo{}, fmt.Errorf("failed to list service instances in namespace %s: %w",
 				ns,
 				apierrors.FromK8sError(err, ServiceBindingResourceType),
 			)
 		}
-		filteredServiceBindings = append(filteredServiceBindings, Filter(serviceBindingList.Items, preds...)...)
+		namespaceBindings := Filter(serviceBindingList.Items, preds...)
+		if len(namespaceBindings) == 0 {
+			continue
+		}
+		filteredServiceBindings = append(filteredServiceBindings, namespaceBindings...)
+
+		namespaceGenerations, err := getCredentialsGenerations(ctx, userClient, ns)
+		if err != nil {
+			return []ServiceBindingRecord{}, PageInfo{}, err
+		}
+		maps.Copy(credentialsGenerations, namespaceGenerations)
+	}
+
+	records := r.toServiceBindingRecords(filteredServiceBindings, credentialsGenerations)
+	slices.SortStableFunc(records, less)
+	records, pageInfo := PageOf(records, message.Page, message.PerPage)
+
+	return records, pageInfo, nil
+}
+
+// serviceBindingsOrder returns the comparison sorting bindings by the given
+// order, falling back to their guid so that pages are stable
+func serviceBindingsOrder(orderBy string) (func(a, b ServiceBindingRecord) int, error) {
+	var compareField func(a, b ServiceBindingRecord) int
+	switch strings.TrimPrefix(orderBy, "-") {
+	case "":
+		compareField = func(a, b ServiceBindingRecord) int { return 0 }
+	case "created_at":
+		compareField = func(a, b ServiceBindingRecord) int { return a.CreatedAt.Compare(b.CreatedAt) }
+	case "updated_at":
+		compareField = func(a, b ServiceBindingRecord) int { return bindingUpdatedAt(a).Compare(bindingUpdatedAt(b)) }
+	default:
+		return nil, apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("invalid order by %q", orderBy),
+			"order_by must be one of created_at, -created_at, updated_at or -updated_at",
+		)
+	}
+
+	descending := strings.HasPrefix(orderBy, "-")
+	return func(a, b ServiceBindingRecord) int {
+		c := compareField(a, b)
+		if descending {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+		return strings.Compare(a.GUID, b.GUID)
+	}, nil
+}
+
+func bindingUpdatedAt(record ServiceBindingRecord) time.Time {
+	if record.UpdatedAt == nil {
+		return record.CreatedAt
+	}
+	return *record.UpdatedAt
+}
+
+// ListServiceBindingsForInstance lists the bindings of a service instance in
+// all spaces the user is authorized in, including the spaces the instance
+// is shared into. Each record carries the guid of its space.
+func (r *ServiceBindingRepo) ListServiceBindingsForInstance(ctx context.Context, authInfo authorization.Info, instanceGUID string) ([]ServiceBindingRecord, error) {
+	return r.ListServiceBindings(ctx, authInfo, ListServiceBindingsMessage{
+		ServiceInstanceGUIDs: []string{instanceGUID},
+	})
+}
+
+// ListOrgServiceBindings lists the service bindings in all spaces of an org
+// the user is authorized in. Each record carries the guid of its space.
+func (r *ServiceBindingRepo) ListOrgServiceBindings(ctx context.Context, authInfo authorization.Info, orgGUID string, message ListServiceBindingsMessage) ([]ServiceBindingRecord, error) {
+	orgSpaceGUIDs, err := r.namespaceRetriever.SpaceGUIDsFor(ctx, orgGUID)
+	if err != nil {
+		return []ServiceBindingRecord{}, err
+	}
+
+	orgSpaceGUIDs = Filter(orgSpaceGUIDs, SetPredicate(message.SpaceGUIDs, func(s string) string { return s }))
+	if len(orgSpaceGUIDs) == 0 {
+		return []ServiceBindingRecord{}, nil
+	}
+
+	message.SpaceGUIDs = orgSpaceGUIDs
+	return r.ListServiceBindings(ctx, authInfo, message)
+}
+
+// CountServiceBindings returns the number of bindings of each of the given
+// service instances, across the spaces the user is authorized in. Instances
+// without bindings are counted as zero.
+func (r *ServiceBindingRepo) CountServiceBindings(ctx context.Context, authInfo authorization.Info, serviceInstanceGUIDs []string) (map[string]int, error) {
+	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	counts := map[string]int{}
+	for _, guid := range serviceInstanceGUIDs {
+		counts[guid] = 0
+	}
+
+	for ns := range nsList {
+		serviceBindingList := new(korifiv1alpha1.CFServiceBindingList)
+		err = userClient.List(ctx, serviceBindingList, client.InNamespace(ns))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list service bindings in namespace %s: %w",
+				ns,
+				apierrors.FromK8sError(err, ServiceBindingResourceType),
+			)
+		}
+
+		bindings := Filter(serviceBindingList.Items, SetPredicate(serviceInstanceGUIDs, func(s korifiv1alpha1.CFServiceBinding) string { return s.Spec.Service.Name }))
+		for _, binding := range bindings {
+			counts[binding.Spec.Service.Name]++
+		}
 	}
 
-	return toServiceBindingRecords(filteredServiceBindings), nil
+	return counts, nil
 }
 
-func toServiceBindingRecords(serviceBindings []korifiv1alpha1.CFServiceBinding) []ServiceBindingRecord {
+func (r *ServiceBindingRepo) toServiceBindingRecords(serviceBindings []korifiv1alpha1.CFServiceBinding, credentialsGenerations map[string]string) []ServiceBindingRecord {
 	serviceInstanceRecords := make([]ServiceBindingRecord, 0, len(serviceBindings))
 
 	for i := range serviceBindings {
-		serviceInstanceRecords = append(serviceInstanceRecords, cfServiceBindingToRecord(&serviceBindings[i]))
+		serviceInstanceRecords = append(serviceInstanceRecords, r.cfServiceBindingToRecord(&serviceBindings[i], credentialsGenerations))
 	}
 	return serviceInstanceRecords
 }
@@ -3,6 +3,7 @@ package repositories_test
 import (
 	"context"
 	"errors"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -45,7 +46,7 @@ var _ = Describe("ServiceBindingRepo", func() {
 			korifiv1alpha1.CFServiceBindingList,
 			*korifiv1alpha1.CFServiceBindingList,
 		]{}
-		repo = repositories.NewServiceBindingRepo(namespaceRetriever, userClientFactory, nsPerms, conditionAwaiter)
+		repo = repositories.NewServiceBindingRepo(namespaceRetriever, userClientFactory, nsPerms, conditionAwaiter, 0, nil)
 
 		org = createOrgWithCleanup(testCtx, prefixedGUID("org"))
 		space = createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("space1"))
@@ -78,15 +79,24 @@ var _ = Describe("ServiceBindingRepo", func() {
 	Describe("CreateServiceBinding", func() {
 		var (
 			serviceBindingRecord repositories.ServiceBindingRecord
+			parameters           map[string]any
 			createErr            error
 		)
 		BeforeEach(func() {
+			parameters = nil
+
 			conditionAwaiter.AwaitConditionStub = func(ctx context.Context, _ client.WithWatch, object client.Object, _ string) (*korifiv1alpha1.CFServiceBinding, error) {
 				cfServiceBinding, ok := object.(*korifiv1alpha1.CFServiceBinding)
 				Expect(ok).To(BeTrue())
 
 				Expect(k8s.Patch(ctx, k8sClient, cfServiceBinding, func() {
 					cfServiceBinding.Status.Binding.Name = "service-secret-name"
+					meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+						Type:    repositories.BindingSecretAvailableCondition,
+						Status:  metav1.ConditionTrue,
+						Reason:  "blah",
+						Message: "blah",
+					})
 					meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
 						Type:    repositories.VCAPServicesSecretAvailableCondition,
 						Status:  metav1.ConditionTrue,
@@ -107,6 +117,7 @@ var _ = Describe("ServiceBindingRepo", func() {
 				ServiceInstanceGUID: serviceInstanceGUID,
 				AppGUID:             appGUID,
 				SpaceGUID:           space.Name,
+				Parameters:          parameters,
 			})
 		})
 
@@ -201,6 +212,39 @@ var _ = Describe("ServiceBindingRepo", func() {
 					Expect(serviceBindingRecord.Name).To(Equal(bindingName))
 				})
 			})
+
+			When("parameters are requested for a user-provided service instance", func() {
+				BeforeEach(func() {
+					createServiceInstanceCR(testCtx, k8sClient, serviceInstanceGUID, space.Name, "some-instance", "some-secret")
+					parameters = map[string]any{"foo": "bar"}
+				})
+
+				It("returns an UnprocessableEntity error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(createErr.(apierrors.UnprocessableEntityError).Detail()).To(Equal("Binding parameters are not supported for user-provided service instances"))
+				})
+
+				It("does not create the binding", func() {
+					serviceBindingList := new(korifiv1alpha1.CFServiceBindingList)
+					Expect(k8sClient.List(testCtx, serviceBindingList, client.InNamespace(space.Name))).To(Succeed())
+					Expect(serviceBindingList.Items).To(BeEmpty())
+				})
+			})
+
+			When("empty parameters are requested", func() {
+				BeforeEach(func() {
+					parameters = map[string]any{}
+				})
+
+				It("creates the binding", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					serviceBinding := new(korifiv1alpha1.CFServiceBinding)
+					Expect(
+						k8sClient.Get(testCtx, types.NamespacedName{Name: serviceBindingRecord.GUID, Namespace: space.Name}, serviceBinding),
+					).To(Succeed())
+				})
+			})
 		})
 	})
 
@@ -275,6 +319,50 @@ var _ = Describe("ServiceBindingRepo", func() {
 				Expect(ret).NotTo(HaveOccurred())
 			})
 
+			It("does not wait for the binding to be gone", func() {
+				Expect(conditionAwaiter.AwaitDeletionCallCount()).To(BeZero())
+			})
+
+			When("the app restarts on config changes", func() {
+				BeforeEach(func() {
+					cfApp := &korifiv1alpha1.CFApp{}
+					Expect(k8sClient.Get(testCtx, client.ObjectKey{Namespace: space.Name, Name: appGUID}, cfApp)).To(Succeed())
+					Expect(k8s.PatchResource(testCtx, k8sClient, cfApp, func() {
+						cfApp.Annotations = map[string]string{
+							repositories.RestartOnConfigChangeAnnotation: "true",
+							korifiv1alpha1.CFAppRevisionKey:              "3",
+						}
+					})).To(Succeed())
+				})
+
+				It("restarts the app once the binding is gone", func() {
+					Expect(ret).NotTo(HaveOccurred())
+
+					Expect(conditionAwaiter.AwaitDeletionCallCount()).To(Equal(1))
+					obj := conditionAwaiter.AwaitDeletionArgsForCall(0)
+					Expect(obj.GetName()).To(Equal(serviceBindingGUID))
+					Expect(obj.GetNamespace()).To(Equal(space.Name))
+
+					cfApp := &korifiv1alpha1.CFApp{}
+					Expect(k8sClient.Get(testCtx, client.ObjectKey{Namespace: space.Name, Name: appGUID}, cfApp)).To(Succeed())
+					Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppRevisionKey, "4"))
+				})
+
+				When("the binding is not gone in time", func() {
+					BeforeEach(func() {
+						conditionAwaiter.AwaitDeletionReturns(errors.New("time-out-err"))
+					})
+
+					It("returns an error and does not restart the app", func() {
+						Expect(ret).To(MatchError(ContainSubstring("time-out-err")))
+
+						cfApp := &korifiv1alpha1.CFApp{}
+						Expect(k8sClient.Get(testCtx, client.ObjectKey{Namespace: space.Name, Name: appGUID}, cfApp)).To(Succeed())
+						Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppRevisionKey, "3"))
+					})
+				})
+			})
+
 			When("the binding doesn't exist", func() {
 				BeforeEach(func() {
 					serviceBindingGUID = "something-that-does-not-match"
@@ -398,6 +486,34 @@ var _ = Describe("ServiceBindingRepo", func() {
 				})
 			})
 
+			When("filtered by space guid", func() {
+				BeforeEach(func() {
+					requestMessage = repositories.ListServiceBindingsMessage{
+						SpaceGUIDs: []string{space2.Name},
+					}
+				})
+
+				It("returns only the ServiceBindings in the provided spaces", func() {
+					Expect(responseServiceBindings).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(serviceBinding2.Name)}),
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(serviceBinding3.Name)}),
+					))
+				})
+
+				When("the user is not authorized in the provided space", func() {
+					BeforeEach(func() {
+						unauthorizedSpace := createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("space-3"))
+						createServiceBindingCR(testCtx, k8sClient, prefixedGUID("binding-4"), unauthorizedSpace.Name, nil, serviceInstance1GUID, cfApp1.Name)
+						requestMessage.SpaceGUIDs = []string{unauthorizedSpace.Name}
+					})
+
+					It("returns no bindings", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(responseServiceBindings).To(BeEmpty())
+					})
+				})
+			})
+
 			When("filtered by app guid", func() {
 				BeforeEach(func() {
 					requestMessage = repositories.ListServiceBindingsMessage{
@@ -501,6 +617,312 @@ var _ = Describe("ServiceBindingRepo", func() {
 		})
 	})
 
+	Describe("ListServiceBindingsPage", func() {
+		var (
+			bindingA, bindingB, bindingC *korifiv1alpha1.CFServiceBinding
+
+			requestMessage repositories.ListServiceBindingsMessage
+			records        []repositories.ServiceBindingRecord
+			pageInfo       repositories.PageInfo
+			listErr        error
+		)
+
+		createBinding := func(guidPrefix string, spaceGUID string) *korifiv1alpha1.CFServiceBinding {
+			cfApp := createAppCR(testCtx, k8sClient, guidPrefix+"-app", prefixedGUID(guidPrefix+"-app"), spaceGUID, "STOPPED")
+			instance := createServiceInstanceCR(testCtx, k8sClient, prefixedGUID(guidPrefix+"-instance"), spaceGUID, guidPrefix+"-instance", guidPrefix+"-secret")
+			return createServiceBindingCR(testCtx, k8sClient, prefixedGUID(guidPrefix), spaceGUID, nil, instance.Name, cfApp.Name)
+		}
+
+		BeforeEach(func() {
+			space2 := createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("space-2"))
+			space3 := createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("space-3"))
+			for _, s := range []*korifiv1alpha1.CFSpace{space, space2, space3} {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, s.Name)
+			}
+
+			// Creation timestamps have a one second resolution, so wait between
+			// the bindings to give each a distinct one. The guids sort in a
+			// different order to the one the bindings are created in.
+			bindingC = createBinding("binding-c", space.Name)
+			time.Sleep(1001 * time.Millisecond)
+			bindingA = createBinding("binding-a", space2.Name)
+			time.Sleep(1001 * time.Millisecond)
+			bindingB = createBinding("binding-b", space3.Name)
+
+			requestMessage = repositories.ListServiceBindingsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			records, pageInfo, listErr = repo.ListServiceBindingsPage(testCtx, authInfo, requestMessage)
+		})
+
+		guids := func(records []repositories.ServiceBindingRecord) []string {
+			var result []string
+			for _, r := range records {
+				result = append(result, r.GUID)
+			}
+			return result
+		}
+
+		It("returns the bindings from all spaces sorted by guid", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(guids(records)).To(Equal([]string{bindingA.Name, bindingB.Name, bindingC.Name}))
+			Expect(pageInfo).To(Equal(repositories.PageInfo{TotalResults: 3}))
+		})
+
+		When("ordering by created_at", func() {
+			BeforeEach(func() {
+				requestMessage.OrderBy = "created_at"
+			})
+
+			It("returns the oldest bindings first", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(guids(records)).To(Equal([]string{bindingC.Name, bindingA.Name, bindingB.Name}))
+			})
+
+			When("a page is requested", func() {
+				BeforeEach(func() {
+					requestMessage.Page = 2
+					requestMessage.PerPage = 1
+				})
+
+				It("returns the page of the sorted bindings", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(guids(records)).To(Equal([]string{bindingA.Name}))
+					Expect(pageInfo).To(Equal(repositories.PageInfo{TotalResults: 3, HasNextPage: true}))
+				})
+			})
+		})
+
+		When("ordering by -created_at", func() {
+			BeforeEach(func() {
+				requestMessage.OrderBy = "-created_at"
+			})
+
+			It("returns the newest bindings first", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(guids(records)).To(Equal([]string{bindingB.Name, bindingA.Name, bindingC.Name}))
+			})
+
+			When("the last page is requested", func() {
+				BeforeEach(func() {
+					requestMessage.Page = 2
+					requestMessage.PerPage = 2
+				})
+
+				It("returns the remaining bindings", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(guids(records)).To(Equal([]string{bindingC.Name}))
+					Expect(pageInfo).To(Equal(repositories.PageInfo{TotalResults: 3, HasNextPage: false}))
+				})
+			})
+		})
+
+		When("the order is not supported", func() {
+			BeforeEach(func() {
+				requestMessage.OrderBy = "name"
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(listErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+	})
+
+	Describe("CountServiceBindings", func() {
+		var (
+			serviceInstanceGUID, otherServiceInstanceGUID string
+			counts                                        map[string]int
+			countErr                                      error
+		)
+
+		BeforeEach(func() {
+			serviceInstanceGUID = prefixedGUID("instance")
+			createServiceInstanceCR(testCtx, k8sClient, serviceInstanceGUID, space.Name, "instance-name", "secret-name")
+			otherServiceInstanceGUID = prefixedGUID("other-instance")
+			createServiceInstanceCR(testCtx, k8sClient, otherServiceInstanceGUID, space.Name, "other-instance-name", "other-secret-name")
+
+			for _, appName := range []string{"app-1", "app-2"} {
+				cfApp := createAppCR(testCtx, k8sClient, appName, prefixedGUID(appName), space.Name, "STOPPED")
+				createServiceBindingCR(testCtx, k8sClient, prefixedGUID("binding"), space.Name, nil, serviceInstanceGUID, cfApp.Name)
+			}
+		})
+
+		JustBeforeEach(func() {
+			counts, countErr = repo.CountServiceBindings(testCtx, authInfo, []string{serviceInstanceGUID, otherServiceInstanceGUID})
+		})
+
+		It("does not count bindings in spaces the user cannot access", func() {
+			Expect(countErr).NotTo(HaveOccurred())
+			Expect(counts).To(Equal(map[string]int{
+				serviceInstanceGUID:      0,
+				otherServiceInstanceGUID: 0,
+			}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the number of bindings of each instance", func() {
+				Expect(countErr).NotTo(HaveOccurred())
+				Expect(counts).To(Equal(map[string]int{
+					serviceInstanceGUID:      2,
+					otherServiceInstanceGUID: 0,
+				}))
+			})
+		})
+	})
+
+	Describe("ListOrgServiceBindings", func() {
+		var (
+			space2, otherOrgSpace            *korifiv1alpha1.CFSpace
+			serviceBinding1, serviceBinding2 *korifiv1alpha1.CFServiceBinding
+			otherOrgServiceBinding           *korifiv1alpha1.CFServiceBinding
+			responseServiceBindings          []repositories.ServiceBindingRecord
+			listMessage                      repositories.ListServiceBindingsMessage
+			listErr                          error
+		)
+
+		BeforeEach(func() {
+			listMessage = repositories.ListServiceBindingsMessage{}
+
+			cfApp1 := createAppCR(testCtx, k8sClient, "app-1-name", prefixedGUID("app-1"), space.Name, "STOPPED")
+			cfServiceInstance1 := createServiceInstanceCR(testCtx, k8sClient, prefixedGUID("instance-1"), space.Name, "service-instance-1-name", "secret-1-name")
+			serviceBinding1 = createServiceBindingCR(testCtx, k8sClient, prefixedGUID("binding-1"), space.Name, nil, cfServiceInstance1.Name, cfApp1.Name)
+
+			space2 = createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("space-2"))
+			cfApp2 := createAppCR(testCtx, k8sClient, "app-2-name", prefixedGUID("app-2"), space2.Name, "STOPPED")
+			cfServiceInstance2 := createServiceInstanceCR(testCtx, k8sClient, prefixedGUID("instance-2"), space2.Name, "service-instance-2-name", "secret-2-name")
+			serviceBinding2 = createServiceBindingCR(testCtx, k8sClient, prefixedGUID("binding-2"), space2.Name, nil, cfServiceInstance2.Name, cfApp2.Name)
+
+			otherOrg := createOrgWithCleanup(testCtx, prefixedGUID("other-org"))
+			otherOrgSpace = createSpaceWithCleanup(testCtx, otherOrg.Name, prefixedGUID("other-space"))
+			cfApp3 := createAppCR(testCtx, k8sClient, "app-3-name", prefixedGUID("app-3"), otherOrgSpace.Name, "STOPPED")
+			cfServiceInstance3 := createServiceInstanceCR(testCtx, k8sClient, prefixedGUID("instance-3"), otherOrgSpace.Name, "service-instance-3-name", "secret-3-name")
+			otherOrgServiceBinding = createServiceBindingCR(testCtx, k8sClient, prefixedGUID("binding-3"), otherOrgSpace.Name, nil, cfServiceInstance3.Name, cfApp3.Name)
+		})
+
+		JustBeforeEach(func() {
+			responseServiceBindings, listErr = repo.ListOrgServiceBindings(context.Background(), authInfo, org.Name, listMessage)
+		})
+
+		When("the user has access to the spaces of both orgs", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space2.Name)
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, otherOrgSpace.Name)
+			})
+
+			It("returns the bindings of all spaces in the org tagged with their space", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(responseServiceBindings).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":      Equal(serviceBinding1.Name),
+						"SpaceGUID": Equal(space.Name),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":      Equal(serviceBinding2.Name),
+						"SpaceGUID": Equal(space2.Name),
+					}),
+				))
+			})
+
+			It("does not return bindings from other orgs", func() {
+				Expect(responseServiceBindings).NotTo(ContainElement(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(otherOrgServiceBinding.Name)}),
+				))
+			})
+
+			When("filtering by spaces", func() {
+				BeforeEach(func() {
+					listMessage.SpaceGUIDs = []string{space2.Name, otherOrgSpace.Name}
+				})
+
+				It("returns the bindings of the requested spaces of the org only", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(responseServiceBindings).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(serviceBinding2.Name)}),
+					))
+				})
+			})
+		})
+
+		When("the user only has access to one space of the org", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space2.Name)
+			})
+
+			It("returns the bindings of that space only", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(responseServiceBindings).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":      Equal(serviceBinding2.Name),
+						"SpaceGUID": Equal(space2.Name),
+					}),
+				))
+			})
+		})
+
+		When("the user does not have access to any spaces", func() {
+			It("returns an empty list", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(responseServiceBindings).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("ListServiceBindingsForInstance", func() {
+		var (
+			space2                           *korifiv1alpha1.CFSpace
+			serviceBinding1, serviceBinding2 *korifiv1alpha1.CFServiceBinding
+			cfServiceInstance                *korifiv1alpha1.CFServiceInstance
+			responseServiceBindings          []repositories.ServiceBindingRecord
+			listErr                          error
+		)
+
+		BeforeEach(func() {
+			space2 = createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("space-2"))
+			createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space2.Name)
+
+			cfServiceInstance = createServiceInstanceCR(testCtx, k8sClient, prefixedGUID("instance"), space.Name, "service-instance-name", "secret-name")
+			Expect(k8s.PatchResource(testCtx, k8sClient, cfServiceInstance, func() {
+				cfServiceInstance.Spec.SharedSpaces = []string{space2.Name}
+			})).To(Succeed())
+
+			cfApp1 := createAppCR(testCtx, k8sClient, "app-1-name", prefixedGUID("app-1"), space.Name, "STOPPED")
+			serviceBinding1 = createServiceBindingCR(testCtx, k8sClient, prefixedGUID("binding-1"), space.Name, nil, cfServiceInstance.Name, cfApp1.Name)
+
+			cfApp2 := createAppCR(testCtx, k8sClient, "app-2-name", prefixedGUID("app-2"), space2.Name, "STOPPED")
+			serviceBinding2 = createServiceBindingCR(testCtx, k8sClient, prefixedGUID("binding-2"), space2.Name, nil, cfServiceInstance.Name, cfApp2.Name)
+
+			otherServiceInstance := createServiceInstanceCR(testCtx, k8sClient, prefixedGUID("other-instance"), space.Name, "other-service-instance-name", "other-secret-name")
+			createServiceBindingCR(testCtx, k8sClient, prefixedGUID("other-binding"), space.Name, nil, otherServiceInstance.Name, cfApp1.Name)
+		})
+
+		JustBeforeEach(func() {
+			responseServiceBindings, listErr = repo.ListServiceBindingsForInstance(testCtx, authInfo, cfServiceInstance.Name)
+		})
+
+		It("returns the bindings of the instance in all spaces tagged with their space", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(responseServiceBindings).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{
+					"GUID":                Equal(serviceBinding1.Name),
+					"ServiceInstanceGUID": Equal(cfServiceInstance.Name),
+					"SpaceGUID":           Equal(space.Name),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"GUID":                Equal(serviceBinding2.Name),
+					"ServiceInstanceGUID": Equal(cfServiceInstance.Name),
+					"SpaceGUID":           Equal(space2.Name),
+				}),
+			))
+		})
+	})
+
 	Describe("GetServiceBinding", func() {
 		var (
 			serviceBindingGUID string
@@ -538,8 +960,8 @@ var _ = Describe("ServiceBindingRepo", func() {
 			serviceBinding, getErr = repo.GetServiceBinding(ctx, authInfo, searchGUID)
 		})
 
-		It("returns a forbidden error as no user bindings are in place", func() {
-			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		It("returns a not found error as no user bindings are in place", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
 		})
 
 		When("the user is a space developer", func() {
@@ -551,6 +973,195 @@ var _ = Describe("ServiceBindingRepo", func() {
 				Expect(getErr).NotTo(HaveOccurred())
 
 				Expect(serviceBinding.GUID).To(Equal(serviceBindingGUID))
+				Expect(serviceBinding.ReconcileFailure).To(BeNil())
+				Expect(serviceBinding.CredentialsUpToDate).To(BeTrue())
+				Expect(serviceBinding.Orphaned).To(BeFalse())
+			})
+
+			Describe("the last operation", func() {
+				var conditions []metav1.Condition
+
+				BeforeEach(func() {
+					conditions = nil
+				})
+
+				JustBeforeEach(func() {
+					cfServiceBinding := &korifiv1alpha1.CFServiceBinding{}
+					Expect(k8sClient.Get(testCtx, types.NamespacedName{Namespace: space.Name, Name: serviceBindingGUID}, cfServiceBinding)).To(Succeed())
+					Expect(k8s.Patch(testCtx, k8sClient, cfServiceBinding, func() {
+						for _, condition := range conditions {
+							meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, condition)
+						}
+					})).To(Succeed())
+
+					serviceBinding, getErr = repo.GetServiceBinding(ctx, authInfo, searchGUID)
+				})
+
+				It("is initial while the binding has not been reconciled", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(serviceBinding.LastOperation.State).To(Equal("initial"))
+					Expect(serviceBinding.LastOperation.Description).To(BeNil())
+				})
+
+				When("the binding secret is not available yet", func() {
+					BeforeEach(func() {
+						conditions = []metav1.Condition{{
+							Type:    repositories.BindingSecretAvailableCondition,
+							Status:  metav1.ConditionFalse,
+							Reason:  "SecretNotFound",
+							Message: "Binding secret does not exist",
+						}}
+					})
+
+					It("is in progress", func() {
+						Expect(getErr).NotTo(HaveOccurred())
+						Expect(serviceBinding.LastOperation.State).To(Equal("in progress"))
+						Expect(serviceBinding.LastOperation.Description).To(PointTo(Equal("Binding secret does not exist")))
+					})
+				})
+
+				When("both secrets are available", func() {
+					BeforeEach(func() {
+						conditions = []metav1.Condition{
+							{Type: repositories.BindingSecretAvailableCondition, Status: metav1.ConditionTrue, Reason: "SecretFound"},
+							{Type: repositories.VCAPServicesSecretAvailableCondition, Status: metav1.ConditionTrue, Reason: "SecretFound"},
+						}
+					})
+
+					It("has succeeded", func() {
+						Expect(getErr).NotTo(HaveOccurred())
+						Expect(serviceBinding.LastOperation.State).To(Equal("succeeded"))
+						Expect(serviceBinding.LastOperation.Description).To(BeNil())
+					})
+				})
+
+				When("the binding is ready", func() {
+					BeforeEach(func() {
+						conditions = []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Bound"}}
+					})
+
+					It("has succeeded", func() {
+						Expect(getErr).NotTo(HaveOccurred())
+						Expect(serviceBinding.LastOperation.State).To(Equal("succeeded"))
+					})
+				})
+
+				When("the binding is not ready", func() {
+					BeforeEach(func() {
+						conditions = []metav1.Condition{{
+							Type:    "Ready",
+							Status:  metav1.ConditionFalse,
+							Reason:  "BindFailed",
+							Message: "the broker refused the binding",
+						}}
+					})
+
+					It("has failed", func() {
+						Expect(getErr).NotTo(HaveOccurred())
+						Expect(serviceBinding.LastOperation.State).To(Equal("failed"))
+						Expect(serviceBinding.LastOperation.Description).To(PointTo(Equal("the broker refused the binding")))
+					})
+				})
+			})
+
+			When("the credentials of the service instance have been rotated", func() {
+				BeforeEach(func() {
+					Expect(k8sClient.Create(testCtx, &korifiv1alpha1.CFServiceInstance{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      serviceInstanceGUID,
+							Namespace: space.Name,
+							Annotations: map[string]string{
+								korifiv1alpha1.CFServiceCredentialsGenerationAnnotationKey: "2",
+							},
+						},
+						Spec: korifiv1alpha1.CFServiceInstanceSpec{
+							DisplayName: "some-instance",
+							SecretName:  "some-secret",
+							Type:        "user-provided",
+						},
+					})).To(Succeed())
+				})
+
+				It("reports the binding as out of date", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(serviceBinding.CredentialsUpToDate).To(BeFalse())
+				})
+
+				When("the controller has refreshed the binding", func() {
+					BeforeEach(func() {
+						cfServiceBinding := &korifiv1alpha1.CFServiceBinding{}
+						Expect(k8sClient.Get(testCtx, types.NamespacedName{Namespace: space.Name, Name: serviceBindingGUID}, cfServiceBinding)).To(Succeed())
+						Expect(k8s.PatchResource(testCtx, k8sClient, cfServiceBinding, func() {
+							cfServiceBinding.Annotations = map[string]string{
+								korifiv1alpha1.CFServiceCredentialsGenerationAnnotationKey: "2",
+							}
+						})).To(Succeed())
+					})
+
+					It("reports the binding as up to date", func() {
+						Expect(getErr).NotTo(HaveOccurred())
+						Expect(serviceBinding.CredentialsUpToDate).To(BeTrue())
+					})
+				})
+			})
+
+			When("the app of the binding has been deleted", func() {
+				BeforeEach(func() {
+					cfServiceBinding := &korifiv1alpha1.CFServiceBinding{}
+					Expect(k8sClient.Get(testCtx, types.NamespacedName{Namespace: space.Name, Name: serviceBindingGUID}, cfServiceBinding)).To(Succeed())
+					Expect(k8s.Patch(testCtx, k8sClient, cfServiceBinding, func() {
+						meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+							Type:    repositories.AppAvailableCondition,
+							Status:  metav1.ConditionFalse,
+							Reason:  "AppNotFound",
+							Message: "App does not exist",
+						})
+					})).To(Succeed())
+				})
+
+				It("flags the binding as orphaned", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(serviceBinding.Orphaned).To(BeTrue())
+				})
+			})
+
+			When("the controller keeps failing to reconcile the binding", func() {
+				var failingSince time.Time
+
+				BeforeEach(func() {
+					failingSince = time.Now().Add(-5 * time.Minute).Truncate(time.Second)
+					cfServiceBinding := &korifiv1alpha1.CFServiceBinding{}
+					Expect(k8sClient.Get(testCtx, types.NamespacedName{Namespace: space.Name, Name: serviceBindingGUID}, cfServiceBinding)).To(Succeed())
+					Expect(k8s.Patch(testCtx, k8sClient, cfServiceBinding, func() {
+						cfServiceBinding.Status.Conditions = []metav1.Condition{{
+							Type:               "BindingSecretAvailable",
+							Status:             metav1.ConditionFalse,
+							Reason:             "SecretNotFound",
+							Message:            "Binding secret does not exist",
+							LastTransitionTime: metav1.NewTime(failingSince),
+						}}
+					})).To(Succeed())
+				})
+
+				It("reports the reconcile failure", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(serviceBinding.ReconcileFailure).To(PointTo(Equal(repositories.ReconcileFailure{
+						Reason:  "SecretNotFound",
+						Message: "Binding secret does not exist",
+						Since:   failingSince,
+					})))
+				})
+
+				When("the failure is more recent than the reconcile failure threshold", func() {
+					BeforeEach(func() {
+						repo = repositories.NewServiceBindingRepo(namespaceRetriever, userClientFactory, nsPerms, conditionAwaiter, time.Hour, nil)
+					})
+
+					It("does not report it yet", func() {
+						Expect(getErr).NotTo(HaveOccurred())
+						Expect(serviceBinding.ReconcileFailure).To(BeNil())
+					})
+				})
 			})
 
 			When("no CFServiceBinding exists", func() {
@@ -586,6 +1197,7 @@ var _ = Describe("ServiceBindingRepo", func() {
 					},
 				},
 				Spec: korifiv1alpha1.CFServiceBindingSpec{
+					DisplayName: tools.PtrTo("old-name"),
 					Service: corev1.ObjectReference{
 						Kind:       "CFServiceInstance",
 						APIVersion: korifiv1alpha1.GroupVersion.Identifier(),
@@ -641,6 +1253,26 @@ var _ = Describe("ServiceBindingRepo", func() {
 				Expect(updatedServiceBinding.Annotations).To(HaveKeyWithValue("baz", "new-baz"))
 			})
 
+			It("leaves the name untouched", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(updatedServiceBinding.Name).To(PointTo(Equal("old-name")))
+			})
+
+			When("the name is updated", func() {
+				BeforeEach(func() {
+					updateMessage.Name = tools.PtrTo("new-name")
+				})
+
+				It("updates the service binding name", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+					Expect(updatedServiceBinding.Name).To(PointTo(Equal("new-name")))
+
+					updatedCFServiceBinding := new(korifiv1alpha1.CFServiceBinding)
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceBinding), updatedCFServiceBinding)).To(Succeed())
+					Expect(updatedCFServiceBinding.Spec.DisplayName).To(PointTo(Equal("new-name")))
+				})
+			})
+
 			When("the service binding does not exist", func() {
 				BeforeEach(func() {
 					updateMessage.GUID = "i-do-not-exist"
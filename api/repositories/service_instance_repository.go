@@ -2,15 +2,19 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/google/uuid"
+	"golang.org/x/exp/maps"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -23,6 +27,12 @@ const (
 	CFServiceInstanceGUIDLabel     = "korifi.cloudfoundry.org/service-instance-guid"
 	ServiceInstanceResourceType    = "Service Instance"
 	serviceBindingSecretTypePrefix = "servicebinding.io/"
+
+	// ServiceInstanceParametersSecretAnnotation names the secret storing the
+	// parameters the service instance was provisioned with
+	ServiceInstanceParametersSecretAnnotation = "korifi.cloudfoundry.org/parameters-secret"
+	serviceInstanceParametersSecretSuffix     = "-parameters"
+	serviceInstanceParametersKey              = "parameters"
 )
 
 type NamespaceGetter interface {
@@ -55,6 +65,10 @@ type CreateServiceInstanceMessage struct {
 	Tags        []string
 	Labels      map[string]string
 	Annotations map[string]string
+	// Parameters are the provisioning parameters of the instance. They are
+	// stored in a secret and returned by GetServiceInstanceParameters.
+	// Instances created without parameters do not support retrieving them.
+	Parameters map[string]any
 }
 
 type PatchServiceInstanceMessage struct {
@@ -111,6 +125,12 @@ func (r *ServiceInstanceRepo) CreateServiceInstance(ctx context.Context, authInf
 	cfServiceInstance := message.toCFServiceInstance()
 	err = userClient.Create(ctx, &cfServiceInstance)
 	if err != nil {
+		if validationError, ok := webhooks.WebhookErrorToValidationError(err); ok {
+			if validationError.Type == webhooks.DuplicateNameErrorType {
+				return ServiceInstanceRecord{}, apierrors.NewUniquenessError(err, validationError.GetMessage())
+			}
+		}
+
 		return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
 	}
 
@@ -128,9 +148,38 @@ func (r *ServiceInstanceRepo) CreateServiceInstance(ctx context.Context, authInf
 		return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
 	}
 
+	if message.Parameters != nil {
+		err = createParametersSecret(ctx, userClient, cfServiceInstance, message.Parameters)
+		if err != nil {
+			return ServiceInstanceRecord{}, err
+		}
+	}
+
 	return cfServiceInstanceToServiceInstanceRecord(cfServiceInstance), nil
 }
 
+// createParametersSecret stores the provisioning parameters of the service
+// instance in the secret named by its parameters secret annotation
+func createParametersSecret(ctx context.Context, userClient client.Client, cfServiceInstance korifiv1alpha1.CFServiceInstance, parameters map[string]any) error {
+	parametersJSON, err := json.Marshal(parameters)
+	if err != nil {
+		return apierrors.NewUnprocessableEntityError(err, "invalid service instance parameters")
+	}
+
+	parametersSecret := cfServiceInstanceToSecret(cfServiceInstance)
+	parametersSecret.Name = cfServiceInstance.Annotations[ServiceInstanceParametersSecretAnnotation]
+	parametersSecret.Data = map[string][]byte{
+		serviceInstanceParametersKey: parametersJSON,
+	}
+
+	err = userClient.Create(ctx, &parametersSecret)
+	if err != nil {
+		return fmt.Errorf("failed to create parameters secret for service instance %q: %w", cfServiceInstance.Name, apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	return nil
+}
+
 func (r *ServiceInstanceRepo) PatchServiceInstance(ctx context.Context, authInfo authorization.Info, message PatchServiceInstanceMessage) (ServiceInstanceRecord, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -144,13 +193,6 @@ func (r *ServiceInstanceRepo) PatchServiceInstance(ctx context.Context, authInfo
 		return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
 	}
 
-	err = k8s.PatchResource(ctx, userClient, &cfServiceInstance, func() {
-		message.Apply(&cfServiceInstance)
-	})
-	if err != nil {
-		return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
-	}
-
 	if message.Credentials != nil {
 		secretObj := new(corev1.Secret)
 		if err = userClient.Get(ctx, client.ObjectKey{Name: cfServiceInstance.Spec.SecretName, Namespace: cfServiceInstance.Namespace}, secretObj); err != nil {
@@ -183,9 +225,31 @@ func (r *ServiceInstanceRepo) PatchServiceInstance(ctx context.Context, authInfo
 		}
 	}
 
+	err = k8s.PatchResource(ctx, userClient, &cfServiceInstance, func() {
+		message.Apply(&cfServiceInstance)
+		if message.Credentials != nil {
+			bumpCredentialsGeneration(&cfServiceInstance)
+		}
+	})
+	if err != nil {
+		return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
+	}
+
 	return cfServiceInstanceToServiceInstanceRecord(cfServiceInstance), nil
 }
 
+// bumpCredentialsGeneration records that the credentials of the service
+// instance have been rotated, so that its bindings can tell whether they have
+// been refreshed since
+func bumpCredentialsGeneration(cfServiceInstance *korifiv1alpha1.CFServiceInstance) {
+	generation, _ := strconv.ParseInt(cfServiceInstance.Annotations[korifiv1alpha1.CFServiceCredentialsGenerationAnnotationKey], 10, 64)
+
+	if cfServiceInstance.Annotations == nil {
+		cfServiceInstance.Annotations = map[string]string{}
+	}
+	cfServiceInstance.Annotations[korifiv1alpha1.CFServiceCredentialsGenerationAnnotationKey] = strconv.FormatInt(generation+1, 10)
+}
+
 // nolint:dupl
 func (r *ServiceInstanceRepo) ListServiceInstances(ctx context.Context, authInfo authorization.Info, message ListServiceInstanceMessage) ([]ServiceInstanceRecord, error) {
 	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
@@ -252,6 +316,70 @@ func (r *ServiceInstanceRepo) GetServiceInstance(ctx context.Context, authInfo a
 	return cfServiceInstanceToServiceInstanceRecord(serviceInstance), nil
 }
 
+// GetServiceInstanceParameters returns the parameters the service instance
+// was provisioned with. Instances whose broker does not support parameter
+// retrieval, such as user-provided ones, result in a not found error.
+func (r *ServiceInstanceRepo) GetServiceInstanceParameters(ctx context.Context, authInfo authorization.Info, guid string) (map[string]any, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	namespace, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceInstanceResourceType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace for service instance: %w", err)
+	}
+
+	var serviceInstance korifiv1alpha1.CFServiceInstance
+	if err = userClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: guid}, &serviceInstance); err != nil {
+		return nil, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	secretName, ok := serviceInstance.Annotations[ServiceInstanceParametersSecretAnnotation]
+	if !ok {
+		return nil, apierrors.NewNotFoundError(
+			fmt.Errorf("service instance %q does not support parameter retrieval", guid),
+			ServiceInstanceResourceType,
+		)
+	}
+
+	parametersSecret := new(corev1.Secret)
+	if err = userClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretName}, parametersSecret); err != nil {
+		return nil, fmt.Errorf("failed to get parameters secret for service instance %q: %w", guid, apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	parameters := map[string]any{}
+	if data, ok := parametersSecret.Data[serviceInstanceParametersKey]; ok {
+		if err = json.Unmarshal(data, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse parameters of service instance %q: %w", guid, err)
+		}
+	}
+
+	return parameters, nil
+}
+
+// ListServiceInstanceSharedSpaces returns the guids of the spaces the service
+// instance can be used in: the space owning it, followed by the spaces it is
+// shared into.
+func (r *ServiceInstanceRepo) ListServiceInstanceSharedSpaces(ctx context.Context, authInfo authorization.Info, guid string) ([]string, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	namespace, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceInstanceResourceType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace for service instance: %w", err)
+	}
+
+	var serviceInstance korifiv1alpha1.CFServiceInstance
+	if err = userClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: guid}, &serviceInstance); err != nil {
+		return nil, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	return append([]string{serviceInstance.Namespace}, serviceInstance.Spec.SharedSpaces...), nil
+}
+
 func (r *ServiceInstanceRepo) DeleteServiceInstance(ctx context.Context, authInfo authorization.Info, message DeleteServiceInstanceMessage) error {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -274,12 +402,22 @@ func (r *ServiceInstanceRepo) DeleteServiceInstance(ctx context.Context, authInf
 
 func (m CreateServiceInstanceMessage) toCFServiceInstance() korifiv1alpha1.CFServiceInstance {
 	guid := uuid.NewString()
+
+	annotations := m.Annotations
+	if m.Parameters != nil {
+		annotations = maps.Clone(m.Annotations)
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[ServiceInstanceParametersSecretAnnotation] = guid + serviceInstanceParametersSecretSuffix
+	}
+
 	return korifiv1alpha1.CFServiceInstance{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        guid,
 			Namespace:   m.SpaceGUID,
 			Labels:      m.Labels,
-			Annotations: m.Annotations,
+			Annotations: annotations,
 		},
 		Spec: korifiv1alpha1.CFServiceInstanceSpec{
 			DisplayName: m.Name,
@@ -151,6 +151,31 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			})
 		})
 
+		When("parameters are provided", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+				serviceInstanceCreateMessage.Annotations = map[string]string{"an-annotation": "a-value"}
+				serviceInstanceCreateMessage.Parameters = map[string]any{"plan": "large"}
+			})
+
+			It("stores them in a secret owned by the service instance", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+				Expect(k8sClient.Get(testCtx, types.NamespacedName{Namespace: space.Name, Name: createdServiceInstanceRecord.GUID}, serviceInstance)).To(Succeed())
+				Expect(serviceInstance.Annotations).To(HaveKeyWithValue("an-annotation", "a-value"))
+				Expect(serviceInstance.Annotations).To(HaveKeyWithValue(repositories.ServiceInstanceParametersSecretAnnotation, createdServiceInstanceRecord.GUID+"-parameters"))
+
+				parametersSecret := new(corev1.Secret)
+				Expect(k8sClient.Get(testCtx, types.NamespacedName{Namespace: space.Name, Name: createdServiceInstanceRecord.GUID + "-parameters"}, parametersSecret)).To(Succeed())
+				Expect(parametersSecret.Data).To(HaveKeyWithValue("parameters", MatchJSON(`{"plan":"large"}`)))
+				Expect(parametersSecret.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Kind": Equal("CFServiceInstance"),
+					"Name": Equal(createdServiceInstanceRecord.GUID),
+				})))
+			})
+		})
+
 		When("user does not have permissions to create ServiceInstances", func() {
 			It("returns a Forbidden error", func() {
 				Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
@@ -311,6 +336,18 @@ var _ = Describe("ServiceInstanceRepository", func() {
 							g.Expect(secret.Type).To(Equal(corev1.SecretType("servicebinding.io/user-provided")))
 						}).Should(Succeed())
 					})
+
+					It("bumps the credentials generation of the service instance", func() {
+						Expect(err).NotTo(HaveOccurred())
+						serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), serviceInstance)).To(Succeed())
+						Expect(serviceInstance.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFServiceCredentialsGenerationAnnotationKey, "1"))
+
+						_, err = serviceInstanceRepo.PatchServiceInstance(ctx, authInfo, patchMessage)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), serviceInstance)).To(Succeed())
+						Expect(serviceInstance.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFServiceCredentialsGenerationAnnotationKey, "2"))
+					})
 				})
 
 				When("the instance credentials pass the old type unchanged", func() {
@@ -602,6 +639,118 @@ var _ = Describe("ServiceInstanceRepository", func() {
 		})
 	})
 
+	Describe("GetServiceInstanceParameters", func() {
+		var (
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			parameters      map[string]any
+			getErr          error
+		)
+
+		BeforeEach(func() {
+			serviceInstance = createServiceInstanceCR(testCtx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+		})
+
+		JustBeforeEach(func() {
+			parameters, getErr = serviceInstanceRepo.GetServiceInstanceParameters(testCtx, authInfo, serviceInstance.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user has permissions to get the service instance", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns a not found error as the instance does not support parameter retrieval", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+
+			When("the instance was created with parameters", func() {
+				BeforeEach(func() {
+					createMessage := initializeServiceInstanceCreateMessage(prefixedGUID("with-parameters"), space.Name, nil, nil)
+					createMessage.Parameters = map[string]any{"plan": "large", "replicas": 3}
+					record, err := serviceInstanceRepo.CreateServiceInstance(testCtx, authInfo, createMessage)
+					Expect(err).NotTo(HaveOccurred())
+
+					serviceInstance = &korifiv1alpha1.CFServiceInstance{}
+					Expect(k8sClient.Get(testCtx, types.NamespacedName{Namespace: space.Name, Name: record.GUID}, serviceInstance)).To(Succeed())
+				})
+
+				It("returns the parameters", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(parameters).To(Equal(map[string]any{
+						"plan":     "large",
+						"replicas": float64(3),
+					}))
+				})
+			})
+		})
+	})
+
+	Describe("ListServiceInstanceSharedSpaces", func() {
+		var (
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			sharedSpace1    *korifiv1alpha1.CFSpace
+			sharedSpace2    *korifiv1alpha1.CFSpace
+			spaceGUIDs      []string
+			listErr         error
+		)
+
+		BeforeEach(func() {
+			sharedSpace1 = createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("shared-space1"))
+			sharedSpace2 = createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("shared-space2"))
+
+			serviceInstance = createServiceInstanceCR(testCtx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+			Expect(k8s.PatchResource(testCtx, k8sClient, serviceInstance, func() {
+				serviceInstance.Spec.SharedSpaces = []string{sharedSpace1.Name, sharedSpace2.Name}
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			spaceGUIDs, listErr = serviceInstanceRepo.ListServiceInstanceSharedSpaces(testCtx, authInfo, serviceInstance.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(listErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user has permissions to get the service instance", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the owning space followed by the spaces the instance is shared into", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(spaceGUIDs).To(Equal([]string{space.Name, sharedSpace1.Name, sharedSpace2.Name}))
+			})
+
+			When("the instance is not shared", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(testCtx, k8sClient, serviceInstance, func() {
+						serviceInstance.Spec.SharedSpaces = nil
+					})).To(Succeed())
+				})
+
+				It("returns the owning space only", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(spaceGUIDs).To(Equal([]string{space.Name}))
+				})
+			})
+		})
+
+		When("the service instance does not exist", func() {
+			JustBeforeEach(func() {
+				spaceGUIDs, listErr = serviceInstanceRepo.ListServiceInstanceSharedSpaces(testCtx, authInfo, "does-not-exist")
+			})
+
+			It("returns a not found error", func() {
+				Expect(listErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
 	Describe("DeleteServiceInstance", func() {
 		var (
 			serviceInstance *korifiv1alpha1.CFServiceInstance
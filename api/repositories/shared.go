@@ -2,17 +2,36 @@ package repositories
 
 import (
 	"context"
+	"slices"
+	"sort"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
 	StatusConditionReady                 = "Ready"
+	BindingSecretAvailableCondition      = "BindingSecretAvailable"
 	VCAPServicesSecretAvailableCondition = "VCAPServicesSecretAvailable"
+	AppAvailableCondition                = "AppAvailable"
+
+	CreatedByAnnotation = "korifi.cloudfoundry.org/created-by"
+
+	TraceOperationKey    = attribute.Key("korifi.operation")
+	TraceResourceTypeKey = attribute.Key("korifi.resource_type")
+	TraceGUIDKey         = attribute.Key("korifi.guid")
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -24,6 +43,7 @@ type RepositoryCreator interface {
 
 type ConditionAwaiter[T runtime.Object] interface {
 	AwaitCondition(ctx context.Context, userClient client.WithWatch, object client.Object, conditionType string) (T, error)
+	AwaitDeletion(ctx context.Context, userClient client.WithWatch, object client.Object) error
 }
 
 func getLastUpdatedTime(obj client.Object) *time.Time {
@@ -64,6 +84,91 @@ func getConditionValue(conditions *[]metav1.Condition, conditionType string) met
 	return conditionStatusValue
 }
 
+// ReconcileFailure describes a resource whose controller keeps failing to
+// reconcile it
+type ReconcileFailure struct {
+	Reason  string
+	Message string
+	Since   time.Time
+}
+
+// getReconcileFailure returns the first of the given conditions that has been
+// false for at least threshold, or nil if there is none. Shorter failures are
+// expected while the controller retries, so they are not reported.
+func getReconcileFailure(conditions []metav1.Condition, threshold time.Duration, conditionTypes ...string) *ReconcileFailure {
+	for _, conditionType := range conditionTypes {
+		condition := meta.FindStatusCondition(conditions, conditionType)
+		if condition == nil || condition.Status != metav1.ConditionFalse {
+			continue
+		}
+
+		if time.Since(condition.LastTransitionTime.Time) < threshold {
+			continue
+		}
+
+		return &ReconcileFailure{
+			Reason:  condition.Reason,
+			Message: condition.Message,
+			Since:   condition.LastTransitionTime.Time,
+		}
+	}
+
+	return nil
+}
+
+// withCreatedBy returns a copy of annotations that records the identity
+// creating the object
+func withCreatedBy(annotations map[string]string, identity authorization.Identity) map[string]string {
+	result := map[string]string{}
+	for key, value := range annotations {
+		result[key] = value
+	}
+	result[CreatedByAnnotation] = identity.Name
+
+	return result
+}
+
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// recordEvent emits a normal event on obj. It does nothing when event
+// recording is disabled, i.e. when recorder is nil.
+func recordEvent(recorder record.EventRecorder, obj runtime.Object, reason, messageFmt string, args ...any) {
+	if recorder == nil {
+		return
+	}
+
+	recorder.Eventf(obj, corev1.EventTypeNormal, reason, messageFmt, args...)
+}
+
+// startSpan starts a span named after a repository operation as a child of
+// any span in ctx. It returns a non-recording span when tracing is disabled,
+// i.e. when tracer is nil.
+func startSpan(ctx context.Context, tracer trace.Tracer, operation, resourceType, guid string) (context.Context, trace.Span) {
+	if tracer == nil {
+		tracer = trace.NewNoopTracerProvider().Tracer("")
+	}
+
+	attributes := []attribute.KeyValue{
+		TraceOperationKey.String(operation),
+		TraceResourceTypeKey.String(resourceType),
+	}
+	if guid != "" {
+		attributes = append(attributes, TraceGUIDKey.String(guid))
+	}
+
+	return tracer.Start(ctx, operation, trace.WithAttributes(attributes...))
+}
+
+// endSpan ends span, recording err and marking the span as failed when err is
+// not nil.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
 func getLabelOrAnnotation(mapObj map[string]string, key string) string {
 	if mapObj == nil {
 		return ""
@@ -113,3 +218,61 @@ func SetPredicate[T comparable, S any](elements []T, mapFn func(S) T) func(S) bo
 }
 
 func AlwaysTrue[T any](_ T) bool { return true }
+
+// AnnotationSelectorPredicate parses a selector in the label selector syntax
+// (e.g. "key" or "key=value") and returns a predicate that matches it against
+// the annotations of a resource. An empty selector matches everything.
+func AnnotationSelectorPredicate[S any](selector string, annotationsFn func(S) map[string]string) (func(S) bool, error) {
+	if selector == "" {
+		return AlwaysTrue[S], nil
+	}
+
+	parsedSelector, err := labels.Parse(selector)
+	if err != nil {
+		return nil, apierrors.NewUnprocessableEntityError(err, "invalid annotation selector")
+	}
+
+	return func(e S) bool {
+		return parsedSelector.Matches(labels.Set(annotationsFn(e)))
+	}, nil
+}
+
+// PageInfo describes where a page of list results sits in the whole list
+type PageInfo struct {
+	TotalResults int
+	HasNextPage  bool
+}
+
+// Paginate sorts the records by guid and returns the requested page, counting
+// from 1. A non-positive perPage returns all records in their original order.
+func Paginate[T any](records []T, guid func(T) string, page, perPage int) ([]T, PageInfo) {
+	pageInfo := PageInfo{TotalResults: len(records)}
+	if perPage <= 0 {
+		return records, pageInfo
+	}
+
+	sorted := slices.Clone(records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return guid(sorted[i]) < guid(sorted[j])
+	})
+
+	return PageOf(sorted, page, perPage)
+}
+
+// PageOf returns the requested page of records that are already sorted,
+// counting from 1. A non-positive perPage returns all records.
+func PageOf[T any](sorted []T, page, perPage int) ([]T, PageInfo) {
+	pageInfo := PageInfo{TotalResults: len(sorted)}
+	if perPage <= 0 {
+		return sorted, pageInfo
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	start := min((page-1)*perPage, len(sorted))
+	end := min(start+perPage, len(sorted))
+	pageInfo.HasNextPage = end < len(sorted)
+
+	return sorted[start:end], pageInfo
+}
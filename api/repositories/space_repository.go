@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,9 +12,13 @@ import (
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/maps"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -27,10 +32,27 @@ type CreateSpaceMessage struct {
 	OrganizationGUID string
 }
 
+// CreateOrgWithDefaultSpacesMessage describes an org and the spaces to create
+// in it once it is ready
+type CreateOrgWithDefaultSpacesMessage struct {
+	CreateOrgMessage
+	DefaultSpaceNames []string
+}
+
 type ListSpacesMessage struct {
 	Names             []string
 	GUIDs             []string
 	OrganizationGUIDs []string
+	CreatedBy         string
+	IncludeOrgName    bool
+	LabelSelector     string
+	// AnnotationSelector filters spaces by their annotations, using the
+	// label selector syntax, e.g. "key" or "key=value"
+	AnnotationSelector string
+	// Page and PerPage select a page of the spaces sorted by guid. A zero
+	// PerPage lists all spaces.
+	Page    int
+	PerPage int
 }
 
 type DeleteSpaceMessage struct {
@@ -44,10 +66,18 @@ type PatchSpaceMetadataMessage struct {
 	OrgGUID string
 }
 
+type UpdateSpaceMessage struct {
+	MetadataPatch
+	GUID    string
+	OrgGUID string
+	Name    *string
+}
+
 type SpaceRecord struct {
 	Name             string
 	GUID             string
 	OrganizationGUID string
+	OrganizationName string
 	Labels           map[string]string
 	Annotations      map[string]string
 	CreatedAt        time.Time
@@ -55,12 +85,56 @@ type SpaceRecord struct {
 	DeletedAt        *time.Time
 }
 
+// SpaceReadinessRecord explains why a space is not ready, e.g. when creating
+// it timed out. Reason and Message come from the Ready condition of the space
+// and are empty while the space has not been reconciled yet.
+type SpaceReadinessRecord struct {
+	GUID    string
+	State   string
+	Reason  string
+	Message string
+}
+
+// AppRestartRecord is the outcome of restarting one of the apps of a space.
+// Err is set when the app could not be restarted.
+type AppRestartRecord struct {
+	AppGUID  string
+	Revision string
+	Err      error
+}
+
+// OrgWithDefaultSpacesRecord is the outcome of creating an org along with its
+// default spaces. The spaces that could not be created are keyed by name in
+// FailedDefaultSpaces; they do not fail the creation of the org.
+type OrgWithDefaultSpacesRecord struct {
+	OrgRecord
+	DefaultSpaces       []SpaceRecord
+	FailedDefaultSpaces map[string]error
+}
+
+const (
+	SpaceStateReady    = "READY"
+	SpaceStateNotReady = "NOT_READY"
+	SpaceStateUnknown  = "UNKNOWN"
+)
+
 type SpaceRepo struct {
 	orgRepo            *OrgRepo
 	namespaceRetriever NamespaceRetriever
 	userClientFactory  authorization.UserK8sClientFactory
 	nsPerms            *authorization.NamespacePermissions
+	identityProvider   authorization.IdentityProvider
 	conditionAwaiter   ConditionAwaiter[*korifiv1alpha1.CFSpace]
+	eventRecorder      record.EventRecorder
+	tracer             trace.Tracer
+	validateSpaceOrg   bool
+}
+
+// SpaceRepoConfig holds the configurable behaviours of the space repository
+type SpaceRepoConfig struct {
+	// ValidateSpaceOrg makes operations given the org of a space check that
+	// the space actually belongs to it
+	ValidateSpaceOrg bool
 }
 
 func NewSpaceRepo(
@@ -68,43 +142,75 @@ func NewSpaceRepo(
 	orgRepo *OrgRepo,
 	userClientFactory authorization.UserK8sClientFactory,
 	nsPerms *authorization.NamespacePermissions,
+	identityProvider authorization.IdentityProvider,
 	conditionAwaiter ConditionAwaiter[*korifiv1alpha1.CFSpace],
+	eventRecorder record.EventRecorder,
+	tracer trace.Tracer,
+	config SpaceRepoConfig,
 ) *SpaceRepo {
 	return &SpaceRepo{
 		orgRepo:            orgRepo,
 		namespaceRetriever: namespaceRetriever,
 		userClientFactory:  userClientFactory,
 		nsPerms:            nsPerms,
+		identityProvider:   identityProvider,
 		conditionAwaiter:   conditionAwaiter,
+		eventRecorder:      eventRecorder,
+		tracer:             tracer,
+		validateSpaceOrg:   config.ValidateSpaceOrg,
 	}
 }
 
-func (r *SpaceRepo) CreateSpace(ctx context.Context, info authorization.Info, message CreateSpaceMessage) (SpaceRecord, error) {
-	_, err := r.orgRepo.GetOrg(ctx, info, message.OrganizationGUID)
+func (r *SpaceRepo) CreateSpace(ctx context.Context, info authorization.Info, message CreateSpaceMessage) (_ SpaceRecord, err error) {
+	ctx, span := startSpan(ctx, r.tracer, "CreateSpace", SpaceResourceType, "")
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.orgRepo.GetOrg(ctx, info, message.OrganizationGUID)
 	if err != nil {
 		return SpaceRecord{}, fmt.Errorf("failed to get parent organization: %w", err)
 	}
 
+	release, err := r.orgRepo.acquireSpaceCreationSlot(ctx, message.OrganizationGUID)
+	if err != nil {
+		return SpaceRecord{}, err
+	}
+	defer release()
+
 	userClient, err := r.userClientFactory.BuildClient(info)
 	if err != nil {
 		return SpaceRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
+	identity, err := r.identityProvider.GetIdentity(ctx, info)
+	if err != nil {
+		return SpaceRecord{}, fmt.Errorf("failed to get identity: %w", err)
+	}
+
 	cfSpace := &korifiv1alpha1.CFSpace{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      SpacePrefix + uuid.NewString(),
-			Namespace: message.OrganizationGUID,
+			Name:        SpacePrefix + uuid.NewString(),
+			Namespace:   message.OrganizationGUID,
+			Annotations: withCreatedBy(nil, identity),
 		},
 		Spec: korifiv1alpha1.CFSpaceSpec{
 			DisplayName: message.Name,
 		},
 	}
+	span.SetAttributes(TraceGUIDKey.String(cfSpace.Name))
+
 	err = userClient.Create(ctx, cfSpace)
 	if err != nil {
 		return SpaceRecord{}, apierrors.FromK8sError(err, SpaceResourceType)
 	}
-
-	cfSpace, err = r.conditionAwaiter.AwaitCondition(ctx, userClient, cfSpace, StatusConditionReady)
+	recordEvent(r.eventRecorder, cfSpace, "SpaceCreated", "Space %q created by %s", message.Name, identity.Name)
+
+	watchCtx, watchSpan := startSpan(ctx, r.tracer, "watch", SpaceResourceType, cfSpace.Name)
+	awaitStart := time.Now()
+	cfSpace, err = r.conditionAwaiter.AwaitCondition(watchCtx, userClient, cfSpace, StatusConditionReady)
+	endSpan(watchSpan, err)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return SpaceRecord{}, NamespaceProvisionTimeoutError{ResourceType: SpaceResourceType, Elapsed: time.Since(awaitStart), Err: err}
+	}
 	if err != nil {
 		return SpaceRecord{}, apierrors.FromK8sError(err, SpaceResourceType)
 	}
@@ -112,24 +218,72 @@ func (r *SpaceRepo) CreateSpace(ctx context.Context, info authorization.Info, me
 	return cfSpaceToSpaceRecord(cfSpace), nil
 }
 
+// CreateOrgWithDefaultSpaces creates an org and then the spaces listed in
+// message.DefaultSpaceNames. As spaces can only be created once the org
+// namespace is ready, the org is always created synchronously.
+func (r *SpaceRepo) CreateOrgWithDefaultSpaces(ctx context.Context, info authorization.Info, message CreateOrgWithDefaultSpacesMessage) (OrgWithDefaultSpacesRecord, error) {
+	orgMessage := message.CreateOrgMessage
+	orgMessage.Async = false
+	orgRecord, err := r.orgRepo.CreateOrg(ctx, info, orgMessage)
+	if err != nil {
+		return OrgWithDefaultSpacesRecord{}, err
+	}
+
+	record := OrgWithDefaultSpacesRecord{
+		OrgRecord:           orgRecord,
+		DefaultSpaces:       []SpaceRecord{},
+		FailedDefaultSpaces: map[string]error{},
+	}
+
+	for _, spaceName := range message.DefaultSpaceNames {
+		spaceRecord, err := r.CreateSpace(ctx, info, CreateSpaceMessage{
+			Name:             spaceName,
+			OrganizationGUID: orgRecord.GUID,
+		})
+		if err != nil {
+			record.FailedDefaultSpaces[spaceName] = err
+			continue
+		}
+
+		record.DefaultSpaces = append(record.DefaultSpaces, spaceRecord)
+	}
+
+	return record, nil
+}
+
 func (r *SpaceRepo) ListSpaces(ctx context.Context, info authorization.Info, message ListSpacesMessage) ([]SpaceRecord, error) {
-	userClient, err := r.userClientFactory.BuildClient(info)
+	records, _, err := r.ListSpacesPage(ctx, info, message)
+	return records, err
+}
+
+// ListSpacesPage lists the spaces like ListSpaces and also describes the
+// returned page, so that pagination links can be built
+func (r *SpaceRepo) ListSpacesPage(ctx context.Context, info authorization.Info, message ListSpacesMessage) (_ []SpaceRecord, _ PageInfo, err error) {
+	ctx, span := startSpan(ctx, r.tracer, "ListSpaces", SpaceResourceType, "")
+	defer func() { endSpan(span, err) }()
+
+	labelSelector, err := labels.Parse(message.LabelSelector)
 	if err != nil {
-		return []SpaceRecord{}, fmt.Errorf("failed to build user client: %w", err)
+		return nil, PageInfo{}, apierrors.NewUnprocessableEntityError(err, "invalid label selector")
 	}
 
-	authorizedOrgNamespaces, err := r.nsPerms.GetAuthorizedOrgNamespaces(ctx, info)
+	annotationPredicate, err := AnnotationSelectorPredicate(message.AnnotationSelector, func(s korifiv1alpha1.CFSpace) map[string]string { return s.Annotations })
 	if err != nil {
-		return nil, err
+		return nil, PageInfo{}, err
 	}
 
-	cfSpaces := []korifiv1alpha1.CFSpace{}
+	userClient, err := r.userClientFactory.BuildClient(info)
+	if err != nil {
+		return []SpaceRecord{}, PageInfo{}, fmt.Errorf("failed to build user client: %w", err)
+	}
 
-	authorizedSpaceNamespaces, err := r.nsPerms.GetAuthorizedSpaceNamespaces(ctx, info)
+	authorizedOrgNamespaces, authorizedSpaceNamespaces, err := r.getAuthorizedNamespaces(ctx, info)
 	if err != nil {
-		return nil, err
+		return nil, PageInfo{}, err
 	}
 
+	cfSpaces := []korifiv1alpha1.CFSpace{}
+
 	preds := []func(korifiv1alpha1.CFSpace) bool{
 		func(s korifiv1alpha1.CFSpace) bool { return authorizedSpaceNamespaces[s.Name] },
 		func(s korifiv1alpha1.CFSpace) bool {
@@ -137,6 +291,10 @@ func (r *SpaceRepo) ListSpaces(ctx context.Context, info authorization.Info, mes
 		},
 		SetPredicate(message.GUIDs, func(s korifiv1alpha1.CFSpace) string { return s.Name }),
 		SetPredicate(message.Names, func(s korifiv1alpha1.CFSpace) string { return s.Spec.DisplayName }),
+		func(s korifiv1alpha1.CFSpace) bool {
+			return message.CreatedBy == "" || s.Annotations[CreatedByAnnotation] == message.CreatedBy
+		},
+		annotationPredicate,
 	}
 
 	orgGUIDs := NewSet(message.OrganizationGUIDs...)
@@ -147,12 +305,12 @@ func (r *SpaceRepo) ListSpaces(ctx context.Context, info authorization.Info, mes
 
 		cfSpaceList := new(korifiv1alpha1.CFSpaceList)
 
-		err = userClient.List(ctx, cfSpaceList, client.InNamespace(org))
+		err = userClient.List(ctx, cfSpaceList, client.InNamespace(org), client.MatchingLabelsSelector{Selector: labelSelector})
 		if k8serrors.IsForbidden(err) {
 			continue
 		}
 		if err != nil {
-			return nil, apierrors.FromK8sError(err, SpaceResourceType)
+			return nil, PageInfo{}, apierrors.FromK8sError(err, SpaceResourceType)
 		}
 
 		cfSpaces = append(cfSpaces, Filter(cfSpaceList.Items, preds...)...)
@@ -163,10 +321,132 @@ func (r *SpaceRepo) ListSpaces(ctx context.Context, info authorization.Info, mes
 		records = append(records, cfSpaceToSpaceRecord(&cfSpaces[i]))
 	}
 
+	records, pageInfo := Paginate(records, func(s SpaceRecord) string { return s.GUID }, message.Page, message.PerPage)
+
+	if message.IncludeOrgName {
+		if err = r.populateOrgNames(ctx, info, records); err != nil {
+			return nil, PageInfo{}, err
+		}
+	}
+
+	return records, pageInfo, nil
+}
+
+// ListProvisioningSpaces returns the spaces that are still being created,
+// i.e. whose CFSpace exists but is not ready yet, in the orgs the user can
+// see. As with ListProvisioningOrgs, spaces created by the user are included
+// before they have been granted access to them.
+func (r *SpaceRepo) ListProvisioningSpaces(ctx context.Context, info authorization.Info) ([]SpaceRecord, error) {
+	identity, err := r.identityProvider.GetIdentity(ctx, info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	authorizedOrgNamespaces, authorizedSpaceNamespaces, err := r.getAuthorizedNamespaces(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+
+	preds := []func(korifiv1alpha1.CFSpace) bool{
+		func(s korifiv1alpha1.CFSpace) bool {
+			return !meta.IsStatusConditionTrue(s.Status.Conditions, StatusConditionReady)
+		},
+		func(s korifiv1alpha1.CFSpace) bool {
+			return authorizedSpaceNamespaces[s.Name] || s.Annotations[CreatedByAnnotation] == identity.Name
+		},
+	}
+
+	records := []SpaceRecord{}
+	for org := range authorizedOrgNamespaces {
+		cfSpaceList := new(korifiv1alpha1.CFSpaceList)
+		err = userClient.List(ctx, cfSpaceList, client.InNamespace(org))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return nil, apierrors.FromK8sError(err, SpaceResourceType)
+		}
+
+		provisioningSpaces := Filter(cfSpaceList.Items, preds...)
+		for i := range provisioningSpaces {
+			records = append(records, cfSpaceToSpaceRecord(&provisioningSpaces[i]))
+		}
+	}
+
 	return records, nil
 }
 
-func (r *SpaceRepo) GetSpace(ctx context.Context, info authorization.Info, spaceGUID string) (SpaceRecord, error) {
+func (r *SpaceRepo) getAuthorizedNamespaces(ctx context.Context, info authorization.Info) (_ map[string]bool, _ map[string]bool, err error) {
+	ctx, span := startSpan(ctx, r.tracer, "permissions", SpaceResourceType, "")
+	defer func() { endSpan(span, err) }()
+
+	authorizedOrgNamespaces, err := r.nsPerms.GetAuthorizedOrgNamespaces(ctx, info)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	authorizedSpaceNamespaces, err := r.nsPerms.GetAuthorizedSpaceNamespaces(ctx, info)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return authorizedOrgNamespaces, authorizedSpaceNamespaces, nil
+}
+
+// GetSpaceWithOrgName returns the space like GetSpace does, with the name of
+// its parent org populated
+func (r *SpaceRepo) GetSpaceWithOrgName(ctx context.Context, info authorization.Info, spaceGUID string) (SpaceRecord, error) {
+	spaceRecord, err := r.GetSpace(ctx, info, spaceGUID)
+	if err != nil {
+		return SpaceRecord{}, err
+	}
+
+	records := []SpaceRecord{spaceRecord}
+	if err = r.populateOrgNames(ctx, info, records); err != nil {
+		return SpaceRecord{}, err
+	}
+
+	return records[0], nil
+}
+
+// populateOrgNames resolves the parent orgs of all the space records with a
+// single org lookup
+func (r *SpaceRepo) populateOrgNames(ctx context.Context, info authorization.Info, records []SpaceRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	orgGUIDs := NewSet[string]()
+	for _, record := range records {
+		orgGUIDs[record.OrganizationGUID] = struct{}{}
+	}
+
+	orgs, err := r.orgRepo.ListOrgs(ctx, info, ListOrgsMessage{GUIDs: maps.Keys(orgGUIDs)})
+	if err != nil {
+		return fmt.Errorf("failed to list parent orgs: %w", err)
+	}
+
+	orgNames := map[string]string{}
+	for _, org := range orgs {
+		orgNames[org.GUID] = org.Name
+	}
+
+	for i := range records {
+		records[i].OrganizationName = orgNames[records[i].OrganizationGUID]
+	}
+
+	return nil
+}
+
+func (r *SpaceRepo) GetSpace(ctx context.Context, info authorization.Info, spaceGUID string) (_ SpaceRecord, err error) {
+	ctx, span := startSpan(ctx, r.tracer, "GetSpace", SpaceResourceType, spaceGUID)
+	defer func() { endSpan(span, err) }()
+
 	ns, err := r.namespaceRetriever.NamespaceFor(ctx, spaceGUID, SpaceResourceType)
 	if err != nil {
 		return SpaceRecord{}, err
@@ -186,6 +466,104 @@ func (r *SpaceRepo) GetSpace(ctx context.Context, info authorization.Info, space
 	return cfSpaceToSpaceRecord(cfSpace), nil
 }
 
+// GetSpaceReadiness returns whether a space is ready and, if it is not, the
+// reason reported by the space controller
+func (r *SpaceRepo) GetSpaceReadiness(ctx context.Context, info authorization.Info, spaceGUID string) (SpaceReadinessRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, spaceGUID, SpaceResourceType)
+	if err != nil {
+		return SpaceReadinessRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(info)
+	if err != nil {
+		return SpaceReadinessRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpace := &korifiv1alpha1.CFSpace{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: spaceGUID}, cfSpace)
+	if err != nil {
+		return SpaceReadinessRecord{}, fmt.Errorf("failed to get space: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	record := SpaceReadinessRecord{
+		GUID:  spaceGUID,
+		State: SpaceStateUnknown,
+	}
+
+	readyCondition := meta.FindStatusCondition(cfSpace.Status.Conditions, StatusConditionReady)
+	if readyCondition == nil {
+		return record, nil
+	}
+
+	switch readyCondition.Status {
+	case metav1.ConditionTrue:
+		record.State = SpaceStateReady
+		return record, nil
+	case metav1.ConditionFalse:
+		record.State = SpaceStateNotReady
+	}
+
+	record.Reason = readyCondition.Reason
+	record.Message = readyCondition.Message
+
+	return record, nil
+}
+
+// RestartAppsInSpace bumps the revision of every app in the space, which makes
+// the app controller roll their workloads. Failing to restart an app does not
+// stop the others from being restarted; the failure is reported in its record.
+func (r *SpaceRepo) RestartAppsInSpace(ctx context.Context, info authorization.Info, spaceGUID string) (_ []AppRestartRecord, err error) {
+	ctx, span := startSpan(ctx, r.tracer, "RestartAppsInSpace", SpaceResourceType, spaceGUID)
+	defer func() { endSpan(span, err) }()
+
+	_, err = r.GetSpace(ctx, info, spaceGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	appList := &korifiv1alpha1.CFAppList{}
+	err = userClient.List(ctx, appList, client.InNamespace(spaceGUID))
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, AppResourceType)
+	}
+
+	records := make([]AppRestartRecord, 0, len(appList.Items))
+	for i := range appList.Items {
+		records = append(records, restartApp(ctx, userClient, &appList.Items[i]))
+	}
+
+	return records, nil
+}
+
+func restartApp(ctx context.Context, userClient client.Client, cfApp *korifiv1alpha1.CFApp) AppRestartRecord {
+	record := AppRestartRecord{
+		AppGUID:  cfApp.Name,
+		Revision: cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey],
+	}
+
+	newRev, err := bumpAppRev(record.Revision)
+	if err != nil {
+		record.Err = fmt.Errorf("expected app-rev to be an integer: %w", err)
+		return record
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfApp, func() {
+		cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = newRev
+	})
+	if err != nil {
+		record.Err = apierrors.FromK8sError(err, AppResourceType)
+		return record
+	}
+
+	record.Revision = newRev
+	return record
+}
+
 func cfSpaceToSpaceRecord(cfSpace *korifiv1alpha1.CFSpace) SpaceRecord {
 	return SpaceRecord{
 		Name:             cfSpace.Spec.DisplayName,
@@ -205,14 +583,40 @@ func (r *SpaceRepo) DeleteSpace(ctx context.Context, info authorization.Info, me
 		return fmt.Errorf("failed to build user client: %w", err)
 	}
 
-	err = userClient.Delete(ctx, &korifiv1alpha1.CFSpace{
+	if r.validateSpaceOrg {
+		if err = r.checkSpaceOrg(ctx, message.GUID, message.OrganizationGUID); err != nil {
+			return err
+		}
+	}
+
+	cfSpace := &korifiv1alpha1.CFSpace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      message.GUID,
 			Namespace: message.OrganizationGUID,
 		},
-	})
+	}
+	err = userClient.Delete(ctx, cfSpace)
+	if err != nil {
+		return apierrors.FromK8sError(err, SpaceResourceType)
+	}
+	recordEvent(r.eventRecorder, cfSpace, "SpaceDeleted", "Space deletion requested")
 
-	return apierrors.FromK8sError(err, SpaceResourceType)
+	return nil
+}
+
+// checkSpaceOrg returns a not found error unless the space resides in the
+// namespace of the given org
+func (r *SpaceRepo) checkSpaceOrg(ctx context.Context, spaceGUID, orgGUID string) error {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, spaceGUID, SpaceResourceType)
+	if err != nil {
+		return err
+	}
+
+	if ns != orgGUID {
+		return apierrors.NewNotFoundError(fmt.Errorf("space %q does not belong to org %q", spaceGUID, orgGUID), SpaceResourceType)
+	}
+
+	return nil
 }
 
 func (r *SpaceRepo) PatchSpaceMetadata(ctx context.Context, authInfo authorization.Info, message PatchSpaceMetadataMessage) (SpaceRecord, error) {
@@ -237,6 +641,38 @@ func (r *SpaceRepo) PatchSpaceMetadata(ctx context.Context, authInfo authorizati
 	return cfSpaceToSpaceRecord(cfSpace), nil
 }
 
+// UpdateSpace renames the space when a name is given and applies the metadata
+// patch. The space validating webhook rejects renaming a space to the name of
+// another space in the same org, which is returned as an
+// UnprocessableEntityError.
+func (r *SpaceRepo) UpdateSpace(ctx context.Context, authInfo authorization.Info, message UpdateSpaceMessage) (_ SpaceRecord, err error) {
+	ctx, span := startSpan(ctx, r.tracer, "UpdateSpace", SpaceResourceType, message.GUID)
+	defer func() { endSpan(span, err) }()
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpace := new(korifiv1alpha1.CFSpace)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.OrgGUID, Name: message.GUID}, cfSpace)
+	if err != nil {
+		return SpaceRecord{}, fmt.Errorf("failed to get space: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSpace, func() {
+		if message.Name != nil {
+			cfSpace.Spec.DisplayName = *message.Name
+		}
+		message.Apply(cfSpace)
+	})
+	if err != nil {
+		return SpaceRecord{}, apierrors.FromK8sError(err, SpaceResourceType)
+	}
+
+	return cfSpaceToSpaceRecord(cfSpace), nil
+}
+
 func (r *SpaceRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, spaceGUID string) (*time.Time, error) {
 	space, err := r.GetSpace(ctx, authInfo, spaceGUID)
 	if err != nil {
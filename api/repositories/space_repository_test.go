@@ -3,6 +3,12 @@ package repositories_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -34,18 +40,18 @@ var _ = Describe("SpaceRepository", func() {
 	)
 
 	BeforeEach(func() {
-		orgRepo = repositories.NewOrgRepo(rootNamespace, k8sClient, userClientFactory, nsPerms, &FakeAwaiter[
+		orgRepo = repositories.NewOrgRepo(rootNamespace, k8sClient, userClientFactory, nsPerms, idProvider, &FakeAwaiter[
 			*korifiv1alpha1.CFOrg,
 			korifiv1alpha1.CFOrgList,
 			*korifiv1alpha1.CFOrgList,
-		]{})
+		]{}, nil, nil, repositories.OrgRepoConfig{})
 
 		conditionAwaiter = &FakeAwaiter[
 			*korifiv1alpha1.CFSpace,
 			korifiv1alpha1.CFSpaceList,
 			*korifiv1alpha1.CFSpaceList,
 		]{}
-		spaceRepo = repositories.NewSpaceRepo(namespaceRetriever, orgRepo, userClientFactory, nsPerms, conditionAwaiter)
+		spaceRepo = repositories.NewSpaceRepo(namespaceRetriever, orgRepo, userClientFactory, nsPerms, idProvider, conditionAwaiter, nil, nil, repositories.SpaceRepoConfig{})
 	})
 
 	Describe("CreateSpace", func() {
@@ -139,6 +145,14 @@ var _ = Describe("SpaceRepository", func() {
 				Expect(spaceRecord.DeletedAt).To(BeNil())
 			})
 
+			It("records the user that created the space", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				spaceCR := new(korifiv1alpha1.CFSpace)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: orgGUID, Name: spaceRecord.GUID}, spaceCR)).To(Succeed())
+				Expect(spaceCR.Annotations).To(HaveKeyWithValue(repositories.CreatedByAnnotation, userName))
+			})
+
 			When("the space does not become ready", func() {
 				BeforeEach(func() {
 					conditionAwaiter.AwaitConditionReturns(&korifiv1alpha1.CFSpace{}, errors.New("time-out-err"))
@@ -149,6 +163,21 @@ var _ = Describe("SpaceRepository", func() {
 				})
 			})
 
+			When("the space is not provisioned within the timeout", func() {
+				BeforeEach(func() {
+					conditionAwaiter.AwaitConditionReturns(&korifiv1alpha1.CFSpace{}, fmt.Errorf("time-out-err: %w", context.DeadlineExceeded))
+				})
+
+				It("returns a namespace provision timeout error", func() {
+					var timeoutErr repositories.NamespaceProvisionTimeoutError
+					Expect(errors.As(createErr, &timeoutErr)).To(BeTrue())
+					Expect(timeoutErr.ResourceType).To(Equal(repositories.SpaceResourceType))
+					Expect(timeoutErr.Elapsed).To(BeNumerically(">=", 0))
+					Expect(timeoutErr.HttpStatus()).To(Equal(http.StatusServiceUnavailable))
+					Expect(createErr).To(MatchError(ContainSubstring("time-out-err")))
+				})
+			})
+
 			When("the org does not exist", func() {
 				BeforeEach(func() {
 					orgGUID = "does-not-exist"
@@ -168,6 +197,231 @@ var _ = Describe("SpaceRepository", func() {
 					Expect(createErr).To(HaveOccurred())
 				})
 			})
+
+			When("space creations in the org are throttled", func() {
+				const maxConcurrentCreations = 2
+
+				var inFlight, maxInFlight int32
+
+				BeforeEach(func() {
+					orgRepo = repositories.NewOrgRepo(rootNamespace, k8sClient, userClientFactory, nsPerms, idProvider, &FakeAwaiter[
+						*korifiv1alpha1.CFOrg,
+						korifiv1alpha1.CFOrgList,
+						*korifiv1alpha1.CFOrgList,
+					]{}, nil, nil, repositories.OrgRepoConfig{MaxConcurrentSpaceCreations: maxConcurrentCreations})
+					spaceRepo = repositories.NewSpaceRepo(namespaceRetriever, orgRepo, userClientFactory, nsPerms, idProvider, conditionAwaiter, nil, nil, repositories.SpaceRepoConfig{})
+
+					inFlight = 0
+					maxInFlight = 0
+					conditionAwaiter.AwaitConditionStub = func(_ context.Context, _ client.WithWatch, object client.Object, _ string) (*korifiv1alpha1.CFSpace, error) {
+						current := atomic.AddInt32(&inFlight, 1)
+						defer atomic.AddInt32(&inFlight, -1)
+
+						for {
+							observedMax := atomic.LoadInt32(&maxInFlight)
+							if current <= observedMax || atomic.CompareAndSwapInt32(&maxInFlight, observedMax, current) {
+								break
+							}
+						}
+
+						time.Sleep(200 * time.Millisecond)
+
+						return object.(*korifiv1alpha1.CFSpace), nil
+					}
+				})
+
+				It("queues creations beyond the limit until they all succeed", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					var wg sync.WaitGroup
+					createErrs := make([]error, 5)
+					for i := range createErrs {
+						wg.Add(1)
+						go func(i int) {
+							defer GinkgoRecover()
+							defer wg.Done()
+
+							_, createErrs[i] = spaceRepo.CreateSpace(ctx, authInfo, repositories.CreateSpaceMessage{
+								Name:             prefixedGUID("concurrent-space"),
+								OrganizationGUID: orgGUID,
+							})
+						}(i)
+					}
+					wg.Wait()
+
+					for _, err := range createErrs {
+						Expect(err).NotTo(HaveOccurred())
+					}
+					Expect(conditionAwaiter.AwaitConditionCallCount()).To(Equal(6))
+					Expect(atomic.LoadInt32(&maxInFlight)).To(BeEquivalentTo(maxConcurrentCreations))
+				})
+
+				When("no slot frees up before the request times out", func() {
+					var unblock chan struct{}
+
+					BeforeEach(func() {
+						unblock = make(chan struct{})
+						DeferCleanup(func() { close(unblock) })
+
+						conditionAwaiter.AwaitConditionStub = func(_ context.Context, _ client.WithWatch, object client.Object, _ string) (*korifiv1alpha1.CFSpace, error) {
+							cfSpace := object.(*korifiv1alpha1.CFSpace)
+							if strings.HasPrefix(cfSpace.Spec.DisplayName, "blocking-space") {
+								<-unblock
+							}
+							return cfSpace, nil
+						}
+					})
+
+					It("returns a space creation throttled error", func() {
+						for i := 0; i < maxConcurrentCreations; i++ {
+							go func() {
+								defer GinkgoRecover()
+								_, _ = spaceRepo.CreateSpace(ctx, authInfo, repositories.CreateSpaceMessage{
+									Name:             prefixedGUID("blocking-space"),
+									OrganizationGUID: orgGUID,
+								})
+							}()
+						}
+						Eventually(conditionAwaiter.AwaitConditionCallCount).Should(Equal(1 + maxConcurrentCreations))
+
+						timeoutCtx, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+						defer cancel()
+						_, err := spaceRepo.CreateSpace(timeoutCtx, authInfo, repositories.CreateSpaceMessage{
+							Name:             prefixedGUID("throttled-space"),
+							OrganizationGUID: orgGUID,
+						})
+						var throttledErr repositories.SpaceCreationThrottledError
+						Expect(errors.As(err, &throttledErr)).To(BeTrue())
+						Expect(throttledErr.OrgGUID).To(Equal(orgGUID))
+						Expect(throttledErr.HttpStatus()).To(Equal(http.StatusServiceUnavailable))
+						Expect(err).To(MatchError(context.DeadlineExceeded))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("CreateOrgWithDefaultSpaces", func() {
+		var (
+			orgConditionAwaiter *FakeAwaiter[
+				*korifiv1alpha1.CFOrg,
+				korifiv1alpha1.CFOrgList,
+				*korifiv1alpha1.CFOrgList,
+			]
+			defaultSpaceNames []string
+			record            repositories.OrgWithDefaultSpacesRecord
+			createErr         error
+		)
+
+		BeforeEach(func() {
+			orgConditionAwaiter = &FakeAwaiter[
+				*korifiv1alpha1.CFOrg,
+				korifiv1alpha1.CFOrgList,
+				*korifiv1alpha1.CFOrgList,
+			]{}
+			orgConditionAwaiter.AwaitConditionStub = func(ctx context.Context, _ client.WithWatch, object client.Object, _ string) (*korifiv1alpha1.CFOrg, error) {
+				cfOrg, ok := object.(*korifiv1alpha1.CFOrg)
+				Expect(ok).To(BeTrue())
+
+				Expect(k8sClient.Create(ctx, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   cfOrg.Name,
+						Labels: map[string]string{korifiv1alpha1.OrgNameKey: cfOrg.Spec.DisplayName},
+					},
+				})).To(Succeed())
+				createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+
+				Expect(k8s.Patch(ctx, k8sClient, cfOrg, func() {
+					cfOrg.Status.GUID = cfOrg.Name
+					meta.SetStatusCondition(&cfOrg.Status.Conditions, metav1.Condition{
+						Type:   "Ready",
+						Status: metav1.ConditionTrue,
+						Reason: "blah",
+					})
+				})).To(Succeed())
+
+				return cfOrg, nil
+			}
+			orgRepo = repositories.NewOrgRepo(rootNamespace, k8sClient, userClientFactory, nsPerms, idProvider, orgConditionAwaiter, nil, nil, repositories.OrgRepoConfig{})
+			spaceRepo = repositories.NewSpaceRepo(namespaceRetriever, orgRepo, userClientFactory, nsPerms, idProvider, conditionAwaiter, nil, nil, repositories.SpaceRepoConfig{})
+
+			conditionAwaiter.AwaitConditionStub = func(ctx context.Context, _ client.WithWatch, object client.Object, _ string) (*korifiv1alpha1.CFSpace, error) {
+				cfSpace, ok := object.(*korifiv1alpha1.CFSpace)
+				Expect(ok).To(BeTrue())
+
+				Expect(k8s.Patch(ctx, k8sClient, cfSpace, func() {
+					cfSpace.Status.GUID = cfSpace.Name
+					meta.SetStatusCondition(&cfSpace.Status.Conditions, metav1.Condition{
+						Type:   "Ready",
+						Status: metav1.ConditionTrue,
+						Reason: "blah",
+					})
+				})).To(Succeed())
+
+				return cfSpace, nil
+			}
+
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			defaultSpaceNames = []string{"dev", "staging"}
+		})
+
+		JustBeforeEach(func() {
+			record, createErr = spaceRepo.CreateOrgWithDefaultSpaces(ctx, authInfo, repositories.CreateOrgWithDefaultSpacesMessage{
+				CreateOrgMessage: repositories.CreateOrgMessage{
+					Name:  prefixedGUID("org"),
+					Async: true,
+				},
+				DefaultSpaceNames: defaultSpaceNames,
+			})
+		})
+
+		It("creates the org and waits for it to become ready", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(record.GUID).To(HavePrefix(repositories.OrgPrefix))
+			Expect(orgConditionAwaiter.AwaitConditionCallCount()).To(Equal(1))
+		})
+
+		It("creates the default spaces in the org", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(record.FailedDefaultSpaces).To(BeEmpty())
+			Expect(record.DefaultSpaces).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"Name": Equal("dev"), "OrganizationGUID": Equal(record.GUID)}),
+				MatchFields(IgnoreExtras, Fields{"Name": Equal("staging"), "OrganizationGUID": Equal(record.GUID)}),
+			))
+
+			spaceList := new(korifiv1alpha1.CFSpaceList)
+			Expect(k8sClient.List(ctx, spaceList, client.InNamespace(record.GUID))).To(Succeed())
+			Expect(spaceList.Items).To(HaveLen(2))
+		})
+
+		When("a default space cannot be created", func() {
+			BeforeEach(func() {
+				defaultSpaceNames = []string{"dev", "this-string-has-illegal-characters-ц"}
+			})
+
+			It("still creates the org and the other default spaces", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record.GUID).NotTo(BeEmpty())
+				Expect(record.DefaultSpaces).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"Name": Equal("dev")}),
+				))
+			})
+
+			It("reports the failure", func() {
+				Expect(record.FailedDefaultSpaces).To(HaveLen(1))
+				Expect(record.FailedDefaultSpaces).To(HaveKeyWithValue("this-string-has-illegal-characters-ц", HaveOccurred()))
+			})
+		})
+
+		When("the org cannot be created", func() {
+			BeforeEach(func() {
+				orgConditionAwaiter.AwaitConditionReturns(nil, errors.New("org-err"))
+			})
+
+			It("returns the error without creating any space", func() {
+				Expect(createErr).To(MatchError(ContainSubstring("org-err")))
+				Expect(conditionAwaiter.AwaitConditionCallCount()).To(BeZero())
+			})
 		})
 	})
 
@@ -224,6 +478,138 @@ var _ = Describe("SpaceRepository", func() {
 			))
 		})
 
+		It("populates the updated at time from the last update of the space", func() {
+			spaces, err := spaceRepo.ListSpaces(ctx, authInfo, repositories.ListSpacesMessage{})
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, space := range spaces {
+				Expect(space.UpdatedAt).To(PointTo(BeTemporally(">=", space.CreatedAt)))
+				Expect(space.UpdatedAt).To(PointTo(BeTemporally("~", time.Now(), timeCheckThreshold)))
+			}
+		})
+
+		When("a page is requested", func() {
+			It("returns the spaces of the page sorted by guid, counting only authorized spaces", func() {
+				sortedGUIDs := []string{space11.Name, space12.Name, space21.Name, space22.Name}
+				sort.Strings(sortedGUIDs)
+
+				spaces, pageInfo, err := spaceRepo.ListSpacesPage(ctx, authInfo, repositories.ListSpacesMessage{Page: 2, PerPage: 3})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(spaces).To(HaveExactElements(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(sortedGUIDs[3])}),
+				))
+				Expect(pageInfo).To(Equal(repositories.PageInfo{TotalResults: 4, HasNextPage: false}))
+			})
+		})
+
+		It("does not populate the org names by default", func() {
+			spaces, err := spaceRepo.ListSpaces(ctx, authInfo, repositories.ListSpacesMessage{})
+			Expect(err).NotTo(HaveOccurred())
+			for _, space := range spaces {
+				Expect(space.OrganizationName).To(BeEmpty())
+			}
+		})
+
+		When("the org names are included", func() {
+			It("populates the name of the parent org of each space", func() {
+				spaces, err := spaceRepo.ListSpaces(ctx, authInfo, repositories.ListSpacesMessage{IncludeOrgName: true})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(spaces).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(space11.Name), "OrganizationName": Equal(cfOrg1.Spec.DisplayName)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(space12.Name), "OrganizationName": Equal(cfOrg1.Spec.DisplayName)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(space21.Name), "OrganizationName": Equal(cfOrg2.Spec.DisplayName)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(space22.Name), "OrganizationName": Equal(cfOrg2.Spec.DisplayName)}),
+				))
+			})
+		})
+
+		When("filtering by the user that created the spaces", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, space12, func() {
+					space12.Annotations = map[string]string{repositories.CreatedByAnnotation: userName}
+				})).To(Succeed())
+				Expect(k8s.PatchResource(ctx, k8sClient, space21, func() {
+					space21.Annotations = map[string]string{repositories.CreatedByAnnotation: "someone-else"}
+				})).To(Succeed())
+			})
+
+			It("returns only the spaces created by that user", func() {
+				spaces, err := spaceRepo.ListSpaces(ctx, authInfo, repositories.ListSpacesMessage{CreatedBy: userName})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(spaces).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(space12.Name),
+					}),
+				))
+			})
+		})
+
+		When("filtering by label", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, space11, func() {
+					space11.Labels = map[string]string{"environment": "prod"}
+				})).To(Succeed())
+				Expect(k8s.PatchResource(ctx, k8sClient, space21, func() {
+					space21.Labels = map[string]string{"environment": "staging"}
+				})).To(Succeed())
+				Expect(k8s.PatchResource(ctx, k8sClient, space22, func() {
+					space22.Labels = map[string]string{"environment": "dev"}
+				})).To(Succeed())
+			})
+
+			It("returns the spaces matching the selector", func() {
+				spaces, err := spaceRepo.ListSpaces(ctx, authInfo, repositories.ListSpacesMessage{LabelSelector: "environment in (prod,staging)"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(spaces).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(space11.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(space21.Name)}),
+				))
+			})
+
+			It("returns an unprocessable entity error for an invalid selector", func() {
+				_, err := spaceRepo.ListSpaces(ctx, authInfo, repositories.ListSpacesMessage{LabelSelector: "environment in prod"})
+				Expect(err).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+
+		When("filtering by annotation", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, space11, func() {
+					space11.Annotations = map[string]string{"lifecycle": "retired"}
+				})).To(Succeed())
+				Expect(k8s.PatchResource(ctx, k8sClient, space22, func() {
+					space22.Annotations = map[string]string{"lifecycle": "active"}
+				})).To(Succeed())
+			})
+
+			It("returns the spaces having the annotation", func() {
+				spaces, err := spaceRepo.ListSpaces(ctx, authInfo, repositories.ListSpacesMessage{AnnotationSelector: "lifecycle"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(spaces).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(space11.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(space22.Name)}),
+				))
+			})
+
+			It("returns the spaces having the annotation value", func() {
+				spaces, err := spaceRepo.ListSpaces(ctx, authInfo, repositories.ListSpacesMessage{AnnotationSelector: "lifecycle=active"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(spaces).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(space22.Name)}),
+				))
+			})
+
+			It("returns an unprocessable entity error for an invalid selector", func() {
+				_, err := spaceRepo.ListSpaces(ctx, authInfo, repositories.ListSpacesMessage{AnnotationSelector: "=active"})
+				Expect(err).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+
 		When("the space anchor is not ready", func() {
 			BeforeEach(func() {
 				meta.SetStatusCondition(&(space11.Status.Conditions), metav1.Condition{
@@ -368,6 +754,59 @@ var _ = Describe("SpaceRepository", func() {
 		})
 	})
 
+	Describe("ListProvisioningSpaces", func() {
+		var (
+			provisioningSpace, createdSpace *korifiv1alpha1.CFSpace
+			spaces                          []repositories.SpaceRecord
+			listErr                         error
+		)
+
+		setNotReady := func(cfSpace *korifiv1alpha1.CFSpace) {
+			meta.SetStatusCondition(&(cfSpace.Status.Conditions), metav1.Condition{
+				Type:    "Ready",
+				Status:  metav1.ConditionFalse,
+				Reason:  "Provisioning",
+				Message: "Provisioning",
+			})
+			Expect(k8sClient.Status().Update(ctx, cfSpace)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			cfOrg := createOrgWithCleanup(ctx, prefixedGUID("org"))
+			createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+
+			readySpace := createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("ready-space"))
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, readySpace.Name)
+
+			provisioningSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("provisioning-space"))
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, provisioningSpace.Name)
+			setNotReady(provisioningSpace)
+
+			createdSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("created-space"))
+			Expect(k8s.PatchResource(ctx, k8sClient, createdSpace, func() {
+				createdSpace.Annotations = map[string]string{repositories.CreatedByAnnotation: userName}
+			})).To(Succeed())
+			setNotReady(createdSpace)
+
+			setNotReady(createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("other-space")))
+
+			otherOrg := createOrgWithCleanup(ctx, prefixedGUID("other-org"))
+			setNotReady(createSpaceWithCleanup(ctx, otherOrg.Name, prefixedGUID("other-org-space")))
+		})
+
+		JustBeforeEach(func() {
+			spaces, listErr = spaceRepo.ListProvisioningSpaces(ctx, authInfo)
+		})
+
+		It("returns the provisioning spaces the user can see or has created", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(spaces).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(provisioningSpace.Name)}),
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(createdSpace.Name)}),
+			))
+		})
+	})
+
 	Describe("GetSpace", func() {
 		var (
 			cfOrg   *korifiv1alpha1.CFOrg
@@ -391,6 +830,13 @@ var _ = Describe("SpaceRepository", func() {
 				Expect(spaceRecord.Name).To(Equal("the-space"))
 				Expect(spaceRecord.OrganizationGUID).To(Equal(cfOrg.Name))
 			})
+
+			It("can get the space with the name of its org", func() {
+				spaceRecord, err := spaceRepo.GetSpaceWithOrgName(ctx, authInfo, cfSpace.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(spaceRecord.GUID).To(Equal(cfSpace.Name))
+				Expect(spaceRecord.OrganizationName).To(Equal("the-org"))
+			})
 		})
 
 		When("the user does not have a role binding in the space", func() {
@@ -408,6 +854,146 @@ var _ = Describe("SpaceRepository", func() {
 		})
 	})
 
+	Describe("GetSpaceReadiness", func() {
+		var (
+			cfSpace         *korifiv1alpha1.CFSpace
+			readinessRecord repositories.SpaceReadinessRecord
+			getErr          error
+		)
+
+		BeforeEach(func() {
+			cfOrg := createOrgWithCleanup(ctx, prefixedGUID("org"))
+			createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+			cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space"))
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+		})
+
+		JustBeforeEach(func() {
+			readinessRecord, getErr = spaceRepo.GetSpaceReadiness(ctx, authInfo, cfSpace.Name)
+		})
+
+		It("reports the space as ready", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(readinessRecord).To(Equal(repositories.SpaceReadinessRecord{
+				GUID:  cfSpace.Name,
+				State: repositories.SpaceStateReady,
+			}))
+		})
+
+		When("the space is not ready", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, k8sClient, cfSpace, func() {
+					meta.SetStatusCondition(&cfSpace.Status.Conditions, metav1.Condition{
+						Type:    shared.StatusConditionReady,
+						Status:  metav1.ConditionFalse,
+						Reason:  "ServiceAccountPropagation",
+						Message: "failed to propagate service accounts",
+					})
+				})).To(Succeed())
+			})
+
+			It("returns the reason from the ready condition", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(readinessRecord).To(Equal(repositories.SpaceReadinessRecord{
+					GUID:    cfSpace.Name,
+					State:   repositories.SpaceStateNotReady,
+					Reason:  "ServiceAccountPropagation",
+					Message: "failed to propagate service accounts",
+				}))
+			})
+		})
+
+		When("the space has not been reconciled yet", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, k8sClient, cfSpace, func() {
+					cfSpace.Status.Conditions = nil
+				})).To(Succeed())
+			})
+
+			It("reports an unknown state", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(readinessRecord.State).To(Equal(repositories.SpaceStateUnknown))
+				Expect(readinessRecord.Reason).To(BeEmpty())
+			})
+		})
+
+		When("the space doesn't exist", func() {
+			BeforeEach(func() {
+				cfSpace = &korifiv1alpha1.CFSpace{ObjectMeta: metav1.ObjectMeta{Name: "non-existent-space"}}
+			})
+
+			It("errors", func() {
+				Expect(getErr).To(MatchError(ContainSubstring("not found")))
+			})
+		})
+	})
+
+	Describe("RestartAppsInSpace", func() {
+		var (
+			cfSpace        *korifiv1alpha1.CFSpace
+			app1, app2     *korifiv1alpha1.CFApp
+			restartRecords []repositories.AppRestartRecord
+			restartErr     error
+		)
+
+		BeforeEach(func() {
+			cfOrg := createOrgWithCleanup(ctx, prefixedGUID("org"))
+			cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space"))
+			app1 = createApp(cfSpace.Name)
+			app2 = createApp(cfSpace.Name)
+		})
+
+		JustBeforeEach(func() {
+			restartRecords, restartErr = spaceRepo.RestartAppsInSpace(ctx, authInfo, cfSpace.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(restartErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfSpace.Namespace)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("bumps the revision of every app in the space", func() {
+				Expect(restartErr).NotTo(HaveOccurred())
+				Expect(restartRecords).To(ConsistOf(
+					repositories.AppRestartRecord{AppGUID: app1.Name, Revision: "2"},
+					repositories.AppRestartRecord{AppGUID: app2.Name, Revision: "2"},
+				))
+
+				for _, app := range []*korifiv1alpha1.CFApp{app1, app2} {
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(app), app)).To(Succeed())
+					Expect(app.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppRevisionKey, "2"))
+				}
+			})
+
+			When("restarting one of the apps fails", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, app1, func() {
+						app1.Annotations[korifiv1alpha1.CFAppRevisionKey] = "not-a-number"
+					})).To(Succeed())
+				})
+
+				It("reports the failure and restarts the other app", func() {
+					Expect(restartErr).NotTo(HaveOccurred())
+					Expect(restartRecords).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{
+							"AppGUID": Equal(app1.Name),
+							"Err":     MatchError(ContainSubstring("expected app-rev to be an integer")),
+						}),
+						repositories.AppRestartRecord{AppGUID: app2.Name, Revision: "2"},
+					))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(app2), app2)).To(Succeed())
+					Expect(app2.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppRevisionKey, "2"))
+				})
+			})
+		})
+	})
+
 	Describe("DeleteSpace", func() {
 		var (
 			cfOrg   *korifiv1alpha1.CFOrg
@@ -446,6 +1032,38 @@ var _ = Describe("SpaceRepository", func() {
 					Expect(err).To(MatchError(ContainSubstring("not found")))
 				})
 			})
+
+			When("validating the org of the space", func() {
+				var otherOrg *korifiv1alpha1.CFOrg
+
+				BeforeEach(func() {
+					spaceRepo = repositories.NewSpaceRepo(namespaceRetriever, orgRepo, userClientFactory, nsPerms, idProvider, conditionAwaiter, nil, nil, repositories.SpaceRepoConfig{ValidateSpaceOrg: true})
+
+					otherOrg = createOrgWithCleanup(ctx, prefixedGUID("other-org"))
+					createRoleBinding(ctx, userName, adminRole.Name, otherOrg.Name)
+				})
+
+				It("deletes the space when it belongs to the org", func() {
+					err := spaceRepo.DeleteSpace(ctx, authInfo, repositories.DeleteSpaceMessage{
+						GUID:             cfSpace.Name,
+						OrganizationGUID: cfOrg.Name,
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					err = k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), &korifiv1alpha1.CFSpace{})
+					Expect(err).To(MatchError(ContainSubstring("not found")))
+				})
+
+				It("returns a not found error when the space belongs to another org", func() {
+					err := spaceRepo.DeleteSpace(ctx, authInfo, repositories.DeleteSpaceMessage{
+						GUID:             cfSpace.Name,
+						OrganizationGUID: otherOrg.Name,
+					})
+					Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), &korifiv1alpha1.CFSpace{})).To(Succeed())
+				})
+			})
 		})
 
 		When("the user does not have permission to delete spaces", func() {
@@ -683,6 +1301,83 @@ var _ = Describe("SpaceRepository", func() {
 		})
 	})
 
+	Describe("UpdateSpace", func() {
+		var (
+			cfOrg       *korifiv1alpha1.CFOrg
+			cfSpace     *korifiv1alpha1.CFSpace
+			message     repositories.UpdateSpaceMessage
+			updateErr   error
+			spaceRecord repositories.SpaceRecord
+		)
+
+		BeforeEach(func() {
+			cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+			cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, "the-space")
+
+			message = repositories.UpdateSpaceMessage{
+				GUID:    cfSpace.Name,
+				OrgGUID: cfOrg.Name,
+				Name:    tools.PtrTo("the-renamed-space"),
+				MetadataPatch: repositories.MetadataPatch{
+					Labels: map[string]*string{
+						"a-label": tools.PtrTo("a-value"),
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			spaceRecord, updateErr = spaceRepo.UpdateSpace(ctx, authInfo, message)
+		})
+
+		When("the user is authorized", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+			})
+
+			It("returns the updated space record", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(spaceRecord.GUID).To(Equal(cfSpace.Name))
+				Expect(spaceRecord.Name).To(Equal("the-renamed-space"))
+				Expect(spaceRecord.Labels).To(HaveKeyWithValue("a-label", "a-value"))
+			})
+
+			It("updates the CFSpace", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), cfSpace)).To(Succeed())
+				Expect(cfSpace.Spec.DisplayName).To(Equal("the-renamed-space"))
+				Expect(cfSpace.Labels).To(HaveKeyWithValue("a-label", "a-value"))
+			})
+
+			When("the name is not set", func() {
+				BeforeEach(func() {
+					message.Name = nil
+				})
+
+				It("keeps the space name", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+					Expect(spaceRecord.Name).To(Equal("the-space"))
+				})
+			})
+
+			When("the space does not exist", func() {
+				BeforeEach(func() {
+					message.GUID = "does-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+
+		When("the user is not authorized", func() {
+			It("returns a forbidden error", func() {
+				Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
 	Describe("GetDeletedAt", func() {
 		var (
 			cfSpace      *korifiv1alpha1.CFSpace
@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -56,7 +57,9 @@ type CreateTaskMessage struct {
 
 type ListTaskMessage struct {
 	AppGUIDs    []string
+	SpaceGUIDs  []string
 	SequenceIDs []int64
+	States      []string
 }
 
 type PatchTaskMetadataMessage struct {
@@ -89,6 +92,7 @@ type TaskRepo struct {
 	namespaceRetriever   NamespaceRetriever
 	namespacePermissions *authorization.NamespacePermissions
 	taskConditionAwaiter ConditionAwaiter[*korifiv1alpha1.CFTask]
+	tracer               trace.Tracer
 }
 
 func NewTaskRepo(
@@ -96,22 +100,29 @@ func NewTaskRepo(
 	nsRetriever NamespaceRetriever,
 	namespacePermissions *authorization.NamespacePermissions,
 	taskConditionAwaiter ConditionAwaiter[*korifiv1alpha1.CFTask],
+	tracer trace.Tracer,
 ) *TaskRepo {
 	return &TaskRepo{
 		userClientFactory:    userClientFactory,
 		namespaceRetriever:   nsRetriever,
 		namespacePermissions: namespacePermissions,
 		taskConditionAwaiter: taskConditionAwaiter,
+		tracer:               tracer,
 	}
 }
 
-func (r *TaskRepo) CreateTask(ctx context.Context, authInfo authorization.Info, createMessage CreateTaskMessage) (TaskRecord, error) {
+func (r *TaskRepo) CreateTask(ctx context.Context, authInfo authorization.Info, createMessage CreateTaskMessage) (_ TaskRecord, err error) {
+	ctx, span := startSpan(ctx, r.tracer, "CreateTask", TaskResourceType, "")
+	defer func() { endSpan(span, err) }()
+
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
 		return TaskRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
 	task := createMessage.toCFTask()
+	span.SetAttributes(TraceGUIDKey.String(task.Name))
+
 	err = userClient.Create(ctx, task)
 	if err != nil {
 		return TaskRecord{}, apierrors.FromK8sError(err, TaskResourceType)
@@ -125,7 +136,10 @@ func (r *TaskRepo) CreateTask(ctx context.Context, authInfo authorization.Info,
 	return taskToRecord(task), nil
 }
 
-func (r *TaskRepo) GetTask(ctx context.Context, authInfo authorization.Info, taskGUID string) (TaskRecord, error) {
+func (r *TaskRepo) GetTask(ctx context.Context, authInfo authorization.Info, taskGUID string) (_ TaskRecord, err error) {
+	ctx, span := startSpan(ctx, r.tracer, "GetTask", TaskResourceType, taskGUID)
+	defer func() { endSpan(span, err) }()
+
 	taskNamespace, err := r.namespaceRetriever.NamespaceFor(ctx, taskGUID, TaskResourceType)
 	if err != nil {
 		return TaskRecord{}, err
@@ -152,7 +166,9 @@ func (r *TaskRepo) GetTask(ctx context.Context, authInfo authorization.Info, tas
 }
 
 func (r *TaskRepo) awaitCondition(ctx context.Context, userClient client.WithWatch, task *korifiv1alpha1.CFTask, conditionType string) (*korifiv1alpha1.CFTask, error) {
-	awaitedTask, err := r.taskConditionAwaiter.AwaitCondition(ctx, userClient, task, conditionType)
+	watchCtx, watchSpan := startSpan(ctx, r.tracer, "watch", TaskResourceType, task.Name)
+	awaitedTask, err := r.taskConditionAwaiter.AwaitCondition(watchCtx, userClient, task, conditionType)
+	endSpan(watchSpan, err)
 	if err != nil {
 		return nil, apierrors.FromK8sError(err, TaskResourceType)
 	}
@@ -160,8 +176,13 @@ func (r *TaskRepo) awaitCondition(ctx context.Context, userClient client.WithWat
 	return awaitedTask, nil
 }
 
-func (r *TaskRepo) ListTasks(ctx context.Context, authInfo authorization.Info, msg ListTaskMessage) ([]TaskRecord, error) {
-	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+func (r *TaskRepo) ListTasks(ctx context.Context, authInfo authorization.Info, msg ListTaskMessage) (_ []TaskRecord, err error) {
+	ctx, span := startSpan(ctx, r.tracer, "ListTasks", TaskResourceType, "")
+	defer func() { endSpan(span, err) }()
+
+	permsCtx, permsSpan := startSpan(ctx, r.tracer, "permissions", TaskResourceType, "")
+	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(permsCtx, authInfo)
+	endSpan(permsSpan, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
 	}
@@ -174,10 +195,16 @@ func (r *TaskRepo) ListTasks(ctx context.Context, authInfo authorization.Info, m
 	preds := []func(korifiv1alpha1.CFTask) bool{
 		SetPredicate(msg.SequenceIDs, func(s korifiv1alpha1.CFTask) int64 { return s.Status.SequenceID }),
 		SetPredicate(msg.AppGUIDs, func(s korifiv1alpha1.CFTask) string { return s.Spec.AppRef.Name }),
+		SetPredicate(msg.States, func(s korifiv1alpha1.CFTask) string { return toRecordState(&s) }),
 	}
 
 	var tasks []korifiv1alpha1.CFTask
+	spaceGUIDSet := NewSet(msg.SpaceGUIDs...)
 	for ns := range nsList {
+		if len(spaceGUIDSet) > 0 && !spaceGUIDSet.Includes(ns) {
+			continue
+		}
+
 		taskList := &korifiv1alpha1.CFTaskList{}
 		err := userClient.List(ctx, taskList, client.InNamespace(ns))
 		if k8serrors.IsForbidden(err) {
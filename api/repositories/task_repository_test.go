@@ -17,6 +17,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gstruct"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,7 +44,7 @@ var _ = Describe("TaskRepository", func() {
 			korifiv1alpha1.CFTaskList,
 			*korifiv1alpha1.CFTaskList,
 		]{}
-		taskRepo = repositories.NewTaskRepo(userClientFactory, namespaceRetriever, nsPerms, conditionAwaiter)
+		taskRepo = repositories.NewTaskRepo(userClientFactory, namespaceRetriever, nsPerms, conditionAwaiter, nil)
 
 		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
 		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
@@ -134,6 +136,34 @@ var _ = Describe("TaskRepository", func() {
 				Expect(taskRecord.Annotations).To(Equal(map[string]string{"extra-bugs": "true"}))
 			})
 
+			When("tracing is enabled", func() {
+				var spanRecorder *tracetest.SpanRecorder
+
+				BeforeEach(func() {
+					spanRecorder = tracetest.NewSpanRecorder()
+					tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+					taskRepo = repositories.NewTaskRepo(userClientFactory, namespaceRetriever, nsPerms, conditionAwaiter, tracerProvider.Tracer("test"))
+				})
+
+				It("records a span for the create with a child span for the watch", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					spans := spanRecorder.Ended()
+					Expect(spans).To(HaveLen(2))
+
+					watchSpan, createSpan := spans[0], spans[1]
+					Expect(createSpan.Name()).To(Equal("CreateTask"))
+					Expect(createSpan.Attributes()).To(ConsistOf(
+						repositories.TraceOperationKey.String("CreateTask"),
+						repositories.TraceResourceTypeKey.String(repositories.TaskResourceType),
+						repositories.TraceGUIDKey.String(taskRecord.GUID),
+					))
+
+					Expect(watchSpan.Name()).To(Equal("watch"))
+					Expect(watchSpan.Parent().SpanID()).To(Equal(createSpan.SpanContext().SpanID()))
+				})
+			})
+
 			When("the task never becomes initialized", func() {
 				BeforeEach(func() {
 					conditionAwaiter.AwaitConditionReturns(&korifiv1alpha1.CFTask{}, errors.New("timed-out-error"))
@@ -490,6 +520,83 @@ var _ = Describe("TaskRepository", func() {
 					})
 				})
 
+				When("filtering by state", func() {
+					BeforeEach(func() {
+						Expect(k8s.Patch(ctx, k8sClient, task2, func() {
+							meta.SetStatusCondition(&task2.Status.Conditions, metav1.Condition{
+								Type:   korifiv1alpha1.TaskStartedConditionType,
+								Status: metav1.ConditionTrue,
+								Reason: "TaskStarted",
+							})
+							meta.SetStatusCondition(&task2.Status.Conditions, metav1.Condition{
+								Type:   korifiv1alpha1.TaskSucceededConditionType,
+								Status: metav1.ConditionTrue,
+								Reason: "TaskSucceeded",
+							})
+						})).To(Succeed())
+
+						listTaskMsg.States = []string{repositories.TaskStateSucceeded}
+					})
+
+					It("returns the tasks in that state", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(listedTasks).To(HaveLen(1))
+						Expect(listedTasks[0].Name).To(Equal(task2.Name))
+						Expect(listedTasks[0].State).To(Equal(repositories.TaskStateSucceeded))
+					})
+
+					When("filtering by several states", func() {
+						BeforeEach(func() {
+							listTaskMsg.States = []string{repositories.TaskStateSucceeded, repositories.TaskStatePending}
+						})
+
+						It("returns the tasks in any of them", func() {
+							Expect(listErr).NotTo(HaveOccurred())
+							Expect(listedTasks).To(ConsistOf(
+								gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"Name": Equal(task1.Name)}),
+								gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"Name": Equal(task2.Name)}),
+							))
+						})
+					})
+
+					When("filtering by state and app", func() {
+						BeforeEach(func() {
+							listTaskMsg.AppGUIDs = []string{cfApp.Name}
+						})
+
+						It("returns the tasks matching both", func() {
+							Expect(listErr).NotTo(HaveOccurred())
+							Expect(listedTasks).To(BeEmpty())
+						})
+					})
+				})
+
+				When("filtering by space", func() {
+					BeforeEach(func() {
+						listTaskMsg.SpaceGUIDs = []string{space.Name}
+					})
+
+					It("only lists tasks in that space", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(listedTasks).To(HaveLen(1))
+						Expect(listedTasks[0].Name).To(Equal(task1.Name))
+					})
+				})
+
+				When("filtering by apps across spaces", func() {
+					BeforeEach(func() {
+						listTaskMsg.AppGUIDs = []string{cfApp.Name, cfApp2.Name}
+					})
+
+					It("lists the tasks of all the apps", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(listedTasks).To(ConsistOf(
+							gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"Name": Equal(task1.Name)}),
+							gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"Name": Equal(task2.Name)}),
+						))
+					})
+				})
+
 				When("filtering by a non-existant app guid", func() {
 					BeforeEach(func() {
 						listTaskMsg.AppGUIDs = []string{"does-not-exist"}
@@ -17,10 +17,22 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"strconv"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// AppWorkloadContainerName is the name of the container running the
+	// process in the pods of an app workload
+	AppWorkloadContainerName = "application"
+	// InstanceIndexEnvVar holds the index of the instance in the env of the
+	// app workload container
+	InstanceIndexEnvVar = "CF_INSTANCE_INDEX"
+)
+
 // AppWorkloadSpec defines the desired state of AppWorkload
 type AppWorkloadSpec struct {
 	// +kubebuilder:validation:Required
@@ -43,6 +55,21 @@ type AppWorkloadSpec struct {
 	ReadinessProbe *corev1.Probe   `json:"readinessProbe,omitempty"`
 	Ports          []int32         `json:"ports,omitempty"`
 
+	// Extra labels to set on the workload pods, e.g. for network policy
+	// selectors. They do not override the labels set by the runner
+	// +kubebuilder:validation:Optional
+	PodLabels map[string]string `json:"podLabels,omitempty"`
+
+	// Extra annotations to set on the workload pods, e.g. to control
+	// service mesh sidecar injection. They do not override the annotations
+	// set by the runner
+	// +kubebuilder:validation:Optional
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// How long the workload pods are given to drain before they are killed
+	// +kubebuilder:validation:Optional
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
 	// +kubebuilder:default:=1
 	Instances int32 `json:"instances"`
 
@@ -52,6 +79,21 @@ type AppWorkloadSpec struct {
 
 	// +kubebuilder:validation:Optional
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Additional containers run in the workload pods next to the application container
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	Sidecars []AppWorkloadSidecar `json:"sidecars,omitempty"`
+}
+
+// AppWorkloadSidecar is an additional container running the workload image
+type AppWorkloadSidecar struct {
+	Name    string   `json:"name"`
+	Command []string `json:"command"`
+
+	// +kubebuilder:validation:Optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
 // AppWorkloadStatus defines the observed state of AppWorkload
@@ -87,3 +129,34 @@ type AppWorkloadList struct {
 func init() {
 	SchemeBuilder.Register(&AppWorkload{}, &AppWorkloadList{})
 }
+
+// AppWorkloadInstanceIndex returns the index of the instance an app workload
+// pod runs, as set in the env of its app workload container
+func AppWorkloadInstanceIndex(pod corev1.Pod) (int, error) {
+	for _, container := range pod.Spec.Containers {
+		if container.Name != AppWorkloadContainerName {
+			continue
+		}
+
+		for _, envVar := range container.Env {
+			if envVar.Name != InstanceIndexEnvVar {
+				continue
+			}
+
+			index, err := strconv.Atoi(envVar.Value)
+			if err != nil {
+				return 0, fmt.Errorf("%s is not a valid index: %w", InstanceIndexEnvVar, err)
+			}
+
+			if index < 0 {
+				return 0, fmt.Errorf("%s is not a valid index: instance indexes can't be negative", InstanceIndexEnvVar)
+			}
+
+			return index, nil
+		}
+
+		return 0, fmt.Errorf("%s not set", InstanceIndexEnvVar)
+	}
+
+	return 0, fmt.Errorf("container %q not found", AppWorkloadContainerName)
+}
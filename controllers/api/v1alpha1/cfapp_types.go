@@ -44,6 +44,21 @@ type CFAppSpec struct {
 
 	// A reference to the CFBuild currently assigned to the app. The CFBuild must be in the same namespace.
 	CurrentDropletRef v1.LocalObjectReference `json:"currentDropletRef,omitempty"`
+
+	// Caps on the resources used by all the processes of the app together
+	// +optional
+	Quota *CFAppQuota `json:"quota,omitempty"`
+}
+
+// CFAppQuota caps the resources of an app. Unset fields are unlimited.
+type CFAppQuota struct {
+	// The maximum total memory of all the instances of the app processes
+	// +optional
+	MemoryMB *int64 `json:"memoryMB,omitempty"`
+
+	// The maximum total number of instances of the app processes
+	// +optional
+	Instances *int `json:"instances,omitempty"`
 }
 
 // DesiredState defines the desired state of CFApp.
@@ -22,12 +22,21 @@ import (
 
 const (
 	CFDomainFinalizerName = "cfDomain.korifi.cloudfoundry.org"
+
+	// DomainRoutingReadyConditionType is true once the ingress backing the
+	// domain is configured
+	DomainRoutingReadyConditionType = "RoutingReady"
 )
 
 // CFDomainSpec defines the desired state of CFDomain
 type CFDomainSpec struct {
 	// The domain name. It is required and must conform to RFC 1035
 	Name string `json:"name"`
+
+	// Internal domains are only reachable from other apps. Routes on them
+	// are exposed via headless services instead of the ingress
+	// +optional
+	Internal bool `json:"internal,omitempty"`
 }
 
 // CFDomainStatus defines the observed state of CFDomain
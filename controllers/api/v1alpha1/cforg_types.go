@@ -30,6 +30,7 @@ const (
 	OrgNameKey             = "cloudfoundry.org/org-name"
 	OrgGUIDKey             = "cloudfoundry.org/org-guid"
 	OrgSpaceDeprecatedName = "XXX-deprecated-XXX"
+	OrgSuspendedLabelKey   = "korifi.cloudfoundry.org/org-suspended"
 )
 
 // CFOrgSpec defines the desired state of CFOrg
@@ -37,6 +38,10 @@ type CFOrgSpec struct {
 	// The mutable, user-friendly name of the CFOrg. Unlike metadata.name, the user can change this field.
 	// +kubebuilder:validation:Pattern="^[[:alnum:][:punct:][:print:]]+$"
 	DisplayName string `json:"displayName"`
+
+	// Whether the org is suspended. Nothing can be pushed into the spaces of a suspended org.
+	// +optional
+	Suspended bool `json:"suspended,omitempty"`
 }
 
 // CFOrgStatus defines the observed state of CFOrg
@@ -54,6 +54,17 @@ type CFProcessSpec struct {
 	// The disk limit in MiB
 	DiskQuotaMB int64 `json:"diskQuotaMB"`
 
+	// The CPU request in millicores, weighting the share of CPU the process gets when the node is busy.
+	// Defaults to a share proportional to the memory limit when unset
+	// +optional
+	CPUMillicores int64 `json:"cpuMillicores,omitempty"`
+
+	// Additional processes run alongside the process, sharing its network
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Sidecars []Sidecar `json:"sidecars,omitempty"`
+
 	// The ports to expose
 	// Deprecated: No longer used
 	// +kubebuilder:validation:Optional
@@ -76,13 +87,29 @@ type HealthCheckType string
 
 // HealthCheckData used to pass through input parameters to liveness probe
 type HealthCheckData struct {
-	// The http endpoint to use with "http" healthchecks
+	// The http endpoint to use with "http" healthchecks. Defaults to "/"
 	HTTPEndpoint string `json:"httpEndpoint,omitempty"`
 
 	InvocationTimeoutSeconds int64 `json:"invocationTimeoutSeconds"`
 	TimeoutSeconds           int64 `json:"timeoutSeconds"`
 }
 
+// Sidecar is an additional process run next to a CFProcess, from the same app image
+type Sidecar struct {
+	// The name of the sidecar, unique within the process. It names the sidecar container, so it must be a DNS-1123 label
+	// other than "application", which is the name of the process container
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// Command string used to run the sidecar on the app image
+	Command string `json:"command"`
+
+	// The memory limit of the sidecar in MiB
+	// +optional
+	MemoryMB int64 `json:"memoryMB,omitempty"`
+}
+
 // CFProcessStatus defines the observed state of CFProcess
 type CFProcessStatus struct {
 	//+kubebuilder:validation:Optional
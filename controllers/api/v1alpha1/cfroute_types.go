@@ -48,6 +48,13 @@ type Destination struct {
 	// +kubebuilder:validation:Enum=http1
 	//+kubebuilder:validation:Optional
 	Protocol *string `json:"protocol,omitempty"`
+	// The relative share of the route traffic sent to this destination. Weight
+	// is optional; weights only apply when every destination of the route has
+	// one, otherwise traffic is split evenly
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=100
+	Weight *int `json:"weight,omitempty"`
 }
 
 // Protocol defines the transport protocol of the route
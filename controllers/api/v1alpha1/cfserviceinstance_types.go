@@ -45,6 +45,10 @@ type CFServiceInstanceSpec struct {
 
 	// Tags are used by apps to identify service instances
 	Tags []string `json:"tags,omitempty"`
+
+	// GUIDs of the spaces, other than its own, the service instance is shared into
+	// +optional
+	SharedSpaces []string `json:"sharedSpaces,omitempty"`
 }
 
 // InstanceType defines the type of the Service Instance
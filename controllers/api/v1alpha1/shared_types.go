@@ -17,6 +17,17 @@ const (
 	CFRouteGUIDLabelKey      = "korifi.cloudfoundry.org/route-guid"
 	CFTaskGUIDLabelKey       = "korifi.cloudfoundry.org/task-guid"
 
+	CFAppRouteCleanupPolicyAnnotationKey = "korifi.cloudfoundry.org/route-cleanup-policy"
+	CFRouteDeleteAfterAnnotationKey      = "korifi.cloudfoundry.org/delete-after"
+	CFBuildCopiedFromAnnotationKey       = "korifi.cloudfoundry.org/copied-from"
+	CFAppForceRecreateAnnotationKey      = "korifi.cloudfoundry.org/force-recreate"
+	CFProcessHPAManagedAnnotationKey     = "korifi.cloudfoundry.org/hpa-managed"
+
+	// CFServiceCredentialsGenerationAnnotationKey counts the credential
+	// rotations of a CFServiceInstance. CFServiceBindings carry the generation
+	// of the credentials they were last reconciled with.
+	CFServiceCredentialsGenerationAnnotationKey = "korifi.cloudfoundry.org/credentials-generation"
+
 	StagingConditionType   = "Staging"
 	ReadyConditionType     = "Ready"
 	SucceededConditionType = "Succeeded"
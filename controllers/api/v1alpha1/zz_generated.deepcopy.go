@@ -85,6 +85,27 @@ func (in *AppWorkloadList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppWorkloadSidecar) DeepCopyInto(out *AppWorkloadSidecar) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppWorkloadSidecar.
+func (in *AppWorkloadSidecar) DeepCopy() *AppWorkloadSidecar {
+	if in == nil {
+		return nil
+	}
+	out := new(AppWorkloadSidecar)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppWorkloadSpec) DeepCopyInto(out *AppWorkloadSpec) {
 	*out = *in
@@ -125,7 +146,33 @@ func (in *AppWorkloadSpec) DeepCopyInto(out *AppWorkloadSpec) {
 		*out = make([]int32, len(*in))
 		copy(*out, *in)
 	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]AppWorkloadSidecar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppWorkloadSpec.
@@ -509,11 +556,41 @@ func (in *CFAppList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAppQuota) DeepCopyInto(out *CFAppQuota) {
+	*out = *in
+	if in.MemoryMB != nil {
+		in, out := &in.MemoryMB, &out.MemoryMB
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppQuota.
+func (in *CFAppQuota) DeepCopy() *CFAppQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAppQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFAppSpec) DeepCopyInto(out *CFAppSpec) {
 	*out = *in
 	in.Lifecycle.DeepCopyInto(&out.Lifecycle)
 	out.CurrentDropletRef = in.CurrentDropletRef
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = new(CFAppQuota)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppSpec.
@@ -1026,6 +1103,11 @@ func (in *CFProcessSpec) DeepCopyInto(out *CFProcessSpec) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]Sidecar, len(*in))
+		copy(*out, *in)
+	}
 	if in.Ports != nil {
 		in, out := &in.Ports, &out.Ports
 		*out = make([]int32, len(*in))
@@ -1352,6 +1434,11 @@ func (in *CFServiceInstanceSpec) DeepCopyInto(out *CFServiceInstanceSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SharedSpaces != nil {
+		in, out := &in.SharedSpaces, &out.SharedSpaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceSpec.
@@ -1595,6 +1682,11 @@ func (in *Destination) DeepCopyInto(out *Destination) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Destination.
@@ -1852,6 +1944,21 @@ func (in *RunnerInfoStatus) DeepCopy() *RunnerInfoStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Sidecar) DeepCopyInto(out *Sidecar) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Sidecar.
+func (in *Sidecar) DeepCopy() *Sidecar {
+	if in == nil {
+		return nil
+	}
+	out := new(Sidecar)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskWorkload) DeepCopyInto(out *TaskWorkload) {
 	*out = *in
@@ -3,10 +3,12 @@ package cleanup
 import (
 	"context"
 	"sort"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/statefulset-runner/controllers"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -14,10 +16,17 @@ import (
 type BuildCleaner struct {
 	k8sClient      client.Client
 	retainedBuilds int
+	// failedBuildRetention is how long failed builds are kept for
+	// debugging once they have failed. Zero means they are kept forever.
+	failedBuildRetention time.Duration
 }
 
-func NewBuildCleaner(k8sClient client.Client, retainedBuilds int) BuildCleaner {
-	return BuildCleaner{k8sClient: k8sClient, retainedBuilds: retainedBuilds}
+func NewBuildCleaner(k8sClient client.Client, retainedBuilds int, failedBuildRetention time.Duration) BuildCleaner {
+	return BuildCleaner{
+		k8sClient:            k8sClient,
+		retainedBuilds:       retainedBuilds,
+		failedBuildRetention: failedBuildRetention,
+	}
 }
 
 func (c BuildCleaner) Clean(ctx context.Context, app types.NamespacedName) error {
@@ -43,6 +52,13 @@ func (c BuildCleaner) Clean(ctx context.Context, app types.NamespacedName) error
 		if cfBuild.Name == cfApp.Spec.CurrentDropletRef.Name {
 			continue
 		}
+		if c.isExpiredFailedBuild(cfBuild) {
+			err = c.k8sClient.Delete(ctx, &cfBuild)
+			if err != nil {
+				return err
+			}
+			continue
+		}
 		if !meta.IsStatusConditionTrue(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType) {
 			continue
 		}
@@ -62,3 +78,16 @@ func (c BuildCleaner) Clean(ctx context.Context, app types.NamespacedName) error
 
 	return nil
 }
+
+func (c BuildCleaner) isExpiredFailedBuild(cfBuild korifiv1alpha1.CFBuild) bool {
+	if c.failedBuildRetention == 0 {
+		return false
+	}
+
+	succeededCondition := meta.FindStatusCondition(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)
+	if succeededCondition == nil || succeededCondition.Status != metav1.ConditionFalse {
+		return false
+	}
+
+	return time.Since(succeededCondition.LastTransitionTime.Time) > c.failedBuildRetention
+}
@@ -26,7 +26,7 @@ var _ = Describe("BuildCleaner", func() {
 	)
 
 	BeforeEach(func() {
-		cleaner = cleanup.NewBuildCleaner(controllersClient, 1)
+		cleaner = cleanup.NewBuildCleaner(controllersClient, 1, 0)
 
 		namespace = GenerateGUID()
 		Expect(k8sClient.Create(ctx, &corev1.Namespace{
@@ -100,6 +100,44 @@ var _ = Describe("BuildCleaner", func() {
 			Expect(bldDeletable).To(BeNotFound())
 		})
 	})
+
+	When("the app has failed builds", func() {
+		var bldFailedRecently, bldFailedLongAgo *korifiv1alpha1.CFBuild
+
+		BeforeEach(func() {
+			bldFailedLongAgo = createFailedBuild(namespace, appGUID, "failed-long-ago", time.Now().Add(-3*time.Hour))
+			bldFailedRecently = createFailedBuild(namespace, appGUID, "failed-recently", time.Now().Add(-time.Minute))
+		})
+
+		It("keeps them by default", func() {
+			Expect(cleanErr).NotTo(HaveOccurred())
+
+			Expect(bldFailedLongAgo).To(BeFound())
+			Expect(bldFailedRecently).To(BeFound())
+			Expect(bldDeletable).To(BeNotFound())
+		})
+
+		When("a failed build retention is configured", func() {
+			BeforeEach(func() {
+				cleaner = cleanup.NewBuildCleaner(controllersClient, 1, time.Hour)
+			})
+
+			It("keeps the failed builds within the retention while cleaning up succeeded builds", func() {
+				Expect(cleanErr).NotTo(HaveOccurred())
+
+				Expect(bldFailedRecently).To(BeFound())
+				Expect(bldCurrent).To(BeFound())
+				Expect(bldReady).To(BeFound())
+				Expect(bldDeletable).To(BeNotFound())
+			})
+
+			It("deletes the failed builds older than the retention", func() {
+				Expect(cleanErr).NotTo(HaveOccurred())
+
+				Expect(bldFailedLongAgo).To(BeNotFound())
+			})
+		})
+	})
 })
 
 func createBuild(namespace, appGUID, name string) *korifiv1alpha1.CFBuild {
@@ -127,3 +165,15 @@ func createSucceededBuild(namespace, appGUID, name string) *korifiv1alpha1.CFBui
 	Expect(k8sClient.Status().Update(ctx, bld)).To(Succeed())
 	return bld
 }
+
+func createFailedBuild(namespace, appGUID, name string, failedAt time.Time) *korifiv1alpha1.CFBuild {
+	bld := createBuild(namespace, appGUID, name)
+	meta.SetStatusCondition(&bld.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.SucceededConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             "BuildFailed",
+		LastTransitionTime: metav1.NewTime(failedAt),
+	})
+	Expect(k8sClient.Status().Update(ctx, bld)).To(Succeed())
+	return bld
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"path/filepath"
 	"time"
 
@@ -27,15 +28,33 @@ type ControllerConfig struct {
 	BuilderName                      string             `yaml:"builderName"`
 	RunnerName                       string             `yaml:"runnerName"`
 	NamespaceLabels                  map[string]string  `yaml:"namespaceLabels"`
+	NamespaceAnnotations             map[string]string  `yaml:"namespaceAnnotations"`
 	ExtraVCAPApplicationValues       map[string]any     `yaml:"extraVCAPApplicationValues"`
 	MaxRetainedPackagesPerApp        int                `yaml:"maxRetainedPackagesPerApp"`
 	MaxRetainedBuildsPerApp          int                `yaml:"maxRetainedBuildsPerApp"`
+	FailedBuildRetention             string             `yaml:"failedBuildRetention"`
+	PropagatedPodLabels              []string           `yaml:"propagatedPodLabels"`
+	DefaultPodAnnotations            map[string]string  `yaml:"defaultPodAnnotations"`
 	LogLevel                         zapcore.Level      `yaml:"logLevel"`
 	SpaceFinalizerAppDeletionTimeout *int64             `yaml:"spaceFinalizerAppDeletionTimeout"`
+	AppRouteCleanupPolicy            string             `yaml:"appRouteCleanupPolicy"`
+	AppRouteCleanupGracePeriod       string             `yaml:"appRouteCleanupGracePeriod"`
+	RouteHostPolicy                  string             `yaml:"routeHostPolicy"`
+	HPAIntegration                   bool               `yaml:"hpaIntegration"`
+	ServiceAccountCreation           string             `yaml:"serviceAccountCreation"`
+	PropagateProcessTypeEnv          bool               `yaml:"propagateProcessTypeEnv"`
+	MinTerminationGracePeriodSeconds int64              `yaml:"minTerminationGracePeriodSeconds"`
+	AllowedLaunchers                 []string           `yaml:"allowedLaunchers"`
+	LifecycleLauncherPath            string             `yaml:"lifecycleLauncherPath"`
+	OrphanedBindingPolicy            string             `yaml:"orphanedBindingPolicy"`
 
 	// job-task-runner
 	JobTTL string `yaml:"jobTTL"`
 
+	// statefulset-runner
+	LRPSecurityContext LRPSecurityContext `yaml:"lrpSecurityContext"`
+	LRPImagePullPolicy string             `yaml:"lrpImagePullPolicy"`
+
 	// kpack-image-builder
 	ClusterBuilderName        string `yaml:"clusterBuilderName"`
 	BuilderServiceAccount     string `yaml:"builderServiceAccount"`
@@ -50,17 +69,74 @@ type CFProcessDefaults struct {
 	Timeout     *int64 `yaml:"timeout"`
 }
 
+// LRPSecurityContext configures the security context of the pods running
+// app instances
+type LRPSecurityContext struct {
+	RunAsNonRoot           *bool  `yaml:"runAsNonRoot"`
+	SeccompProfileType     string `yaml:"seccompProfileType"`
+	ReadOnlyRootFilesystem bool   `yaml:"readOnlyRootFilesystem"`
+}
+
 type CFStagingResources struct {
 	BuildCacheMB int64 `yaml:"buildCacheMB"`
 	DiskMB       int64 `yaml:"diskMB"`
 	MemoryMB     int64 `yaml:"memoryMB"`
 }
 
+const (
+	// RouteCleanupPolicyOrphan keeps the routes of a deleted app without
+	// destinations
+	RouteCleanupPolicyOrphan = "orphan"
+	// RouteCleanupPolicyDelete deletes the routes left without destinations
+	// once an app is deleted
+	RouteCleanupPolicyDelete = "delete"
+	// RouteCleanupPolicyRetain deletes the routes left without destinations
+	// once the grace period after the app deletion has passed
+	RouteCleanupPolicyRetain = "retain"
+)
+
+const (
+	// RouteHostPolicyShared lets routes in any org use the same host on a
+	// domain, as long as their paths differ
+	RouteHostPolicyShared = "shared"
+	// RouteHostPolicyOrg reserves a host on a domain for the org of the
+	// first route using it
+	RouteHostPolicyOrg = "org"
+)
+
+const (
+	// ServiceAccountCreationEager propagates the service accounts of the root
+	// namespace into a space as soon as the space is created
+	ServiceAccountCreationEager = "eager"
+	// ServiceAccountCreationLazy propagates the service accounts of the root
+	// namespace into a space when the first build or app workload is created
+	// in it
+	ServiceAccountCreationLazy = "lazy"
+)
+
+const (
+	// OrphanedBindingPolicyFlag marks service bindings whose app no longer
+	// exists as orphaned, leaving it to the user to delete them
+	OrphanedBindingPolicyFlag = "flag"
+	// OrphanedBindingPolicyDelete deletes service bindings whose app no
+	// longer exists
+	OrphanedBindingPolicyDelete = "delete"
+)
+
 const (
 	defaultTaskTTL            = 30 * 24 * time.Hour
 	defaultTimeout      int64 = 60
 	defaultJobTTL             = 24 * time.Hour
 	defaultBuildCacheMB       = 2048
+	defaultGracePeriod        = time.Hour
+
+	defaultLifecycleLauncherPath = "/cnb/lifecycle/launcher"
+
+	seccompProfileTypeRuntimeDefault = "RuntimeDefault"
+	seccompProfileTypeUnconfined     = "Unconfined"
+
+	imagePullPolicyAlways       = "Always"
+	imagePullPolicyIfNotPresent = "IfNotPresent"
 )
 
 func LoadFromPath(path string) (*ControllerConfig, error) {
@@ -82,6 +158,88 @@ func LoadFromPath(path string) (*ControllerConfig, error) {
 		config.CFStagingResources.BuildCacheMB = defaultBuildCacheMB
 	}
 
+	if config.AppRouteCleanupPolicy == "" {
+		config.AppRouteCleanupPolicy = RouteCleanupPolicyOrphan
+	}
+
+	if !IsValidRouteCleanupPolicy(config.AppRouteCleanupPolicy) {
+		return nil, fmt.Errorf("invalid appRouteCleanupPolicy %q: must be one of %q, %q or %q",
+			config.AppRouteCleanupPolicy,
+			RouteCleanupPolicyOrphan,
+			RouteCleanupPolicyDelete,
+			RouteCleanupPolicyRetain,
+		)
+	}
+
+	if config.RouteHostPolicy == "" {
+		config.RouteHostPolicy = RouteHostPolicyShared
+	}
+
+	if config.RouteHostPolicy != RouteHostPolicyShared && config.RouteHostPolicy != RouteHostPolicyOrg {
+		return nil, fmt.Errorf("invalid routeHostPolicy %q: must be one of %q or %q",
+			config.RouteHostPolicy,
+			RouteHostPolicyShared,
+			RouteHostPolicyOrg,
+		)
+	}
+
+	if config.ServiceAccountCreation == "" {
+		config.ServiceAccountCreation = ServiceAccountCreationEager
+	}
+
+	if config.ServiceAccountCreation != ServiceAccountCreationEager && config.ServiceAccountCreation != ServiceAccountCreationLazy {
+		return nil, fmt.Errorf("invalid serviceAccountCreation %q: must be one of %q or %q",
+			config.ServiceAccountCreation,
+			ServiceAccountCreationEager,
+			ServiceAccountCreationLazy,
+		)
+	}
+
+	if config.LifecycleLauncherPath == "" {
+		config.LifecycleLauncherPath = defaultLifecycleLauncherPath
+	}
+
+	if config.LRPSecurityContext.RunAsNonRoot == nil {
+		config.LRPSecurityContext.RunAsNonRoot = tools.PtrTo(true)
+	}
+
+	if config.LRPSecurityContext.SeccompProfileType == "" {
+		config.LRPSecurityContext.SeccompProfileType = seccompProfileTypeRuntimeDefault
+	}
+
+	if config.LRPSecurityContext.SeccompProfileType != seccompProfileTypeRuntimeDefault &&
+		config.LRPSecurityContext.SeccompProfileType != seccompProfileTypeUnconfined {
+		return nil, fmt.Errorf("invalid lrpSecurityContext.seccompProfileType %q: must be one of %q or %q",
+			config.LRPSecurityContext.SeccompProfileType,
+			seccompProfileTypeRuntimeDefault,
+			seccompProfileTypeUnconfined,
+		)
+	}
+
+	if config.LRPImagePullPolicy == "" {
+		config.LRPImagePullPolicy = imagePullPolicyAlways
+	}
+
+	if config.LRPImagePullPolicy != imagePullPolicyAlways && config.LRPImagePullPolicy != imagePullPolicyIfNotPresent {
+		return nil, fmt.Errorf("invalid lrpImagePullPolicy %q: must be one of %q or %q",
+			config.LRPImagePullPolicy,
+			imagePullPolicyAlways,
+			imagePullPolicyIfNotPresent,
+		)
+	}
+
+	if config.OrphanedBindingPolicy == "" {
+		config.OrphanedBindingPolicy = OrphanedBindingPolicyFlag
+	}
+
+	if config.OrphanedBindingPolicy != OrphanedBindingPolicyFlag && config.OrphanedBindingPolicy != OrphanedBindingPolicyDelete {
+		return nil, fmt.Errorf("invalid orphanedBindingPolicy %q: must be one of %q or %q",
+			config.OrphanedBindingPolicy,
+			OrphanedBindingPolicyFlag,
+			OrphanedBindingPolicyDelete,
+		)
+	}
+
 	return &config, nil
 }
 
@@ -113,6 +271,33 @@ func (c ControllerConfig) ParseBuilderReadinessTimeout() (time.Duration, error)
 	return tools.ParseDuration(c.BuilderReadinessTimeout)
 }
 
+func (c ControllerConfig) ParseAppRouteCleanupGracePeriod() (time.Duration, error) {
+	if c.AppRouteCleanupGracePeriod == "" {
+		return defaultGracePeriod, nil
+	}
+
+	return tools.ParseDuration(c.AppRouteCleanupGracePeriod)
+}
+
+// ParseFailedBuildRetention returns how long failed builds are kept for
+// debugging. Zero (the default) keeps them until their app is deleted.
+func (c ControllerConfig) ParseFailedBuildRetention() (time.Duration, error) {
+	if c.FailedBuildRetention == "" {
+		return 0, nil
+	}
+
+	return tools.ParseDuration(c.FailedBuildRetention)
+}
+
+func IsValidRouteCleanupPolicy(policy string) bool {
+	switch policy {
+	case RouteCleanupPolicyOrphan, RouteCleanupPolicyDelete, RouteCleanupPolicyRetain:
+		return true
+	default:
+		return false
+	}
+}
+
 func (c ControllerConfig) ParseJobTTL() (time.Duration, error) {
 	if c.JobTTL == "" {
 		return defaultJobTTL, nil
@@ -50,6 +50,25 @@ var _ = Describe("LoadFromPath", func() {
 			JobTTL:                           "jobTTL",
 			LogLevel:                         zapcore.DebugLevel,
 			SpaceFinalizerAppDeletionTimeout: tools.PtrTo(int64(42)),
+			AppRouteCleanupPolicy:            "retain",
+			AppRouteCleanupGracePeriod:       "5m",
+			FailedBuildRetention:             "2h",
+			PropagatedPodLabels:              []string{"security-group"},
+			DefaultPodAnnotations:            map[string]string{"sidecar.istio.io/inject": "true"},
+			RouteHostPolicy:                  "org",
+			HPAIntegration:                   true,
+			ServiceAccountCreation:           "lazy",
+			PropagateProcessTypeEnv:          true,
+			MinTerminationGracePeriodSeconds: 30,
+			AllowedLaunchers:                 []string{"/cnb/lifecycle/launcher"},
+			LifecycleLauncherPath:            "/custom/lifecycle/launcher",
+			OrphanedBindingPolicy:            "delete",
+			LRPSecurityContext: config.LRPSecurityContext{
+				RunAsNonRoot:           tools.PtrTo(false),
+				SeccompProfileType:     "Unconfined",
+				ReadOnlyRootFilesystem: true,
+			},
+			LRPImagePullPolicy: "IfNotPresent",
 		}
 	})
 
@@ -86,13 +105,99 @@ var _ = Describe("LoadFromPath", func() {
 			BuilderName:                      "buildReconciler",
 			RunnerName:                       "statefulset-runner",
 			NamespaceLabels:                  map[string]string{},
+			NamespaceAnnotations:             map[string]string{},
 			ExtraVCAPApplicationValues:       map[string]any{},
 			JobTTL:                           "jobTTL",
 			LogLevel:                         zapcore.DebugLevel,
 			SpaceFinalizerAppDeletionTimeout: tools.PtrTo(int64(42)),
+			AppRouteCleanupPolicy:            "retain",
+			AppRouteCleanupGracePeriod:       "5m",
+			FailedBuildRetention:             "2h",
+			PropagatedPodLabels:              []string{"security-group"},
+			DefaultPodAnnotations:            map[string]string{"sidecar.istio.io/inject": "true"},
+			RouteHostPolicy:                  "org",
+			HPAIntegration:                   true,
+			ServiceAccountCreation:           "lazy",
+			PropagateProcessTypeEnv:          true,
+			MinTerminationGracePeriodSeconds: 30,
+			AllowedLaunchers:                 []string{"/cnb/lifecycle/launcher"},
+			LifecycleLauncherPath:            "/custom/lifecycle/launcher",
+			OrphanedBindingPolicy:            "delete",
+			LRPSecurityContext: config.LRPSecurityContext{
+				RunAsNonRoot:           tools.PtrTo(false),
+				SeccompProfileType:     "Unconfined",
+				ReadOnlyRootFilesystem: true,
+			},
+			LRPImagePullPolicy: "IfNotPresent",
 		}))
 	})
 
+	When("the LRP security context is not set", func() {
+		BeforeEach(func() {
+			cfg.LRPSecurityContext = config.LRPSecurityContext{}
+		})
+
+		It("runs LRPs as non-root with the runtime default seccomp profile", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(retConfig.LRPSecurityContext).To(Equal(config.LRPSecurityContext{
+				RunAsNonRoot:       tools.PtrTo(true),
+				SeccompProfileType: "RuntimeDefault",
+			}))
+		})
+	})
+
+	When("the LRP seccomp profile type is invalid", func() {
+		BeforeEach(func() {
+			cfg.LRPSecurityContext.SeccompProfileType = "Localhost"
+		})
+
+		It("returns an error", func() {
+			Expect(retErr).To(MatchError(ContainSubstring("invalid lrpSecurityContext.seccompProfileType")))
+		})
+	})
+
+	When("the LRP image pull policy is not set", func() {
+		BeforeEach(func() {
+			cfg.LRPImagePullPolicy = ""
+		})
+
+		It("always pulls LRP images", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(retConfig.LRPImagePullPolicy).To(Equal("Always"))
+		})
+	})
+
+	When("the LRP image pull policy is invalid", func() {
+		BeforeEach(func() {
+			cfg.LRPImagePullPolicy = "Never"
+		})
+
+		It("returns an error", func() {
+			Expect(retErr).To(MatchError(ContainSubstring("invalid lrpImagePullPolicy")))
+		})
+	})
+
+	When("the orphaned binding policy is not set", func() {
+		BeforeEach(func() {
+			cfg.OrphanedBindingPolicy = ""
+		})
+
+		It("flags orphaned bindings by default", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(retConfig.OrphanedBindingPolicy).To(Equal(config.OrphanedBindingPolicyFlag))
+		})
+	})
+
+	When("the orphaned binding policy is invalid", func() {
+		BeforeEach(func() {
+			cfg.OrphanedBindingPolicy = "ignore"
+		})
+
+		It("returns an error", func() {
+			Expect(retErr).To(MatchError(ContainSubstring(`invalid orphanedBindingPolicy "ignore"`)))
+		})
+	})
+
 	When("the CFProcess default timeout is not set", func() {
 		BeforeEach(func() {
 			cfg.CFProcessDefaults.Timeout = nil
@@ -132,6 +237,76 @@ var _ = Describe("LoadFromPath", func() {
 			Expect(retConfig.CFStagingResources.BuildCacheMB).To(Equal(int64(2048)))
 		})
 	})
+
+	When("the app route cleanup policy is not set", func() {
+		BeforeEach(func() {
+			cfg.AppRouteCleanupPolicy = ""
+		})
+
+		It("orphans routes by default", func() {
+			Expect(retConfig.AppRouteCleanupPolicy).To(Equal(config.RouteCleanupPolicyOrphan))
+		})
+	})
+
+	When("the app route cleanup policy is invalid", func() {
+		BeforeEach(func() {
+			cfg.AppRouteCleanupPolicy = "shred"
+		})
+
+		It("returns an error", func() {
+			Expect(retErr).To(MatchError(ContainSubstring(`invalid appRouteCleanupPolicy "shred"`)))
+		})
+	})
+
+	When("the lifecycle launcher path is not set", func() {
+		BeforeEach(func() {
+			cfg.LifecycleLauncherPath = ""
+		})
+
+		It("uses the buildpack lifecycle launcher", func() {
+			Expect(retConfig.LifecycleLauncherPath).To(Equal("/cnb/lifecycle/launcher"))
+		})
+	})
+
+	When("the route host policy is not set", func() {
+		BeforeEach(func() {
+			cfg.RouteHostPolicy = ""
+		})
+
+		It("shares hosts between orgs by default", func() {
+			Expect(retConfig.RouteHostPolicy).To(Equal(config.RouteHostPolicyShared))
+		})
+	})
+
+	When("the route host policy is invalid", func() {
+		BeforeEach(func() {
+			cfg.RouteHostPolicy = "space"
+		})
+
+		It("returns an error", func() {
+			Expect(retErr).To(MatchError(ContainSubstring(`invalid routeHostPolicy "space"`)))
+		})
+	})
+
+	When("the service account creation is not set", func() {
+		BeforeEach(func() {
+			cfg.ServiceAccountCreation = ""
+		})
+
+		It("creates service accounts eagerly by default", func() {
+			Expect(retConfig.ServiceAccountCreation).To(Equal(config.ServiceAccountCreationEager))
+		})
+	})
+
+	When("the service account creation is invalid", func() {
+		BeforeEach(func() {
+			cfg.ServiceAccountCreation = "never"
+		})
+
+		It("returns an error", func() {
+			Expect(retErr).To(MatchError(ContainSubstring(`invalid serviceAccountCreation "never"`)))
+		})
+	})
 })
 
 var _ = Describe("ParseTaskTTL", func() {
@@ -224,3 +399,93 @@ var _ = Describe("ParseJobTTL", func() {
 		})
 	})
 })
+
+var _ = Describe("ParseAppRouteCleanupGracePeriod", func() {
+	var (
+		gracePeriod    time.Duration
+		parseErr       error
+		gracePeriodStr string
+	)
+
+	BeforeEach(func() {
+		gracePeriodStr = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			AppRouteCleanupGracePeriod: gracePeriodStr,
+		}
+		gracePeriod, parseErr = cfg.ParseAppRouteCleanupGracePeriod()
+	})
+
+	It("returns 1 hour by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(gracePeriod).To(Equal(time.Hour))
+	})
+
+	When("the grace period is something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			gracePeriodStr = "1d30m"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(gracePeriod).To(Equal(24*time.Hour + 30*time.Minute))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			gracePeriodStr = "a while"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("ParseFailedBuildRetention", func() {
+	var (
+		retention    time.Duration
+		parseErr     error
+		retentionStr string
+	)
+
+	BeforeEach(func() {
+		retentionStr = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			FailedBuildRetention: retentionStr,
+		}
+		retention, parseErr = cfg.ParseFailedBuildRetention()
+	})
+
+	It("returns zero by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(retention).To(BeZero())
+	})
+
+	When("the retention is something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			retentionStr = "2d"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(retention).To(Equal(48 * time.Hour))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			retentionStr = "a while"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
@@ -18,34 +18,45 @@ package networking
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+// routingNotReadyRequeueInterval is how often the readiness of the ingress
+// is checked again while it is not ready
+const routingNotReadyRequeueInterval = 5 * time.Second
+
 type CFDomainReconciler struct {
-	client client.Client
-	scheme *runtime.Scheme
-	log    logr.Logger
+	client           client.Client
+	scheme           *runtime.Scheme
+	log              logr.Logger
+	controllerConfig *config.ControllerConfig
 }
 
 func NewCFDomainReconciler(
 	client client.Client,
 	scheme *runtime.Scheme,
 	log logr.Logger,
+	controllerConfig *config.ControllerConfig,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFDomain, *korifiv1alpha1.CFDomain] {
-	routeReconciler := CFDomainReconciler{client: client, scheme: scheme, log: log}
+	routeReconciler := CFDomainReconciler{client: client, scheme: scheme, log: log, controllerConfig: controllerConfig}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFDomain, *korifiv1alpha1.CFDomain](log, client, &routeReconciler)
 }
 
@@ -58,6 +69,8 @@ func (r *CFDomainReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdomains/status,verbs=patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdomains/finalizers,verbs=update
 
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
 func (r *CFDomainReconciler) ReconcileResource(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
@@ -76,9 +89,62 @@ func (r *CFDomainReconciler) ReconcileResource(ctx context.Context, cfDomain *ko
 		ObservedGeneration: cfDomain.Generation,
 	})
 
+	routingReady, message, err := r.isRoutingReady(ctx, cfDomain)
+	if err != nil {
+		log.Info("failed to check domain routing", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	routingReadyCondition := metav1.Condition{
+		Type:               korifiv1alpha1.DomainRoutingReadyConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             "RoutingReady",
+		Message:            message,
+		ObservedGeneration: cfDomain.Generation,
+	}
+	if !routingReady {
+		routingReadyCondition.Status = metav1.ConditionFalse
+		routingReadyCondition.Reason = "RoutingNotReady"
+	}
+	meta.SetStatusCondition(&cfDomain.Status.Conditions, routingReadyCondition)
+
+	if !routingReady {
+		return ctrl.Result{RequeueAfter: routingNotReadyRequeueInterval}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// isRoutingReady checks that the ingress backing the domain is configured:
+// the contour router is enabled and its workloads TLS certificate has been
+// issued. Internal domains are not served by the ingress.
+func (r *CFDomainReconciler) isRoutingReady(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) (bool, string, error) {
+	if cfDomain.Spec.Internal {
+		return true, "Internal domains are not exposed via the ingress", nil
+	}
+
+	if !r.controllerConfig.IncludeContourRouter {
+		return false, "No ingress router is configured", nil
+	}
+
+	if r.controllerConfig.WorkloadsTLSSecretName != "" {
+		tlsSecret := &corev1.Secret{}
+		err := r.client.Get(ctx, types.NamespacedName{Namespace: r.controllerConfig.WorkloadsTLSSecretNamespace, Name: r.controllerConfig.WorkloadsTLSSecretName}, tlsSecret)
+		if apierrors.IsNotFound(err) {
+			return false, fmt.Sprintf("Ingress TLS secret %s does not exist", r.controllerConfig.WorkloadsTLSSecretNameWithNamespace()), nil
+		}
+		if err != nil {
+			return false, "", err
+		}
+
+		if len(tlsSecret.Data[corev1.TLSCertKey]) == 0 {
+			return false, fmt.Sprintf("Ingress TLS secret %s has no certificate", r.controllerConfig.WorkloadsTLSSecretNameWithNamespace()), nil
+		}
+	}
+
+	return true, "The ingress is configured", nil
+}
+
 func (r *CFDomainReconciler) finalizeCFDomain(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("finalizeCFDomain")
 
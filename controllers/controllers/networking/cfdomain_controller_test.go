@@ -96,6 +96,81 @@ var _ = Describe("CFDomainReconciler Integration Tests", func() {
 		})
 	})
 
+	getRoutingReadyCondition := func(g Gomega, domain *korifiv1alpha1.CFDomain) *metav1.Condition {
+		updatedDomain := &korifiv1alpha1.CFDomain{}
+		g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(domain), updatedDomain)).To(Succeed())
+		g.Expect(updatedDomain.Status.ObservedGeneration).To(Equal(updatedDomain.Generation))
+
+		condition := meta.FindStatusCondition(updatedDomain.Status.Conditions, korifiv1alpha1.DomainRoutingReadyConditionType)
+		g.Expect(condition).NotTo(BeNil())
+		return condition
+	}
+
+	When("the ingress TLS secret does not exist", func() {
+		BeforeEach(func() {
+			Expect(client.IgnoreNotFound(adminClient.Delete(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ingressTLSSecretName,
+					Namespace: rootNamespace,
+				},
+			}))).To(Succeed())
+		})
+
+		It("reports the domain routing as not ready", func() {
+			Eventually(func(g Gomega) {
+				condition := getRoutingReadyCondition(g, cfDomain)
+				g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(condition.Message).To(ContainSubstring("does not exist"))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the ingress TLS secret has been issued", func() {
+		BeforeEach(func() {
+			Expect(client.IgnoreAlreadyExists(adminClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ingressTLSSecretName,
+					Namespace: rootNamespace,
+				},
+				Type: corev1.SecretTypeTLS,
+				Data: map[string][]byte{
+					corev1.TLSCertKey:       []byte("cert"),
+					corev1.TLSPrivateKeyKey: []byte("key"),
+				},
+			}))).To(Succeed())
+		})
+
+		It("reports the domain routing as ready", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(getRoutingReadyCondition(g, cfDomain).Status).To(Equal(metav1.ConditionTrue))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the domain is internal", func() {
+		var internalDomain *korifiv1alpha1.CFDomain
+
+		BeforeEach(func() {
+			internalDomain = &korifiv1alpha1.CFDomain{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      GenerateGUID(),
+					Namespace: domainNamespace,
+				},
+				Spec: korifiv1alpha1.CFDomainSpec{
+					Name:     "a" + GenerateGUID() + ".internal",
+					Internal: true,
+				},
+			}
+			Expect(adminClient.Create(ctx, internalDomain)).To(Succeed())
+		})
+
+		It("reports the domain routing as ready", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(getRoutingReadyCondition(g, internalDomain).Status).To(Equal(metav1.ConditionTrue))
+			}).Should(Succeed())
+		})
+	})
+
 	When("a domain is deleted", func() {
 		JustBeforeEach(func() {
 			Expect(adminClient.Delete(ctx, cfDomain)).To(Succeed())
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
@@ -125,6 +126,12 @@ func (r *CFRouteReconciler) ReconcileResource(ctx context.Context, cfRoute *kori
 		return ctrl.Result{}, err
 	}
 
+	retainedFor, expired := r.checkRetention(ctx, cfRoute)
+	if expired {
+		log.V(1).Info("deleting route whose retention period has expired")
+		return ctrl.Result{}, client.IgnoreNotFound(r.client.Delete(ctx, cfRoute))
+	}
+
 	cfDomain := &korifiv1alpha1.CFDomain{}
 	err = r.client.Get(ctx, types.NamespacedName{Name: cfRoute.Spec.DomainRef.Name, Namespace: cfRoute.Spec.DomainRef.Namespace}, cfDomain)
 	if err != nil {
@@ -141,19 +148,23 @@ func (r *CFRouteReconciler) ReconcileResource(ctx context.Context, cfRoute *kori
 
 	setValidRouteStatus(log, cfRoute, cfDomain, effectiveDestinations, "Valid CFRoute", "Valid", "Valid CFRoute")
 
-	err = r.createOrPatchServices(ctx, cfRoute)
+	err = r.createOrPatchServices(ctx, cfRoute, cfDomain.Spec.Internal)
 	if err != nil {
 		return setInvalidRouteStatus(log, cfRoute, "Error creating/patching services", "CreatePatchServices", err)
 	}
 
-	err = r.createOrPatchRouteProxy(ctx, cfRoute)
-	if err != nil {
-		return setInvalidRouteStatus(log, cfRoute, "Error creating/patching Route Proxy", "CreatePatchRouteProxy", err)
-	}
+	// internal routes are resolved by sibling apps through their headless
+	// services and are not exposed via the ingress
+	if !cfDomain.Spec.Internal {
+		err = r.createOrPatchRouteProxy(ctx, cfRoute)
+		if err != nil {
+			return setInvalidRouteStatus(log, cfRoute, "Error creating/patching Route Proxy", "CreatePatchRouteProxy", err)
+		}
 
-	err = r.createOrPatchFQDNProxy(ctx, cfRoute, cfDomain)
-	if err != nil {
-		return setInvalidRouteStatus(log, cfRoute, "Error creating/patching FQDN Proxy", "CreatePatchFQDNProxy", err)
+		err = r.createOrPatchFQDNProxy(ctx, cfRoute, cfDomain)
+		if err != nil {
+			return setInvalidRouteStatus(log, cfRoute, "Error creating/patching FQDN Proxy", "CreatePatchFQDNProxy", err)
+		}
 	}
 
 	err = r.deleteOrphanedServices(ctx, cfRoute)
@@ -162,7 +173,33 @@ func (r *CFRouteReconciler) ReconcileResource(ctx context.Context, cfRoute *kori
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: retainedFor}, nil
+}
+
+// checkRetention looks at routes retained after their app has been deleted.
+// It returns how long the route is still retained for, or whether it should
+// be deleted now. Routes that got destinations again are no longer retained.
+func (r *CFRouteReconciler) checkRetention(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) (time.Duration, bool) {
+	log := logr.FromContextOrDiscard(ctx).WithName("checkRetention")
+
+	deleteAfter, ok := cfRoute.Annotations[korifiv1alpha1.CFRouteDeleteAfterAnnotationKey]
+	if !ok {
+		return 0, false
+	}
+
+	if len(cfRoute.Spec.Destinations) > 0 {
+		delete(cfRoute.Annotations, korifiv1alpha1.CFRouteDeleteAfterAnnotationKey)
+		return 0, false
+	}
+
+	deleteAfterTime, err := time.Parse(time.RFC3339, deleteAfter)
+	if err != nil {
+		log.Info("ignoring invalid delete-after annotation", "deleteAfter", deleteAfter, "reason", err)
+		return 0, false
+	}
+
+	retainedFor := time.Until(deleteAfterTime)
+	return retainedFor, retainedFor <= 0
 }
 
 func setValidRouteStatus(
@@ -257,7 +294,7 @@ func (r *CFRouteReconciler) finalizeFQDNProxy(ctx context.Context, cfRouteName s
 	})
 }
 
-func (r *CFRouteReconciler) createOrPatchServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
+func (r *CFRouteReconciler) createOrPatchServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, headless bool) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchServices")
 
 	for i, destination := range cfRoute.Status.Destinations {
@@ -291,6 +328,11 @@ func (r *CFRouteReconciler) createOrPatchServices(ctx context.Context, cfRoute *
 				Port: int32(*destination.Port),
 			}}
 
+			// the cluster IP is immutable, so it can only be set on creation
+			if headless && service.CreationTimestamp.IsZero() {
+				service.Spec.ClusterIP = corev1.ClusterIPNone
+			}
+
 			service.Spec.Selector = map[string]string{
 				korifiv1alpha1.CFAppGUIDLabelKey:     destination.AppRef.Name,
 				korifiv1alpha1.CFProcessTypeLabelKey: destination.ProcessType,
@@ -375,13 +417,28 @@ func (r *CFRouteReconciler) createOrPatchRouteProxy(ctx context.Context, cfRoute
 	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchRouteProxy").WithValues("httpProxyNamespace", cfRoute.Namespace, "httpProxyName", cfRoute.Name)
 
 	services := []contourv1.Service{}
+	weighted := true
 
 	for i, destination := range cfRoute.Status.Destinations {
 		if destination.Port != nil {
-			services = append(services, contourv1.Service{
+			service := contourv1.Service{
 				Name: generateServiceName(&cfRoute.Status.Destinations[i]),
 				Port: *destination.Port,
-			})
+			}
+			if destination.Weight != nil {
+				service.Weight = int64(*destination.Weight)
+			} else {
+				weighted = false
+			}
+			services = append(services, service)
+		}
+	}
+
+	// Contour sends no traffic to unweighted services as soon as one service
+	// has a weight, so weights only apply when all destinations have one
+	if !weighted {
+		for i := range services {
+			services[i].Weight = 0
 		}
 	}
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
@@ -340,6 +341,57 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 		})
 	})
 
+	When("the CFRoute is on an internal domain", func() {
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, adminClient, cfDomain, func() {
+				cfDomain.Spec.Internal = true
+			})).To(Succeed())
+
+			cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
+				{
+					GUID: GenerateGUID(),
+					AppRef: corev1.LocalObjectReference{
+						Name: testAppGUID,
+					},
+					ProcessType: "web",
+					Port:        tools.PtrTo(8080),
+				},
+			}
+		})
+
+		It("creates a headless service selecting the app LRPs", func() {
+			serviceName := fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)
+			Eventually(func(g Gomega) {
+				var svc corev1.Service
+
+				g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: testNamespace}, &svc)).To(Succeed())
+				g.Expect(svc.Spec.ClusterIP).To(Equal(corev1.ClusterIPNone))
+				g.Expect(svc.Spec.Selector).To(SatisfyAll(
+					HaveLen(2),
+					HaveKeyWithValue("korifi.cloudfoundry.org/app-guid", testAppGUID),
+					HaveKeyWithValue("korifi.cloudfoundry.org/process-type", "web"),
+				))
+				g.Expect(svc.Spec.Ports).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Port": BeEquivalentTo(8080),
+				})))
+			}).Should(Succeed())
+		})
+
+		It("does not expose the route via the ingress", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+				g.Expect(cfRoute.Status.Destinations).To(HaveLen(1))
+			}).Should(Succeed())
+
+			Consistently(func(g Gomega) {
+				err := adminClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &contourv1.HTTPProxy{})
+				g.Expect(errors.IsNotFound(err)).To(BeTrue())
+				err = adminClient.Get(ctx, types.NamespacedName{Name: fqdnProxyName(), Namespace: testNamespace}, &contourv1.HTTPProxy{})
+				g.Expect(errors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
+	})
+
 	When("there are multiple routes in the space", func() {
 		var (
 			anotherRouteGUID string
@@ -421,6 +473,83 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 		})
 	})
 
+	When("the CFRoute destinations are weighted", func() {
+		BeforeEach(func() {
+			cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
+				{
+					GUID:        GenerateGUID(),
+					AppRef:      corev1.LocalObjectReference{Name: testAppGUID},
+					ProcessType: "web",
+					Port:        tools.PtrTo(8080),
+					Weight:      tools.PtrTo(80),
+				},
+				{
+					GUID:        GenerateGUID(),
+					AppRef:      corev1.LocalObjectReference{Name: testAppGUID},
+					ProcessType: "worker",
+					Port:        tools.PtrTo(9090),
+					Weight:      tools.PtrTo(20),
+				},
+			}
+		})
+
+		It("sets the weights on the http proxy services", func() {
+			Eventually(func(g Gomega) {
+				var proxy contourv1.HTTPProxy
+				g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
+				g.Expect(proxy.Spec.Routes).To(HaveLen(1))
+				g.Expect(proxy.Spec.Routes[0].Services).To(ConsistOf(
+					contourv1.Service{
+						Name:   fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID),
+						Port:   8080,
+						Weight: 80,
+					},
+					contourv1.Service{
+						Name:   fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[1].GUID),
+						Port:   9090,
+						Weight: 20,
+					},
+				))
+			}).Should(Succeed())
+		})
+
+		It("keeps the weights in the effective destinations", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, cfRoute)).To(Succeed())
+				g.Expect(cfRoute.Status.Destinations).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"Weight": PointTo(Equal(80))}),
+					MatchFields(IgnoreExtras, Fields{"Weight": PointTo(Equal(20))}),
+				))
+			}).Should(Succeed())
+		})
+
+		When("an unweighted destination is added", func() {
+			var unweightedDestinationGUID string
+
+			JustBeforeEach(func() {
+				unweightedDestinationGUID = GenerateGUID()
+				Expect(k8s.PatchResource(ctx, adminClient, cfRoute, func() {
+					cfRoute.Spec.Destinations = append(cfRoute.Spec.Destinations, korifiv1alpha1.Destination{
+						GUID:        unweightedDestinationGUID,
+						AppRef:      corev1.LocalObjectReference{Name: testAppGUID},
+						ProcessType: "web",
+						Port:        tools.PtrTo(7070),
+					})
+				})).To(Succeed())
+			})
+
+			It("splits the traffic evenly", func() {
+				Eventually(func(g Gomega) {
+					var proxy contourv1.HTTPProxy
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
+					g.Expect(proxy.Spec.Routes).To(HaveLen(1))
+					g.Expect(proxy.Spec.Routes[0].Services).To(HaveLen(3))
+					g.Expect(proxy.Spec.Routes[0].Services).To(HaveEach(MatchFields(IgnoreExtras, Fields{"Weight": BeZero()})))
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	When("a destination is added to a CFRoute", func() {
 		BeforeEach(func() {
 			cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
@@ -542,4 +671,62 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 			}).Should(Succeed())
 		})
 	})
+
+	When("the CFRoute is retained after its app was deleted", func() {
+		var deleteAfter time.Time
+
+		BeforeEach(func() {
+			deleteAfter = time.Now().Add(time.Hour)
+		})
+
+		JustBeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, adminClient, cfRoute, func() {
+				cfRoute.Annotations = map[string]string{
+					korifiv1alpha1.CFRouteDeleteAfterAnnotationKey: deleteAfter.UTC().Format(time.RFC3339),
+				}
+			})).To(Succeed())
+		})
+
+		It("keeps the CFRoute during the grace period", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, cfRoute)).To(Succeed())
+				g.Expect(cfRoute.DeletionTimestamp).To(BeNil())
+			}).Should(Succeed())
+		})
+
+		When("the grace period has passed", func() {
+			BeforeEach(func() {
+				deleteAfter = time.Now().Add(-time.Minute)
+			})
+
+			It("deletes the CFRoute", func() {
+				Eventually(func(g Gomega) {
+					err := adminClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, cfRoute)
+					g.Expect(errors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+
+		When("the CFRoute got destinations again", func() {
+			BeforeEach(func() {
+				deleteAfter = time.Now().Add(-time.Minute)
+				cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
+					{
+						GUID:        GenerateGUID(),
+						AppRef:      corev1.LocalObjectReference{Name: testAppGUID},
+						ProcessType: "web",
+						Port:        tools.PtrTo(80),
+					},
+				}
+			})
+
+			It("is no longer retained", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, cfRoute)).To(Succeed())
+					g.Expect(cfRoute.Annotations).NotTo(HaveKey(korifiv1alpha1.CFRouteDeleteAfterAnnotationKey))
+				}).Should(Succeed())
+				Expect(cfRoute.DeletionTimestamp).To(BeNil())
+			})
+		})
+	})
 })
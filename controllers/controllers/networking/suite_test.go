@@ -32,7 +32,10 @@ import (
 	//+kubebuilder:scaffold:imports
 )
 
-const rootNamespace = "cf"
+const (
+	rootNamespace        = "cf"
+	ingressTLSSecretName = "korifi-workloads-ingress-cert"
+)
 
 var (
 	stopManager     context.CancelFunc
@@ -96,6 +99,11 @@ var _ = BeforeSuite(func() {
 		k8sManager.GetClient(),
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFDomain"),
+		&config.ControllerConfig{
+			IncludeContourRouter:        true,
+			WorkloadsTLSSecretName:      ingressTLSSecretName,
+			WorkloadsTLSSecretNamespace: rootNamespace,
+		},
 	)).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -114,6 +122,8 @@ var _ = BeforeSuite(func() {
 		webhooks.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, networking.RouteEntityType)),
 		rootNamespace,
 		uncachedClient,
+		k8sManager.GetClient(),
+		config.RouteHostPolicyShared,
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect((&korifiv1alpha1.CFBuild{}).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
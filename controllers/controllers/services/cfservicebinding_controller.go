@@ -23,6 +23,8 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/go-logr/logr"
@@ -37,34 +39,63 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const (
 	BindingSecretAvailableCondition      = "BindingSecretAvailable"
 	VCAPServicesSecretAvailableCondition = "VCAPServicesSecretAvailable"
+	AppAvailableCondition                = "AppAvailable"
 	ServiceBindingGUIDLabel              = "korifi.cloudfoundry.org/service-binding-guid"
 	ServiceCredentialBindingTypeLabel    = "korifi.cloudfoundry.org/service-credential-binding-type"
 )
 
 // CFServiceBindingReconciler reconciles a CFServiceBinding object
 type CFServiceBindingReconciler struct {
-	k8sClient client.Client
-	scheme    *runtime.Scheme
-	log       logr.Logger
+	k8sClient        client.Client
+	apiReader        client.Reader
+	scheme           *runtime.Scheme
+	log              logr.Logger
+	controllerConfig *config.ControllerConfig
 }
 
 func NewCFServiceBindingReconciler(
 	k8sClient client.Client,
+	apiReader client.Reader,
 	scheme *runtime.Scheme,
 	log logr.Logger,
+	controllerConfig *config.ControllerConfig,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceBinding, *korifiv1alpha1.CFServiceBinding] {
-	cfBindingReconciler := &CFServiceBindingReconciler{k8sClient: k8sClient, scheme: scheme, log: log}
+	cfBindingReconciler := &CFServiceBindingReconciler{k8sClient: k8sClient, apiReader: apiReader, scheme: scheme, log: log, controllerConfig: controllerConfig}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFServiceBinding, *korifiv1alpha1.CFServiceBinding](log, k8sClient, cfBindingReconciler)
 }
 
 func (r *CFServiceBindingReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&korifiv1alpha1.CFServiceBinding{})
+		For(&korifiv1alpha1.CFServiceBinding{}).
+		Watches(
+			&korifiv1alpha1.CFServiceInstance{},
+			handler.EnqueueRequestsFromMapFunc(r.serviceInstanceToServiceBindings),
+		)
+}
+
+func (r *CFServiceBindingReconciler) serviceInstanceToServiceBindings(ctx context.Context, o client.Object) []reconcile.Request {
+	serviceBindings := korifiv1alpha1.CFServiceBindingList{}
+	if err := r.k8sClient.List(ctx, &serviceBindings,
+		client.InNamespace(o.GetNamespace()),
+		client.MatchingFields{shared.IndexServiceBindingServiceInstanceGUID: o.GetName()},
+	); err != nil {
+		r.log.Error(fmt.Errorf("listing CFServiceBindings for CFServiceInstance failed: %w", err), "cfServiceInstanceGUID", o.GetName())
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for i := range serviceBindings.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&serviceBindings.Items[i])})
+	}
+
+	return requests
 }
 
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings,verbs=get;list;watch;create;update;patch;delete
@@ -110,10 +141,21 @@ func (r *CFServiceBindingReconciler) ReconcileResource(ctx context.Context, cfSe
 	cfApp := new(korifiv1alpha1.CFApp)
 	err = r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.AppRef.Name, Namespace: cfServiceBinding.Namespace}, cfApp)
 	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return r.handleOrphanedBinding(ctx, cfServiceBinding)
+		}
 		log.Info("error when fetching CFApp", "reason", err)
 		return ctrl.Result{}, err
 	}
 
+	meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+		Type:               AppAvailableCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             "AppFound",
+		Message:            "",
+		ObservedGeneration: cfServiceBinding.Generation,
+	})
+
 	if cfApp.Status.VCAPServicesSecretName == "" {
 		log.V(1).Info("did not find VCAPServiceSecret name on status of CFApp", "CFServiceBinding", cfServiceBinding.Name)
 		meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
@@ -150,9 +192,63 @@ func (r *CFServiceBindingReconciler) ReconcileResource(ctx context.Context, cfSe
 		return ctrl.Result{}, err
 	}
 
+	setCredentialsGeneration(cfServiceBinding, instance)
+
 	return ctrl.Result{}, nil
 }
 
+// setCredentialsGeneration records on the binding which generation of the
+// service instance credentials it has been refreshed with
+func setCredentialsGeneration(cfServiceBinding *korifiv1alpha1.CFServiceBinding, instance *korifiv1alpha1.CFServiceInstance) {
+	generation, ok := instance.Annotations[korifiv1alpha1.CFServiceCredentialsGenerationAnnotationKey]
+	if !ok {
+		return
+	}
+
+	if cfServiceBinding.Annotations == nil {
+		cfServiceBinding.Annotations = map[string]string{}
+	}
+	cfServiceBinding.Annotations[korifiv1alpha1.CFServiceCredentialsGenerationAnnotationKey] = generation
+}
+
+// handleOrphanedBinding deals with a binding whose app no longer exists,
+// either deleting it or flagging it as orphaned depending on the configured
+// policy. As the app lookup goes through the cache, which may not have seen a
+// newly created app yet, the deletion is only done once the API server
+// confirms that the app does not exist.
+func (r *CFServiceBindingReconciler) handleOrphanedBinding(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if r.controllerConfig.OrphanedBindingPolicy == config.OrphanedBindingPolicyDelete {
+		err := r.apiReader.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.AppRef.Name, Namespace: cfServiceBinding.Namespace}, new(korifiv1alpha1.CFApp))
+		if err == nil {
+			log.V(1).Info("app-not-in-cache-yet", "appGUID", cfServiceBinding.Spec.AppRef.Name)
+			return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			log.Info("error when confirming CFApp absence", "reason", err)
+			return ctrl.Result{}, err
+		}
+
+		log.V(1).Info("deleting-orphaned-binding", "appGUID", cfServiceBinding.Spec.AppRef.Name)
+		err = r.k8sClient.Delete(ctx, cfServiceBinding)
+		if err != nil {
+			log.Info("error-deleting-orphaned-binding", "reason", err)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+		Type:               AppAvailableCondition,
+		Status:             metav1.ConditionFalse,
+		Reason:             "AppNotFound",
+		Message:            "App does not exist",
+		ObservedGeneration: cfServiceBinding.Generation,
+	})
+
+	return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+}
+
 func (r *CFServiceBindingReconciler) handleGetError(ctx context.Context, err error, cfServiceBinding *korifiv1alpha1.CFServiceBinding, conditionType, notFoundReason, objectType string) (ctrl.Result, error) {
 	cfServiceBinding.Status.Binding = corev1.LocalObjectReference{}
 	if apierrors.IsNotFound(err) {
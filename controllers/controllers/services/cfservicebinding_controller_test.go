@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -14,10 +15,15 @@ import (
 	. "github.com/onsi/gomega/gstruct"
 	servicebindingv1beta1 "github.com/servicebinding/runtime/apis/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 var _ = Describe("CFServiceBinding", func() {
@@ -191,6 +197,27 @@ var _ = Describe("CFServiceBinding", func() {
 		}).Should(Succeed())
 	})
 
+	When("the credentials of the service instance are rotated", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(cfServiceBinding), cfServiceBinding)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(cfServiceBinding.Status.Conditions, services.VCAPServicesSecretAvailableCondition)).To(BeTrue())
+			}).Should(Succeed())
+			Expect(cfServiceBinding.Annotations).NotTo(HaveKey(korifiv1alpha1.CFServiceCredentialsGenerationAnnotationKey))
+
+			Expect(k8s.PatchResource(context.Background(), adminClient, cfServiceInstance, func() {
+				cfServiceInstance.Annotations = map[string]string{korifiv1alpha1.CFServiceCredentialsGenerationAnnotationKey: "1"}
+			})).To(Succeed())
+		})
+
+		It("refreshes the binding with the credentials generation of the service instance", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(cfServiceBinding), cfServiceBinding)).To(Succeed())
+				g.Expect(cfServiceBinding.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFServiceCredentialsGenerationAnnotationKey, "1"))
+			}).Should(Succeed())
+		})
+	})
+
 	When("the CFServiceBinding has a displayName set", func() {
 		var bindingName string
 
@@ -308,4 +335,82 @@ var _ = Describe("CFServiceBinding", func() {
 			})
 		})
 	})
+
+	When("the referenced app does not exist", func() {
+		BeforeEach(func() {
+			cfServiceBinding.Spec.AppRef.Name = GenerateGUID()
+		})
+
+		It("flags the CFServiceBinding as orphaned", func() {
+			Eventually(func(g Gomega) {
+				updatedCFServiceBinding := new(korifiv1alpha1.CFServiceBinding)
+				g.Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(cfServiceBinding), updatedCFServiceBinding)).To(Succeed())
+				g.Expect(updatedCFServiceBinding.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":    Equal("AppAvailable"),
+					"Status":  Equal(metav1.ConditionFalse),
+					"Reason":  Equal("AppNotFound"),
+					"Message": Equal("App does not exist"),
+				})))
+			}).Should(Succeed())
+		})
+
+		When("the orphaned binding policy is delete", func() {
+			var reconciler reconcile.Reconciler
+
+			BeforeEach(func() {
+				// the suite config is shared with the reconciler running in
+				// the manager, so this reconciler gets its own copy
+				deleteConfig := *controllerConfig
+				deleteConfig.OrphanedBindingPolicy = config.OrphanedBindingPolicyDelete
+				reconciler = services.NewCFServiceBindingReconciler(
+					appCacheMissClient{Client: adminClient},
+					adminClient,
+					scheme.Scheme,
+					ctrl.Log.WithName("controllers").WithName("CFServiceBinding").WithName("delete"),
+					&deleteConfig,
+				)
+			})
+
+			When("the app is only missing from the cache", func() {
+				BeforeEach(func() {
+					Expect(adminClient.Create(context.Background(), BuildCFAppCRObject(cfServiceBinding.Spec.AppRef.Name, namespace.Name))).To(Succeed())
+				})
+
+				It("does not delete the CFServiceBinding", func() {
+					Eventually(func(g Gomega) {
+						result, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(cfServiceBinding)})
+						g.Expect(err).NotTo(HaveOccurred())
+						g.Expect(result.RequeueAfter).NotTo(BeZero())
+					}).Should(Succeed())
+
+					Consistently(func(g Gomega) {
+						g.Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(cfServiceBinding), new(korifiv1alpha1.CFServiceBinding))).To(Succeed())
+					}).Should(Succeed())
+				})
+			})
+
+			It("deletes the CFServiceBinding", func() {
+				Eventually(func(g Gomega) {
+					_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(cfServiceBinding)})
+					g.Expect(err).NotTo(HaveOccurred())
+
+					err = adminClient.Get(context.Background(), client.ObjectKeyFromObject(cfServiceBinding), new(korifiv1alpha1.CFServiceBinding))
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
 })
+
+// appCacheMissClient simulates a cache that has not seen any CFApp yet
+type appCacheMissClient struct {
+	client.Client
+}
+
+func (c appCacheMissClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if _, ok := obj.(*korifiv1alpha1.CFApp); ok {
+		return k8serrors.NewNotFound(schema.GroupResource{Group: korifiv1alpha1.GroupVersion.Group, Resource: "cfapps"}, key.Name)
+	}
+
+	return c.Client.Get(ctx, key, obj, opts...)
+}
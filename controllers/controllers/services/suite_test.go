@@ -23,6 +23,7 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/services"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tests/helpers"
@@ -39,11 +40,12 @@ import (
 )
 
 var (
-	ctx             context.Context
-	stopManager     context.CancelFunc
-	stopClientCache context.CancelFunc
-	testEnv         *envtest.Environment
-	adminClient     client.Client
+	ctx              context.Context
+	stopManager      context.CancelFunc
+	stopClientCache  context.CancelFunc
+	testEnv          *envtest.Environment
+	adminClient      client.Client
+	controllerConfig *config.ControllerConfig
 )
 
 func TestAPIs(t *testing.T) {
@@ -78,10 +80,16 @@ var _ = BeforeSuite(func() {
 
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
+	controllerConfig = &config.ControllerConfig{
+		OrphanedBindingPolicy: config.OrphanedBindingPolicyFlag,
+	}
+
 	err = (NewCFServiceBindingReconciler(
 		k8sManager.GetClient(),
+		k8sManager.GetAPIReader(),
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFServiceBinding"),
+		controllerConfig,
 	)).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -16,6 +16,7 @@ import (
 const (
 	IndexRouteDestinationAppName           = "destinationAppName"
 	IndexRouteDomainQualifiedName          = "domainQualifiedName"
+	IndexRouteHostDomain                   = "routeHostDomain"
 	IndexServiceBindingAppGUID             = "serviceBindingAppGUID"
 	IndexServiceBindingServiceInstanceGUID = "serviceBindingServiceInstanceGUID"
 	IndexAppTasks                          = "appTasks"
@@ -36,6 +37,11 @@ func SetupIndexWithManager(mgr manager.Manager) error {
 		return err
 	}
 
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), new(korifiv1alpha1.CFRoute), IndexRouteHostDomain, routeHostDomainIndexFn)
+	if err != nil {
+		return err
+	}
+
 	err = mgr.GetFieldIndexer().IndexField(context.Background(), new(korifiv1alpha1.CFServiceBinding), IndexServiceBindingAppGUID, serviceBindingAppGUIDIndexFn)
 	if err != nil {
 		return err
@@ -87,6 +93,17 @@ func routeDomainQualifiedNameIndexFn(rawObj client.Object) []string {
 	return []string{route.Spec.DomainRef.Namespace + "." + route.Spec.DomainRef.Name}
 }
 
+func routeHostDomainIndexFn(rawObj client.Object) []string {
+	route := rawObj.(*korifiv1alpha1.CFRoute)
+	return []string{RouteHostDomainKey(route)}
+}
+
+// RouteHostDomainKey returns the IndexRouteHostDomain value of the route,
+// which identifies its host on its domain
+func RouteHostDomainKey(route *korifiv1alpha1.CFRoute) string {
+	return route.Spec.Host + "." + route.Spec.DomainRef.Namespace + "." + route.Spec.DomainRef.Name
+}
+
 func serviceBindingAppGUIDIndexFn(rawObj client.Object) []string {
 	serviceBinding := rawObj.(*korifiv1alpha1.CFServiceBinding)
 	return []string{serviceBinding.Spec.AppRef.Name}
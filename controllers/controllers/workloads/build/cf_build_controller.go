@@ -94,6 +94,10 @@ func (r *CFBuildReconciler) ReconcileResource(ctx context.Context, cfBuild *kori
 		return ctrl.Result{}, err
 	}
 
+	if copiedFrom, ok := cfBuild.Annotations[korifiv1alpha1.CFBuildCopiedFromAnnotationKey]; ok {
+		return ctrl.Result{}, r.copyDroplet(ctx, cfBuild, copiedFrom)
+	}
+
 	cfPackage := new(korifiv1alpha1.CFPackage)
 	err = r.k8sClient.Get(ctx, types.NamespacedName{Name: cfBuild.Spec.PackageRef.Name, Namespace: cfBuild.Namespace}, cfPackage)
 	if err != nil {
@@ -124,6 +128,37 @@ func (r *CFBuildReconciler) ReconcileResource(ctx context.Context, cfBuild *kori
 	return r.delegate.ReconcileBuild(ctx, cfBuild, cfApp, cfPackage)
 }
 
+// copyDroplet completes a build that was created by copying the droplet of
+// another build. The API copies the droplet into the build status once it has
+// checked that the user can access both apps; the source build is never read
+// here, so that users cannot copy droplets (and their image pull secrets) out
+// of spaces they have no access to by annotating a build.
+func (r *CFBuildReconciler) copyDroplet(ctx context.Context, cfBuild *korifiv1alpha1.CFBuild, copiedFrom string) error {
+	log := logr.FromContextOrDiscard(ctx)
+
+	meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.StagingConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             "BuildNotRunning",
+		ObservedGeneration: cfBuild.Generation,
+	})
+
+	if cfBuild.Status.Droplet == nil {
+		log.V(1).Info("waiting for the droplet to be copied", "copiedFrom", copiedFrom)
+		return nil
+	}
+
+	meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.SucceededConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             "DropletCopied",
+		Message:            fmt.Sprintf("droplet copied from build %s", copiedFrom),
+		ObservedGeneration: cfBuild.Generation,
+	})
+
+	return nil
+}
+
 func validateLifecycleTypes(
 	cfApp *korifiv1alpha1.CFApp,
 	cfPackage *korifiv1alpha1.CFPackage,
@@ -194,6 +194,52 @@ var _ = Describe("CFBuildReconciler", func() {
 		})
 	})
 
+	When("the build is a copy of another build", func() {
+		BeforeEach(func() {
+			cfBuild.Annotations = map[string]string{
+				korifiv1alpha1.CFBuildCopiedFromAnnotationKey: "source-namespace/source-build",
+			}
+		})
+
+		It("does not stage the build", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(reconciledBuilds()).NotTo(HaveKey(cfBuild.Name))
+			}).Should(Succeed())
+		})
+
+		It("waits for the droplet to be copied", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)).To(BeFalse())
+				g.Expect(cfBuild.Status.Droplet).To(BeNil())
+			}).Should(Succeed())
+		})
+
+		When("the droplet has been copied", func() {
+			JustBeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, cfBuild, func() {
+					cfBuild.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+						Registry: korifiv1alpha1.Registry{Image: "registry/source-image"},
+						ProcessTypes: []korifiv1alpha1.ProcessType{{
+							Type:    "web",
+							Command: "run-me",
+						}},
+					}
+				})).To(Succeed())
+			})
+
+			It("completes the build", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+					g.Expect(meta.IsStatusConditionTrue(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)).To(BeTrue())
+					g.Expect(meta.IsStatusConditionFalse(cfBuild.Status.Conditions, korifiv1alpha1.StagingConditionType)).To(BeTrue())
+					g.Expect(cfBuild.Status.Droplet).NotTo(BeNil())
+					g.Expect(cfBuild.Status.Droplet.Registry.Image).To(Equal("registry/source-image"))
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	When("the build succeeds", func() {
 		JustBeforeEach(func() {
 			Eventually(func(g Gomega) {
@@ -63,6 +63,7 @@ func NewCFBuildpackBuildReconciler(
 				controllerConfig: controllerConfig,
 				envBuilder:       envBuilder,
 				scheme:           scheme,
+				serviceAccounts:  NewServiceAccountPropagator(k8sClient, controllerConfig),
 			},
 		))
 }
@@ -72,6 +73,7 @@ type buildpackBuildReconciler struct {
 	controllerConfig *config.ControllerConfig
 	envBuilder       EnvBuilder
 	scheme           *runtime.Scheme
+	serviceAccounts  *ServiceAccountPropagator
 }
 
 func (r *buildpackBuildReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
@@ -247,6 +249,12 @@ func (r *buildpackBuildReconciler) createBuildWorkload(ctx context.Context, cfBu
 		return err
 	}
 
+	err = r.serviceAccounts.EnsureServiceAccounts(ctx, namespace)
+	if err != nil {
+		log.Info("failed to ensure service accounts", "reason", err)
+		return err
+	}
+
 	err = r.createBuildWorkloadIfNotExists(ctx, desiredWorkload)
 	if err != nil {
 		return err
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
@@ -36,15 +37,29 @@ type CFAppReconciler struct {
 	scheme                    *runtime.Scheme
 	vcapServicesEnvBuilder    EnvValueBuilder
 	vcapApplicationEnvBuilder EnvValueBuilder
+	// routeCleanupPolicy decides what happens to the routes left without
+	// destinations when an app is deleted. It can be overridden per app via
+	// the route cleanup policy annotation.
+	routeCleanupPolicy      string
+	routeCleanupGracePeriod time.Duration
 }
 
-func NewCFAppReconciler(k8sClient client.Client, scheme *runtime.Scheme, log logr.Logger, vcapServicesBuilder, vcapApplicationBuilder EnvValueBuilder) *k8s.PatchingReconciler[korifiv1alpha1.CFApp, *korifiv1alpha1.CFApp] {
+func NewCFAppReconciler(
+	k8sClient client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+	vcapServicesBuilder, vcapApplicationBuilder EnvValueBuilder,
+	routeCleanupPolicy string,
+	routeCleanupGracePeriod time.Duration,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFApp, *korifiv1alpha1.CFApp] {
 	appReconciler := CFAppReconciler{
 		log:                       log,
 		k8sClient:                 k8sClient,
 		scheme:                    scheme,
 		vcapServicesEnvBuilder:    vcapServicesBuilder,
 		vcapApplicationEnvBuilder: vcapApplicationBuilder,
+		routeCleanupPolicy:        routeCleanupPolicy,
+		routeCleanupGracePeriod:   routeCleanupGracePeriod,
 	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFApp, *korifiv1alpha1.CFApp](log, k8sClient, &appReconciler)
 }
@@ -324,9 +339,56 @@ func (r *CFAppReconciler) finalizeCFAppRoutes(ctx context.Context, cfApp *korifi
 		return err
 	}
 
+	policy := r.getRouteCleanupPolicy(ctx, cfApp)
+	for i := range cfRoutes {
+		if len(cfRoutes[i].Spec.Destinations) > 0 {
+			continue
+		}
+
+		err = r.cleanupRoute(ctx, &cfRoutes[i], policy)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+func (r *CFAppReconciler) getRouteCleanupPolicy(ctx context.Context, cfApp *korifiv1alpha1.CFApp) string {
+	policy, ok := cfApp.Annotations[korifiv1alpha1.CFAppRouteCleanupPolicyAnnotationKey]
+	if !ok {
+		return r.routeCleanupPolicy
+	}
+
+	if !config.IsValidRouteCleanupPolicy(policy) {
+		logr.FromContextOrDiscard(ctx).Info("ignoring invalid route cleanup policy", "policy", policy)
+		return r.routeCleanupPolicy
+	}
+
+	return policy
+}
+
+func (r *CFAppReconciler) cleanupRoute(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, policy string) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("cleanupRoute").WithValues("routeName", cfRoute.Name, "policy", policy)
+
+	switch policy {
+	case config.RouteCleanupPolicyDelete:
+		log.V(1).Info("deleting route without destinations")
+		return client.IgnoreNotFound(r.k8sClient.Delete(ctx, cfRoute))
+	case config.RouteCleanupPolicyRetain:
+		deleteAfter := time.Now().Add(r.routeCleanupGracePeriod).UTC().Format(time.RFC3339)
+		log.V(1).Info("retaining route without destinations", "deleteAfter", deleteAfter)
+		return k8s.Patch(ctx, r.k8sClient, cfRoute, func() {
+			if cfRoute.Annotations == nil {
+				cfRoute.Annotations = map[string]string{}
+			}
+			cfRoute.Annotations[korifiv1alpha1.CFRouteDeleteAfterAnnotationKey] = deleteAfter
+		})
+	default:
+		return nil
+	}
+}
+
 func (r *CFAppReconciler) finalizeCFServiceBindings(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("finalizeCFServiceBindings")
 
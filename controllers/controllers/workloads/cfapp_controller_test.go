@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -536,6 +537,150 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 			}).Should(Succeed())
 		})
 
+		When("the route destinations are weighted", func() {
+			var otherCFAppGUID string
+
+			BeforeEach(func() {
+				otherCFAppGUID = GenerateGUID()
+				Expect(adminClient.Create(context.Background(), BuildCFAppCRObject(otherCFAppGUID, cfSpace.Status.GUID))).To(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, cfRoute, func() {
+					cfRoute.Spec.Destinations[0].Weight = tools.PtrTo(80)
+					cfRoute.Spec.Destinations = append(cfRoute.Spec.Destinations, korifiv1alpha1.Destination{
+						GUID:        "destination-2-guid",
+						AppRef:      corev1.LocalObjectReference{Name: otherCFAppGUID},
+						ProcessType: "web",
+						Protocol:    tools.PtrTo("http1"),
+						Weight:      tools.PtrTo(20),
+					})
+				})).To(Succeed())
+			})
+
+			It("removes the destination of the deleted app and keeps the other weights", func() {
+				Eventually(func(g Gomega) {
+					var createdCFRoute korifiv1alpha1.CFRoute
+					g.Expect(adminClient.Get(context.Background(), types.NamespacedName{Name: cfRouteGUID, Namespace: cfSpace.Status.GUID}, &createdCFRoute)).To(Succeed())
+					g.Expect(createdCFRoute.Spec.Destinations).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID":   Equal("destination-2-guid"),
+						"Weight": PointTo(Equal(20)),
+					})))
+				}).Should(Succeed())
+			})
+
+			It("eventually deletes the CFApp", func() {
+				Eventually(func(g Gomega) {
+					err := adminClient.Get(context.Background(), types.NamespacedName{Name: cfAppGUID, Namespace: cfSpace.Status.GUID}, &korifiv1alpha1.CFApp{})
+					g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+
+		When("the app route cleanup policy is delete", func() {
+			var otherCFRouteGUID string
+
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+					cfApp.Annotations[korifiv1alpha1.CFAppRouteCleanupPolicyAnnotationKey] = "delete"
+				})).To(Succeed())
+
+				otherCFRouteGUID = GenerateGUID()
+				Expect(adminClient.Create(context.Background(), &korifiv1alpha1.CFRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      otherCFRouteGUID,
+						Namespace: cfSpace.Status.GUID,
+					},
+					Spec: korifiv1alpha1.CFRouteSpec{
+						Host:     "other-route-host",
+						Protocol: "http",
+						DomainRef: corev1.ObjectReference{
+							Name:      cfDomainGUID,
+							Namespace: cfSpace.Status.GUID,
+						},
+						Destinations: []korifiv1alpha1.Destination{
+							{
+								GUID:        "destination-1-guid",
+								AppRef:      corev1.LocalObjectReference{Name: cfAppGUID},
+								ProcessType: "web",
+								Protocol:    tools.PtrTo("http1"),
+							},
+							{
+								GUID:        "destination-2-guid",
+								AppRef:      corev1.LocalObjectReference{Name: "another-app"},
+								ProcessType: "web",
+								Protocol:    tools.PtrTo("http1"),
+							},
+						},
+					},
+				})).To(Succeed())
+			})
+
+			It("deletes the CFRoute left without destinations", func() {
+				Eventually(func(g Gomega) {
+					var createdCFRoute korifiv1alpha1.CFRoute
+					err := adminClient.Get(context.Background(), types.NamespacedName{Name: cfRouteGUID, Namespace: cfSpace.Status.GUID}, &createdCFRoute)
+					if apierrors.IsNotFound(err) {
+						return
+					}
+					g.Expect(err).NotTo(HaveOccurred())
+					g.Expect(createdCFRoute.DeletionTimestamp).NotTo(BeNil())
+				}).Should(Succeed())
+			})
+
+			It("keeps the CFRoute that still has destinations of other apps", func() {
+				Eventually(func(g Gomega) {
+					var otherCFRoute korifiv1alpha1.CFRoute
+					g.Expect(adminClient.Get(context.Background(), types.NamespacedName{Name: otherCFRouteGUID, Namespace: cfSpace.Status.GUID}, &otherCFRoute)).To(Succeed())
+					g.Expect(otherCFRoute.Spec.Destinations).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal("destination-2-guid"),
+					})))
+					g.Expect(otherCFRoute.DeletionTimestamp).To(BeNil())
+				}).Should(Succeed())
+			})
+		})
+
+		When("the app route cleanup policy is orphan", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+					cfApp.Annotations[korifiv1alpha1.CFAppRouteCleanupPolicyAnnotationKey] = "orphan"
+				})).To(Succeed())
+			})
+
+			It("keeps the CFRoute without destinations", func() {
+				Eventually(func(g Gomega) {
+					var createdCFRoute korifiv1alpha1.CFRoute
+					g.Expect(adminClient.Get(context.Background(), types.NamespacedName{Name: cfRouteGUID, Namespace: cfSpace.Status.GUID}, &createdCFRoute)).To(Succeed())
+					g.Expect(createdCFRoute.Spec.Destinations).To(BeEmpty())
+				}).Should(Succeed())
+
+				Consistently(func(g Gomega) {
+					var createdCFRoute korifiv1alpha1.CFRoute
+					g.Expect(adminClient.Get(context.Background(), types.NamespacedName{Name: cfRouteGUID, Namespace: cfSpace.Status.GUID}, &createdCFRoute)).To(Succeed())
+					g.Expect(createdCFRoute.DeletionTimestamp).To(BeNil())
+				}, "2s").Should(Succeed())
+			})
+		})
+
+		When("the app route cleanup policy is retain", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+					cfApp.Annotations[korifiv1alpha1.CFAppRouteCleanupPolicyAnnotationKey] = "retain"
+				})).To(Succeed())
+			})
+
+			It("marks the CFRoute for deletion after the grace period", func() {
+				Eventually(func(g Gomega) {
+					var createdCFRoute korifiv1alpha1.CFRoute
+					g.Expect(adminClient.Get(context.Background(), types.NamespacedName{Name: cfRouteGUID, Namespace: cfSpace.Status.GUID}, &createdCFRoute)).To(Succeed())
+					g.Expect(createdCFRoute.DeletionTimestamp).To(BeNil())
+					g.Expect(createdCFRoute.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFRouteDeleteAfterAnnotationKey, WithTransform(func(s string) time.Time {
+						t, err := time.Parse(time.RFC3339, s)
+						g.Expect(err).NotTo(HaveOccurred())
+						return t
+					}, BeTemporally("~", time.Now().Add(time.Hour), time.Minute))))
+				}).Should(Succeed())
+			})
+		})
+
 		When("the app is referenced by service bindings", func() {
 			BeforeEach(func() {
 				cfServiceBinding := korifiv1alpha1.CFServiceBinding{
@@ -28,8 +28,10 @@ import (
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -39,6 +41,7 @@ import (
 
 type CFOrgReconciler struct {
 	client              client.Client
+	rootNamespace       string
 	namespaceReconciler *k8sns.Reconciler[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg]
 }
 
@@ -46,7 +49,9 @@ func NewCFOrgReconciler(
 	client client.Client,
 	log logr.Logger,
 	containerRegistrySecretNames []string,
+	rootNamespace string,
 	labelCompiler labels.Compiler,
+	annotationCompiler labels.Compiler,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg] {
 	namespaceController := k8sns.NewReconciler[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg](
 		client,
@@ -56,13 +61,15 @@ func NewCFOrgReconciler(
 			korifiv1alpha1.CFOrgFinalizerName,
 		),
 		&cfOrgMetadataCompiler{
-			labelCompiler: labelCompiler,
+			labelCompiler:      labelCompiler,
+			annotationCompiler: annotationCompiler,
 		},
 		containerRegistrySecretNames,
 	)
 
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFOrg, *korifiv1alpha1.CFOrg](log, client, &CFOrgReconciler{
 		client:              client,
+		rootNamespace:       rootNamespace,
 		namespaceReconciler: namespaceController,
 	})
 }
@@ -77,9 +84,20 @@ func (r *CFOrgReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 		Watches(
 			&rbacv1.RoleBinding{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueCFOrgRequests),
+		).
+		Watches(
+			&korifiv1alpha1.CFSpace{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueSpaceOrgRequests),
 		)
 }
 
+func (r *CFOrgReconciler) enqueueSpaceOrgRequests(ctx context.Context, object client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Namespace: r.rootNamespace,
+		Name:      object.GetNamespace(),
+	}}}
+}
+
 func (r *CFOrgReconciler) enqueueCFOrgRequests(ctx context.Context, object client.Object) []reconcile.Request {
 	cfOrgList := &korifiv1alpha1.CFOrgList{}
 	err := r.client.List(ctx, cfOrgList, client.InNamespace(object.GetNamespace()))
@@ -128,6 +146,11 @@ func (r *CFOrgReconciler) ReconcileResource(ctx context.Context, cfOrg *korifiv1
 		return nsReconcileResult, err
 	}
 
+	err = r.reconcileSpacesSuspension(ctx, cfOrg)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	meta.SetStatusCondition(&cfOrg.Status.Conditions, metav1.Condition{
 		Type:               shared.StatusConditionReady,
 		Status:             metav1.ConditionTrue,
@@ -138,8 +161,50 @@ func (r *CFOrgReconciler) ReconcileResource(ctx context.Context, cfOrg *korifiv1
 	return ctrl.Result{}, nil
 }
 
+// reconcileSpacesSuspension labels the namespaces of the org spaces while the
+// org is suspended, so that the webhooks can reject pushes into them
+func (r *CFOrgReconciler) reconcileSpacesSuspension(ctx context.Context, cfOrg *korifiv1alpha1.CFOrg) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileSpacesSuspension")
+
+	cfSpaceList := &korifiv1alpha1.CFSpaceList{}
+	if err := r.client.List(ctx, cfSpaceList, client.InNamespace(cfOrg.Name)); err != nil {
+		log.Info("error listing spaces", "reason", err)
+		return err
+	}
+
+	for _, cfSpace := range cfSpaceList.Items {
+		namespace := &corev1.Namespace{}
+		err := r.client.Get(ctx, types.NamespacedName{Name: cfSpace.Name}, namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			log.Info("error getting space namespace", "namespace", cfSpace.Name, "reason", err)
+			return err
+		}
+
+		err = k8s.PatchResource(ctx, r.client, namespace, func() {
+			if cfOrg.Spec.Suspended {
+				if namespace.Labels == nil {
+					namespace.Labels = map[string]string{}
+				}
+				namespace.Labels[korifiv1alpha1.OrgSuspendedLabelKey] = "true"
+			} else {
+				delete(namespace.Labels, korifiv1alpha1.OrgSuspendedLabelKey)
+			}
+		})
+		if err != nil {
+			log.Info("error patching space namespace", "namespace", cfSpace.Name, "reason", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
 type cfOrgMetadataCompiler struct {
-	labelCompiler labels.Compiler
+	labelCompiler      labels.Compiler
+	annotationCompiler labels.Compiler
 }
 
 func (c *cfOrgMetadataCompiler) CompileLabels(cfOrg *korifiv1alpha1.CFOrg) map[string]string {
@@ -150,7 +215,7 @@ func (c *cfOrgMetadataCompiler) CompileLabels(cfOrg *korifiv1alpha1.CFOrg) map[s
 }
 
 func (c *cfOrgMetadataCompiler) CompileAnnotations(cfOrg *korifiv1alpha1.CFOrg) map[string]string {
-	return map[string]string{
+	return c.annotationCompiler.Compile(map[string]string{
 		korifiv1alpha1.OrgNameKey: cfOrg.Spec.DisplayName,
-	}
+	})
 }
@@ -3,6 +3,7 @@ package workloads_test
 import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
@@ -80,4 +81,50 @@ var _ = Describe("CFOrgReconciler Integration Tests", func() {
 			g.Expect(ns.Labels).To(HaveKeyWithValue(api.EnforceLevelLabel, string(api.LevelRestricted)))
 		}).Should(Succeed())
 	})
+
+	When("the org is suspended", func() {
+		var cfSpace *korifiv1alpha1.CFSpace
+
+		BeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfOrg), cfOrg)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(cfOrg.Status.Conditions, "Ready")).To(BeTrue())
+			}).Should(Succeed())
+			cfSpace = createSpace(cfOrg)
+
+			Expect(k8s.PatchResource(ctx, adminClient, cfOrg, func() {
+				cfOrg.Spec.Suspended = true
+			})).To(Succeed())
+		})
+
+		It("labels the space namespaces as suspended", func() {
+			Eventually(func(g Gomega) {
+				var ns corev1.Namespace
+				g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfSpace.Name}, &ns)).To(Succeed())
+				g.Expect(ns.Labels).To(HaveKeyWithValue(korifiv1alpha1.OrgSuspendedLabelKey, "true"))
+			}).Should(Succeed())
+		})
+
+		When("the org is unsuspended", func() {
+			BeforeEach(func() {
+				Eventually(func(g Gomega) {
+					var ns corev1.Namespace
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfSpace.Name}, &ns)).To(Succeed())
+					g.Expect(ns.Labels).To(HaveKey(korifiv1alpha1.OrgSuspendedLabelKey))
+				}).Should(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, cfOrg, func() {
+					cfOrg.Spec.Suspended = false
+				})).To(Succeed())
+			})
+
+			It("removes the suspended label from the space namespaces", func() {
+				Eventually(func(g Gomega) {
+					var ns corev1.Namespace
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfSpace.Name}, &ns)).To(Succeed())
+					g.Expect(ns.Labels).NotTo(HaveKey(korifiv1alpha1.OrgSuspendedLabelKey))
+				}).Should(Succeed())
+			})
+		})
+	})
 })
@@ -21,12 +21,13 @@ import (
 	"crypto/sha1"
 	"errors"
 	"fmt"
-	"sort"
-	"strconv"
+	"slices"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/go-logr/logr"
@@ -56,6 +57,7 @@ type CFProcessReconciler struct {
 	log              logr.Logger
 	controllerConfig *config.ControllerConfig
 	envBuilder       EnvBuilder
+	serviceAccounts  *ServiceAccountPropagator
 }
 
 func NewCFProcessReconciler(
@@ -65,7 +67,14 @@ func NewCFProcessReconciler(
 	controllerConfig *config.ControllerConfig,
 	envBuilder EnvBuilder,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFProcess, *korifiv1alpha1.CFProcess] {
-	processReconciler := CFProcessReconciler{k8sClient: client, scheme: scheme, log: log, controllerConfig: controllerConfig, envBuilder: envBuilder}
+	processReconciler := CFProcessReconciler{
+		k8sClient:        client,
+		scheme:           scheme,
+		log:              log,
+		controllerConfig: controllerConfig,
+		envBuilder:       envBuilder,
+		serviceAccounts:  NewServiceAccountPropagator(client, controllerConfig),
+	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFProcess, *korifiv1alpha1.CFProcess](log, client, &processReconciler)
 }
 
@@ -126,6 +135,21 @@ func (r *CFProcessReconciler) enqueueCFProcessRequestsForRoute(ctx context.Conte
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;patch
 
 func (r *CFProcessReconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess) (ctrl.Result, error) {
+	result, err := r.reconcileProcess(ctx, cfProcess)
+	if err != nil {
+		meta.SetStatusCondition(&cfProcess.Status.Conditions, metav1.Condition{
+			Type:               shared.StatusConditionReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             "ReconcileFailed",
+			Message:            err.Error(),
+			ObservedGeneration: cfProcess.Generation,
+		})
+	}
+
+	return result, err
+}
+
+func (r *CFProcessReconciler) reconcileProcess(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
 	cfProcess.Status.ObservedGeneration = cfProcess.Generation
@@ -201,6 +225,18 @@ func (r *CFProcessReconciler) createOrPatchAppWorkload(ctx context.Context, cfAp
 		return errors.New("no build droplet status on CFBuild")
 	}
 
+	err = r.validateLauncher(cfApp, r.commandForProcess(cfProcess, cfApp))
+	if err != nil {
+		log.Info("process command uses a launcher that is not allowed", "namespace", cfProcess.Namespace, "name", cfProcess.Name, "reason", err)
+		return err
+	}
+
+	err = r.validateSidecars(cfProcess, cfApp)
+	if err != nil {
+		log.Info("process sidecars are not valid", "namespace", cfProcess.Namespace, "name", cfProcess.Name, "reason", err)
+		return err
+	}
+
 	appPorts, err := r.getPorts(ctx, cfProcess.Spec.ProcessType, cfApp)
 	if err != nil {
 		log.Info("error when trying to fetch ports for CFApp", "namespace", cfProcess.Namespace, "name", cfApp.Spec.DisplayName, "reason", err)
@@ -213,6 +249,18 @@ func (r *CFProcessReconciler) createOrPatchAppWorkload(ctx context.Context, cfAp
 		return err
 	}
 
+	podLabels, err := r.getPodLabels(ctx, cfApp)
+	if err != nil {
+		log.Info("error when trying to get the pod labels for app", "namespace", cfProcess.Namespace, "name", cfApp.Spec.DisplayName, "reason", err)
+		return err
+	}
+
+	err = r.serviceAccounts.EnsureServiceAccounts(ctx, cfProcess.Namespace)
+	if err != nil {
+		log.Info("error when trying to ensure the service accounts for app", "namespace", cfProcess.Namespace, "name", cfApp.Spec.DisplayName, "reason", err)
+		return err
+	}
+
 	actualAppWorkload := &korifiv1alpha1.AppWorkload{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: cfProcess.Namespace,
@@ -221,12 +269,18 @@ func (r *CFProcessReconciler) createOrPatchAppWorkload(ctx context.Context, cfAp
 	}
 
 	var desiredAppWorkload *korifiv1alpha1.AppWorkload
-	desiredAppWorkload, err = r.generateAppWorkload(actualAppWorkload, cfApp, cfProcess, cfBuild, appPorts, envVars, cfAppRev, cfLastStopAppRev)
+	desiredAppWorkload, err = r.generateAppWorkload(actualAppWorkload, cfApp, cfProcess, cfBuild, appPorts, envVars, podLabels, cfAppRev, cfLastStopAppRev)
 	if err != nil { // untested
 		log.Info("error when initializing AppWorkload", "reason", err)
 		return err
 	}
 
+	err = r.deleteAppWorkloadIfForcedToRecreate(ctx, cfApp, actualAppWorkload)
+	if err != nil {
+		log.Info("error when deleting AppWorkload to recreate it", "reason", err)
+		return err
+	}
+
 	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, actualAppWorkload, appWorkloadMutateFunction(actualAppWorkload, desiredAppWorkload))
 	if err != nil {
 		log.Info("error calling CreateOrPatch on AppWorkload", "reason", err)
@@ -235,6 +289,28 @@ func (r *CFProcessReconciler) createOrPatchAppWorkload(ctx context.Context, cfAp
 	return nil
 }
 
+// deleteAppWorkloadIfForcedToRecreate deletes the app workload when the app
+// force-recreate annotation has a value the workload has not been created
+// with yet, so that it gets recreated under the same name and app revision
+func (r *CFProcessReconciler) deleteAppWorkloadIfForcedToRecreate(ctx context.Context, cfApp *korifiv1alpha1.CFApp, appWorkload *korifiv1alpha1.AppWorkload) error {
+	forceRecreate := cfApp.Annotations[korifiv1alpha1.CFAppForceRecreateAnnotationKey]
+	if forceRecreate == "" {
+		return nil
+	}
+
+	existingAppWorkload := new(korifiv1alpha1.AppWorkload)
+	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(appWorkload), existingAppWorkload)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if existingAppWorkload.Annotations[korifiv1alpha1.CFAppForceRecreateAnnotationKey] == forceRecreate {
+		return nil
+	}
+
+	return client.IgnoreNotFound(r.k8sClient.Delete(ctx, existingAppWorkload))
+}
+
 func (r *CFProcessReconciler) cleanUpAppWorkloads(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess, desiredState korifiv1alpha1.DesiredState, cfLastStopAppRev string) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("cleanUpAppWorkloads")
 
@@ -277,7 +353,66 @@ func appWorkloadMutateFunction(actualAppWorkload, desiredAppWorkload *korifiv1al
 	}
 }
 
-func (r *CFProcessReconciler) generateAppWorkload(actualAppWorkload *korifiv1alpha1.AppWorkload, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess, cfBuild *korifiv1alpha1.CFBuild, appPorts []int32, envVars []corev1.EnvVar, cfAppRev, cfLastStopAppRev string) (*korifiv1alpha1.AppWorkload, error) {
+// getPodLabels returns the configured labels to propagate onto the workload
+// pods. App labels take precedence over the labels of the space namespace.
+func (r *CFProcessReconciler) getPodLabels(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (map[string]string, error) {
+	if len(r.controllerConfig.PropagatedPodLabels) == 0 {
+		return nil, nil
+	}
+
+	spaceNamespace := new(corev1.Namespace)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfApp.Namespace}, spaceNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	podLabels := map[string]string{}
+	for _, key := range r.controllerConfig.PropagatedPodLabels {
+		if value, ok := cfApp.Labels[key]; ok {
+			podLabels[key] = value
+			continue
+		}
+
+		if value, ok := spaceNamespace.Labels[key]; ok {
+			podLabels[key] = value
+		}
+	}
+
+	return podLabels, nil
+}
+
+// getPodAnnotations returns the configured default annotations for the
+// workload pods. An app can override a default by setting an annotation with
+// the same key, e.g. to opt out of service mesh sidecar injection.
+func (r *CFProcessReconciler) getPodAnnotations(cfApp *korifiv1alpha1.CFApp) map[string]string {
+	if len(r.controllerConfig.DefaultPodAnnotations) == 0 {
+		return nil
+	}
+
+	podAnnotations := map[string]string{}
+	for key, defaultValue := range r.controllerConfig.DefaultPodAnnotations {
+		podAnnotations[key] = defaultValue
+		if value, ok := cfApp.Annotations[key]; ok {
+			podAnnotations[key] = value
+		}
+	}
+
+	return podAnnotations
+}
+
+// terminationGracePeriodSeconds is at least as long as the health check
+// timeout of the process, so that draining instances are not killed before
+// they could have become healthy. Nil leaves the kubernetes default in place.
+func (r *CFProcessReconciler) terminationGracePeriodSeconds(cfProcess *korifiv1alpha1.CFProcess) *int64 {
+	gracePeriod := max(r.controllerConfig.MinTerminationGracePeriodSeconds, cfProcess.Spec.HealthCheck.Data.TimeoutSeconds)
+	if gracePeriod == 0 {
+		return nil
+	}
+
+	return tools.PtrTo(gracePeriod)
+}
+
+func (r *CFProcessReconciler) generateAppWorkload(actualAppWorkload *korifiv1alpha1.AppWorkload, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess, cfBuild *korifiv1alpha1.CFBuild, appPorts []int32, envVars []corev1.EnvVar, podLabels map[string]string, cfAppRev, cfLastStopAppRev string) (*korifiv1alpha1.AppWorkload, error) {
 	var desiredAppWorkload korifiv1alpha1.AppWorkload
 	actualAppWorkload.DeepCopyInto(&desiredAppWorkload)
 
@@ -289,11 +424,17 @@ func (r *CFProcessReconciler) generateAppWorkload(actualAppWorkload *korifiv1alp
 
 	desiredAppWorkload.Annotations = make(map[string]string)
 	desiredAppWorkload.Annotations[korifiv1alpha1.CFAppLastStopRevisionKey] = cfLastStopAppRev
+	if forceRecreate := cfApp.Annotations[korifiv1alpha1.CFAppForceRecreateAnnotationKey]; forceRecreate != "" {
+		desiredAppWorkload.Annotations[korifiv1alpha1.CFAppForceRecreateAnnotationKey] = forceRecreate
+	}
+	if r.controllerConfig.HPAIntegration && isHPAManaged(cfProcess) {
+		desiredAppWorkload.Annotations[korifiv1alpha1.CFProcessHPAManagedAnnotationKey] = "true"
+	}
 
 	desiredAppWorkload.Spec.GUID = cfProcess.Name
 	desiredAppWorkload.Spec.Version = cfAppRev
 	desiredAppWorkload.Spec.Resources.Requests = corev1.ResourceList{
-		corev1.ResourceCPU:              calculateCPURequest(cfProcess.Spec.MemoryMB),
+		corev1.ResourceCPU:              calculateCPURequest(cfProcess),
 		corev1.ResourceEphemeralStorage: mebibyteQuantity(cfProcess.Spec.DiskQuotaMB),
 		corev1.ResourceMemory:           mebibyteQuantity(cfProcess.Spec.MemoryMB),
 	}
@@ -302,17 +443,21 @@ func (r *CFProcessReconciler) generateAppWorkload(actualAppWorkload *korifiv1alp
 		corev1.ResourceMemory:           mebibyteQuantity(cfProcess.Spec.MemoryMB),
 	}
 	desiredAppWorkload.Spec.ProcessType = cfProcess.Spec.ProcessType
-	desiredAppWorkload.Spec.Command = commandForProcess(cfProcess, cfApp)
+	desiredAppWorkload.Spec.Command = r.commandForProcess(cfProcess, cfApp)
+	desiredAppWorkload.Spec.Sidecars = r.sidecarsForProcess(cfProcess, cfApp)
 	desiredAppWorkload.Spec.AppGUID = cfApp.Name
 	desiredAppWorkload.Spec.Image = cfBuild.Status.Droplet.Registry.Image
 	desiredAppWorkload.Spec.ImagePullSecrets = cfBuild.Status.Droplet.Registry.ImagePullSecrets
 
 	desiredAppWorkload.Spec.Ports = appPorts
+	desiredAppWorkload.Spec.PodLabels = podLabels
+	desiredAppWorkload.Spec.PodAnnotations = r.getPodAnnotations(cfApp)
+	desiredAppWorkload.Spec.TerminationGracePeriodSeconds = r.terminationGracePeriodSeconds(cfProcess)
 	if cfProcess.Spec.DesiredInstances != nil {
 		desiredAppWorkload.Spec.Instances = int32(*cfProcess.Spec.DesiredInstances)
 	}
 
-	desiredAppWorkload.Spec.Env = generateEnvVars(appPorts, envVars)
+	desiredAppWorkload.Spec.Env = env.ProcessEnv(cfProcess.Spec.ProcessType, appPorts, envVars, r.controllerConfig.PropagateProcessTypeEnv)
 
 	desiredAppWorkload.Spec.StartupProbe = startupProbe(cfProcess, appPorts)
 	desiredAppWorkload.Spec.LivenessProbe = livenessProbe(cfProcess, appPorts)
@@ -326,12 +471,22 @@ func (r *CFProcessReconciler) generateAppWorkload(actualAppWorkload *korifiv1alp
 	return &desiredAppWorkload, err
 }
 
-func calculateCPURequest(memoryMiB int64) resource.Quantity {
+// isHPAManaged returns whether the instances of the process are scaled by a
+// horizontal pod autoscaler rather than by its desired instances
+func isHPAManaged(cfProcess *korifiv1alpha1.CFProcess) bool {
+	return cfProcess.Annotations[korifiv1alpha1.CFProcessHPAManagedAnnotationKey] == "true"
+}
+
+func calculateCPURequest(cfProcess *korifiv1alpha1.CFProcess) resource.Quantity {
 	const (
 		cpuRequestRatio         int64 = 1024
 		cpuRequestMinMillicores int64 = 5
 	)
-	cpuMillicores := int64(100) * memoryMiB / cpuRequestRatio
+	if cfProcess.Spec.CPUMillicores > 0 {
+		return *resource.NewScaledQuantity(cfProcess.Spec.CPUMillicores, resource.Milli)
+	}
+
+	cpuMillicores := int64(100) * cfProcess.Spec.MemoryMB / cpuRequestRatio
 	if cpuMillicores < cpuRequestMinMillicores {
 		cpuMillicores = cpuRequestMinMillicores
 	}
@@ -370,48 +525,47 @@ func (r *CFProcessReconciler) getPorts(ctx context.Context, processType string,
 		return nil, err
 	}
 
-	// In case there are multiple routes, prefer the oldest one
-	sort.Slice(cfRoutesForProcess.Items, func(i, j int) bool {
-		return cfRoutesForProcess.Items[i].CreationTimestamp.Before(&cfRoutesForProcess.Items[j].CreationTimestamp)
-	})
+	return env.ProcessPorts(cfRoutesForProcess.Items, cfApp.Name, processType), nil
+}
 
-	ports := []int32{}
-	for _, cfRoute := range cfRoutesForProcess.Items {
-		for _, destination := range cfRoute.Status.Destinations {
-			if destination.AppRef.Name == cfApp.Name &&
-				destination.ProcessType == processType &&
-				destination.Port != nil {
-				ports = append(ports, int32(*destination.Port))
-			}
-		}
+// validateLauncher checks that the command of a buildpack app runs with one of
+// the configured allowed launchers. Docker app commands always run with
+// /bin/sh, so they are not restricted.
+func (r *CFProcessReconciler) validateLauncher(app *korifiv1alpha1.CFApp, command []string) error {
+	if app.Spec.Lifecycle.Type != korifiv1alpha1.BuildpackLifecycle || len(command) == 0 {
+		return nil
 	}
 
-	return ports, nil
+	return validateLauncher(r.controllerConfig.AllowedLaunchers, command[0])
 }
 
-func generateEnvVars(ports []int32, commonEnv []corev1.EnvVar) []corev1.EnvVar {
-	result := []corev1.EnvVar{
-		{Name: "VCAP_APP_HOST", Value: "0.0.0.0"},
+// validateLauncher checks that launcher is one of allowedLaunchers. All
+// launchers are allowed when none are configured.
+func validateLauncher(allowedLaunchers []string, launcher string) error {
+	if len(allowedLaunchers) == 0 || slices.Contains(allowedLaunchers, launcher) {
+		return nil
 	}
-	result = append(result, commonEnv...)
 
-	if len(ports) != 0 {
-		portString := strconv.Itoa(int(ports[0]))
-		result = append(result,
-			corev1.EnvVar{Name: "VCAP_APP_PORT", Value: portString},
-			corev1.EnvVar{Name: "PORT", Value: portString},
-		)
-	}
+	return fmt.Errorf("launcher %q is not allowed", launcher)
+}
 
-	// Sort env vars to guarantee idempotency
-	sort.SliceStable(result, func(i, j int) bool {
-		return result[i].Name < result[j].Name
-	})
+// validateSidecars checks that no sidecar takes the name of the process
+// container and that every sidecar command runs with an allowed launcher
+func (r *CFProcessReconciler) validateSidecars(process *korifiv1alpha1.CFProcess, app *korifiv1alpha1.CFApp) error {
+	for _, sidecar := range process.Spec.Sidecars {
+		if sidecar.Name == korifiv1alpha1.AppWorkloadContainerName {
+			return fmt.Errorf("sidecar name %q is reserved for the process container", sidecar.Name)
+		}
 
-	return result
+		if err := r.validateLauncher(app, r.launchCommand(sidecar.Command, app)); err != nil {
+			return fmt.Errorf("sidecar %q: %w", sidecar.Name, err)
+		}
+	}
+
+	return nil
 }
 
-func commandForProcess(process *korifiv1alpha1.CFProcess, app *korifiv1alpha1.CFApp) []string {
+func (r *CFProcessReconciler) commandForProcess(process *korifiv1alpha1.CFProcess, app *korifiv1alpha1.CFApp) []string {
 	cmd := process.Spec.Command
 	if cmd == "" {
 		cmd = process.Spec.DetectedCommand
@@ -421,20 +575,55 @@ func commandForProcess(process *korifiv1alpha1.CFProcess, app *korifiv1alpha1.CF
 		return []string{}
 	}
 
+	return r.launchCommand(cmd, app)
+}
+
+func (r *CFProcessReconciler) launchCommand(cmd string, app *korifiv1alpha1.CFApp) []string {
 	if app.Spec.Lifecycle.Type == korifiv1alpha1.BuildpackLifecycle {
-		return []string{"/cnb/lifecycle/launcher", cmd}
+		return []string{r.controllerConfig.LifecycleLauncherPath, cmd}
 	}
 
 	return []string{"/bin/sh", "-c", cmd}
 }
 
+// sidecarsForProcess returns the sidecars of the app workload, which are run
+// from the app image like the process itself. Processes without sidecars
+// result in nil.
+func (r *CFProcessReconciler) sidecarsForProcess(process *korifiv1alpha1.CFProcess, app *korifiv1alpha1.CFApp) []korifiv1alpha1.AppWorkloadSidecar {
+	if len(process.Spec.Sidecars) == 0 {
+		return nil
+	}
+
+	sidecars := make([]korifiv1alpha1.AppWorkloadSidecar, 0, len(process.Spec.Sidecars))
+	for _, sidecar := range process.Spec.Sidecars {
+		appWorkloadSidecar := korifiv1alpha1.AppWorkloadSidecar{
+			Name:    sidecar.Name,
+			Command: r.launchCommand(sidecar.Command, app),
+		}
+		if sidecar.MemoryMB > 0 {
+			appWorkloadSidecar.Resources = corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: mebibyteQuantity(sidecar.MemoryMB)},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: mebibyteQuantity(sidecar.MemoryMB)},
+			}
+		}
+
+		sidecars = append(sidecars, appWorkloadSidecar)
+	}
+
+	return sidecars
+}
+
 func makeProbeHandler(cfProcess *korifiv1alpha1.CFProcess, port int32) corev1.ProbeHandler {
 	var probeHandler corev1.ProbeHandler
 
 	switch cfProcess.Spec.HealthCheck.Type {
 	case korifiv1alpha1.HTTPHealthCheckType:
+		endpoint := cfProcess.Spec.HealthCheck.Data.HTTPEndpoint
+		if endpoint == "" {
+			endpoint = "/"
+		}
 		probeHandler.HTTPGet = &corev1.HTTPGetAction{
-			Path: cfProcess.Spec.HealthCheck.Data.HTTPEndpoint,
+			Path: endpoint,
 			Port: intstr.FromInt32(port),
 		}
 	case korifiv1alpha1.PortHealthCheckType:
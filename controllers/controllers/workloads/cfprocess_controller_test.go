@@ -5,6 +5,9 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
@@ -15,9 +18,13 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 var _ = Describe("CFProcessReconciler Integration Tests", func() {
@@ -154,6 +161,27 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 		}).Should(Succeed())
 	})
 
+	When("the process keeps failing to reconcile", func() {
+		var orphanProcess *korifiv1alpha1.CFProcess
+
+		BeforeEach(func() {
+			orphanProcess = BuildCFProcessCRObject(GenerateGUID(), cfSpace.Status.GUID, "does-not-exist", processTypeWeb, processTypeWebCommand, detectedCommand)
+			Expect(adminClient.Create(ctx, orphanProcess)).To(Succeed())
+		})
+
+		It("sets the ready condition to false with the reconcile error", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(orphanProcess), orphanProcess)).To(Succeed())
+				g.Expect(orphanProcess.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":    Equal("Ready"),
+					"Status":  Equal(metav1.ConditionFalse),
+					"Reason":  Equal("ReconcileFailed"),
+					"Message": ContainSubstring("not found"),
+				})))
+			}).Should(Succeed())
+		})
+	})
+
 	When("the CFApp desired state is STARTED", func() {
 		BeforeEach(func() {
 			Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
@@ -189,6 +217,7 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 				g.Expect(appWorkload.Spec.AppGUID).To(Equal(cfApp.Name))
 				g.Expect(appWorkload.Spec.Ports).To(ConsistOf(int32(8080)))
 				g.Expect(appWorkload.Spec.Instances).To(Equal(int32(*cfProcess.Spec.DesiredInstances)))
+				g.Expect(appWorkload.Spec.Sidecars).To(BeNil())
 
 				g.Expect(appWorkload.Spec.Resources.Limits.StorageEphemeral()).To(matchers.RepresentResourceQuantity(cfProcess.Spec.DiskQuotaMB, "Mi"))
 				g.Expect(appWorkload.Spec.Resources.Limits.Memory()).To(matchers.RepresentResourceQuantity(cfProcess.Spec.MemoryMB, "Mi"))
@@ -263,6 +292,254 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the process type is propagated into the environment", func() {
+			BeforeEach(func() {
+				controllerConfig.PropagateProcessTypeEnv = true
+				DeferCleanup(func() {
+					controllerConfig.PropagateProcessTypeEnv = false
+				})
+			})
+
+			It("sets the CF runtime environment variables on the app workload", func() {
+				eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Env).To(ContainElements(
+						Equal(corev1.EnvVar{Name: "CF_PROCESS_TYPE", Value: processTypeWeb}),
+						Equal(corev1.EnvVar{Name: "VCAP_APP_HOST", Value: "0.0.0.0"}),
+						Equal(corev1.EnvVar{Name: "VCAP_APP_PORT", Value: "8080"}),
+						Equal(corev1.EnvVar{Name: "PORT", Value: "8080"}),
+					))
+				})
+			})
+		})
+
+		It("does not set a termination grace period on the app workload", func() {
+			eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+				g.Expect(appWorkload.Spec.TerminationGracePeriodSeconds).To(BeNil())
+			})
+		})
+
+		When("the process has a sidecar", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, cfProcess, func() {
+					cfProcess.Spec.Sidecars = []korifiv1alpha1.Sidecar{{
+						Name:     "my-sidecar",
+						Command:  "./sidecar",
+						MemoryMB: 64,
+					}}
+				})).To(Succeed())
+			})
+
+			It("adds the sidecar to the app workload", func() {
+				eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Sidecars).To(HaveLen(1))
+					g.Expect(appWorkload.Spec.Sidecars[0].Name).To(Equal("my-sidecar"))
+					g.Expect(appWorkload.Spec.Sidecars[0].Command).To(HaveExactElements("/cnb/lifecycle/launcher", "./sidecar"))
+					g.Expect(appWorkload.Spec.Sidecars[0].Resources.Limits.Memory()).To(matchers.RepresentResourceQuantity(64, "Mi"))
+					g.Expect(appWorkload.Spec.Sidecars[0].Resources.Requests.Memory()).To(matchers.RepresentResourceQuantity(64, "Mi"))
+				})
+			})
+
+			When("the sidecar is named after the process container", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, adminClient, cfProcess, func() {
+						cfProcess.Spec.Sidecars[0].Name = "application"
+					})).To(Succeed())
+				})
+
+				It("fails to reconcile the process", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+						g.Expect(cfProcess.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+							"Type":    Equal("Ready"),
+							"Status":  Equal(metav1.ConditionFalse),
+							"Reason":  Equal("ReconcileFailed"),
+							"Message": ContainSubstring("reserved"),
+						})))
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the process has a cpu weight", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, cfProcess, func() {
+					cfProcess.Spec.CPUMillicores = 250
+				})).To(Succeed())
+			})
+
+			It("requests that much cpu for the app workload", func() {
+				eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Resources.Requests.Cpu()).To(matchers.RepresentResourceQuantity(250, "m"))
+				})
+			})
+		})
+
+		When("the process has a long health check timeout", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, cfProcess, func() {
+					cfProcess.Spec.HealthCheck.Data.TimeoutSeconds = 300
+				})).To(Succeed())
+			})
+
+			It("sets a correspondingly long termination grace period on the app workload", func() {
+				eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.TerminationGracePeriodSeconds).To(PointTo(BeEquivalentTo(300)))
+				})
+			})
+
+			When("the configured minimum termination grace period is longer", func() {
+				BeforeEach(func() {
+					controllerConfig.MinTerminationGracePeriodSeconds = 600
+					DeferCleanup(func() {
+						controllerConfig.MinTerminationGracePeriodSeconds = 0
+					})
+				})
+
+				It("uses the configured minimum", func() {
+					eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(appWorkload.Spec.TerminationGracePeriodSeconds).To(PointTo(BeEquivalentTo(600)))
+					})
+				})
+			})
+		})
+
+		When("the launcher of the process is allowed", func() {
+			BeforeEach(func() {
+				controllerConfig.AllowedLaunchers = []string{"/bin/sh", "/cnb/lifecycle/launcher"}
+				DeferCleanup(func() {
+					controllerConfig.AllowedLaunchers = nil
+				})
+			})
+
+			It("creates the app workload", func() {
+				eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Command).To(HaveExactElements("/cnb/lifecycle/launcher", processTypeWebCommand))
+				})
+			})
+		})
+
+		When("a custom lifecycle launcher path is configured", func() {
+			BeforeEach(func() {
+				controllerConfig.LifecycleLauncherPath = "/custom/lifecycle/launcher"
+				DeferCleanup(func() {
+					controllerConfig.LifecycleLauncherPath = "/cnb/lifecycle/launcher"
+				})
+			})
+
+			It("runs the process command with the configured launcher", func() {
+				eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Command).To(HaveExactElements("/custom/lifecycle/launcher", processTypeWebCommand))
+				})
+			})
+		})
+
+		When("the launcher of the process is not allowed", func() {
+			BeforeEach(func() {
+				controllerConfig.AllowedLaunchers = []string{"/bin/sh"}
+				DeferCleanup(func() {
+					controllerConfig.AllowedLaunchers = nil
+				})
+			})
+
+			It("sets the ready condition to false and does not create an app workload", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+					g.Expect(cfProcess.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Type":    Equal("Ready"),
+						"Status":  Equal(metav1.ConditionFalse),
+						"Reason":  Equal("ReconcileFailed"),
+						"Message": ContainSubstring(`launcher "/cnb/lifecycle/launcher" is not allowed`),
+					})))
+				}).Should(Succeed())
+
+				Consistently(func(g Gomega) {
+					var appWorkloads korifiv1alpha1.AppWorkloadList
+					g.Expect(adminClient.List(ctx, &appWorkloads, client.InNamespace(cfSpace.Status.GUID))).To(Succeed())
+					g.Expect(appWorkloads.Items).To(BeEmpty())
+				}, "1s").Should(Succeed())
+			})
+
+			When("the process belongs to a docker app", func() {
+				var dockerProcessGUID string
+
+				BeforeEach(func() {
+					controllerConfig.AllowedLaunchers = []string{"/cnb/lifecycle/launcher"}
+
+					dockerAppGUID := GenerateGUID()
+					dockerProcessGUID = GenerateGUID()
+
+					dockerApp := BuildCFAppCRObject(dockerAppGUID, cfSpace.Status.GUID)
+					dockerApp.Spec.DisplayName = "docker-app-name"
+					dockerApp.Spec.Lifecycle = korifiv1alpha1.Lifecycle{Type: "docker"}
+					dockerApp.Spec.EnvSecretName = cfApp.Spec.EnvSecretName
+					dockerApp.Spec.CurrentDropletRef = corev1.LocalObjectReference{Name: testBuildGUID}
+					Expect(adminClient.Create(ctx, dockerApp)).To(Succeed())
+
+					dockerProcess := BuildCFProcessCRObject(dockerProcessGUID, cfSpace.Status.GUID, dockerAppGUID, processTypeWeb, processTypeWebCommand, detectedCommand)
+					Expect(adminClient.Create(ctx, dockerProcess)).To(Succeed())
+				})
+
+				It("does not restrict the launcher of the process", func() {
+					eventuallyCreatedAppWorkloadShould(dockerProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(appWorkload.Spec.Command).To(HaveExactElements("/bin/sh", "-c", processTypeWebCommand))
+					})
+				})
+			})
+		})
+
+		When("the app and its space have labels configured to be propagated to the pods", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+					cfApp.Labels = map[string]string{
+						"security-group": "app-group",
+						"not-propagated": "foo",
+					}
+				})).To(Succeed())
+
+				spaceNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: cfSpace.Status.GUID}}
+				Expect(k8s.PatchResource(ctx, adminClient, spaceNamespace, func() {
+					spaceNamespace.Labels["security-group"] = "space-security-group"
+					spaceNamespace.Labels["space-group"] = "the-space-group"
+				})).To(Succeed())
+			})
+
+			It("sets them as pod labels on the app workload, preferring the app labels", func() {
+				eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.PodLabels).To(Equal(map[string]string{
+						"security-group": "app-group",
+						"space-group":    "the-space-group",
+					}))
+				})
+			})
+		})
+
+		It("sets the default pod annotations on the app workload", func() {
+			eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+				g.Expect(appWorkload.Spec.PodAnnotations).To(Equal(map[string]string{
+					"sidecar.istio.io/inject": "true",
+				}))
+			})
+		})
+
+		When("the app overrides a default pod annotation", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+					cfApp.Annotations = map[string]string{
+						"sidecar.istio.io/inject": "false",
+						"not-a-pod-annotation":    "foo",
+					}
+				})).To(Succeed())
+			})
+
+			It("sets the app value as pod annotation on the app workload", func() {
+				eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.PodAnnotations).To(Equal(map[string]string{
+						"sidecar.istio.io/inject": "false",
+					}))
+				})
+			})
+		})
+
 		When("The process command field isn't set", func() {
 			BeforeEach(func() {
 				Expect(k8s.PatchResource(ctx, adminClient, cfProcess, func() {
@@ -360,6 +637,27 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		It("does not mark the app workload as HPA-managed", func() {
+			eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+				g.Expect(appWorkload.Annotations).NotTo(HaveKey(korifiv1alpha1.CFProcessHPAManagedAnnotationKey))
+			})
+		})
+
+		When("the process is HPA-managed", func() {
+			JustBeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfProcess, func() {
+					cfProcess.Annotations = map[string]string{korifiv1alpha1.CFProcessHPAManagedAnnotationKey: "true"}
+				})).To(Succeed())
+			})
+
+			It("marks the app workload as HPA-managed", func() {
+				eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFProcessHPAManagedAnnotationKey, "true"))
+					g.Expect(appWorkload.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFProcessGUIDLabelKey, testProcessGUID))
+				})
+			})
+		})
+
 		When("both the app-rev and the last-stop-app-rev are bumped", func() {
 			var prevAppWorkloadName string
 
@@ -397,6 +695,69 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the force-recreate annotation is set on the app", func() {
+			var prevAppWorkload korifiv1alpha1.AppWorkload
+
+			getAppWorkloads := func(g Gomega) []korifiv1alpha1.AppWorkload {
+				var appWorkloads korifiv1alpha1.AppWorkloadList
+				g.Expect(adminClient.List(context.Background(), &appWorkloads,
+					client.InNamespace(cfSpace.Status.GUID),
+					client.MatchingLabels{
+						korifiv1alpha1.CFProcessGUIDLabelKey: testProcessGUID,
+					}),
+				).To(Succeed())
+				return appWorkloads.Items
+			}
+
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					appWorkloads := getAppWorkloads(g)
+					g.Expect(appWorkloads).To(HaveLen(1))
+					prevAppWorkload = appWorkloads[0]
+				}).Should(Succeed())
+
+				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+					cfApp.Annotations[korifiv1alpha1.CFAppForceRecreateAnnotationKey] = "recreate-1"
+				})).To(Succeed())
+			})
+
+			It("recreates the app workload without changing the app revision", func() {
+				Eventually(func(g Gomega) {
+					appWorkloads := getAppWorkloads(g)
+					g.Expect(appWorkloads).To(HaveLen(1))
+					g.Expect(appWorkloads[0].UID).NotTo(Equal(prevAppWorkload.UID))
+					g.Expect(appWorkloads[0].Name).To(Equal(prevAppWorkload.Name))
+					g.Expect(appWorkloads[0].Spec.Version).To(Equal(prevAppWorkload.Spec.Version))
+					g.Expect(appWorkloads[0].Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppForceRecreateAnnotationKey, "recreate-1"))
+				}).Should(Succeed())
+			})
+
+			When("the annotation is cleared", func() {
+				var recreatedAppWorkload korifiv1alpha1.AppWorkload
+
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						appWorkloads := getAppWorkloads(g)
+						g.Expect(appWorkloads).To(HaveLen(1))
+						g.Expect(appWorkloads[0].UID).NotTo(Equal(prevAppWorkload.UID))
+						recreatedAppWorkload = appWorkloads[0]
+					}).Should(Succeed())
+
+					Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+						delete(cfApp.Annotations, korifiv1alpha1.CFAppForceRecreateAnnotationKey)
+					})).To(Succeed())
+				})
+
+				It("leaves the app workload in place", func() {
+					Consistently(func(g Gomega) {
+						appWorkloads := getAppWorkloads(g)
+						g.Expect(appWorkloads).To(HaveLen(1))
+						g.Expect(appWorkloads[0].UID).To(Equal(recreatedAppWorkload.UID))
+					}, "2s").Should(Succeed())
+				})
+			})
+		})
+
 		When("the app-rev is bumped and the last-stop-app-rev is not", func() {
 			var appWorkloadName string
 
@@ -561,6 +922,53 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 				g.Expect(appWorkload.Spec.LivenessProbe.FailureThreshold).To(BeEquivalentTo(1))
 			})
 		})
+
+		When("the health check is updated", func() {
+			JustBeforeEach(func() {
+				eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.StartupProbe).ToNot(BeNil())
+				})
+
+				Expect(k8s.Patch(ctx, adminClient, cfProcess, func() {
+					cfProcess.Spec.HealthCheck.Data.HTTPEndpoint = "/ready"
+					cfProcess.Spec.HealthCheck.Data.InvocationTimeoutSeconds = 7
+				})).To(Succeed())
+			})
+
+			It("updates the probes on the AppWorkload", func() {
+				eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.StartupProbe).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.StartupProbe.HTTPGet).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.StartupProbe.HTTPGet.Path).To(Equal("/ready"))
+					g.Expect(appWorkload.Spec.StartupProbe.TimeoutSeconds).To(BeEquivalentTo(7))
+
+					g.Expect(appWorkload.Spec.LivenessProbe).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.LivenessProbe.HTTPGet).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.LivenessProbe.HTTPGet.Path).To(Equal("/ready"))
+					g.Expect(appWorkload.Spec.LivenessProbe.TimeoutSeconds).To(BeEquivalentTo(7))
+				})
+			})
+		})
+
+		When("the health check endpoint is not set", func() {
+			JustBeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, cfProcess, func() {
+					cfProcess.Spec.HealthCheck.Data.HTTPEndpoint = ""
+				})).To(Succeed())
+			})
+
+			It("probes the root path", func() {
+				eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.StartupProbe).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.StartupProbe.HTTPGet).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.StartupProbe.HTTPGet.Path).To(Equal("/"))
+
+					g.Expect(appWorkload.Spec.LivenessProbe).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.LivenessProbe.HTTPGet).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.LivenessProbe.HTTPGet.Path).To(Equal("/"))
+				})
+			})
+		})
 	})
 
 	When("the CFProcess has a port health check", func() {
@@ -630,6 +1038,76 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 	})
 })
 
+var _ = Describe("CFProcessReconciler with lazy service account creation", func() {
+	var (
+		namespace          string
+		rootServiceAccount *corev1.ServiceAccount
+		cfProcess          *korifiv1alpha1.CFProcess
+		reconciler         reconcile.Reconciler
+	)
+
+	BeforeEach(func() {
+		rootServiceAccount = createServiceAccount(ctx, PrefixedGUID("service-account"), cfRootNamespace, map[string]string{
+			korifiv1alpha1.PropagateServiceAccountAnnotation: "true",
+		})
+		DeferCleanup(func() {
+			Expect(adminClient.Delete(ctx, rootServiceAccount)).To(Succeed())
+		})
+
+		// a plain namespace rather than a space, so that only the lazy
+		// reconciler below can propagate service accounts into it
+		namespace = createNamespace(PrefixedGUID("ns")).Name
+
+		appGUID := GenerateGUID()
+		buildGUID := GenerateGUID()
+		packageGUID := GenerateGUID()
+
+		cfApp := BuildCFAppCRObject(appGUID, namespace)
+		cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+		cfApp.Spec.CurrentDropletRef = corev1.LocalObjectReference{Name: buildGUID}
+		Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+
+		Expect(adminClient.Create(ctx, BuildCFAppEnvVarsSecret(appGUID, namespace, map[string]string{"foo": "bar"}))).To(Succeed())
+		Expect(adminClient.Create(ctx, BuildCFPackageCRObject(packageGUID, namespace, appGUID, "ref"))).To(Succeed())
+		createBuildWithDroplet(ctx, adminClient,
+			BuildCFBuildObject(buildGUID, namespace, packageGUID, appGUID),
+			BuildCFBuildDropletStatusObject(map[string]string{"web": "command-from-droplet"}),
+		)
+
+		cfProcess = BuildCFProcessCRObject(GenerateGUID(), namespace, appGUID, "web", "web-command", "detected-command")
+		Expect(adminClient.Create(ctx, cfProcess)).To(Succeed())
+
+		Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(rootServiceAccount), &corev1.ServiceAccount{})).To(Succeed())
+		Expect(apierrors.IsNotFound(
+			adminClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: rootServiceAccount.Name}, &corev1.ServiceAccount{}),
+		)).To(BeTrue())
+
+		// the suite config is shared with the reconcilers running in the
+		// manager, so this reconciler gets its own copy
+		lazyConfig := *controllerConfig
+		lazyConfig.ServiceAccountCreation = config.ServiceAccountCreationLazy
+		reconciler = NewCFProcessReconciler(
+			adminClient,
+			scheme.Scheme,
+			ctrl.Log.WithName("controllers").WithName("CFProcess").WithName("lazy"),
+			&lazyConfig,
+			env.NewWorkloadEnvBuilder(adminClient),
+		)
+	})
+
+	It("propagates the service accounts before creating the app workload", func() {
+		Eventually(func(g Gomega) {
+			_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(cfProcess)})
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(adminClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: rootServiceAccount.Name}, &corev1.ServiceAccount{})).To(Succeed())
+		}).Should(Succeed())
+
+		eventuallyCreatedAppWorkloadShould(cfProcess.Name, namespace, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+			g.Expect(appWorkload.Spec.ProcessType).To(Equal("web"))
+		})
+	})
+})
+
 func eventuallyCreatedAppWorkloadShould(processGUID, namespace string, shouldFn func(Gomega, korifiv1alpha1.AppWorkload)) {
 	GinkgoHelper()
 
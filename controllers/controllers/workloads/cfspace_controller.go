@@ -18,7 +18,6 @@ package workloads
 
 import (
 	"context"
-	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
@@ -31,11 +30,9 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	k8s_labels "k8s.io/apimachinery/pkg/labels"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -47,6 +44,7 @@ type CFSpaceReconciler struct {
 	containerRegistrySecretNames []string
 	rootNamespace                string
 	appDeletionTimeout           int64
+	serviceAccounts              *ServiceAccountPropagator
 }
 
 func NewCFSpaceReconciler(
@@ -56,6 +54,8 @@ func NewCFSpaceReconciler(
 	rootNamespace string,
 	appDeletionTimeout int64,
 	labelCompiler labels.Compiler,
+	annotationCompiler labels.Compiler,
+	serviceAccounts *ServiceAccountPropagator,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFSpace, *korifiv1alpha1.CFSpace] {
 	namespaceController := k8sns.NewReconciler[korifiv1alpha1.CFSpace, *korifiv1alpha1.CFSpace](
 		client,
@@ -65,7 +65,8 @@ func NewCFSpaceReconciler(
 			korifiv1alpha1.CFSpaceFinalizerName,
 		),
 		&cfSpaceMetadataCompiler{
-			labelCompiler: labelCompiler,
+			labelCompiler:      labelCompiler,
+			annotationCompiler: annotationCompiler,
 		},
 		containerRegistrySecretNames,
 	)
@@ -76,6 +77,7 @@ func NewCFSpaceReconciler(
 		rootNamespace:                rootNamespace,
 		appDeletionTimeout:           appDeletionTimeout,
 		containerRegistrySecretNames: containerRegistrySecretNames,
+		serviceAccounts:              serviceAccounts,
 	})
 }
 
@@ -147,7 +149,7 @@ func (r *CFSpaceReconciler) ReconcileResource(ctx context.Context, cfSpace *kori
 
 	log := logr.FromContextOrDiscard(ctx)
 
-	err = r.reconcileServiceAccounts(ctx, cfSpace)
+	err = r.serviceAccounts.ReconcileSpaceServiceAccounts(ctx, cfSpace.Name)
 	if err != nil {
 		log.Info("not ready yet", "reason", "error propagating service accounts", "error", err)
 
@@ -172,132 +174,9 @@ func (r *CFSpaceReconciler) ReconcileResource(ctx context.Context, cfSpace *kori
 	return ctrl.Result{}, nil
 }
 
-func (r *CFSpaceReconciler) reconcileServiceAccounts(ctx context.Context, space client.Object) error {
-	log := logr.FromContextOrDiscard(ctx).WithName("reconcileServiceAccounts").
-		WithValues("rootNamespace", r.rootNamespace, "targetNamespace", space.GetName())
-
-	serviceAccounts := new(corev1.ServiceAccountList)
-	err := r.client.List(ctx, serviceAccounts, client.InNamespace(r.rootNamespace))
-	if err != nil {
-		log.Info("error listing service accounts from root namespace", "reason", err)
-		return err
-	}
-
-	var result controllerutil.OperationResult
-	serviceAccountMap := make(map[string]struct{})
-	for _, rootServiceAccount := range serviceAccounts.Items {
-		loopLog := log.WithValues("serviceAccountName", rootServiceAccount.Name)
-
-		if rootServiceAccount.Annotations[korifiv1alpha1.PropagateServiceAccountAnnotation] == "true" {
-			serviceAccountMap[rootServiceAccount.Name] = struct{}{}
-
-			spaceServiceAccount := &corev1.ServiceAccount{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      rootServiceAccount.Name,
-					Namespace: space.GetName(),
-				},
-			}
-
-			var rootPackageRegistrySecrets []corev1.ObjectReference
-			var rootPackageRegistryImagePullSecrets []corev1.LocalObjectReference
-
-			// Some versions of K8s will add their own secret/imagepullsecret references which will not be available in the new namespace, so we will only reference the package registry secret we explicitly propagate.
-			for _, secretName := range r.containerRegistrySecretNames {
-				for _, secret := range rootServiceAccount.Secrets {
-					if secret.Name == secretName {
-						rootPackageRegistrySecrets = append(rootPackageRegistrySecrets, secret)
-						break
-					}
-				}
-				for _, secret := range rootServiceAccount.ImagePullSecrets {
-					if secret.Name == secretName {
-						rootPackageRegistryImagePullSecrets = append(rootPackageRegistryImagePullSecrets, secret)
-						break
-					}
-				}
-			}
-
-			result, err = controllerutil.CreateOrPatch(ctx, r.client, spaceServiceAccount, func() error {
-				spaceServiceAccount.Annotations = shared.RemovePackageManagerKeys(rootServiceAccount.Annotations, loopLog)
-
-				spaceServiceAccount.Labels = shared.RemovePackageManagerKeys(rootServiceAccount.Labels, loopLog)
-				if spaceServiceAccount.Labels == nil {
-					spaceServiceAccount.Labels = map[string]string{}
-				}
-				spaceServiceAccount.Labels[korifiv1alpha1.PropagatedFromLabel] = r.rootNamespace
-
-				spaceServiceAccount.Secrets = keepSecrets(spaceServiceAccount.Name, spaceServiceAccount.Secrets)
-				spaceServiceAccount.Secrets = append(spaceServiceAccount.Secrets, rootPackageRegistrySecrets...)
-
-				spaceServiceAccount.ImagePullSecrets = keepImagePullSecrets(spaceServiceAccount.Name, spaceServiceAccount.ImagePullSecrets)
-				spaceServiceAccount.ImagePullSecrets = append(spaceServiceAccount.ImagePullSecrets, rootPackageRegistryImagePullSecrets...)
-
-				return nil
-			})
-			if err != nil {
-				loopLog.Info("error creating/patching service account", "reason", err)
-				return err
-			}
-
-			loopLog.V(1).Info("service Account propagated", "operation", result)
-		}
-	}
-
-	propagatedServiceAccounts := new(corev1.ServiceAccountList)
-	labelSelector, err := k8s_labels.ValidatedSelectorFromSet(map[string]string{
-		korifiv1alpha1.PropagatedFromLabel: r.rootNamespace,
-	})
-	if err != nil {
-		log.Info("failed to create label selector", "reason", err)
-		return err
-	}
-
-	err = r.client.List(ctx, propagatedServiceAccounts, &client.ListOptions{Namespace: space.GetName(), LabelSelector: labelSelector})
-	if err != nil {
-		log.Info("error listing role-bindings from target namespace", "reason", err)
-		return err
-	}
-
-	for index := range propagatedServiceAccounts.Items {
-		propagatedServiceAccount := propagatedServiceAccounts.Items[index]
-		if propagatedServiceAccount.Annotations[korifiv1alpha1.PropagateDeletionAnnotation] == "false" {
-			continue
-		}
-
-		if _, found := serviceAccountMap[propagatedServiceAccount.Name]; !found {
-			err = r.client.Delete(ctx, &propagatedServiceAccount)
-			if err != nil {
-				log.Info("error deleting service account from the target namespace", "serviceAccount", propagatedServiceAccount.Name, "reason", err)
-				return err
-			}
-		}
-	}
-
-	return nil
-}
-
-func keepSecrets(serviceAccountName string, secretRefs []corev1.ObjectReference) []corev1.ObjectReference {
-	var results []corev1.ObjectReference
-	for _, secretRef := range secretRefs {
-		if strings.HasPrefix(secretRef.Name, serviceAccountName+"-token-") || strings.HasPrefix(secretRef.Name, serviceAccountName+"-dockercfg-") {
-			results = append(results, secretRef)
-		}
-	}
-	return results
-}
-
-func keepImagePullSecrets(serviceAccountName string, secretRefs []corev1.LocalObjectReference) []corev1.LocalObjectReference {
-	var results []corev1.LocalObjectReference
-	for _, secretRef := range secretRefs {
-		if strings.HasPrefix(secretRef.Name, serviceAccountName+"-token-") || strings.HasPrefix(secretRef.Name, serviceAccountName+"-dockercfg-") {
-			results = append(results, secretRef)
-		}
-	}
-	return results
-}
-
 type cfSpaceMetadataCompiler struct {
-	labelCompiler labels.Compiler
+	labelCompiler      labels.Compiler
+	annotationCompiler labels.Compiler
 }
 
 func (c *cfSpaceMetadataCompiler) CompileLabels(cfSpace *korifiv1alpha1.CFSpace) map[string]string {
@@ -308,7 +187,7 @@ func (c *cfSpaceMetadataCompiler) CompileLabels(cfSpace *korifiv1alpha1.CFSpace)
 }
 
 func (c *cfSpaceMetadataCompiler) CompileAnnotations(cfSpace *korifiv1alpha1.CFSpace) map[string]string {
-	return map[string]string{
+	return c.annotationCompiler.Compile(map[string]string{
 		korifiv1alpha1.SpaceNameKey: cfSpace.Spec.DisplayName,
-	}
+	})
 }
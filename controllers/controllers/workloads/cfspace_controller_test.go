@@ -50,8 +50,12 @@ var _ = Describe("CFSpaceReconciler Integration Tests", func() {
 				HaveKeyWithValue(korifiv1alpha1.SpaceNameKey, korifiv1alpha1.OrgSpaceDeprecatedName),
 				HaveKeyWithValue(korifiv1alpha1.SpaceGUIDKey, spaceGUID),
 				HaveKeyWithValue(api.EnforceLevelLabel, string(api.LevelRestricted)),
+				HaveKeyWithValue("istio-injection", "enabled"),
+			))
+			g.Expect(ns.Annotations).To(SatisfyAll(
+				HaveKeyWithValue(korifiv1alpha1.SpaceNameKey, cfSpace.Spec.DisplayName),
+				HaveKeyWithValue("example.com/owner", "korifi"),
 			))
-			g.Expect(ns.Annotations).To(HaveKeyWithValue(korifiv1alpha1.SpaceNameKey, cfSpace.Spec.DisplayName))
 		}).Should(Succeed())
 	})
 
@@ -205,6 +209,7 @@ var _ = Describe("CFSpaceReconciler Integration Tests", func() {
 					})
 				})
 			})
+
 		})
 	})
 
@@ -42,18 +42,19 @@ import (
 )
 
 const (
-	TaskCanceledReason    = "TaskCanceled"
-	LifecycleLauncherPath = "/cnb/lifecycle/launcher"
+	TaskCanceledReason = "TaskCanceled"
 )
 
 // CFTaskReconciler reconciles a CFTask object
 type CFTaskReconciler struct {
-	k8sClient       client.Client
-	scheme          *runtime.Scheme
-	recorder        record.EventRecorder
-	log             logr.Logger
-	envBuilder      EnvBuilder
-	taskTTLDuration time.Duration
+	k8sClient             client.Client
+	scheme                *runtime.Scheme
+	recorder              record.EventRecorder
+	log                   logr.Logger
+	envBuilder            EnvBuilder
+	taskTTLDuration       time.Duration
+	lifecycleLauncherPath string
+	allowedLaunchers      []string
 }
 
 func NewCFTaskReconciler(
@@ -63,14 +64,18 @@ func NewCFTaskReconciler(
 	log logr.Logger,
 	envBuilder EnvBuilder,
 	taskTTLDuration time.Duration,
+	lifecycleLauncherPath string,
+	allowedLaunchers []string,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFTask, *korifiv1alpha1.CFTask] {
 	taskReconciler := CFTaskReconciler{
-		k8sClient:       client,
-		scheme:          scheme,
-		recorder:        recorder,
-		log:             log,
-		envBuilder:      envBuilder,
-		taskTTLDuration: taskTTLDuration,
+		k8sClient:             client,
+		scheme:                scheme,
+		recorder:              recorder,
+		log:                   log,
+		envBuilder:            envBuilder,
+		taskTTLDuration:       taskTTLDuration,
+		lifecycleLauncherPath: lifecycleLauncherPath,
+		allowedLaunchers:      allowedLaunchers,
 	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFTask, *korifiv1alpha1.CFTask](log, client, &taskReconciler)
 }
@@ -107,6 +112,13 @@ func (r *CFTaskReconciler) ReconcileResource(ctx context.Context, cfTask *korifi
 		return r.reconcileResult(cfTask, err)
 	}
 
+	err := validateLauncher(r.allowedLaunchers, r.lifecycleLauncherPath)
+	if err != nil {
+		log.Info("task command uses a launcher that is not allowed", "reason", err)
+		r.recorder.Eventf(cfTask, "Warning", "LauncherNotAllowed", "Task command launcher %s is not allowed", r.lifecycleLauncherPath)
+		return ctrl.Result{}, err
+	}
+
 	cfApp, err := r.getApp(ctx, cfTask)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -256,7 +268,7 @@ func (r *CFTaskReconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask
 
 		taskWorkload.Labels[korifiv1alpha1.CFTaskGUIDLabelKey] = cfTask.Name
 
-		taskWorkload.Spec.Command = []string{LifecycleLauncherPath, cfTask.Spec.Command}
+		taskWorkload.Spec.Command = []string{r.lifecycleLauncherPath, cfTask.Spec.Command}
 		taskWorkload.Spec.Image = cfDroplet.Status.Droplet.Registry.Image
 		taskWorkload.Spec.ImagePullSecrets = cfDroplet.Status.Droplet.Registry.ImagePullSecrets
 
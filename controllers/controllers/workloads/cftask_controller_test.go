@@ -2,9 +2,13 @@ package workloads_test
 
 import (
 	"fmt"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+	controllerfake "code.cloudfoundry.org/korifi/controllers/fake"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -14,7 +18,10 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 var _ = Describe("CFTaskReconciler Integration Tests", func() {
@@ -208,7 +215,7 @@ var _ = Describe("CFTaskReconciler Integration Tests", func() {
 
 				taskWorkload = taskWorkloads.Items[0]
 				g.Expect(taskWorkload.Name).To(Equal(cfTask.Name))
-				g.Expect(taskWorkload.Spec.Command).To(Equal([]string{"/cnb/lifecycle/launcher", "echo hello"}))
+				g.Expect(taskWorkload.Spec.Command).To(Equal([]string{"/custom/task/launcher", "echo hello"}))
 				g.Expect(taskWorkload.Spec.Image).To(Equal("registry.io/my/image"))
 				g.Expect(taskWorkload.Spec.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{{Name: "registry-secret"}}))
 				g.Expect(taskWorkload.Spec.Resources.Requests.Memory().String()).To(Equal(fmt.Sprintf("%dM", defaultMemoryMB)))
@@ -316,6 +323,42 @@ var _ = Describe("CFTaskReconciler Integration Tests", func() {
 		})
 	})
 
+	Describe("CFTask launcher", func() {
+		var (
+			reconciler        reconcile.Reconciler
+			reconcileErr      error
+			taskEventRecorder *controllerfake.EventRecorder
+		)
+
+		BeforeEach(func() {
+			taskEventRecorder = new(controllerfake.EventRecorder)
+			reconciler = workloads.NewCFTaskReconciler(
+				adminClient,
+				scheme.Scheme,
+				taskEventRecorder,
+				ctrl.Log.WithName("controllers").WithName("CFTask").WithName("launcher"),
+				env.NewWorkloadEnvBuilder(adminClient),
+				2*time.Second,
+				"/custom/task/launcher",
+				[]string{"/cnb/lifecycle/launcher"},
+			)
+
+			Expect(adminClient.Create(ctx, cfTask)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			_, reconcileErr = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(cfTask)})
+		})
+
+		It("does not run tasks with a launcher that is not allowed", func() {
+			Expect(reconcileErr).To(MatchError(`launcher "/custom/task/launcher" is not allowed`))
+			Expect(taskEventRecorder.EventfCallCount()).To(Equal(1))
+			_, eventType, reason, _, _ := taskEventRecorder.EventfArgsForCall(0)
+			Expect(eventType).To(Equal("Warning"))
+			Expect(reason).To(Equal("LauncherNotAllowed"))
+		})
+	})
+
 	Describe("CFTask Cancellation", func() {
 		BeforeEach(func() {
 			Expect(adminClient.Create(ctx, cfTask)).To(Succeed())
@@ -0,0 +1,63 @@
+package env
+
+import (
+	"sort"
+	"strconv"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const ProcessTypeEnvVar = "CF_PROCESS_TYPE"
+
+// ProcessPorts returns the ports of the destinations of the routes that
+// target the given app process. In case there are multiple routes, the ports
+// of the oldest one come first.
+func ProcessPorts(routes []korifiv1alpha1.CFRoute, appGUID, processType string) []int32 {
+	routes = append([]korifiv1alpha1.CFRoute{}, routes...)
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routes[i].CreationTimestamp.Before(&routes[j].CreationTimestamp)
+	})
+
+	ports := []int32{}
+	for _, cfRoute := range routes {
+		for _, destination := range cfRoute.Status.Destinations {
+			if destination.AppRef.Name == appGUID &&
+				destination.ProcessType == processType &&
+				destination.Port != nil {
+				ports = append(ports, int32(*destination.Port))
+			}
+		}
+	}
+
+	return ports
+}
+
+// ProcessEnv returns the env vars of the workload of a process: the app env
+// vars, the host and port vars derived from the process ports and, when
+// propagateProcessType is set, the process type.
+func ProcessEnv(processType string, ports []int32, appEnv []corev1.EnvVar, propagateProcessType bool) []corev1.EnvVar {
+	result := []corev1.EnvVar{
+		{Name: "VCAP_APP_HOST", Value: "0.0.0.0"},
+	}
+	if propagateProcessType {
+		result = append(result, corev1.EnvVar{Name: ProcessTypeEnvVar, Value: processType})
+	}
+	result = append(result, appEnv...)
+
+	if len(ports) != 0 {
+		portString := strconv.Itoa(int(ports[0]))
+		result = append(result,
+			corev1.EnvVar{Name: "VCAP_APP_PORT", Value: portString},
+			corev1.EnvVar{Name: "PORT", Value: portString},
+		)
+	}
+
+	// Sort env vars to guarantee idempotency
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
@@ -0,0 +1,89 @@
+package env_test
+
+import (
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("ProcessEnv", func() {
+	var (
+		appEnv               []corev1.EnvVar
+		ports                []int32
+		propagateProcessType bool
+		envVars              []corev1.EnvVar
+	)
+
+	BeforeEach(func() {
+		appEnv = []corev1.EnvVar{{Name: "FOO", Value: "bar"}}
+		ports = []int32{8080, 9000}
+		propagateProcessType = false
+	})
+
+	JustBeforeEach(func() {
+		envVars = env.ProcessEnv("web", ports, appEnv, propagateProcessType)
+	})
+
+	It("returns the sorted app, host and port env vars", func() {
+		Expect(envVars).To(Equal([]corev1.EnvVar{
+			{Name: "FOO", Value: "bar"},
+			{Name: "PORT", Value: "8080"},
+			{Name: "VCAP_APP_HOST", Value: "0.0.0.0"},
+			{Name: "VCAP_APP_PORT", Value: "8080"},
+		}))
+	})
+
+	When("there are no ports", func() {
+		BeforeEach(func() {
+			ports = nil
+		})
+
+		It("omits the port env vars", func() {
+			Expect(envVars).To(Equal([]corev1.EnvVar{
+				{Name: "FOO", Value: "bar"},
+				{Name: "VCAP_APP_HOST", Value: "0.0.0.0"},
+			}))
+		})
+	})
+
+	When("the process type is propagated", func() {
+		BeforeEach(func() {
+			propagateProcessType = true
+		})
+
+		It("sets CF_PROCESS_TYPE", func() {
+			Expect(envVars).To(ContainElement(corev1.EnvVar{Name: "CF_PROCESS_TYPE", Value: "web"}))
+		})
+	})
+})
+
+var _ = Describe("ProcessPorts", func() {
+	It("returns the ports of the process destinations, oldest route first", func() {
+		now := time.Now()
+		routes := []korifiv1alpha1.CFRoute{
+			{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now)},
+				Status: korifiv1alpha1.CFRouteStatus{Destinations: []korifiv1alpha1.Destination{
+					{AppRef: corev1.LocalObjectReference{Name: "app"}, ProcessType: "web", Port: tools.PtrTo(9000)},
+				}},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+				Status: korifiv1alpha1.CFRouteStatus{Destinations: []korifiv1alpha1.Destination{
+					{AppRef: corev1.LocalObjectReference{Name: "app"}, ProcessType: "worker", Port: tools.PtrTo(7000)},
+					{AppRef: corev1.LocalObjectReference{Name: "other-app"}, ProcessType: "web", Port: tools.PtrTo(6000)},
+					{AppRef: corev1.LocalObjectReference{Name: "app"}, ProcessType: "web", Port: tools.PtrTo(8080)},
+					{AppRef: corev1.LocalObjectReference{Name: "app"}, ProcessType: "web"},
+				}},
+			},
+		}
+
+		Expect(env.ProcessPorts(routes, "app", "web")).To(Equal([]int32{8080, 9000}))
+	})
+})
@@ -0,0 +1,205 @@
+package workloads
+
+import (
+	"context"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s_labels "k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// ServiceAccountPropagator propagates the kpack and eirini service accounts
+// from the root namespace into space namespaces. Depending on the
+// serviceAccountCreation setting they are either created as soon as the space
+// is reconciled (eager) or only once a build or process in the space needs
+// them (lazy).
+type ServiceAccountPropagator struct {
+	client           client.Client
+	controllerConfig *config.ControllerConfig
+}
+
+func NewServiceAccountPropagator(client client.Client, controllerConfig *config.ControllerConfig) *ServiceAccountPropagator {
+	return &ServiceAccountPropagator{
+		client:           client,
+		controllerConfig: controllerConfig,
+	}
+}
+
+func (p *ServiceAccountPropagator) isLazy() bool {
+	return p.controllerConfig.ServiceAccountCreation == config.ServiceAccountCreationLazy
+}
+
+// ReconcileSpaceServiceAccounts is called when a space is reconciled. In lazy
+// mode it only keeps service accounts that have already been propagated in
+// sync with the root namespace.
+func (p *ServiceAccountPropagator) ReconcileSpaceServiceAccounts(ctx context.Context, namespace string) error {
+	if p.isLazy() {
+		propagated, err := p.hasPropagatedServiceAccounts(ctx, namespace)
+		if err != nil || !propagated {
+			return err
+		}
+	}
+
+	return p.Propagate(ctx, namespace)
+}
+
+// EnsureServiceAccounts is called before creating workloads that run with the
+// propagated service accounts. It is a no-op in eager mode, as the space
+// reconciler has already created them.
+func (p *ServiceAccountPropagator) EnsureServiceAccounts(ctx context.Context, namespace string) error {
+	if !p.isLazy() {
+		return nil
+	}
+
+	return p.Propagate(ctx, namespace)
+}
+
+func (p *ServiceAccountPropagator) hasPropagatedServiceAccounts(ctx context.Context, namespace string) (bool, error) {
+	serviceAccounts := new(corev1.ServiceAccountList)
+	err := p.client.List(ctx, serviceAccounts,
+		client.InNamespace(namespace),
+		client.MatchingLabels{korifiv1alpha1.PropagatedFromLabel: p.controllerConfig.CFRootNamespace},
+		client.Limit(1),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return len(serviceAccounts.Items) > 0, nil
+}
+
+// Propagate copies the service accounts annotated for propagation from the
+// root namespace into the target namespace and deletes previously propagated
+// ones that no longer exist in the root namespace
+func (p *ServiceAccountPropagator) Propagate(ctx context.Context, namespace string) error {
+	rootNamespace := p.controllerConfig.CFRootNamespace
+	log := logr.FromContextOrDiscard(ctx).WithName("propagateServiceAccounts").
+		WithValues("rootNamespace", rootNamespace, "targetNamespace", namespace)
+
+	serviceAccounts := new(corev1.ServiceAccountList)
+	err := p.client.List(ctx, serviceAccounts, client.InNamespace(rootNamespace))
+	if err != nil {
+		log.Info("error listing service accounts from root namespace", "reason", err)
+		return err
+	}
+
+	var result controllerutil.OperationResult
+	serviceAccountMap := make(map[string]struct{})
+	for _, rootServiceAccount := range serviceAccounts.Items {
+		loopLog := log.WithValues("serviceAccountName", rootServiceAccount.Name)
+
+		if rootServiceAccount.Annotations[korifiv1alpha1.PropagateServiceAccountAnnotation] == "true" {
+			serviceAccountMap[rootServiceAccount.Name] = struct{}{}
+
+			spaceServiceAccount := &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      rootServiceAccount.Name,
+					Namespace: namespace,
+				},
+			}
+
+			var rootPackageRegistrySecrets []corev1.ObjectReference
+			var rootPackageRegistryImagePullSecrets []corev1.LocalObjectReference
+
+			// Some versions of K8s will add their own secret/imagepullsecret references which will not be available in the new namespace, so we will only reference the package registry secret we explicitly propagate.
+			for _, secretName := range p.controllerConfig.ContainerRegistrySecretNames {
+				for _, secret := range rootServiceAccount.Secrets {
+					if secret.Name == secretName {
+						rootPackageRegistrySecrets = append(rootPackageRegistrySecrets, secret)
+						break
+					}
+				}
+				for _, secret := range rootServiceAccount.ImagePullSecrets {
+					if secret.Name == secretName {
+						rootPackageRegistryImagePullSecrets = append(rootPackageRegistryImagePullSecrets, secret)
+						break
+					}
+				}
+			}
+
+			result, err = controllerutil.CreateOrPatch(ctx, p.client, spaceServiceAccount, func() error {
+				spaceServiceAccount.Annotations = shared.RemovePackageManagerKeys(rootServiceAccount.Annotations, loopLog)
+
+				spaceServiceAccount.Labels = shared.RemovePackageManagerKeys(rootServiceAccount.Labels, loopLog)
+				if spaceServiceAccount.Labels == nil {
+					spaceServiceAccount.Labels = map[string]string{}
+				}
+				spaceServiceAccount.Labels[korifiv1alpha1.PropagatedFromLabel] = rootNamespace
+
+				spaceServiceAccount.Secrets = keepSecrets(spaceServiceAccount.Name, spaceServiceAccount.Secrets)
+				spaceServiceAccount.Secrets = append(spaceServiceAccount.Secrets, rootPackageRegistrySecrets...)
+
+				spaceServiceAccount.ImagePullSecrets = keepImagePullSecrets(spaceServiceAccount.Name, spaceServiceAccount.ImagePullSecrets)
+				spaceServiceAccount.ImagePullSecrets = append(spaceServiceAccount.ImagePullSecrets, rootPackageRegistryImagePullSecrets...)
+
+				return nil
+			})
+			if err != nil {
+				loopLog.Info("error creating/patching service account", "reason", err)
+				return err
+			}
+
+			loopLog.V(1).Info("service Account propagated", "operation", result)
+		}
+	}
+
+	propagatedServiceAccounts := new(corev1.ServiceAccountList)
+	labelSelector, err := k8s_labels.ValidatedSelectorFromSet(map[string]string{
+		korifiv1alpha1.PropagatedFromLabel: rootNamespace,
+	})
+	if err != nil {
+		log.Info("failed to create label selector", "reason", err)
+		return err
+	}
+
+	err = p.client.List(ctx, propagatedServiceAccounts, &client.ListOptions{Namespace: namespace, LabelSelector: labelSelector})
+	if err != nil {
+		log.Info("error listing role-bindings from target namespace", "reason", err)
+		return err
+	}
+
+	for index := range propagatedServiceAccounts.Items {
+		propagatedServiceAccount := propagatedServiceAccounts.Items[index]
+		if propagatedServiceAccount.Annotations[korifiv1alpha1.PropagateDeletionAnnotation] == "false" {
+			continue
+		}
+
+		if _, found := serviceAccountMap[propagatedServiceAccount.Name]; !found {
+			err = p.client.Delete(ctx, &propagatedServiceAccount)
+			if err != nil {
+				log.Info("error deleting service account from the target namespace", "serviceAccount", propagatedServiceAccount.Name, "reason", err)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func keepSecrets(serviceAccountName string, secretRefs []corev1.ObjectReference) []corev1.ObjectReference {
+	var results []corev1.ObjectReference
+	for _, secretRef := range secretRefs {
+		if strings.HasPrefix(secretRef.Name, serviceAccountName+"-token-") || strings.HasPrefix(secretRef.Name, serviceAccountName+"-dockercfg-") {
+			results = append(results, secretRef)
+		}
+	}
+	return results
+}
+
+func keepImagePullSecrets(serviceAccountName string, secretRefs []corev1.LocalObjectReference) []corev1.LocalObjectReference {
+	var results []corev1.LocalObjectReference
+	for _, secretRef := range secretRefs {
+		if strings.HasPrefix(secretRef.Name, serviceAccountName+"-token-") || strings.HasPrefix(secretRef.Name, serviceAccountName+"-dockercfg-") {
+			results = append(results, secretRef)
+		}
+	}
+	return results
+}
@@ -0,0 +1,117 @@
+package workloads_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ServiceAccountPropagator", func() {
+	var (
+		serviceAccountCreation string
+		propagator             *ServiceAccountPropagator
+		rootServiceAccount     *corev1.ServiceAccount
+		namespace              string
+	)
+
+	serviceAccountExists := func() bool {
+		err := adminClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: rootServiceAccount.Name}, &corev1.ServiceAccount{})
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		Expect(err).NotTo(HaveOccurred())
+		return true
+	}
+
+	BeforeEach(func() {
+		serviceAccountCreation = config.ServiceAccountCreationEager
+
+		rootServiceAccount = createServiceAccount(ctx, PrefixedGUID("service-account"), cfRootNamespace, map[string]string{
+			korifiv1alpha1.PropagateServiceAccountAnnotation: "true",
+		})
+		DeferCleanup(func() {
+			Expect(adminClient.Delete(ctx, rootServiceAccount)).To(Succeed())
+		})
+
+		// a plain namespace rather than a space, so that the space reconciler
+		// of the test manager does not propagate service accounts into it
+		namespace = createNamespace(PrefixedGUID("ns")).Name
+	})
+
+	JustBeforeEach(func() {
+		// each propagator gets its own copy of the config, as the suite one
+		// is shared with the reconcilers running in the manager
+		propagatorConfig := *controllerConfig
+		propagatorConfig.ServiceAccountCreation = serviceAccountCreation
+		propagator = NewServiceAccountPropagator(adminClient, &propagatorConfig)
+	})
+
+	Describe("ReconcileSpaceServiceAccounts", func() {
+		JustBeforeEach(func() {
+			Expect(propagator.ReconcileSpaceServiceAccounts(ctx, namespace)).To(Succeed())
+		})
+
+		It("propagates the service accounts", func() {
+			Expect(serviceAccountExists()).To(BeTrue())
+		})
+
+		When("service accounts are created lazily", func() {
+			BeforeEach(func() {
+				serviceAccountCreation = config.ServiceAccountCreationLazy
+			})
+
+			It("does not propagate the service accounts", func() {
+				Expect(serviceAccountExists()).To(BeFalse())
+			})
+
+			When("service accounts have already been propagated", func() {
+				var staleServiceAccount *corev1.ServiceAccount
+
+				BeforeEach(func() {
+					staleServiceAccount = &corev1.ServiceAccount{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      PrefixedGUID("stale-service-account"),
+							Namespace: namespace,
+							Labels:    map[string]string{korifiv1alpha1.PropagatedFromLabel: cfRootNamespace},
+						},
+					}
+					Expect(adminClient.Create(ctx, staleServiceAccount)).To(Succeed())
+				})
+
+				It("keeps them in sync with the root namespace", func() {
+					Expect(serviceAccountExists()).To(BeTrue())
+					Expect(apierrors.IsNotFound(adminClient.Get(ctx, client.ObjectKeyFromObject(staleServiceAccount), &corev1.ServiceAccount{}))).To(BeTrue())
+				})
+			})
+		})
+	})
+
+	Describe("EnsureServiceAccounts", func() {
+		JustBeforeEach(func() {
+			Expect(propagator.EnsureServiceAccounts(ctx, namespace)).To(Succeed())
+		})
+
+		It("leaves propagation to the space reconciler", func() {
+			Expect(serviceAccountExists()).To(BeFalse())
+		})
+
+		When("service accounts are created lazily", func() {
+			BeforeEach(func() {
+				serviceAccountCreation = config.ServiceAccountCreationLazy
+			})
+
+			It("propagates the service accounts", func() {
+				Expect(serviceAccountExists()).To(BeTrue())
+			})
+		})
+	})
+})
@@ -60,6 +60,7 @@ var (
 	eventRecorder       *controllerfake.EventRecorder
 	imageClient         image.Client
 	containerRegistry   *oci.Registry
+	controllerConfig    *config.ControllerConfig
 )
 
 const (
@@ -109,7 +110,7 @@ var _ = BeforeSuite(func() {
 
 	cfRootNamespace = testutils.PrefixedGUID("root-namespace")
 
-	controllerConfig := &config.ControllerConfig{
+	controllerConfig = &config.ControllerConfig{
 		CFProcessDefaults: config.CFProcessDefaults{
 			MemoryMB:    500,
 			DiskQuotaMB: 512,
@@ -119,6 +120,13 @@ var _ = BeforeSuite(func() {
 		WorkloadsTLSSecretName:           "korifi-workloads-ingress-cert",
 		WorkloadsTLSSecretNamespace:      "korifi-controllers-system",
 		SpaceFinalizerAppDeletionTimeout: tools.PtrTo(int64(2)),
+		PropagatedPodLabels:              []string{"security-group", "space-group"},
+		DefaultPodAnnotations:            map[string]string{"sidecar.istio.io/inject": "true"},
+		HPAIntegration:                   true,
+		ServiceAccountCreation:           config.ServiceAccountCreationEager,
+		NamespaceLabels:                  map[string]string{"istio-injection": "enabled"},
+		NamespaceAnnotations:             map[string]string{"example.com/owner": "korifi"},
+		LifecycleLauncherPath:            "/cnb/lifecycle/launcher",
 	}
 
 	k8sClient, err := k8sclient.NewForConfig(k8sManager.GetConfig())
@@ -133,6 +141,8 @@ var _ = BeforeSuite(func() {
 		ctrl.Log.WithName("controllers").WithName("CFApp"),
 		env.NewVCAPServicesEnvValueBuilder(k8sManager.GetClient()),
 		env.NewVCAPApplicationEnvValueBuilder(k8sManager.GetClient(), nil),
+		config.RouteCleanupPolicyOrphan,
+		time.Hour,
 	)).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -178,16 +188,21 @@ var _ = BeforeSuite(func() {
 	)).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
-	labelCompiler := labels.NewCompiler().Defaults(map[string]string{
-		admission.EnforceLevelLabel: string(admission.LevelRestricted),
-		admission.AuditLevelLabel:   string(admission.LevelRestricted),
-	})
+	labelCompiler := labels.NewCompiler().
+		Defaults(map[string]string{
+			admission.EnforceLevelLabel: string(admission.LevelRestricted),
+			admission.AuditLevelLabel:   string(admission.LevelRestricted),
+		}).
+		Defaults(controllerConfig.NamespaceLabels)
+	annotationCompiler := labels.NewCompiler().Defaults(controllerConfig.NamespaceAnnotations)
 
 	err = NewCFOrgReconciler(
 		k8sManager.GetClient(),
 		ctrl.Log.WithName("controllers").WithName("CFOrg"),
 		controllerConfig.ContainerRegistrySecretNames,
+		controllerConfig.CFRootNamespace,
 		labelCompiler,
+		annotationCompiler,
 	).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -198,6 +213,8 @@ var _ = BeforeSuite(func() {
 		controllerConfig.CFRootNamespace,
 		*controllerConfig.SpaceFinalizerAppDeletionTimeout,
 		labelCompiler,
+		annotationCompiler,
+		NewServiceAccountPropagator(k8sManager.GetClient(), controllerConfig),
 	).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -208,6 +225,8 @@ var _ = BeforeSuite(func() {
 		ctrl.Log.WithName("controllers").WithName("CFTask"),
 		env.NewWorkloadEnvBuilder(k8sManager.GetClient()),
 		2*time.Second,
+		"/custom/task/launcher",
+		nil,
 	).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -249,6 +268,8 @@ var _ = BeforeSuite(func() {
 		webhooks.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, networking.RouteEntityType)),
 		cfRootNamespace,
 		uncachedClient,
+		k8sManager.GetClient(),
+		config.RouteHostPolicyShared,
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(services.NewCFServiceBindingValidator(
 		webhooks.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, services.ServiceBindingEntityType)),
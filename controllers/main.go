@@ -53,6 +53,7 @@ import (
 	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	contourv1 "github.com/projectcontour/contour/apis/projectcontour/v1"
 	servicebindingv1beta1 "github.com/servicebinding/runtime/apis/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	k8sclient "k8s.io/client-go/kubernetes"
@@ -151,18 +152,34 @@ func main() {
 	if os.Getenv("ENABLE_CONTROLLERS") != "false" {
 		imageClient := image.NewClient(k8sClient)
 
+		var routeCleanupGracePeriod time.Duration
+		routeCleanupGracePeriod, err = controllerConfig.ParseAppRouteCleanupGracePeriod()
+		if err != nil {
+			setupLog.Error(err, "failed to parse app route cleanup grace period", "controller", "CFApp", "appRouteCleanupGracePeriod", controllerConfig.AppRouteCleanupGracePeriod)
+			os.Exit(1)
+		}
+
 		if err = (workloadscontrollers.NewCFAppReconciler(
 			mgr.GetClient(),
 			mgr.GetScheme(),
 			ctrl.Log.WithName("controllers").WithName("CFApp"),
 			env.NewVCAPServicesEnvValueBuilder(mgr.GetClient()),
 			env.NewVCAPApplicationEnvValueBuilder(mgr.GetClient(), controllerConfig.ExtraVCAPApplicationValues),
+			controllerConfig.AppRouteCleanupPolicy,
+			routeCleanupGracePeriod,
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFApp")
 			os.Exit(1)
 		}
 
-		buildCleaner := cleanup.NewBuildCleaner(mgr.GetClient(), controllerConfig.MaxRetainedBuildsPerApp)
+		var failedBuildRetention time.Duration
+		failedBuildRetention, err = controllerConfig.ParseFailedBuildRetention()
+		if err != nil {
+			setupLog.Error(err, "failed to parse failed build retention", "controller", "CFBuildpackBuild", "failedBuildRetention", controllerConfig.FailedBuildRetention)
+			os.Exit(1)
+		}
+
+		buildCleaner := cleanup.NewBuildCleaner(mgr.GetClient(), controllerConfig.MaxRetainedBuildsPerApp, failedBuildRetention)
 		if err = (workloadscontrollers.NewCFBuildpackBuildReconciler(
 			mgr.GetClient(),
 			buildCleaner,
@@ -220,8 +237,10 @@ func main() {
 
 		if err = (servicescontrollers.NewCFServiceBindingReconciler(
 			mgr.GetClient(),
+			mgr.GetAPIReader(),
 			mgr.GetScheme(),
 			ctrl.Log.WithName("controllers").WithName("CFServiceBinding"),
+			controllerConfig,
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFServiceBinding")
 			os.Exit(1)
@@ -233,12 +252,15 @@ func main() {
 				admission.AuditLevelLabel:   string(admission.LevelRestricted),
 			}).
 			Defaults(controllerConfig.NamespaceLabels)
+		annotationCompiler := labels.NewCompiler().Defaults(controllerConfig.NamespaceAnnotations)
 
 		if err = workloadscontrollers.NewCFOrgReconciler(
 			mgr.GetClient(),
 			ctrl.Log.WithName("controllers").WithName("CFOrg"),
 			controllerConfig.ContainerRegistrySecretNames,
+			controllerConfig.CFRootNamespace,
 			labelCompiler,
+			annotationCompiler,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFOrg")
 			os.Exit(1)
@@ -251,6 +273,8 @@ func main() {
 			controllerConfig.CFRootNamespace,
 			*controllerConfig.SpaceFinalizerAppDeletionTimeout,
 			labelCompiler,
+			annotationCompiler,
+			workloadscontrollers.NewServiceAccountPropagator(mgr.GetClient(), controllerConfig),
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFSpace")
 			os.Exit(1)
@@ -270,6 +294,8 @@ func main() {
 			ctrl.Log.WithName("controllers").WithName("CFTask"),
 			env.NewWorkloadEnvBuilder(mgr.GetClient()),
 			taskTTL,
+			controllerConfig.LifecycleLauncherPath,
+			controllerConfig.AllowedLaunchers,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFTask")
 			os.Exit(1)
@@ -279,6 +305,7 @@ func main() {
 			mgr.GetClient(),
 			mgr.GetScheme(),
 			ctrl.Log.WithName("controllers").WithName("CFDomain"),
+			controllerConfig,
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFDomain")
 			os.Exit(1)
@@ -361,7 +388,11 @@ func main() {
 			if err = statefulsetcontrollers.NewAppWorkloadReconciler(
 				mgr.GetClient(),
 				mgr.GetScheme(),
-				statefulsetcontrollers.NewAppWorkloadToStatefulsetConverter(mgr.GetScheme()),
+				statefulsetcontrollers.NewAppWorkloadToStatefulsetConverter(mgr.GetScheme(), statefulsetcontrollers.SecurityContext{
+					RunAsNonRoot:           *controllerConfig.LRPSecurityContext.RunAsNonRoot,
+					SeccompProfileType:     corev1.SeccompProfileType(controllerConfig.LRPSecurityContext.SeccompProfileType),
+					ReadOnlyRootFilesystem: controllerConfig.LRPSecurityContext.ReadOnlyRootFilesystem,
+				}, corev1.PullPolicy(controllerConfig.LRPImagePullPolicy)),
 				statefulsetcontrollers.NewPDBUpdater(mgr.GetClient()),
 				logger,
 			).SetupWithManager(mgr); err != nil {
@@ -441,6 +472,8 @@ func main() {
 			webhooks.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, networking.RouteEntityType)),
 			controllerConfig.CFRootNamespace,
 			uncachedClient,
+			mgr.GetClient(),
+			controllerConfig.RouteHostPolicy,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFRoute")
 			os.Exit(1)
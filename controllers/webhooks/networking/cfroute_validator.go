@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	"github.com/hashicorp/go-multierror"
 
@@ -31,6 +33,9 @@ const (
 	RoutePathValidationErrorType           = "RoutePathValidationError"
 	RouteSubdomainValidationErrorType      = "RouteSubdomainValidationError"
 	RouteSubdomainValidationErrorMessage   = "Subdomains must each be at most 63 characters"
+	RouteHostTakenErrorType                = "RouteHostTakenError"
+	RouteDestinationWeightsErrorType       = "RouteDestinationWeightsError"
+	RouteDestinationWeightsErrorMessage    = "Destination weights must be set on either all or none of the route destinations"
 
 	HostEmptyError  = "host cannot be empty"
 	HostLengthError = "host is too long (maximum is 63 characters)"
@@ -50,6 +55,8 @@ type CFRouteValidator struct {
 	duplicateValidator webhooks.NameValidator
 	rootNamespace      string
 	client             client.Client
+	indexedClient      client.Client
+	hostPolicy         string
 }
 
 var _ webhook.CustomValidator = &CFRouteValidator{}
@@ -58,11 +65,15 @@ func NewCFRouteValidator(
 	nameValidator webhooks.NameValidator,
 	rootNamespace string,
 	client client.Client,
+	indexedClient client.Client,
+	hostPolicy string,
 ) *CFRouteValidator {
 	return &CFRouteValidator{
 		duplicateValidator: nameValidator,
 		rootNamespace:      rootNamespace,
 		client:             client,
+		indexedClient:      indexedClient,
+		hostPolicy:         hostPolicy,
 	}
 }
 
@@ -84,6 +95,11 @@ func (v *CFRouteValidator) ValidateCreate(ctx context.Context, obj runtime.Objec
 		return nil, err
 	}
 
+	err = v.validateHostOwnership(ctx, route, cfDomain)
+	if err != nil {
+		return nil, err
+	}
+
 	route.Status.FQDN = cfDomain.Spec.Name
 
 	return nil, v.duplicateValidator.ValidateCreate(ctx, logger, v.rootNamespace, route)
@@ -128,7 +144,7 @@ func (v *CFRouteValidator) ValidateUpdate(ctx context.Context, oldObj, obj runti
 		return nil, immutableError.ExportJSONError()
 	}
 
-	err := v.validateDestinations(ctx, route)
+	err := v.validateDestinations(ctx, oldRoute, route)
 	if err != nil {
 		return nil, err
 	}
@@ -151,7 +167,7 @@ func (v *CFRouteValidator) validateRoute(ctx context.Context, route *korifiv1alp
 		return domain, err
 	}
 
-	err = v.validateDestinations(ctx, route)
+	err = v.validateDestinations(ctx, nil, route)
 	if err != nil {
 		return domain, err
 	}
@@ -181,7 +197,14 @@ func (v *CFRouteValidator) fetchDomain(ctx context.Context, route *korifiv1alpha
 	return domain, err
 }
 
-func (v *CFRouteValidator) validateDestinations(ctx context.Context, route *korifiv1alpha1.CFRoute) error {
+func (v *CFRouteValidator) validateDestinations(ctx context.Context, oldRoute, route *korifiv1alpha1.CFRoute) error {
+	if destinationWeightsChanged(oldRoute, route) && !destinationWeightsAreValid(route.Spec.Destinations) {
+		return webhooks.ValidationError{
+			Type:    RouteDestinationWeightsErrorType,
+			Message: RouteDestinationWeightsErrorMessage,
+		}.ExportJSONError()
+	}
+
 	err := v.checkDestinationsExistInNamespace(ctx, *route)
 	if err != nil {
 		validationErr := webhooks.ValidationError{}
@@ -201,6 +224,81 @@ func (v *CFRouteValidator) validateDestinations(ctx context.Context, route *kori
 	return nil
 }
 
+// validateHostOwnership rejects routes using a host that routes in another
+// org already use on the same domain, when hosts are reserved per org
+func (v *CFRouteValidator) validateHostOwnership(ctx context.Context, route *korifiv1alpha1.CFRoute, domain *korifiv1alpha1.CFDomain) error {
+	if v.hostPolicy != config.RouteHostPolicyOrg {
+		return nil
+	}
+
+	routes := &korifiv1alpha1.CFRouteList{}
+	err := v.indexedClient.List(ctx, routes, client.MatchingFields{shared.IndexRouteHostDomain: shared.RouteHostDomainKey(route)})
+	if err != nil {
+		logger.Info("failed to list routes", "reason", err)
+		return webhooks.ValidationError{
+			Type:    webhooks.UnknownErrorType,
+			Message: webhooks.UnknownErrorMessage,
+		}.ExportJSONError()
+	}
+
+	if len(routes.Items) == 0 {
+		return nil
+	}
+
+	namespaceOrgs := map[string]string{}
+	routeOrg, err := v.orgOfNamespace(ctx, namespaceOrgs, route.Namespace)
+	if err != nil {
+		logger.Info("failed to list spaces", "reason", err)
+		return webhooks.ValidationError{
+			Type:    webhooks.UnknownErrorType,
+			Message: webhooks.UnknownErrorMessage,
+		}.ExportJSONError()
+	}
+
+	for _, existingRoute := range routes.Items {
+		existingRouteOrg, err := v.orgOfNamespace(ctx, namespaceOrgs, existingRoute.Namespace)
+		if err != nil {
+			logger.Info("failed to list spaces", "reason", err)
+			return webhooks.ValidationError{
+				Type:    webhooks.UnknownErrorType,
+				Message: webhooks.UnknownErrorMessage,
+			}.ExportJSONError()
+		}
+
+		if existingRouteOrg != routeOrg {
+			return webhooks.ValidationError{
+				Type:    RouteHostTakenErrorType,
+				Message: fmt.Sprintf("Host %q on domain %q is already in use by another organization", route.Spec.Host, domain.Spec.Name),
+			}.ExportJSONError()
+		}
+	}
+
+	return nil
+}
+
+// orgOfNamespace returns the org namespace of a space namespace, looking up
+// only the space owning it. Namespaces that are not spaces are treated as
+// their own org.
+func (v *CFRouteValidator) orgOfNamespace(ctx context.Context, namespaceOrgs map[string]string, namespace string) (string, error) {
+	if org, ok := namespaceOrgs[namespace]; ok {
+		return org, nil
+	}
+
+	spaces := &korifiv1alpha1.CFSpaceList{}
+	err := v.indexedClient.List(ctx, spaces, client.MatchingFields{shared.IndexSpaceNamespaceName: namespace})
+	if err != nil {
+		return "", err
+	}
+
+	org := namespace
+	if len(spaces.Items) == 1 {
+		org = spaces.Items[0].Namespace
+	}
+
+	namespaceOrgs[namespace] = org
+	return org, nil
+}
+
 func validateFQDN(host, domain string) error {
 	// we only need to validate that "<host>.<domain>" is not too long and that
 	// <host> is either "*" or a valid dns label. The domain webhook already
@@ -278,6 +376,49 @@ func validatePath(path string) error {
 	return nil
 }
 
+// destinationWeightsChanged checks whether the route sets a weight that the
+// old route did not have. Updates that only remove destinations or add
+// unweighted ones are not validated, so that deleting an app never leaves
+// its routes unpatchable.
+func destinationWeightsChanged(oldRoute, route *korifiv1alpha1.CFRoute) bool {
+	oldWeights := map[string]*int{}
+	if oldRoute != nil {
+		for _, destination := range oldRoute.Spec.Destinations {
+			oldWeights[destination.GUID] = destination.Weight
+		}
+	}
+
+	for _, destination := range route.Spec.Destinations {
+		if destination.Weight != nil && !equalWeights(oldWeights[destination.GUID], destination.Weight) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func equalWeights(w1, w2 *int) bool {
+	if w1 == nil || w2 == nil {
+		return w1 == w2
+	}
+
+	return *w1 == *w2
+}
+
+// destinationWeightsAreValid checks that either no destination has a weight,
+// or all of them have one. Weights are relative, so they need not add up to
+// any particular total.
+func destinationWeightsAreValid(destinations []korifiv1alpha1.Destination) bool {
+	weighted := 0
+	for _, destination := range destinations {
+		if destination.Weight != nil {
+			weighted++
+		}
+	}
+
+	return weighted == 0 || weighted == len(destinations)
+}
+
 func (v *CFRouteValidator) checkDestinationsExistInNamespace(ctx context.Context, route korifiv1alpha1.CFRoute) error {
 	for _, destination := range route.Spec.Destinations {
 		err := v.client.Get(ctx, client.ObjectKey{Namespace: route.Namespace, Name: destination.AppRef.Name}, &korifiv1alpha1.CFApp{})
@@ -8,17 +8,21 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	controllerfake "code.cloudfoundry.org/korifi/controllers/fake"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/networking"
 	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -102,7 +106,7 @@ var _ = Describe("CFRouteValidator", func() {
 			}
 		}
 
-		validatingWebhook = networking.NewCFRouteValidator(duplicateValidator, rootNamespace, fakeClient)
+		validatingWebhook = networking.NewCFRouteValidator(duplicateValidator, rootNamespace, fakeClient, fakeClient, config.RouteHostPolicyShared)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -163,6 +167,112 @@ var _ = Describe("CFRouteValidator", func() {
 			})
 		})
 
+		When("a route in another org uses the same host on the domain", func() {
+			var existingRoute *korifiv1alpha1.CFRoute
+
+			BeforeEach(func() {
+				existingRoute = initializeRouteCR(testRouteProtocol, testRouteHost, "/other-path", "other-route-guid", "other-ns", testDomainGUID, testDomainNamespace)
+
+				fakeClient.ListStub = func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+					listOpts := &client.ListOptions{}
+					for _, opt := range opts {
+						opt.ApplyToList(listOpts)
+					}
+
+					switch list := list.(type) {
+					case *korifiv1alpha1.CFRouteList:
+						list.Items = []korifiv1alpha1.CFRoute{}
+						if listOpts.FieldSelector.Matches(fields.Set{shared.IndexRouteHostDomain: shared.RouteHostDomainKey(existingRoute)}) {
+							list.Items = append(list.Items, *existingRoute)
+						}
+					case *korifiv1alpha1.CFSpaceList:
+						list.Items = []korifiv1alpha1.CFSpace{}
+						spaceOrgs := map[string]string{testRouteNamespace: "org-ns", "other-ns": "other-org-ns"}
+						for spaceNamespace, orgNamespace := range spaceOrgs {
+							if listOpts.FieldSelector.Matches(fields.Set{shared.IndexSpaceNamespaceName: spaceNamespace}) {
+								list.Items = append(list.Items, korifiv1alpha1.CFSpace{ObjectMeta: metav1.ObjectMeta{Name: spaceNamespace, Namespace: orgNamespace}})
+							}
+						}
+					default:
+						panic("TestClient List provided an unexpected object type")
+					}
+					return nil
+				}
+			})
+
+			It("allows the request", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+
+			When("hosts are reserved per org", func() {
+				BeforeEach(func() {
+					validatingWebhook = networking.NewCFRouteValidator(duplicateValidator, rootNamespace, fakeClient, fakeClient, config.RouteHostPolicyOrg)
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteHostTakenErrorType,
+						Equal(`Host "my-host" on domain "test.domain.name" is already in use by another organization`),
+					))
+				})
+
+				It("only lists the routes using the host on the domain", func() {
+					Expect(fakeClient.ListCallCount()).To(BeNumerically(">", 0))
+					_, list, opts := fakeClient.ListArgsForCall(0)
+					Expect(list).To(BeAssignableToTypeOf(&korifiv1alpha1.CFRouteList{}))
+					Expect(opts).To(ConsistOf(client.MatchingFields{
+						shared.IndexRouteHostDomain: testRouteHost + "." + testDomainNamespace + "." + testDomainGUID,
+					}))
+				})
+
+				When("the route using the host is in the same org", func() {
+					BeforeEach(func() {
+						existingRoute.Namespace = testRouteNamespace
+					})
+
+					It("allows the request", func() {
+						Expect(retErr).NotTo(HaveOccurred())
+					})
+				})
+
+				When("the route using the host is on another domain", func() {
+					BeforeEach(func() {
+						existingRoute.Spec.DomainRef.Name = "other-domain-guid"
+					})
+
+					It("allows the request", func() {
+						Expect(retErr).NotTo(HaveOccurred())
+					})
+				})
+
+				When("listing routes fails", func() {
+					BeforeEach(func() {
+						fakeClient.ListReturns(errors.New("list-err"))
+					})
+
+					It("denies the request", func() {
+						Expect(retErr).To(matchers.BeValidationError(webhooks.UnknownErrorType, Equal(webhooks.UnknownErrorMessage)))
+					})
+				})
+
+				When("listing spaces fails", func() {
+					BeforeEach(func() {
+						listRoutes := fakeClient.ListStub
+						fakeClient.ListStub = func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+							if _, ok := list.(*korifiv1alpha1.CFSpaceList); ok {
+								return errors.New("list-err")
+							}
+							return listRoutes(ctx, list, opts...)
+						}
+					})
+
+					It("denies the request", func() {
+						Expect(retErr).To(matchers.BeValidationError(webhooks.UnknownErrorType, Equal(webhooks.UnknownErrorMessage)))
+					})
+				})
+			})
+		})
+
 		When("the route name is a duplicate", func() {
 			BeforeEach(func() {
 				duplicateValidator.ValidateCreateReturns(errors.New("foo"))
@@ -294,6 +404,46 @@ var _ = Describe("CFRouteValidator", func() {
 					))
 				})
 			})
+
+			When("the destinations are weighted", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Destinations[0].Weight = tools.PtrTo(40)
+					cfRoute.Spec.Destinations = append(cfRoute.Spec.Destinations, korifiv1alpha1.Destination{
+						AppRef: v1.LocalObjectReference{
+							Name: "some-other-name",
+						},
+						Weight: tools.PtrTo(60),
+					})
+				})
+
+				It("allows the request", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+				})
+
+				When("the weights do not add up to 100", func() {
+					BeforeEach(func() {
+						cfRoute.Spec.Destinations[1].Weight = tools.PtrTo(50)
+					})
+
+					It("allows the request", func() {
+						Expect(retErr).NotTo(HaveOccurred())
+					})
+				})
+
+				When("only some destinations are weighted", func() {
+					BeforeEach(func() {
+						cfRoute.Spec.Destinations[0].Weight = tools.PtrTo(100)
+						cfRoute.Spec.Destinations[1].Weight = nil
+					})
+
+					It("denies the request", func() {
+						Expect(retErr).To(matchers.BeValidationError(
+							networking.RouteDestinationWeightsErrorType,
+							Equal(networking.RouteDestinationWeightsErrorMessage),
+						))
+					})
+				})
+			})
 		})
 	})
 
@@ -429,6 +579,91 @@ var _ = Describe("CFRouteValidator", func() {
 				))
 			})
 		})
+
+		When("a destination weight is set on only some of the destinations", func() {
+			BeforeEach(func() {
+				updatedCFRoute.Spec.Destinations = append(updatedCFRoute.Spec.Destinations, korifiv1alpha1.Destination{
+					GUID: "weighted-destination-guid",
+					AppRef: v1.LocalObjectReference{
+						Name: "some-other-name",
+					},
+					Weight: tools.PtrTo(100),
+				})
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					networking.RouteDestinationWeightsErrorType,
+					Equal(networking.RouteDestinationWeightsErrorMessage),
+				))
+			})
+		})
+
+		When("the route destinations are weighted", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
+					{
+						GUID:   "destination-1-guid",
+						AppRef: v1.LocalObjectReference{Name: "some-name"},
+						Weight: tools.PtrTo(80),
+					},
+					{
+						GUID:   "destination-2-guid",
+						AppRef: v1.LocalObjectReference{Name: "some-other-name"},
+						Weight: tools.PtrTo(20),
+					},
+				}
+				updatedCFRoute = cfRoute.DeepCopy()
+			})
+
+			When("a destination is removed", func() {
+				BeforeEach(func() {
+					updatedCFRoute.Spec.Destinations = updatedCFRoute.Spec.Destinations[:1]
+				})
+
+				It("allows the request", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("an unweighted destination is added", func() {
+				BeforeEach(func() {
+					updatedCFRoute.Spec.Destinations = append(updatedCFRoute.Spec.Destinations, korifiv1alpha1.Destination{
+						GUID:   "destination-3-guid",
+						AppRef: v1.LocalObjectReference{Name: "some-name"},
+					})
+				})
+
+				It("allows the request", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("a weight is changed while other destinations are unweighted", func() {
+				BeforeEach(func() {
+					updatedCFRoute.Spec.Destinations[0].Weight = tools.PtrTo(50)
+					updatedCFRoute.Spec.Destinations[1].Weight = nil
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						networking.RouteDestinationWeightsErrorType,
+						Equal(networking.RouteDestinationWeightsErrorMessage),
+					))
+				})
+			})
+
+			When("all weights are cleared", func() {
+				BeforeEach(func() {
+					updatedCFRoute.Spec.Destinations[0].Weight = nil
+					updatedCFRoute.Spec.Destinations[1].Weight = nil
+				})
+
+				It("allows the request", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+				})
+			})
+		})
 	})
 
 	Describe("ValidateDelete", func() {
@@ -22,6 +22,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -31,12 +32,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
+const OrgSuspendedErrorType = "OrgSuspendedError"
+
 // log is for logging in this package.
 var (
 	cfpackagelog = logf.Log.WithName("cftask-resource")
 )
 
-//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfpackage,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfpackages,verbs=update,versions=v1alpha1,name=vcfpackage.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfpackage,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfpackages,verbs=create;update,versions=v1alpha1,name=vcfpackage.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
 
 type CFPackageValidator struct {
 	client client.Client
@@ -60,6 +63,27 @@ func (v *CFPackageValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
 var _ webhook.CustomValidator = &CFPackageValidator{}
 
 func (v *CFPackageValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cfPackage, ok := obj.(*v1alpha1.CFPackage)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFPackage but got a %T", obj))
+	}
+
+	namespace := new(corev1.Namespace)
+	if err := v.client.Get(ctx, client.ObjectKey{Name: cfPackage.Namespace}, namespace); err != nil {
+		cfpackagelog.Info("failed to get the package namespace", "namespace", cfPackage.Namespace, "reason", err)
+		return nil, webhooks.ValidationError{
+			Type:    webhooks.UnknownErrorType,
+			Message: webhooks.UnknownErrorMessage,
+		}.ExportJSONError()
+	}
+
+	if namespace.Labels[v1alpha1.OrgSuspendedLabelKey] == "true" {
+		return nil, webhooks.ValidationError{
+			Type:    OrgSuspendedErrorType,
+			Message: "the org of this space is suspended",
+		}.ExportJSONError()
+	}
+
 	return nil, nil
 }
 
@@ -63,4 +63,49 @@ var _ = Describe("CFPackage Validation", func() {
 			})
 		})
 	})
+
+	Describe("package creation", func() {
+		var (
+			namespace *v1.Namespace
+			createErr error
+		)
+
+		BeforeEach(func() {
+			namespace = &v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: testutils.PrefixedGUID("space"),
+				},
+			}
+			Expect(adminClient.Create(context.Background(), namespace)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			createErr = adminClient.Create(context.Background(), &korifiv1alpha1.CFPackage{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace.Name,
+					Name:      testutils.PrefixedGUID("cfpackage"),
+				},
+				Spec: korifiv1alpha1.CFPackageSpec{
+					AppRef: v1.LocalObjectReference{Name: "some-app"},
+					Type:   "bits",
+				},
+			})
+		})
+
+		It("allows it", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+		})
+
+		When("the org of the space is suspended", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(context.Background(), adminClient, namespace, func() {
+					namespace.Labels = map[string]string{korifiv1alpha1.OrgSuspendedLabelKey: "true"}
+				})).To(Succeed())
+			})
+
+			It("denies it", func() {
+				Expect(createErr).To(MatchError(ContainSubstring("suspended")))
+			})
+		})
+	})
 })
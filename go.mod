@@ -32,6 +32,10 @@ require (
 	github.com/pivotal/kpack v0.12.3
 	github.com/projectcontour/contour v1.27.0
 	github.com/servicebinding/runtime v0.7.0
+	go.opentelemetry.io/otel v1.19.0
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp v1.19.0
+	go.opentelemetry.io/otel/sdk v1.19.0
+	go.opentelemetry.io/otel/trace v1.19.0
 	golang.org/x/exp v0.0.0-20231006140011-7918f672742d
 	golang.org/x/text v0.14.0
 	gopkg.in/square/go-jose.v2 v2.6.0
@@ -46,7 +50,17 @@ require (
 	sigs.k8s.io/controller-tools v0.13.0
 )
 
-require github.com/aws/aws-sdk-go-v2/service/internal/accept-encoding v1.10.4 // indirect
+require (
+	github.com/aws/aws-sdk-go-v2/service/internal/accept-encoding v1.10.4 // indirect
+	github.com/cenkalti/backoff/v4 v4.2.1 // indirect
+	github.com/evanphx/json-patch v5.6.0+incompatible // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.18.0 // indirect
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace v1.19.0 // indirect
+	go.opentelemetry.io/otel/metric v1.19.0 // indirect
+	go.opentelemetry.io/proto/otlp v1.0.0 // indirect
+	google.golang.org/genproto/googleapis/api v0.0.0-20231016165738-49dd2c1f3d0b // indirect
+)
 
 require (
 	cloud.google.com/go/compute v1.23.2 // indirect
@@ -45,7 +45,7 @@ const (
 	EnvCFInstanceIP         = "CF_INSTANCE_IP"
 	EnvCFInstanceGUID       = "CF_INSTANCE_GUID"
 	EnvCFInstanceInternalIP = "CF_INSTANCE_INTERNAL_IP"
-	EnvCFInstanceIndex      = "CF_INSTANCE_INDEX"
+	EnvCFInstanceIndex      = korifiv1alpha1.InstanceIndexEnvVar
 
 	// StatefulSet Keys
 	AnnotationVersion     = "korifi.cloudfoundry.org/version"
@@ -59,7 +59,7 @@ const (
 	LabelProcessType            = "korifi.cloudfoundry.org/process-type"
 	LabelStatefulSetRunnerIndex = "korifi.cloudfoundry.org/add-stsr-index"
 
-	ApplicationContainerName  = "application"
+	ApplicationContainerName  = korifiv1alpha1.AppWorkloadContainerName
 	AppWorkloadReconcilerName = "statefulset-runner"
 	ServiceAccountName        = "korifi-app"
 
@@ -174,11 +174,18 @@ func (r *AppWorkloadReconciler) ReconcileResource(ctx context.Context, appWorklo
 		},
 	}
 	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, orig, func() error {
+		actualReplicas := orig.Spec.Replicas
+
 		orig.Labels = statefulSet.Labels
 		orig.Annotations = statefulSet.Annotations
 		orig.OwnerReferences = statefulSet.OwnerReferences
 		orig.Spec = statefulSet.Spec
 
+		// the autoscaler owns the replicas of hpa-managed workloads once they exist
+		if isHPAManaged(appWorkload) && actualReplicas != nil {
+			orig.Spec.Replicas = actualReplicas
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -212,3 +219,9 @@ func (r *AppWorkloadReconciler) ReconcileResource(ctx context.Context, appWorklo
 
 	return ctrl.Result{}, nil
 }
+
+// isHPAManaged returns whether the replicas of the workload statefulset are
+// scaled by a horizontal pod autoscaler rather than by the workload instances
+func isHPAManaged(appWorkload *korifiv1alpha1.AppWorkload) bool {
+	return appWorkload.Annotations[korifiv1alpha1.CFProcessHPAManagedAnnotationKey] == "true"
+}
@@ -198,6 +198,23 @@ var _ = Describe("AppWorkload Reconcile", func() {
 			Expect(updatedStSet.Spec.Replicas).To(Equal(tools.PtrTo(int32(2))))
 		})
 
+		When("the appworkload is HPA-managed", func() {
+			BeforeEach(func() {
+				appWorkload.Annotations = map[string]string{korifiv1alpha1.CFProcessHPAManagedAnnotationKey: "true"}
+				statefulSet.Spec.Replicas = tools.PtrTo(int32(5))
+			})
+
+			It("keeps the replicas set by the autoscaler", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				for i := 0; i < fakeClient.PatchCallCount(); i++ {
+					_, updatedObject, _, _ := fakeClient.PatchArgsForCall(i)
+					if updatedStSet, ok := updatedObject.(*v1.StatefulSet); ok {
+						Expect(updatedStSet.Spec.Replicas).To(Equal(tools.PtrTo(int32(5))))
+					}
+				}
+			})
+		})
+
 		When("updating the pod disruption budget fails", func() {
 			BeforeEach(func() {
 				fakePDB.UpdateReturns(errors.New("boom"))
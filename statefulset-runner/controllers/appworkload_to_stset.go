@@ -17,14 +17,65 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+// SecurityContext configures how locked down the pods of the generated
+// statefulsets run, e.g. to satisfy the Pod Security Standards of a cluster
+type SecurityContext struct {
+	RunAsNonRoot bool
+	// SeccompProfileType is the seccomp profile of the application
+	// container. No profile is set when it is empty.
+	SeccompProfileType     corev1.SeccompProfileType
+	ReadOnlyRootFilesystem bool
+}
+
+// DefaultSecurityContext returns the security context LRP pods run with
+// unless configured otherwise
+func DefaultSecurityContext() SecurityContext {
+	return SecurityContext{
+		RunAsNonRoot:       true,
+		SeccompProfileType: corev1.SeccompProfileTypeRuntimeDefault,
+	}
+}
+
 type AppWorkloadToStatefulsetConverter struct {
-	scheme *runtime.Scheme
+	scheme          *runtime.Scheme
+	securityContext SecurityContext
+	imagePullPolicy corev1.PullPolicy
 }
 
-func NewAppWorkloadToStatefulsetConverter(scheme *runtime.Scheme) *AppWorkloadToStatefulsetConverter {
+// NewAppWorkloadToStatefulsetConverter creates a converter whose statefulsets
+// pull the app images according to imagePullPolicy. An empty policy always
+// pulls them.
+func NewAppWorkloadToStatefulsetConverter(scheme *runtime.Scheme, securityContext SecurityContext, imagePullPolicy corev1.PullPolicy) *AppWorkloadToStatefulsetConverter {
+	if imagePullPolicy == "" {
+		imagePullPolicy = corev1.PullAlways
+	}
+
 	return &AppWorkloadToStatefulsetConverter{
-		scheme: scheme,
+		scheme:          scheme,
+		securityContext: securityContext,
+		imagePullPolicy: imagePullPolicy,
+	}
+}
+
+func (r *AppWorkloadToStatefulsetConverter) containerSecurityContext() *corev1.SecurityContext {
+	securityContext := &corev1.SecurityContext{
+		AllowPrivilegeEscalation: tools.PtrTo(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+	}
+
+	if r.securityContext.SeccompProfileType != "" {
+		securityContext.SeccompProfile = &corev1.SeccompProfile{
+			Type: r.securityContext.SeccompProfileType,
+		}
+	}
+
+	if r.securityContext.ReadOnlyRootFilesystem {
+		securityContext.ReadOnlyRootFilesystem = tools.PtrTo(true)
 	}
+
+	return securityContext
 }
 
 func getStatefulSetName(appWorkload *korifiv1alpha1.AppWorkload) (string, error) {
@@ -98,25 +149,29 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 		{
 			Name:            ApplicationContainerName,
 			Image:           appWorkload.Spec.Image,
-			ImagePullPolicy: corev1.PullAlways,
+			ImagePullPolicy: r.imagePullPolicy,
 			Command:         appWorkload.Spec.Command,
 			Env:             envs,
 			Ports:           ports,
-			SecurityContext: &corev1.SecurityContext{
-				AllowPrivilegeEscalation: tools.PtrTo(false),
-				Capabilities: &corev1.Capabilities{
-					Drop: []corev1.Capability{"ALL"},
-				},
-				SeccompProfile: &corev1.SeccompProfile{
-					Type: corev1.SeccompProfileTypeRuntimeDefault,
-				},
-			},
-			Resources:     appWorkload.Spec.Resources,
-			StartupProbe:  appWorkload.Spec.StartupProbe,
-			LivenessProbe: appWorkload.Spec.LivenessProbe,
+			SecurityContext: r.containerSecurityContext(),
+			Resources:       appWorkload.Spec.Resources,
+			StartupProbe:    appWorkload.Spec.StartupProbe,
+			LivenessProbe:   appWorkload.Spec.LivenessProbe,
 		},
 	}
 
+	for _, sidecar := range appWorkload.Spec.Sidecars {
+		containers = append(containers, corev1.Container{
+			Name:            sidecar.Name,
+			Image:           appWorkload.Spec.Image,
+			ImagePullPolicy: r.imagePullPolicy,
+			Command:         sidecar.Command,
+			Env:             envs,
+			SecurityContext: r.containerSecurityContext(),
+			Resources:       sidecar.Resources,
+		})
+	}
+
 	statefulsetName, err := getStatefulSetName(appWorkload)
 	if err != nil {
 		return nil, err
@@ -135,9 +190,10 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 					Containers:       containers,
 					ImagePullSecrets: appWorkload.Spec.ImagePullSecrets,
 					SecurityContext: &corev1.PodSecurityContext{
-						RunAsNonRoot: tools.PtrTo(true),
+						RunAsNonRoot: tools.PtrTo(r.securityContext.RunAsNonRoot),
 					},
-					ServiceAccountName: ServiceAccountName,
+					ServiceAccountName:            ServiceAccountName,
+					TerminationGracePeriodSeconds: appWorkload.Spec.TerminationGracePeriodSeconds,
 				},
 			},
 		},
@@ -176,7 +232,15 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 		LabelStatefulSetRunnerIndex: "true",
 	}
 
-	statefulSet.Spec.Template.Labels = labels
+	podLabels := map[string]string{}
+	for k, v := range appWorkload.Spec.PodLabels {
+		podLabels[k] = v
+	}
+	for k, v := range labels {
+		podLabels[k] = v
+	}
+
+	statefulSet.Spec.Template.Labels = podLabels
 	statefulSet.Labels = labels
 
 	annotations := map[string]string{
@@ -185,8 +249,16 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 		AnnotationProcessGUID: fmt.Sprintf("%s-%s", appWorkload.Spec.GUID, appWorkload.Spec.Version),
 	}
 
+	podAnnotations := map[string]string{}
+	for k, v := range appWorkload.Spec.PodAnnotations {
+		podAnnotations[k] = v
+	}
+	for k, v := range annotations {
+		podAnnotations[k] = v
+	}
+
 	statefulSet.Annotations = annotations
-	statefulSet.Spec.Template.Annotations = annotations
+	statefulSet.Spec.Template.Annotations = podAnnotations
 
 	return statefulSet, nil
 }
@@ -18,18 +18,23 @@ import (
 
 var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 	var (
-		statefulSet *appsv1.StatefulSet
-		appWorkload *korifiv1alpha1.AppWorkload
-		converter   *controllers.AppWorkloadToStatefulsetConverter
+		statefulSet     *appsv1.StatefulSet
+		appWorkload     *korifiv1alpha1.AppWorkload
+		converter       *controllers.AppWorkloadToStatefulsetConverter
+		secContext      controllers.SecurityContext
+		imagePullPolicy corev1.PullPolicy
 	)
 
 	BeforeEach(func() {
 		Expect(korifiv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
 		appWorkload = createAppWorkload("some-namespace", "guid_1234")
-		converter = controllers.NewAppWorkloadToStatefulsetConverter(scheme.Scheme)
+		secContext = controllers.DefaultSecurityContext()
+		imagePullPolicy = ""
 	})
 
 	JustBeforeEach(func() {
+		converter = controllers.NewAppWorkloadToStatefulsetConverter(scheme.Scheme, secContext, imagePullPolicy)
+
 		var err error
 		statefulSet, err = converter.Convert(appWorkload)
 
@@ -128,6 +133,20 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 		Expect(statefulSet.Spec.Template.Spec.Containers[0].LivenessProbe).To(Equal(appWorkload.Spec.LivenessProbe))
 	})
 
+	It("should not set the termination grace period", func() {
+		Expect(statefulSet.Spec.Template.Spec.TerminationGracePeriodSeconds).To(BeNil())
+	})
+
+	When("the app workload has a termination grace period", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.TerminationGracePeriodSeconds = tools.PtrTo(int64(300))
+		})
+
+		It("sets it on the pod template", func() {
+			Expect(statefulSet.Spec.Template.Spec.TerminationGracePeriodSeconds).To(Equal(tools.PtrTo(int64(300))))
+		})
+	})
+
 	It("should not automount service account token", func() {
 		Expect(statefulSet.Spec.Template.Spec.AutomountServiceAccountToken).To(Equal(tools.PtrTo(false)))
 	})
@@ -148,6 +167,44 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 		Expect(string(statefulSet.Spec.Template.Spec.Containers[0].ImagePullPolicy)).To(Equal("Always"))
 	})
 
+	When("an image pull policy is configured", func() {
+		BeforeEach(func() {
+			imagePullPolicy = corev1.PullIfNotPresent
+		})
+
+		It("uses it", func() {
+			Expect(statefulSet.Spec.Template.Spec.Containers[0].ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
+		})
+	})
+
+	It("should only run the application container", func() {
+		Expect(statefulSet.Spec.Template.Spec.Containers).To(HaveLen(1))
+	})
+
+	When("the appworkload has sidecars", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Sidecars = []korifiv1alpha1.AppWorkloadSidecar{{
+				Name:    "my-sidecar",
+				Command: []string{"/cnb/lifecycle/launcher", "./sidecar"},
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+				},
+			}}
+		})
+
+		It("runs them next to the application container from the same image", func() {
+			Expect(statefulSet.Spec.Template.Spec.Containers).To(HaveLen(2))
+
+			sidecar := statefulSet.Spec.Template.Spec.Containers[1]
+			Expect(sidecar.Name).To(Equal("my-sidecar"))
+			Expect(sidecar.Image).To(Equal(appWorkload.Spec.Image))
+			Expect(sidecar.Command).To(Equal([]string{"/cnb/lifecycle/launcher", "./sidecar"}))
+			Expect(sidecar.Env).To(Equal(statefulSet.Spec.Template.Spec.Containers[0].Env))
+			Expect(sidecar.Resources.Limits.Memory().String()).To(Equal("64Mi"))
+			Expect(sidecar.SecurityContext).To(Equal(statefulSet.Spec.Template.Spec.Containers[0].SecurityContext))
+		})
+	})
+
 	It("should set app_guid as a label", func() {
 		Expect(statefulSet.Labels).To(HaveKeyWithValue(controllers.LabelAppGUID, "premium_app_guid_1234"))
 		Expect(statefulSet.Spec.Template.Labels).To(HaveKeyWithValue(controllers.LabelAppGUID, "premium_app_guid_1234"))
@@ -157,6 +214,46 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 		Expect(statefulSet.Labels).To(HaveKeyWithValue(controllers.LabelAppWorkloadGUID, "guid_1234"))
 	})
 
+	When("the appworkload has pod labels", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.PodLabels = map[string]string{
+				"security-group":         "my-group",
+				controllers.LabelAppGUID: "not-the-app-guid",
+			}
+		})
+
+		It("sets them on the pod template only", func() {
+			Expect(statefulSet.Spec.Template.Labels).To(HaveKeyWithValue("security-group", "my-group"))
+			Expect(statefulSet.Labels).NotTo(HaveKey("security-group"))
+		})
+
+		It("does not let them override the runner labels", func() {
+			Expect(statefulSet.Spec.Template.Labels).To(HaveKeyWithValue(controllers.LabelAppGUID, "premium_app_guid_1234"))
+		})
+
+		It("does not add them to the selector", func() {
+			Expect(statefulSet.Spec.Selector.MatchLabels).NotTo(HaveKey("security-group"))
+		})
+	})
+
+	When("the appworkload has pod annotations", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.PodAnnotations = map[string]string{
+				"sidecar.istio.io/inject":   "true",
+				controllers.AnnotationAppID: "not-the-app-guid",
+			}
+		})
+
+		It("sets them on the pod template only", func() {
+			Expect(statefulSet.Spec.Template.Annotations).To(HaveKeyWithValue("sidecar.istio.io/inject", "true"))
+			Expect(statefulSet.Annotations).NotTo(HaveKey("sidecar.istio.io/inject"))
+		})
+
+		It("does not let them override the runner annotations", func() {
+			Expect(statefulSet.Spec.Template.Annotations).To(HaveKeyWithValue(controllers.AnnotationAppID, "premium_app_guid_1234"))
+		})
+	})
+
 	It("should set process_type as a label", func() {
 		Expect(statefulSet.Labels).To(HaveKeyWithValue(controllers.LabelProcessType, "worker"))
 		Expect(statefulSet.Spec.Template.Labels).To(HaveKeyWithValue(controllers.LabelProcessType, "worker"))
@@ -212,6 +309,37 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 		Expect(*statefulSet.Spec.Template.Spec.SecurityContext.RunAsNonRoot).To(BeTrue())
 	})
 
+	It("should not make the root filesystem read-only", func() {
+		Expect(statefulSet.Spec.Template.Spec.Containers[0].SecurityContext.ReadOnlyRootFilesystem).To(BeNil())
+	})
+
+	When("the security context is configured", func() {
+		BeforeEach(func() {
+			secContext = controllers.SecurityContext{
+				RunAsNonRoot:           false,
+				SeccompProfileType:     corev1.SeccompProfileTypeUnconfined,
+				ReadOnlyRootFilesystem: true,
+			}
+		})
+
+		It("applies it to the pod template", func() {
+			podSpec := statefulSet.Spec.Template.Spec
+			Expect(podSpec.SecurityContext.RunAsNonRoot).To(Equal(tools.PtrTo(false)))
+			Expect(podSpec.Containers[0].SecurityContext.SeccompProfile).To(Equal(&corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined}))
+			Expect(podSpec.Containers[0].SecurityContext.ReadOnlyRootFilesystem).To(Equal(tools.PtrTo(true)))
+		})
+	})
+
+	When("no seccomp profile is configured", func() {
+		BeforeEach(func() {
+			secContext.SeccompProfileType = ""
+		})
+
+		It("does not set one", func() {
+			Expect(statefulSet.Spec.Template.Spec.Containers[0].SecurityContext.SeccompProfile).To(BeNil())
+		})
+	})
+
 	It("should set soft inter-pod anti-affinity", func() {
 		podAntiAffinity := statefulSet.Spec.Template.Spec.Affinity.PodAntiAffinity
 		Expect(podAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(BeEmpty())
@@ -107,6 +107,24 @@ var _ = Describe("AppWorkloadsController", func() {
 			}).Should(Succeed())
 			Expect(*pdb.Spec.MinAvailable).To(Equal(intstr.FromString("50%")))
 		})
+
+		It("applies the configured security context to the pod template", func() {
+			podSpec := getStatefulsetForAppWorkload(Default).Spec.Template.Spec
+
+			Expect(podSpec.SecurityContext.RunAsNonRoot).To(gstruct.PointTo(BeTrue()))
+			Expect(podSpec.Containers).To(HaveLen(1))
+			Expect(podSpec.Containers[0].SecurityContext.SeccompProfile).To(gstruct.PointTo(Equal(corev1.SeccompProfile{
+				Type: corev1.SeccompProfileTypeRuntimeDefault,
+			})))
+			Expect(podSpec.Containers[0].SecurityContext.ReadOnlyRootFilesystem).To(gstruct.PointTo(BeTrue()))
+		})
+
+		It("always pulls the app image when no image pull policy is configured", func() {
+			podSpec := getStatefulsetForAppWorkload(Default).Spec.Template.Spec
+
+			Expect(podSpec.Containers).To(HaveLen(1))
+			Expect(podSpec.Containers[0].ImagePullPolicy).To(Equal(corev1.PullAlways))
+		})
 	})
 
 	When("AppWorkload update", func() {
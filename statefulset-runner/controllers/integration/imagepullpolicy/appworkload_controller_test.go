@@ -0,0 +1,72 @@
+package imagepullpolicy_test
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/statefulset-runner/controllers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AppWorkloadsController with an IfNotPresent image pull policy", func() {
+	var (
+		ctx         context.Context
+		appWorkload *korifiv1alpha1.AppWorkload
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		namespaceName := prefixedGUID("ns")
+		createNamespace(ctx, k8sClient, namespaceName)
+
+		appWorkload = &korifiv1alpha1.AppWorkload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      prefixedGUID("rw"),
+				Namespace: namespaceName,
+			},
+			Spec: korifiv1alpha1.AppWorkloadSpec{
+				GUID:        prefixedGUID("process"),
+				Version:     "1",
+				AppGUID:     "my-app",
+				ProcessType: "web",
+				Image:       "my-image",
+				Command:     []string{"do-it", "with", "args"},
+				Ports:       []int32{8080},
+				Instances:   1,
+				RunnerName:  "statefulset-runner",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceEphemeralStorage: resource.MustParse("100Mi"),
+						corev1.ResourceMemory:           resource.MustParse("5Mi"),
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("5m"),
+						corev1.ResourceMemory: resource.MustParse("5Mi"),
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, appWorkload)).To(Succeed())
+	})
+
+	It("pulls the app image only if it is not present", func() {
+		stsetList := appsv1.StatefulSetList{}
+		Eventually(func(g Gomega) {
+			g.Expect(k8sClient.List(ctx, &stsetList, client.MatchingLabels{
+				controllers.LabelGUID: appWorkload.Spec.GUID,
+			})).To(Succeed())
+			g.Expect(stsetList.Items).To(HaveLen(1))
+		}).Should(Succeed())
+
+		containers := stsetList.Items[0].Spec.Template.Spec.Containers
+		Expect(containers).To(HaveLen(1))
+		Expect(containers[0].ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
+	})
+})
@@ -77,7 +77,11 @@ var _ = BeforeSuite(func() {
 	appWorkloadReconciler := NewAppWorkloadReconciler(
 		k8sManager.GetClient(),
 		k8sManager.GetScheme(),
-		NewAppWorkloadToStatefulsetConverter(k8sManager.GetScheme()),
+		NewAppWorkloadToStatefulsetConverter(k8sManager.GetScheme(), SecurityContext{
+			RunAsNonRoot:           true,
+			SeccompProfileType:     corev1.SeccompProfileTypeRuntimeDefault,
+			ReadOnlyRootFilesystem: true,
+		}, ""),
 		NewPDBUpdater(k8sManager.GetClient()),
 		logger,
 	)
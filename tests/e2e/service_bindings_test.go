@@ -45,6 +45,19 @@ var _ = Describe("Service Bindings", func() {
 			Expect(httpError).NotTo(HaveOccurred())
 			Expect(httpResp).To(HaveRestyStatusCode(http.StatusCreated))
 		})
+
+		When("the app is already bound to the service instance", func() {
+			BeforeEach(func() {
+				createServiceBinding(appGUID, instanceGUID, "")
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(httpError).NotTo(HaveOccurred())
+				Expect(httpResp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+				Expect(httpResp).To(HaveRestyBody(ContainSubstring("CF-UnprocessableEntity")))
+				Expect(httpResp).To(HaveRestyBody(ContainSubstring("Service binding already exists")))
+			})
+		})
 	})
 
 	Describe("GET /v3/service_credential_bindings/{guid}", func() {
@@ -71,6 +71,19 @@ var _ = Describe("Service Instances", func() {
 				Expect(serviceInstance.Tags).To(ConsistOf("some", "tags"))
 				Expect(serviceInstance.InstanceType).To(Equal("user-provided"))
 			})
+
+			When("a service instance with the same name already exists in the space", func() {
+				BeforeEach(func() {
+					instanceName = existingInstanceName
+				})
+
+				It("returns a uniqueness error", func() {
+					Expect(httpError).NotTo(HaveOccurred())
+					Expect(httpResp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+					Expect(httpResp).To(HaveRestyBody(ContainSubstring("CF-UniquenessError")))
+					Expect(httpResp).To(HaveRestyBody(ContainSubstring("The service instance name is taken: " + existingInstanceName)))
+				})
+			})
 		})
 	})
 
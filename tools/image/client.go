@@ -13,6 +13,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
@@ -40,6 +41,9 @@ type Config struct {
 	Labels       map[string]string
 	User         string
 	ExposedPorts []int32
+	// Size is the number of bytes the image takes up in the registry, i.e.
+	// the size of its config blob plus its compressed layers
+	Size int64
 }
 
 func NewClient(k8sClient kubernetes.Interface) Client {
@@ -127,6 +131,11 @@ func (c Client) Config(ctx context.Context, creds Creds, imageRef string) (Confi
 		return Config{}, fmt.Errorf("error getting image config file: %w", err)
 	}
 
+	manifest, err := img.Manifest()
+	if err != nil {
+		return Config{}, fmt.Errorf("error getting image manifest: %w", err)
+	}
+
 	ports := []int32{}
 	for _, p := range parseExposedPorts(cfgFile.Config.ExposedPorts) {
 		parsed, err := net.ParsePort(p, false)
@@ -140,9 +149,19 @@ func (c Client) Config(ctx context.Context, creds Creds, imageRef string) (Confi
 		Labels:       cfgFile.Config.Labels,
 		User:         cfgFile.Config.User,
 		ExposedPorts: ports,
+		Size:         imageSize(manifest),
 	}, nil
 }
 
+func imageSize(manifest *v1.Manifest) int64 {
+	size := manifest.Config.Size
+	for _, layer := range manifest.Layers {
+		size += layer.Size
+	}
+
+	return size
+}
+
 func parseExposedPorts(ports map[string]struct{}) []string {
 	result := []string{}
 	for p := range ports {
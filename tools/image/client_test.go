@@ -147,6 +147,10 @@ var _ = Describe("Client", func() {
 			Expect(config.ExposedPorts).To(ConsistOf(int32(123), int32(456)))
 		})
 
+		It("fetches the image size", func() {
+			Expect(config.Size).To(BeNumerically(">", 0))
+		})
+
 		When("the ref is invalid", func() {
 			BeforeEach(func() {
 				pushRef += "::ads"